@@ -1,20 +1,87 @@
 package analytics
 
 import (
+	"errors"
+	"net/http"
+
+	"github.com/endpointhandler/handler/params"
 	"github.com/endpointhandler/repository"
 	"github.com/gin-gonic/gin"
-	"net/http"
 )
 
-func TimeOpenAnalytics(c *gin.Context) {
+// projectQuery is the project of an analytics request and the optional filter of its issues
+type projectQuery struct {
+	key    string
+	source string
+	filter params.Filter
+}
+
+// args returns query arguments: $1 key, $2 source, $3 and $4 custom field
+// filter, $5 label, $6 component, $7 fix version, $8 include deleted issues,
+// extra arguments start with $9
+func (q projectQuery) args(extra ...any) []any {
+	f := q.filter
+	return append([]any{q.key, q.source, f.CustomField, f.CustomValue,
+		f.Label, f.Component, f.FixVersion, f.IncludeDeleted}, extra...)
+}
+
+// issueFilter limits issues i to the filter of projectQuery. An empty custom
+// field value keeps all issues which have the field
+const issueFilter = `
+		  AND ($8 OR i.deletedTime IS NULL)
+		  AND ($3 = '' OR EXISTS (
+			SELECT 1 FROM IssueCustomField cf
+			WHERE cf.issueId = i.id AND cf.name = $3 AND ($4 = '' OR cf.value = $4)
+		  ))
+		  AND ($5 = '' OR EXISTS (
+			SELECT 1 FROM IssueLabel il JOIN Label lb ON lb.id = il.labelId
+			WHERE il.issueId = i.id AND lb.name = $5
+		  ))
+		  AND ($6 = '' OR EXISTS (
+			SELECT 1 FROM IssueComponent ic JOIN Component co ON co.id = ic.componentId
+			WHERE ic.issueId = i.id AND co.name = $6
+		  ))
+		  AND ($7 = '' OR EXISTS (
+			SELECT 1 FROM IssueFixVersion ifv JOIN Version fv ON fv.id = ifv.versionId
+			WHERE ifv.issueId = i.id AND fv.name = $7
+		  ))`
+
+// projectParams reads the project key, its optional Jira source and the filter
+// of issues. The source is needed only when the key exists in several
+// sources. On error the response is already written
+func projectParams(c *gin.Context) (projectQuery, bool) {
 	key := c.Query("key")
 	if key == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "project key is required"})
-		return
+		return projectQuery{}, false
+	}
+
+	filter, ok := params.IssueFilter(c)
+	if !ok {
+		return projectQuery{}, false
 	}
 
 	if repository.DB == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "database not initialized"})
+		return projectQuery{}, false
+	}
+
+	source, err := repository.ResolveProjectSource(key, c.Query("source"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, repository.ErrAmbiguousProject) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return projectQuery{}, false
+	}
+
+	return projectQuery{key: key, source: source, filter: filter}, true
+}
+
+func TimeOpenAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
 		return
 	}
 
@@ -42,11 +109,11 @@ func TimeOpenAnalytics(c *gin.Context) {
 			SELECT DATE_PART('day', NOW() - i.createdTime) AS age
 			FROM Projects p
 			JOIN Issue i ON p.id = i.projectId
-			WHERE i.status NOT IN ('Closed', 'Resolved') AND p.key = $1
+			WHERE i.status NOT IN ('Closed', 'Resolved') AND p.key = $1 AND p.source = $2`+issueFilter+`
 		) sub
 		GROUP BY range
 		ORDER BY MIN(age)
-	`, key)
+	`, query.args()...)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -56,14 +123,8 @@ func TimeOpenAnalytics(c *gin.Context) {
 }
 
 func StatusDistribution(c *gin.Context) {
-	key := c.Query("key")
-	if key == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "project key is required"})
-		return
-	}
-
-	if repository.DB == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database not initialized"})
+	query, ok := projectParams(c)
+	if !ok {
 		return
 	}
 
@@ -76,10 +137,10 @@ func StatusDistribution(c *gin.Context) {
 		SELECT i.status, COUNT(*) AS count
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		WHERE p.key = $1
+		WHERE p.key = $1 AND p.source = $2`+issueFilter+`
 		GROUP BY i.status
 		ORDER BY i.status
-	`, key)
+	`, query.args()...)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -88,35 +149,42 @@ func StatusDistribution(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// worklogPeriod limits worklogs w to the period of params.Period, $9 from and $10 the day after to
+const worklogPeriod = `
+		  AND ($9::timestamp IS NULL OR w.started >= $9)
+		  AND ($10::timestamp IS NULL OR w.started < $10)`
+
+// TimeSpentAnalytics возвращает время из worklog задач проекта по авторам записей,
+// а не по создателям задач. Параметры from и to ограничивают дату начала работы
 func TimeSpentAnalytics(c *gin.Context) {
-	projectKey := c.Query("key")
-	if projectKey == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing project key"})
+	query, ok := projectParams(c)
+	if !ok {
 		return
 	}
-
-	if repository.DB == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database not initialized"})
+	from, to, ok := params.Period(c)
+	if !ok {
 		return
 	}
 
 	var result []struct {
 		Author         string `db:"author" json:"author"`
+		AuthorId       string `db:"author_id" json:"author_id"`
 		TotalTimeSpent int    `db:"total_time_spent" json:"total_time_spent"`
 	}
 
 	err := repository.DB.Select(&result, `
 		SELECT 
 			a.name AS author,
-			SUM(i.timeSpent) AS total_time_spent
+			a.accountId AS author_id,
+			SUM(w.timeSpentSeconds) AS total_time_spent
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		JOIN Author a ON a.id = i.authorId
-		WHERE p.key = $1
-		  AND i.timeSpent IS NOT NULL
-		GROUP BY a.name
+		JOIN Worklog w ON w.issueId = i.id
+		JOIN Author a ON a.id = w.authorId
+		WHERE p.key = $1 AND p.source = $2`+worklogPeriod+issueFilter+`
+		GROUP BY a.accountId, a.name
 		ORDER BY total_time_spent DESC;
-	`, projectKey)
+	`, query.args(from, to)...)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -126,15 +194,50 @@ func TimeSpentAnalytics(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
-func PriorityAnalytics(c *gin.Context) {
-	key := c.Query("key")
-	if key == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "project key is required"})
+// TimeSpentDailyAnalytics возвращает время из worklog по дням и авторам записей
+func TimeSpentDailyAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+	from, to, ok := params.Period(c)
+	if !ok {
 		return
 	}
 
-	if repository.DB == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database not initialized"})
+	var result []struct {
+		Day       string `db:"day" json:"day"`
+		Author    string `db:"author" json:"author"`
+		AuthorId  string `db:"author_id" json:"author_id"`
+		TimeSpent int    `db:"time_spent" json:"time_spent"`
+	}
+
+	err := repository.DB.Select(&result, `
+		SELECT
+			TO_CHAR(DATE_TRUNC('day', w.started), 'YYYY-MM-DD') AS day,
+			a.name AS author,
+			a.accountId AS author_id,
+			SUM(w.timeSpentSeconds) AS time_spent
+		FROM Projects p
+		JOIN Issue i ON p.id = i.projectId
+		JOIN Worklog w ON w.issueId = i.id
+		JOIN Author a ON a.id = w.authorId
+		WHERE p.key = $1 AND p.source = $2`+worklogPeriod+issueFilter+`
+		GROUP BY day, a.accountId, a.name
+		ORDER BY day, a.name, a.accountId
+	`, query.args(from, to)...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func PriorityAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
 		return
 	}
 
@@ -147,10 +250,10 @@ func PriorityAnalytics(c *gin.Context) {
 		SELECT i.priority, COUNT(*) AS count
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		WHERE p.key = $1
+		WHERE p.key = $1 AND p.source = $2`+issueFilter+`
 		GROUP BY i.priority
 		ORDER BY i.priority
-	`, key)
+	`, query.args()...)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -161,14 +264,8 @@ func PriorityAnalytics(c *gin.Context) {
 
 // ThroughputAnalytics возвращает количество созданных задач по дням за последние 30 дней
 func ThroughputAnalytics(c *gin.Context) {
-	key := c.Query("key")
-	if key == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "project key is required"})
-		return
-	}
-
-	if repository.DB == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database not initialized"})
+	query, ok := projectParams(c)
+	if !ok {
 		return
 	}
 
@@ -183,16 +280,135 @@ func ThroughputAnalytics(c *gin.Context) {
 			COUNT(*) AS count
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		WHERE p.key = $1
-		  AND i.createdTime > NOW() - INTERVAL '30 days'
+		WHERE p.key = $1 AND p.source = $2
+		  AND i.createdTime > NOW() - INTERVAL '30 days'`+issueFilter+`
 		GROUP BY created_date
 		ORDER BY created_date
-	`, key)
+	`, query.args()...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PriorityChangesAnalytics возвращает количество смен приоритета по направлению (повышение/понижение).
+// В changelog Jira хранит id приоритета, направление определяется по позиции приоритета в списке
+// приоритетов Jira (таблица Priority). Если приоритет не найден в списке, направление unknown
+func PriorityChangesAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var result []struct {
+		FromPriority string `db:"from_priority" json:"from_priority"`
+		ToPriority   string `db:"to_priority" json:"to_priority"`
+		Direction    string `db:"direction" json:"direction"`
+		Count        int    `db:"count" json:"count"`
+	}
+
+	err := repository.DB.Select(&result, `
+		SELECT
+			fc.fromString AS from_priority,
+			fc.toString AS to_priority,
+			CASE
+				WHEN pf.rank IS NULL OR pt.rank IS NULL THEN 'unknown'
+				WHEN pt.rank < pf.rank THEN 'escalated'
+				WHEN pt.rank > pf.rank THEN 'lowered'
+				ELSE 'unchanged'
+			END AS direction,
+			COUNT(*) AS count
+		FROM Projects p
+		JOIN Issue i ON p.id = i.projectId
+		JOIN IssueFieldChanges fc ON fc.issueId = i.id
+		LEFT JOIN Priority pf ON pf.source = p.source AND pf.jiraId = fc.fromValue
+		LEFT JOIN Priority pt ON pt.source = p.source AND pt.jiraId = fc.toValue
+		WHERE p.key = $1 AND p.source = $2 AND fc.field = 'priority'`+issueFilter+`
+		GROUP BY from_priority, to_priority, direction
+		ORDER BY count DESC, from_priority, to_priority
+	`, query.args()...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ReassignmentAnalytics возвращает количество переназначений задач проекта,
+// параметр issue ограничивает выборку одной задачей
+func ReassignmentAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var result []struct {
+		Issue         string `db:"issue" json:"issue"`
+		Reassignments int    `db:"reassignments" json:"reassignments"`
+	}
+
+	err := repository.DB.Select(&result, `
+		SELECT i.key AS issue, COUNT(*) AS reassignments
+		FROM Projects p
+		JOIN Issue i ON p.id = i.projectId
+		JOIN IssueFieldChanges fc ON fc.issueId = i.id
+		WHERE p.key = $1 AND p.source = $2 AND fc.field = 'assignee'
+		  AND ($9 = '' OR i.key = $9)`+issueFilter+`
+		GROUP BY i.key
+		ORDER BY reassignments DESC, i.key
+	`, query.args(c.Query("issue"))...)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CustomFieldAnalytics группирует задачи проекта по значениям пользовательского поля
+// (например sprint или team): количество задач, открытые задачи и сумма story points.
+// Задача с несколькими значениями поля попадает в каждую группу
+func CustomFieldAnalytics(c *gin.Context) {
+	field := c.Query("field")
+	if field == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "field is required"})
+		return
+	}
 
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var result []struct {
+		Value       string  `db:"value" json:"value"`
+		Count       int     `db:"count" json:"count"`
+		Open        int     `db:"open" json:"open"`
+		StoryPoints float64 `db:"story_points" json:"story_points"`
+	}
+
+	err := repository.DB.Select(&result, `
+		SELECT
+			v.value,
+			COUNT(DISTINCT i.id) AS count,
+			COUNT(DISTINCT i.id) FILTER (WHERE i.status NOT IN ('Closed', 'Resolved')) AS open,
+			COALESCE(SUM(sp.numberValue), 0) AS story_points
+		FROM Projects p
+		JOIN Issue i ON p.id = i.projectId
+		JOIN IssueCustomField v ON v.issueId = i.id AND v.name = $9
+		LEFT JOIN IssueCustomField sp ON sp.issueId = i.id AND sp.name = 'story_points' AND sp.valueIndex = 0
+		WHERE p.key = $1 AND p.source = $2`+issueFilter+`
+		GROUP BY v.value
+		ORDER BY count DESC, v.value
+	`, query.args(field)...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, result)
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
@@ -23,6 +24,17 @@ func setupMockDB(t *testing.T) sqlmock.Sqlmock {
 	return mock
 }
 
+// expectSource expects the lookup of the project source by key
+func expectSource(mock sqlmock.Sqlmock, key string, sources ...string) {
+	rows := sqlmock.NewRows([]string{"source"})
+	for _, source := range sources {
+		rows.AddRow(source)
+	}
+	mock.ExpectQuery("SELECT DISTINCT source FROM Projects").
+		WithArgs(key).
+		WillReturnRows(rows)
+}
+
 func performRequest(method, path string, handlerFunc gin.HandlerFunc) *httptest.ResponseRecorder {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -34,8 +46,9 @@ func performRequest(method, path string, handlerFunc gin.HandlerFunc) *httptest.
 func TestTimeOpenAnalytics(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT.*FROM.*Projects p").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
 		WillReturnRows(sqlmock.NewRows([]string{"range", "count"}).
 			AddRow("0-1", 5).
 			AddRow("1-2", 3),
@@ -50,8 +63,9 @@ func TestTimeOpenAnalytics(t *testing.T) {
 func TestStatusDistribution(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT i.status, COUNT").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
 		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
 			AddRow("Open", 10).
 			AddRow("In Progress", 4),
@@ -66,24 +80,88 @@ func TestStatusDistribution(t *testing.T) {
 func TestTimeSpentAnalytics(t *testing.T) {
 	mock := setupMockDB(t)
 
-	mock.ExpectQuery("SELECT a.name AS author").
-		WithArgs("test-project").
-		WillReturnRows(sqlmock.NewRows([]string{"author", "total_time_spent"}).
-			AddRow("Alice", 120).
-			AddRow("Bob", 90),
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT a.name AS author.*JOIN Worklog w").
+		WithArgs("test-project", "default", "", "", "", "", "", false, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"author", "author_id", "total_time_spent"}).
+			AddRow("Alice", "5b10a1", 120).
+			// two people with the same display name stay apart
+			AddRow("Bob", "5b10b1", 90).
+			AddRow("Bob", "5b10b2", 30),
 		)
 
 	w := performRequest(http.MethodGet, "/analytics/time-spent?key=test-project", TimeSpentAnalytics)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	expected := `[{"author":"Alice","author_id":"5b10a1","total_time_spent":120},` +
+		`{"author":"Bob","author_id":"5b10b1","total_time_spent":90},` +
+		`{"author":"Bob","author_id":"5b10b2","total_time_spent":30}]`
+	if w.Body.String() != expected {
+		t.Errorf("expected %s, got %s", expected, w.Body.String())
+	}
+}
+
+func TestTimeSpentAnalytics_Period(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT a.name AS author").
+		WithArgs("test-project", "default", "", "", "", "", "", false,
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows([]string{"author", "author_id", "total_time_spent"}).AddRow("Alice", "5b10a1", 120))
+
+	w := performRequest(http.MethodGet, "/analytics/time-spent?key=test-project&from=2024-03-01&to=2024-03-07", TimeSpentAnalytics)
 	if w.Code != http.StatusOK {
 		t.Errorf("expected 200, got %d", w.Code)
 	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestTimeSpentAnalytics_InvalidPeriod(t *testing.T) {
+	for _, period := range []string{"from=2024-13-01", "to=yesterday", "from=2024-03-07&to=2024-03-01"} {
+		mock := setupMockDB(t)
+		expectSource(mock, "test-project", "default")
+
+		w := performRequest(http.MethodGet, "/analytics/time-spent?key=test-project&"+period, TimeSpentAnalytics)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", period, w.Code)
+		}
+	}
+}
+
+func TestTimeSpentDailyAnalytics(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT.*AS day.*JOIN Worklog w").
+		WithArgs("test-project", "default", "", "", "", "", "", false, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), nil).
+		WillReturnRows(sqlmock.NewRows([]string{"day", "author", "author_id", "time_spent"}).
+			AddRow("2024-03-01", "Alice", "5b10a1", 3600).
+			AddRow("2024-03-01", "Bob", "5b10b1", 1800).
+			AddRow("2024-03-02", "Alice", "5b10a1", 7200),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/time-spent-daily?key=test-project&from=2024-03-01", TimeSpentDailyAnalytics)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	expected := `[{"day":"2024-03-01","author":"Alice","author_id":"5b10a1","time_spent":3600},` +
+		`{"day":"2024-03-01","author":"Bob","author_id":"5b10b1","time_spent":1800},` +
+		`{"day":"2024-03-02","author":"Alice","author_id":"5b10a1","time_spent":7200}]`
+	if w.Body.String() != expected {
+		t.Errorf("expected %s, got %s", expected, w.Body.String())
+	}
 }
 
 func TestPriorityAnalytics(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT i.priority, COUNT").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
 		WillReturnRows(sqlmock.NewRows([]string{"priority", "count"}).
 			AddRow("High", 7).
 			AddRow("Low", 3),
@@ -98,8 +176,9 @@ func TestPriorityAnalytics(t *testing.T) {
 func TestTimeOpenAnalytics_DBError(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT.*FROM.*Projects p").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
 		WillReturnError(fmt.Errorf("db error"))
 
 	w := performRequest(http.MethodGet, "/analytics/time-open?key=test-project", TimeOpenAnalytics)
@@ -111,8 +190,9 @@ func TestTimeOpenAnalytics_DBError(t *testing.T) {
 func TestStatusDistribution_DBError(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT i.status, COUNT").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
 		WillReturnError(fmt.Errorf("db error"))
 
 	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project", StatusDistribution)
@@ -124,8 +204,9 @@ func TestStatusDistribution_DBError(t *testing.T) {
 func TestTimeSpentAnalytics_DBError(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT a.name AS author").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false, nil, nil).
 		WillReturnError(fmt.Errorf("db error"))
 
 	w := performRequest(http.MethodGet, "/analytics/time-spent?key=test-project", TimeSpentAnalytics)
@@ -137,8 +218,9 @@ func TestTimeSpentAnalytics_DBError(t *testing.T) {
 func TestPriorityAnalytics_DBError(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT i.priority, COUNT").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
 		WillReturnError(fmt.Errorf("db error"))
 
 	w := performRequest(http.MethodGet, "/analytics/priority?key=test-project", PriorityAnalytics)
@@ -178,8 +260,9 @@ func TestPriorityAnalytics_MissingKey(t *testing.T) {
 func TestThroughputAnalytics(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT .*FROM Projects p").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
 		WillReturnRows(sqlmock.NewRows([]string{"created_date", "count"}).
 			AddRow("2025-01-01", 5).
 			AddRow("2025-01-02", 3),
@@ -194,8 +277,9 @@ func TestThroughputAnalytics(t *testing.T) {
 func TestThroughputAnalytics_DBError(t *testing.T) {
 	mock := setupMockDB(t)
 
+	expectSource(mock, "test-project", "default")
 	mock.ExpectQuery("SELECT .*FROM Projects p").
-		WithArgs("test-project").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
 		WillReturnError(fmt.Errorf("db error"))
 
 	w := performRequest(http.MethodGet, "/analytics/throughput?key=test-project", ThroughputAnalytics)
@@ -210,3 +294,267 @@ func TestThroughputAnalytics_MissingKey(t *testing.T) {
 		t.Errorf("expected status 400, got %d", w.Code)
 	}
 }
+
+func TestPriorityChangesAnalytics(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery(`SELECT .*pt.rank < pf.rank THEN 'escalated'.*FROM Projects p.*JOIN IssueFieldChanges fc.*`+
+		`LEFT JOIN Priority pf ON pf.source = p.source AND pf.jiraId = fc.fromValue`).
+		WithArgs("test-project", "default", "", "", "", "", "", false).
+		WillReturnRows(sqlmock.NewRows([]string{"from_priority", "to_priority", "direction", "count"}).
+			AddRow("Low", "High", "escalated", 4).
+			AddRow("High", "Medium", "lowered", 1),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/priority-changes?key=test-project", PriorityChangesAnalytics)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestPriorityChangesAnalytics_DBError(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT .*FROM Projects p.*JOIN IssueFieldChanges fc").
+		WithArgs("test-project", "default", "", "", "", "", "", false).
+		WillReturnError(fmt.Errorf("db error"))
+
+	w := performRequest(http.MethodGet, "/analytics/priority-changes?key=test-project", PriorityChangesAnalytics)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
+
+func TestPriorityChangesAnalytics_MissingKey(t *testing.T) {
+	w := performRequest(http.MethodGet, "/analytics/priority-changes", PriorityChangesAnalytics)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestReassignmentAnalytics(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.key AS issue, COUNT").
+		WithArgs("test-project", "default", "", "", "", "", "", false, "").
+		WillReturnRows(sqlmock.NewRows([]string{"issue", "reassignments"}).
+			AddRow("TP-1", 3).
+			AddRow("TP-2", 1),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/reassignments?key=test-project", ReassignmentAnalytics)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestReassignmentAnalytics_SingleIssue(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.key AS issue, COUNT").
+		WithArgs("test-project", "default", "", "", "", "", "", false, "TP-1").
+		WillReturnRows(sqlmock.NewRows([]string{"issue", "reassignments"}).
+			AddRow("TP-1", 3),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/reassignments?key=test-project&issue=TP-1", ReassignmentAnalytics)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestReassignmentAnalytics_DBError(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.key AS issue, COUNT").
+		WithArgs("test-project", "default", "", "", "", "", "", false, "").
+		WillReturnError(fmt.Errorf("db error"))
+
+	w := performRequest(http.MethodGet, "/analytics/reassignments?key=test-project", ReassignmentAnalytics)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
+
+func TestReassignmentAnalytics_MissingKey(t *testing.T) {
+	w := performRequest(http.MethodGet, "/analytics/reassignments", ReassignmentAnalytics)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestStatusDistribution_WithSource(t *testing.T) {
+	mock := setupMockDB(t)
+
+	// explicit source doesn't need the lookup
+	mock.ExpectQuery("SELECT i.status, COUNT").
+		WithArgs("test-project", "cloud", "", "", "", "", "", false).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+			AddRow("Open", 2),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project&source=cloud", StatusDistribution)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestStatusDistribution_AmbiguousKey(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "cloud", "default")
+
+	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project", StatusDistribution)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestStatusDistribution_SourceLookupError(t *testing.T) {
+	mock := setupMockDB(t)
+
+	mock.ExpectQuery("SELECT DISTINCT source FROM Projects").
+		WithArgs("test-project").
+		WillReturnError(fmt.Errorf("db error"))
+
+	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project", StatusDistribution)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
+
+func TestStatusDistribution_CustomFieldFilter(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.status, COUNT.*FROM IssueCustomField cf").
+		WithArgs("test-project", "default", "sprint", "Sprint 1", "", "", "", false).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+			AddRow("Open", 2),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project&customField=sprint&customValue=Sprint+1", StatusDistribution)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestStatusDistribution_LabelComponentVersionFilter(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.status, COUNT.*FROM IssueLabel il.*FROM IssueComponent ic.*FROM IssueFixVersion ifv").
+		WithArgs("test-project", "default", "", "", "backend", "API", "1.0", false).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+			AddRow("Open", 1),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project&label=backend&component=API&fixVersion=1.0", StatusDistribution)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestStatusDistribution_IncludeDeleted(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.status, COUNT.*i.deletedTime IS NULL").
+		WithArgs("test-project", "default", "", "", "", "", "", true).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+			AddRow("Open", 3),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project&includeDeleted=true", StatusDistribution)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestStatusDistribution_InvalidIncludeDeleted(t *testing.T) {
+	setupMockDB(t)
+
+	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project&includeDeleted=maybe", StatusDistribution)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestStatusDistribution_CustomValueWithoutField(t *testing.T) {
+	setupMockDB(t)
+
+	w := performRequest(http.MethodGet, "/analytics/status-distribution?key=test-project&customValue=Sprint+1", StatusDistribution)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestCustomFieldAnalytics(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT .*JOIN IssueCustomField v").
+		WithArgs("test-project", "default", "team", "", "", "", "", false, "sprint").
+		WillReturnRows(sqlmock.NewRows([]string{"value", "count", "open", "story_points"}).
+			AddRow("Sprint 2", 6, 4, 21.5).
+			AddRow("Sprint 1", 3, 0, 8),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/custom-field?key=test-project&field=sprint&customField=team", CustomFieldAnalytics)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestCustomFieldAnalytics_DBError(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT .*JOIN IssueCustomField v").
+		WithArgs("test-project", "default", "", "", "", "", "", false, "sprint").
+		WillReturnError(fmt.Errorf("db error"))
+
+	w := performRequest(http.MethodGet, "/analytics/custom-field?key=test-project&field=sprint", CustomFieldAnalytics)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
+
+func TestCustomFieldAnalytics_MissingField(t *testing.T) {
+	w := performRequest(http.MethodGet, "/analytics/custom-field?key=test-project", CustomFieldAnalytics)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
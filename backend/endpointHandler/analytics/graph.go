@@ -0,0 +1,163 @@
+package analytics
+
+import (
+	"slices"
+	"sort"
+)
+
+// dependencyGraph - направленный граф блокировок: ребро from -> to означает, что from блокирует to
+type dependencyGraph struct {
+	nodes []string
+	next  map[string][]string
+}
+
+func newDependencyGraph(edges []dependencyEdge) *dependencyGraph {
+	g := &dependencyGraph{next: make(map[string][]string)}
+	seen := make(map[string]bool)
+	addNode := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			g.nodes = append(g.nodes, key)
+		}
+	}
+
+	for _, edge := range edges {
+		addNode(edge.From)
+		addNode(edge.To)
+		if !slices.Contains(g.next[edge.From], edge.To) {
+			g.next[edge.From] = append(g.next[edge.From], edge.To)
+		}
+	}
+
+	// результат не зависит от порядка строк из базы
+	sort.Strings(g.nodes)
+	for _, next := range g.next {
+		sort.Strings(next)
+	}
+	return g
+}
+
+// cycles возвращает циклы блокировок - компоненты сильной связности (алгоритм Тарьяна)
+// из нескольких задач или задачу, блокирующую саму себя
+func (g *dependencyGraph) cycles() [][]string {
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var result [][]string
+
+	var visit func(node string)
+	visit = func(node string) {
+		index[node] = len(index)
+		low[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range g.next[node] {
+			if _, ok := index[next]; !ok {
+				visit(next)
+				low[node] = min(low[node], low[next])
+			} else if onStack[next] {
+				low[node] = min(low[node], index[next])
+			}
+		}
+
+		if low[node] != index[node] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == node {
+				break
+			}
+		}
+
+		if len(component) > 1 || slices.Contains(g.next[node], node) {
+			sort.Strings(component)
+			result = append(result, component)
+		}
+	}
+
+	for _, node := range g.nodes {
+		if _, ok := index[node]; !ok {
+			visit(node)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i][0] < result[j][0] })
+	return result
+}
+
+// chains возвращает самые длинные цепочки блокировок незакрытых задач: от первой
+// блокирующей задачи до задачи, которая сама уже ничего не блокирует. Задачи из
+// циклов в цепочки не входят, циклы возвращаются отдельно
+func (g *dependencyGraph) chains(open func(key string) bool, cycles [][]string) [][]string {
+	inCycle := make(map[string]bool)
+	for _, cycle := range cycles {
+		for _, key := range cycle {
+			inCycle[key] = true
+		}
+	}
+	active := func(key string) bool { return open(key) && !inCycle[key] }
+
+	prev := make(map[string][]string)
+	for _, from := range g.nodes {
+		if !active(from) {
+			continue
+		}
+		for _, to := range g.next[from] {
+			if active(to) {
+				prev[to] = append(prev[to], from)
+			}
+		}
+	}
+
+	// длина самой длинной цепочки, которая заканчивается задачей, и предыдущая задача в ней
+	length := make(map[string]int)
+	best := make(map[string]string)
+	var longest func(node string) int
+	longest = func(node string) int {
+		if l, ok := length[node]; ok {
+			return l
+		}
+		length[node] = 1
+		for _, from := range prev[node] {
+			if l := longest(from) + 1; l > length[node] {
+				length[node] = l
+				best[node] = from
+			}
+		}
+		return length[node]
+	}
+
+	var result [][]string
+	for _, node := range g.nodes {
+		if !active(node) || len(prev[node]) == 0 {
+			continue
+		}
+		blocksOpen := false
+		for _, to := range g.next[node] {
+			if active(to) {
+				blocksOpen = true
+				break
+			}
+		}
+		if blocksOpen {
+			continue
+		}
+
+		chain := make([]string, longest(node))
+		for i, key := len(chain)-1, node; i >= 0; i, key = i-1, best[key] {
+			chain[i] = key
+		}
+		result = append(result, chain)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return len(result[i]) > len(result[j]) })
+	return result
+}
@@ -0,0 +1,55 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependencyGraphCycles(t *testing.T) {
+	graph := newDependencyGraph([]dependencyEdge{
+		{From: "A-1", To: "A-2"},
+		{From: "A-2", To: "A-3"},
+		{From: "A-3", To: "A-1"},
+		{From: "A-3", To: "A-4"},
+		{From: "B-1", To: "B-1"},
+		{From: "C-1", To: "C-2"},
+	})
+
+	expected := [][]string{{"A-1", "A-2", "A-3"}, {"B-1"}}
+	if cycles := graph.cycles(); !reflect.DeepEqual(cycles, expected) {
+		t.Errorf("expected cycles %v, got %v", expected, cycles)
+	}
+}
+
+func TestDependencyGraphChains(t *testing.T) {
+	graph := newDependencyGraph([]dependencyEdge{
+		// A-1 -> A-2 -> A-3 -> A-4 and a shortcut A-1 -> A-4
+		{From: "A-1", To: "A-2"},
+		{From: "A-2", To: "A-3"},
+		{From: "A-3", To: "A-4"},
+		{From: "A-1", To: "A-4"},
+		// closed B-1 doesn't block B-2
+		{From: "B-1", To: "B-2"},
+		// C-1 blocks C-2 which is in a cycle
+		{From: "C-1", To: "C-2"},
+		{From: "C-2", To: "C-3"},
+		{From: "C-3", To: "C-2"},
+		{From: "D-1", To: "D-2"},
+	})
+	open := func(key string) bool { return key != "B-1" }
+
+	expected := [][]string{{"A-1", "A-2", "A-3", "A-4"}, {"D-1", "D-2"}}
+	if chains := graph.chains(open, graph.cycles()); !reflect.DeepEqual(chains, expected) {
+		t.Errorf("expected chains %v, got %v", expected, chains)
+	}
+}
+
+func TestDependencyGraphEmpty(t *testing.T) {
+	graph := newDependencyGraph(nil)
+	if cycles := graph.cycles(); len(cycles) != 0 {
+		t.Errorf("expected no cycles, got %v", cycles)
+	}
+	if chains := graph.chains(func(string) bool { return true }, nil); len(chains) != 0 {
+		t.Errorf("expected no chains, got %v", chains)
+	}
+}
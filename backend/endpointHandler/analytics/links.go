@@ -0,0 +1,286 @@
+package analytics
+
+import (
+	"net/http"
+
+	"github.com/endpointhandler/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+)
+
+// максимальная глубина иерархии эпика: эпик - история - подзадача и запас на случай циклов
+const maxHierarchyDepth = 5
+
+type epicIssue struct {
+	Key         string  `db:"key" json:"key"`
+	Parent      string  `db:"parent" json:"parent,omitempty"`
+	Depth       int     `db:"depth" json:"depth"`
+	Summary     string  `db:"summary" json:"summary"`
+	Type        string  `db:"type" json:"type"`
+	Status      string  `db:"status" json:"status"`
+	Done        bool    `db:"done" json:"done"`
+	StoryPoints float64 `db:"story_points" json:"story_points"`
+}
+
+type epicProgress struct {
+	Epic           epicIssue   `json:"epic"`
+	TotalIssues    int         `json:"total_issues"`
+	DoneIssues     int         `json:"done_issues"`
+	TotalPoints    float64     `json:"total_points"`
+	DonePoints     float64     `json:"done_points"`
+	Progress       float64     `json:"progress"`
+	PointsProgress float64     `json:"points_progress"`
+	Children       []epicIssue `json:"children"`
+}
+
+// EpicAnalytics возвращает прогресс эпика по всем вложенным задачам: историям эпика
+// (parent в Jira Cloud или поле epic_link) и их подзадачам. Задача выполнена, если у неё есть resolution.
+// Учитываются вложенные задачи того же проекта. Фильтры запроса применяются к вложенным задачам,
+// сам эпик возвращается всегда
+func EpicAnalytics(c *gin.Context) {
+	epic := c.Query("epic")
+	if epic == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "epic is required"})
+		return
+	}
+
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var issues []epicIssue
+	err := repository.DB.Select(&issues, `
+		WITH RECURSIVE hierarchy AS (
+			SELECT l.linkedKey AS parent_key, l.issueId AS child_id
+			FROM Projects hp
+			JOIN Issue ci ON ci.projectId = hp.id
+			JOIN IssueLink l ON l.issueId = ci.id
+			WHERE hp.key = $1 AND hp.source = $2 AND l.linkType = 'parent'
+			UNION
+			SELECT cf.value, cf.issueId
+			FROM Projects hp
+			JOIN Issue ci ON ci.projectId = hp.id
+			JOIN IssueCustomField cf ON cf.issueId = ci.id
+			WHERE hp.key = $1 AND hp.source = $2 AND cf.name = 'epic_link'
+			UNION
+			SELECT pi.key, ci.id
+			FROM Projects hp
+			JOIN Issue pi ON pi.projectId = hp.id
+			JOIN IssueLink l ON l.issueId = pi.id
+			JOIN Issue ci ON ci.projectId = pi.projectId AND ci.key = l.linkedKey
+			WHERE hp.key = $1 AND hp.source = $2 AND l.linkType = 'subtask'
+		),
+		tree AS (
+			SELECT i.id, i.key, CAST('' AS TEXT) AS parent, 0 AS depth
+			FROM Projects p
+			JOIN Issue i ON p.id = i.projectId
+			WHERE p.key = $1 AND p.source = $2 AND i.key = $9 AND ($8 OR i.deletedTime IS NULL)
+			UNION
+			SELECT c.id, c.key, t.key, t.depth + 1
+			FROM tree t
+			JOIN hierarchy h ON h.parent_key = t.key
+			JOIN Issue c ON c.id = h.child_id
+			WHERE t.depth < $10
+		)
+		SELECT
+			t.key,
+			t.parent,
+			t.depth,
+			COALESCE(i.summary, '') AS summary,
+			COALESCE(i.type, '') AS type,
+			COALESCE(i.status, '') AS status,
+			COALESCE(i.closedTime > '0001-01-01', false) AS done,
+			COALESCE(sp.numberValue, 0) AS story_points
+		FROM tree t
+		JOIN Issue i ON i.id = t.id
+		LEFT JOIN IssueCustomField sp ON sp.issueId = i.id AND sp.name = 'story_points' AND sp.valueIndex = 0
+		WHERE t.depth = 0 OR (TRUE`+issueFilter+`)
+		ORDER BY t.depth, t.key
+	`, query.args(epic, maxHierarchyDepth)...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(issues) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "epic not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rollUpEpic(issues))
+}
+
+// rollUpEpic считает прогресс, issues отсортированы по глубине, первая - сам эпик.
+// Задача, найденная по нескольким путям, учитывается один раз
+func rollUpEpic(issues []epicIssue) epicProgress {
+	result := epicProgress{Epic: issues[0], Children: []epicIssue{}}
+	seen := map[string]bool{issues[0].Key: true}
+
+	for _, issue := range issues[1:] {
+		if seen[issue.Key] {
+			continue
+		}
+		seen[issue.Key] = true
+
+		result.Children = append(result.Children, issue)
+		result.TotalIssues++
+		result.TotalPoints += issue.StoryPoints
+		if issue.Done {
+			result.DoneIssues++
+			result.DonePoints += issue.StoryPoints
+		}
+	}
+
+	if result.TotalIssues > 0 {
+		result.Progress = float64(result.DoneIssues) / float64(result.TotalIssues)
+	}
+	if result.TotalPoints > 0 {
+		result.PointsProgress = result.DonePoints / result.TotalPoints
+	}
+	return result
+}
+
+type dependencyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type dependencyNode struct {
+	Key       string   `db:"key" json:"key"`
+	Project   string   `db:"project" json:"project"`
+	Summary   string   `db:"summary" json:"summary"`
+	Status    string   `db:"status" json:"status"`
+	Done      bool     `db:"done" json:"done"`
+	External  bool     `db:"-" json:"external"`
+	BlockedBy []string `db:"-" json:"blocked_by"`
+}
+
+type dependencyGraphResponse struct {
+	LinkType string           `json:"link_type"`
+	Nodes    []dependencyNode `json:"nodes"`
+	Edges    []dependencyEdge `json:"edges"`
+	Cycles   [][]string       `json:"cycles"`
+	Chains   [][]string       `json:"chains"`
+}
+
+// DependencyAnalytics возвращает граф блокировок задач проекта: задачи, рёбра "from блокирует to",
+// циклы и самые длинные цепочки незакрытых блокировок. Параметр linkType - тип связи Jira (по умолчанию Blocks).
+// В граф попадают и задачи других проектов, связанные с задачами проекта (external)
+func DependencyAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+	linkType := c.DefaultQuery("linkType", "Blocks")
+
+	var links []struct {
+		Issue     string `db:"issue"`
+		Direction string `db:"direction"`
+		Linked    string `db:"linked"`
+	}
+
+	err := repository.DB.Select(&links, `
+		SELECT i.key AS issue, l.direction, l.linkedKey AS linked
+		FROM Projects p
+		JOIN Issue i ON p.id = i.projectId
+		JOIN IssueLink l ON l.issueId = i.id
+		WHERE p.key = $1 AND p.source = $2 AND l.linkType = $9 AND l.direction <> ''`+issueFilter+`
+		ORDER BY i.key, l.linkedKey
+	`, query.args(linkType)...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// связь хранится у обеих задач, поэтому одно ребро может прийти дважды
+	edges := []dependencyEdge{}
+	seenEdges := make(map[dependencyEdge]bool)
+	for _, link := range links {
+		edge := dependencyEdge{From: link.Issue, To: link.Linked}
+		if link.Direction == "inward" {
+			edge = dependencyEdge{From: link.Linked, To: link.Issue}
+		}
+		if !seenEdges[edge] {
+			seenEdges[edge] = true
+			edges = append(edges, edge)
+		}
+	}
+
+	graph := newDependencyGraph(edges)
+	response := dependencyGraphResponse{
+		LinkType: linkType,
+		Nodes:    []dependencyNode{},
+		Edges:    edges,
+		Cycles:   graph.cycles(),
+	}
+
+	if len(graph.nodes) > 0 {
+		nodes, err := dependencyNodes(query.source, graph.nodes, query.filter.IncludeDeleted)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		byKey := make(map[string]*dependencyNode)
+		for i := range nodes {
+			byKey[nodes[i].Key] = &nodes[i]
+		}
+		// не загруженные задачи считаются незакрытыми
+		open := func(key string) bool { return byKey[key] == nil || !byKey[key].Done }
+
+		position := make(map[string]int)
+		for _, key := range graph.nodes {
+			node := dependencyNode{Key: key}
+			if found := byKey[key]; found != nil {
+				node = *found
+			}
+			node.External = node.Project != query.key
+			node.BlockedBy = []string{}
+			position[key] = len(response.Nodes)
+			response.Nodes = append(response.Nodes, node)
+		}
+		for _, edge := range edges {
+			if open(edge.From) {
+				blocked := &response.Nodes[position[edge.To]]
+				blocked.BlockedBy = append(blocked.BlockedBy, edge.From)
+			}
+		}
+
+		response.Chains = graph.chains(open, response.Cycles)
+	}
+
+	if response.Chains == nil {
+		response.Chains = [][]string{}
+	}
+	if response.Cycles == nil {
+		response.Cycles = [][]string{}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// dependencyNodes загружает задачи графа из того же источника Jira, удалённые в Jira задачи
+// загружаются только при includeDeleted
+func dependencyNodes(source string, keys []string, includeDeleted bool) ([]dependencyNode, error) {
+	query, args, err := sqlx.In(`
+		SELECT
+			i.key,
+			p.key AS project,
+			COALESCE(i.summary, '') AS summary,
+			COALESCE(i.status, '') AS status,
+			COALESCE(i.closedTime > '0001-01-01', false) AS done
+		FROM Projects p
+		JOIN Issue i ON p.id = i.projectId
+		WHERE p.source = ? AND i.key IN (?) AND (? OR i.deletedTime IS NULL)
+	`, source, keys, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []dependencyNode
+	if err := repository.DB.Select(&nodes, repository.DB.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
@@ -0,0 +1,170 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestEpicAnalytics(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	// every branch of the hierarchy is limited to the issues of the project
+	mock.ExpectQuery(`WITH RECURSIVE hierarchy AS \(`+
+		`(\s*(UNION\s*)?SELECT [^()]*FROM Projects hp[^()]*WHERE hp.key = \$1 AND hp.source = \$2 AND [^()]*){3}\)`).
+		WithArgs("test-project", "default", "", "", "", "", "", false, "TP-1", maxHierarchyDepth).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "parent", "depth", "summary", "type", "status", "done", "story_points"}).
+			AddRow("TP-1", "", 0, "Epic", "Epic", "In Progress", false, 0.0).
+			AddRow("TP-2", "TP-1", 1, "Story", "Story", "Done", true, 5.0).
+			AddRow("TP-3", "TP-1", 1, "Story", "Story", "Open", false, 3.0).
+			AddRow("TP-4", "TP-2", 2, "Subtask", "Sub-task", "Done", true, 0.0).
+			// the same subtask found by its parent link and by the subtask link of the story
+			AddRow("TP-4", "TP-3", 2, "Subtask", "Sub-task", "Done", true, 0.0),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/epic?key=test-project&epic=TP-1", EpicAnalytics)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var result epicProgress
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("can't decode response: %s", err)
+	}
+	if result.Epic.Key != "TP-1" || result.TotalIssues != 3 || result.DoneIssues != 2 {
+		t.Errorf("unexpected rollup %+v", result)
+	}
+	if result.TotalPoints != 8 || result.DonePoints != 5 || result.PointsProgress != 0.625 {
+		t.Errorf("unexpected points %+v", result)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestEpicAnalytics_NotFound(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("WITH RECURSIVE hierarchy AS").
+		WillReturnRows(sqlmock.NewRows([]string{"key", "parent", "depth", "summary", "type", "status", "done", "story_points"}))
+
+	w := performRequest(http.MethodGet, "/analytics/epic?key=test-project&epic=TP-404", EpicAnalytics)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestEpicAnalytics_DBError(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("WITH RECURSIVE hierarchy AS").WillReturnError(fmt.Errorf("db error"))
+
+	w := performRequest(http.MethodGet, "/analytics/epic?key=test-project&epic=TP-1", EpicAnalytics)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
+
+func TestEpicAnalytics_MissingEpic(t *testing.T) {
+	w := performRequest(http.MethodGet, "/analytics/epic?key=test-project", EpicAnalytics)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestDependencyAnalytics(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.key AS issue, l.direction").
+		WithArgs("test-project", "default", "", "", "", "", "", false, "Blocks").
+		WillReturnRows(sqlmock.NewRows([]string{"issue", "direction", "linked"}).
+			AddRow("TP-1", "outward", "TP-2").
+			AddRow("TP-2", "inward", "TP-1").
+			AddRow("TP-2", "outward", "TP-3").
+			AddRow("TP-3", "inward", "OTHER-1").
+			AddRow("TP-4", "outward", "TP-5").
+			AddRow("TP-5", "outward", "TP-4"),
+		)
+	mock.ExpectQuery(`SELECT .*WHERE p.source = .+ AND i.key IN .+ AND \(.+ OR i.deletedTime IS NULL\)`).
+		WithArgs("default", "OTHER-1", "TP-1", "TP-2", "TP-3", "TP-4", "TP-5", false).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "project", "summary", "status", "done"}).
+			AddRow("OTHER-1", "OTHER", "Other", "Done", true).
+			AddRow("TP-1", "test-project", "First", "Open", false).
+			AddRow("TP-2", "test-project", "Second", "Open", false).
+			AddRow("TP-3", "test-project", "Third", "Open", false).
+			AddRow("TP-4", "test-project", "Fourth", "Open", false).
+			AddRow("TP-5", "test-project", "Fifth", "Open", false),
+		)
+
+	w := performRequest(http.MethodGet, "/analytics/dependencies?key=test-project", DependencyAnalytics)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var result dependencyGraphResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("can't decode response: %s", err)
+	}
+	if len(result.Edges) != 5 {
+		t.Errorf("expected 5 edges, got %v", result.Edges)
+	}
+	if !reflect.DeepEqual(result.Cycles, [][]string{{"TP-4", "TP-5"}}) {
+		t.Errorf("unexpected cycles %v", result.Cycles)
+	}
+	if !reflect.DeepEqual(result.Chains, [][]string{{"TP-1", "TP-2", "TP-3"}}) {
+		t.Errorf("unexpected chains %v", result.Chains)
+	}
+
+	nodes := make(map[string]dependencyNode)
+	for _, node := range result.Nodes {
+		nodes[node.Key] = node
+	}
+	if !nodes["OTHER-1"].External || nodes["TP-1"].External {
+		t.Errorf("unexpected external flags %+v", result.Nodes)
+	}
+	// closed OTHER-1 doesn't block TP-3 anymore
+	if !reflect.DeepEqual(nodes["TP-3"].BlockedBy, []string{"TP-2"}) {
+		t.Errorf("unexpected blockers of TP-3 %v", nodes["TP-3"].BlockedBy)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestDependencyAnalytics_NoLinks(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.key AS issue, l.direction").
+		WithArgs("test-project", "default", "", "", "", "", "", false, "Depends").
+		WillReturnRows(sqlmock.NewRows([]string{"issue", "direction", "linked"}))
+
+	w := performRequest(http.MethodGet, "/analytics/dependencies?key=test-project&linkType=Depends", DependencyAnalytics)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	expected := `{"link_type":"Depends","nodes":[],"edges":[],"cycles":[],"chains":[]}`
+	if w.Body.String() != expected {
+		t.Errorf("expected %s, got %s", expected, w.Body.String())
+	}
+}
+
+func TestDependencyAnalytics_DBError(t *testing.T) {
+	mock := setupMockDB(t)
+
+	expectSource(mock, "test-project", "default")
+	mock.ExpectQuery("SELECT i.key AS issue, l.direction").WillReturnError(fmt.Errorf("db error"))
+
+	w := performRequest(http.MethodGet, "/analytics/dependencies?key=test-project", DependencyAnalytics)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
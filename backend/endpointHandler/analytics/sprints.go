@@ -0,0 +1,172 @@
+package analytics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/endpointhandler/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// sprintIssues связывает задачи проекта со спринтами его scrum-досок (кроме будущих спринтов).
+// Задача выполнена в спринте, если закрыта между началом и завершением спринта (для активного - до текущего момента).
+// Задача добавлена после начала спринта, если создана позже или поле Sprint получило этот спринт после старта.
+// Story points берутся текущие, история оценок не учитывается.
+// У незакрытых задач closedTime - нулевое время '0001-01-01'
+const sprintIssues = `
+		WITH sprint_issues AS (
+			SELECT
+				s.id AS sprint_id,
+				s.name,
+				s.state,
+				s.startTime,
+				COALESCE(s.completeTime, s.endTime) AS end_time,
+				i.id AS issue_id,
+				COALESCE(sp.numberValue, 0) AS points,
+				COALESCE(i.closedTime > s.startTime AND i.closedTime <= COALESCE(s.completeTime, NOW()), false) AS completed,
+				COALESCE(i.closedTime > '0001-01-01' AND i.closedTime <= COALESCE(s.completeTime, NOW()), false) AS done,
+				(i.createdTime > s.startTime OR EXISTS (
+					SELECT 1 FROM IssueFieldChanges sc
+					WHERE sc.issueId = i.id AND sc.field = 'Sprint' AND sc.changeTime > s.startTime
+					  AND POSITION(s.name IN COALESCE(sc.toString, '')) > 0
+					  AND POSITION(s.name IN COALESCE(sc.fromString, '')) = 0
+				)) AS added
+			FROM Projects p
+			JOIN Board b ON b.projectId = p.id
+			JOIN Sprint s ON s.boardId = b.id
+			JOIN SprintIssue si ON si.sprintId = s.id
+			JOIN Issue i ON i.id = si.issueId
+			LEFT JOIN IssueCustomField sp ON sp.issueId = i.id AND sp.name = 'story_points' AND sp.valueIndex = 0
+			WHERE p.key = $1 AND p.source = $2 AND s.state <> 'future' AND s.startTime IS NOT NULL` + issueFilter + `
+		)`
+
+// VelocityAnalytics возвращает выполненные задачи и story points по спринтам проекта
+func VelocityAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var result []struct {
+		Sprint          string     `db:"sprint" json:"sprint"`
+		State           string     `db:"state" json:"state"`
+		StartTime       time.Time  `db:"start_time" json:"start_time"`
+		EndTime         *time.Time `db:"end_time" json:"end_time"`
+		CompletedIssues int        `db:"completed_issues" json:"completed_issues"`
+		CompletedPoints float64    `db:"completed_points" json:"completed_points"`
+	}
+
+	err := repository.DB.Select(&result, sprintIssues+`
+		SELECT
+			name AS sprint,
+			state,
+			startTime AS start_time,
+			end_time,
+			COUNT(*) FILTER (WHERE completed) AS completed_issues,
+			COALESCE(SUM(points) FILTER (WHERE completed), 0) AS completed_points
+		FROM sprint_issues
+		GROUP BY sprint_id, name, state, startTime, end_time
+		ORDER BY startTime, sprint_id
+	`, query.args()...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// SprintCommitmentAnalytics сравнивает обязательства спринта (задачи на момент старта)
+// с выполненным, задачи, добавленные после старта, считаются отдельно
+func SprintCommitmentAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var result []struct {
+		Sprint              string  `db:"sprint" json:"sprint"`
+		State               string  `db:"state" json:"state"`
+		CommittedIssues     int     `db:"committed_issues" json:"committed_issues"`
+		CommittedPoints     float64 `db:"committed_points" json:"committed_points"`
+		AddedIssues         int     `db:"added_issues" json:"added_issues"`
+		AddedPoints         float64 `db:"added_points" json:"added_points"`
+		CompletedIssues     int     `db:"completed_issues" json:"completed_issues"`
+		CompletedPoints     float64 `db:"completed_points" json:"completed_points"`
+		CommittedDone       int     `db:"committed_done" json:"committed_done"`
+		CommittedDonePoints float64 `db:"committed_done_points" json:"committed_done_points"`
+	}
+
+	err := repository.DB.Select(&result, sprintIssues+`
+		SELECT
+			name AS sprint,
+			state,
+			COUNT(*) FILTER (WHERE NOT added) AS committed_issues,
+			COALESCE(SUM(points) FILTER (WHERE NOT added), 0) AS committed_points,
+			COUNT(*) FILTER (WHERE added) AS added_issues,
+			COALESCE(SUM(points) FILTER (WHERE added), 0) AS added_points,
+			COUNT(*) FILTER (WHERE completed) AS completed_issues,
+			COALESCE(SUM(points) FILTER (WHERE completed), 0) AS completed_points,
+			COUNT(*) FILTER (WHERE completed AND NOT added) AS committed_done,
+			COALESCE(SUM(points) FILTER (WHERE completed AND NOT added), 0) AS committed_done_points
+		FROM sprint_issues
+		GROUP BY sprint_id, name, state, startTime
+		ORDER BY startTime, sprint_id
+	`, query.args()...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CarryOverAnalytics возвращает задачи, не выполненные в завершённых спринтах,
+// и сколько из них перенесено в следующие спринты (в том числе ещё не начатые)
+func CarryOverAnalytics(c *gin.Context) {
+	query, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var result []struct {
+		Sprint            string  `db:"sprint" json:"sprint"`
+		UnfinishedIssues  int     `db:"unfinished_issues" json:"unfinished_issues"`
+		UnfinishedPoints  float64 `db:"unfinished_points" json:"unfinished_points"`
+		CarriedOverIssues int     `db:"carried_over_issues" json:"carried_over_issues"`
+		CarriedOverPoints float64 `db:"carried_over_points" json:"carried_over_points"`
+	}
+
+	err := repository.DB.Select(&result, sprintIssues+`,
+		unfinished AS (
+			SELECT
+				cur.sprint_id,
+				cur.name,
+				cur.startTime,
+				cur.points,
+				EXISTS (
+					SELECT 1 FROM SprintIssue nsi
+					JOIN Sprint ns ON ns.id = nsi.sprintId
+					WHERE nsi.issueId = cur.issue_id AND ns.id <> cur.sprint_id
+					  AND (ns.state = 'future' OR ns.startTime > cur.startTime)
+				) AS carried
+			FROM sprint_issues cur
+			WHERE cur.state = 'closed' AND NOT cur.done
+		)
+		SELECT
+			name AS sprint,
+			COUNT(*) AS unfinished_issues,
+			COALESCE(SUM(points), 0) AS unfinished_points,
+			COUNT(*) FILTER (WHERE carried) AS carried_over_issues,
+			COALESCE(SUM(points) FILTER (WHERE carried), 0) AS carried_over_points
+		FROM unfinished
+		GROUP BY sprint_id, name, startTime
+		ORDER BY startTime, sprint_id
+	`, query.args()...)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,91 @@
+package analytics
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSprintAnalytics(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(14 * 24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		path    string
+		handler gin.HandlerFunc
+		query   string
+		rows    *sqlmock.Rows
+	}{
+		{
+			name:    "velocity",
+			path:    "/analytics/velocity",
+			handler: VelocityAnalytics,
+			query:   "WITH sprint_issues AS .*COUNT\\(\\*\\) FILTER \\(WHERE completed\\) AS completed_issues",
+			rows: sqlmock.NewRows([]string{"sprint", "state", "start_time", "end_time", "completed_issues", "completed_points"}).
+				AddRow("Sprint 1", "closed", start, end, 5, 13.0).
+				AddRow("Sprint 2", "active", end, nil, 1, 2.0),
+		},
+		{
+			name:    "commitment",
+			path:    "/analytics/sprint-commitment",
+			handler: SprintCommitmentAnalytics,
+			query:   "WITH sprint_issues AS .*AS committed_issues",
+			rows: sqlmock.NewRows([]string{"sprint", "state", "committed_issues", "committed_points", "added_issues",
+				"added_points", "completed_issues", "completed_points", "committed_done", "committed_done_points"}).
+				AddRow("Sprint 1", "closed", 6, 15.0, 2, 3.0, 5, 13.0, 4, 10.0),
+		},
+		{
+			name:    "carry-over",
+			path:    "/analytics/carry-over",
+			handler: CarryOverAnalytics,
+			query:   "WITH sprint_issues AS .*unfinished AS",
+			rows: sqlmock.NewRows([]string{"sprint", "unfinished_issues", "unfinished_points", "carried_over_issues", "carried_over_points"}).
+				AddRow("Sprint 1", 3, 5.0, 2, 3.0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := setupMockDB(t)
+
+			expectSource(mock, "test-project", "default")
+			mock.ExpectQuery(tt.query).
+				WithArgs("test-project", "default", "", "", "", "", "", false).
+				WillReturnRows(tt.rows)
+
+			w := performRequest(http.MethodGet, tt.path+"?key=test-project", tt.handler)
+			if w.Code != http.StatusOK {
+				t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %s", err)
+			}
+		})
+
+		t.Run(tt.name+" db error", func(t *testing.T) {
+			mock := setupMockDB(t)
+
+			expectSource(mock, "test-project", "default")
+			mock.ExpectQuery(tt.query).
+				WithArgs("test-project", "default", "team", "Core", "", "", "", false).
+				WillReturnError(fmt.Errorf("db error"))
+
+			w := performRequest(http.MethodGet, tt.path+"?key=test-project&customField=team&customValue=Core", tt.handler)
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("expected status 500, got %d", w.Code)
+			}
+		})
+
+		t.Run(tt.name+" missing key", func(t *testing.T) {
+			w := performRequest(http.MethodGet, tt.path, tt.handler)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/endpointhandler/config"
 	"github.com/endpointhandler/repository"
@@ -24,6 +30,39 @@ func main() {
 		log.Fatalf("Failed to init DB: %v", err)
 	}
 
-	r := router.SetupRouter(cfg)
-	log.Fatal(r.Run(":" + cfg.Server.Port))
+	srv := &http.Server{
+		Addr:    ":" + cfg.Server.Port,
+		Handler: router.SetupRouter(cfg),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErr:
+		repository.CloseDB()
+		log.Fatalf("Server error: %v", err)
+	case <-ctx.Done():
+	}
+
+	// stop accepting requests and wait for in-flight ones, then close DB pool
+	log.Printf("Shutting down, timeout %s", cfg.Server.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shutdown server gracefully: %v", err)
+		srv.Close()
+	}
+	if err := repository.CloseDB(); err != nil {
+		log.Printf("Failed to close DB: %v", err)
+	}
+	log.Println("Server stopped")
 }
@@ -1,33 +1,116 @@
 package compare
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/endpointhandler/handler/params"
 	"github.com/endpointhandler/repository"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 )
 
-func parseProjectKeys(c *gin.Context) ([]string, error) {
+// projectRef is a compared project. Label is the item as the client wrote it,
+// "KEY" or "source/KEY", it is used as the key of the response
+type projectRef struct {
+	Label  string
+	Source string
+	Key    string
+}
+
+func (p projectRef) String() string {
+	return p.Source + "/" + p.Key
+}
+
+func parseProjectKeys(c *gin.Context) ([]projectRef, error) {
 	raw := c.Query("key")
 	if raw == "" {
 		return nil, fmt.Errorf("missing ?key=KEY1,KEY2,...")
 	}
 	parts := strings.Split(raw, ",")
-	var keys []string
+	var projects []projectRef
 	for _, p := range parts {
-		key := strings.TrimSpace(p)
-		if key == "" {
+		label := strings.TrimSpace(p)
+		if label == "" {
 			continue
 		}
-		keys = append(keys, key)
+		project := projectRef{Label: label, Key: label}
+		if source, key, found := strings.Cut(label, "/"); found {
+			project.Source, project.Key = source, key
+		}
+		projects = append(projects, project)
 	}
-	if len(keys) == 0 {
+	if len(projects) == 0 {
 		return nil, fmt.Errorf("no valid project keys provided")
 	}
-	return keys, nil
+	return projects, nil
+}
+
+// resolveProjects parses the keys and finds sources of the keys without one.
+// On error the response is already written
+func resolveProjects(c *gin.Context) ([]projectRef, bool) {
+	projects, err := parseProjectKeys(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	for i := range projects {
+		source, err := repository.ResolveProjectSource(projects[i].Key, projects[i].Source)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, repository.ErrAmbiguousProject) {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return nil, false
+		}
+		projects[i].Source = source
+	}
+	return projects, true
+}
+
+// issueFilter limits issues i to params.Filter, its arguments are filterArgs.
+// An empty custom field value keeps all issues which have the field
+const issueFilter = `
+		  AND (? OR i.deletedTime IS NULL)
+		  AND (? = '' OR EXISTS (
+			SELECT 1 FROM IssueCustomField cf
+			WHERE cf.issueId = i.id AND cf.name = ? AND (? = '' OR cf.value = ?)
+		  ))
+		  AND (? = '' OR EXISTS (
+			SELECT 1 FROM IssueLabel il JOIN Label lb ON lb.id = il.labelId
+			WHERE il.issueId = i.id AND lb.name = ?
+		  ))
+		  AND (? = '' OR EXISTS (
+			SELECT 1 FROM IssueComponent ic JOIN Component co ON co.id = ic.componentId
+			WHERE ic.issueId = i.id AND co.name = ?
+		  ))
+		  AND (? = '' OR EXISTS (
+			SELECT 1 FROM IssueFixVersion ifv JOIN Version fv ON fv.id = ifv.versionId
+			WHERE ifv.issueId = i.id AND fv.name = ?
+		  ))`
+
+// filterArgs returns arguments of issueFilter in the order of its placeholders
+func filterArgs(f params.Filter) []any {
+	return []any{f.IncludeDeleted,
+		f.CustomField, f.CustomField, f.CustomValue, f.CustomValue,
+		f.Label, f.Label,
+		f.Component, f.Component,
+		f.FixVersion, f.FixVersion}
+}
+
+// projectLabels maps "source/KEY" of the query rows to the labels of the request
+func projectLabels(projects []projectRef) ([]string, map[string]string) {
+	refs := make([]string, 0, len(projects))
+	labels := make(map[string]string, len(projects))
+	for _, p := range projects {
+		refs = append(refs, p.String())
+		labels[p.String()] = p.Label
+	}
+	return refs, labels
 }
 
 type AgeRangeCount struct {
@@ -36,15 +119,18 @@ type AgeRangeCount struct {
 }
 
 func CompareTimeOpen(c *gin.Context) {
-	keys, err := parseProjectKeys(c)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	projects, ok := resolveProjects(c)
+	if !ok {
+		return
+	}
+	filter, ok := params.IssueFilter(c)
+	if !ok {
 		return
 	}
 
 	response := make(map[string][]AgeRangeCount)
 
-	for _, key := range keys {
+	for _, project := range projects {
 		var ranges []AgeRangeCount
 
 		query := `
@@ -66,41 +152,46 @@ func CompareTimeOpen(c *gin.Context) {
 				SELECT DATE_PART('day', NOW() - i.createdTime) AS age
 				FROM Projects p
 				JOIN Issue i ON p.id = i.projectId
-				WHERE i.status NOT IN ('Closed', 'Resolved') AND p.key = $1
+				WHERE i.status NOT IN ('Closed', 'Resolved') AND p.key = ? AND p.source = ?` + issueFilter + `
 			) sub
 			GROUP BY range
 			ORDER BY MIN(age)
 		`
+		args := append([]any{project.Key, project.Source}, filterArgs(filter)...)
 
-		if err := repository.DB.Select(&ranges, query, key); err != nil {
+		if err := repository.DB.Select(&ranges, repository.DB.Rebind(query), args...); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 
-		response[key] = ranges
+		response[project.Label] = ranges
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
 func CompareStatusDistribution(c *gin.Context) {
-	keys, err := parseProjectKeys(c)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	projects, ok := resolveProjects(c)
+	if !ok {
+		return
+	}
+	filter, ok := params.IssueFilter(c)
+	if !ok {
 		return
 	}
 
+	refs, labels := projectLabels(projects)
 	query, args, _ := sqlx.In(`
 		SELECT 
-			p.key AS project,
+			p.source || '/' || p.key AS project,
 			i.status,
 			COUNT(*) AS count
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		WHERE p.key IN (?)
-		GROUP BY p.key, i.status
-		ORDER BY p.key, i.status
-	`, keys)
+		WHERE p.source || '/' || p.key IN (?)`+issueFilter+`
+		GROUP BY p.source, p.key, i.status
+		ORDER BY p.source, p.key, i.status
+	`, append([]any{refs}, filterArgs(filter)...)...)
 	query = repository.DB.Rebind(query)
 
 	var rows []struct {
@@ -115,39 +206,54 @@ func CompareStatusDistribution(c *gin.Context) {
 
 	response := make(map[string]map[string]int)
 	for _, r := range rows {
-		if _, exists := response[r.Project]; !exists {
-			response[r.Project] = make(map[string]int)
+		label := labels[r.Project]
+		if _, exists := response[label]; !exists {
+			response[label] = make(map[string]int)
 		}
-		response[r.Project][r.Status] = r.Count
+		response[label][r.Status] = r.Count
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// CompareTimeSpent compares time logged in worklogs by their authors
 func CompareTimeSpent(c *gin.Context) {
-	keys, err := parseProjectKeys(c)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	projects, ok := resolveProjects(c)
+	if !ok {
+		return
+	}
+	from, to, ok := params.Period(c)
+	if !ok {
+		return
+	}
+	filter, ok := params.IssueFilter(c)
+	if !ok {
 		return
 	}
 
+	refs, labels := projectLabels(projects)
 	query, args, _ := sqlx.In(`
 		SELECT 
-			p.key AS project,
+			p.source || '/' || p.key AS project,
 			a.name AS author,
-			SUM(i.timeSpent) AS total_time_spent
+			a.accountId AS author_id,
+			SUM(w.timeSpentSeconds) AS total_time_spent
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		JOIN Author a ON a.id = i.authorId
-		WHERE p.key IN (?) AND i.timeSpent IS NOT NULL
-		GROUP BY p.key, a.name
-		ORDER BY p.key, total_time_spent DESC
-	`, keys)
+		JOIN Worklog w ON w.issueId = i.id
+		JOIN Author a ON a.id = w.authorId
+		WHERE p.source || '/' || p.key IN (?)
+		  AND (CAST(? AS timestamp) IS NULL OR w.started >= ?)
+		  AND (CAST(? AS timestamp) IS NULL OR w.started < ?)`+issueFilter+`
+		GROUP BY p.source, p.key, a.accountId, a.name
+		ORDER BY p.source, p.key, total_time_spent DESC
+	`, append([]any{refs, from, from, to, to}, filterArgs(filter)...)...)
 	query = repository.DB.Rebind(query)
 
 	var rows []struct {
 		Project        string `db:"project" json:"project"`
 		Author         string `db:"author" json:"author"`
+		AuthorId       string `db:"author_id" json:"author_id"`
 		TotalTimeSpent int    `db:"total_time_spent" json:"total_time_spent"`
 	}
 	if err := repository.DB.Select(&rows, query, args...); err != nil {
@@ -157,41 +263,48 @@ func CompareTimeSpent(c *gin.Context) {
 
 	type authorStat struct {
 		Author         string `json:"author"`
+		AuthorId       string `json:"author_id"`
 		TotalTimeSpent int    `json:"total_time_spent"`
 	}
 	response := make(map[string]struct {
 		Authors []authorStat `json:"authors"`
 	})
 	for _, r := range rows {
-		projectBlock := response[r.Project]
+		label := labels[r.Project]
+		projectBlock := response[label]
 		projectBlock.Authors = append(projectBlock.Authors, authorStat{
 			Author:         r.Author,
+			AuthorId:       r.AuthorId,
 			TotalTimeSpent: r.TotalTimeSpent,
 		})
-		response[r.Project] = projectBlock
+		response[label] = projectBlock
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
 func ComparePriority(c *gin.Context) {
-	keys, err := parseProjectKeys(c)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	projects, ok := resolveProjects(c)
+	if !ok {
+		return
+	}
+	filter, ok := params.IssueFilter(c)
+	if !ok {
 		return
 	}
 
+	refs, labels := projectLabels(projects)
 	query, args, _ := sqlx.In(`
 		SELECT 
-			p.key AS project,
+			p.source || '/' || p.key AS project,
 			i.priority,
 			COUNT(*) AS count
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		WHERE p.key IN (?)
-		GROUP BY p.key, i.priority
-		ORDER BY p.key, i.priority
-	`, keys)
+		WHERE p.source || '/' || p.key IN (?)`+issueFilter+`
+		GROUP BY p.source, p.key, i.priority
+		ORDER BY p.source, p.key, i.priority
+	`, append([]any{refs}, filterArgs(filter)...)...)
 	query = repository.DB.Rebind(query)
 
 	var rows []struct {
@@ -206,10 +319,11 @@ func ComparePriority(c *gin.Context) {
 
 	response := make(map[string]map[string]int)
 	for _, r := range rows {
-		if _, exists := response[r.Project]; !exists {
-			response[r.Project] = make(map[string]int)
+		label := labels[r.Project]
+		if _, exists := response[label]; !exists {
+			response[label] = make(map[string]int)
 		}
-		response[r.Project][r.Priority] = r.Count
+		response[label][r.Priority] = r.Count
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -7,8 +7,10 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/endpointhandler/handler/params"
 	"github.com/endpointhandler/repository"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
@@ -36,6 +38,17 @@ func setupDB(t *testing.T) (sqlmock.Sqlmock, func()) {
 	}
 }
 
+// expectSource expects the lookup of the project source by key
+func expectSource(mock sqlmock.Sqlmock, key string, sources ...string) {
+	rows := sqlmock.NewRows([]string{"source"})
+	for _, source := range sources {
+		rows.AddRow(source)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT source FROM Projects")).
+		WithArgs(key).
+		WillReturnRows(rows)
+}
+
 func setupRouterWithHandler(path string, handlerFunc gin.HandlerFunc) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
@@ -51,7 +64,8 @@ func TestCompareTimeOpen_Success(t *testing.T) {
 		AddRow("0-1", 10).
 		AddRow("1-2", 5)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`
+	expectSource(mock, "TESTKEY", "default")
+	mock.ExpectQuery(regexp.QuoteMeta(repository.DB.Rebind(`
 			SELECT
 				CASE
 					WHEN age <= 1 THEN '0-1'
@@ -70,12 +84,12 @@ func TestCompareTimeOpen_Success(t *testing.T) {
 				SELECT DATE_PART('day', NOW() - i.createdTime) AS age
 				FROM Projects p
 				JOIN Issue i ON p.id = i.projectId
-				WHERE i.status NOT IN ('Closed', 'Resolved') AND p.key = $1
+				WHERE i.status NOT IN ('Closed', 'Resolved') AND p.key = ? AND p.source = ?` + issueFilter + `
 			) sub
 			GROUP BY range
 			ORDER BY MIN(age)
-		`)).
-		WithArgs("TESTKEY").
+		`))).
+		WithArgs(toDriverValues(append([]any{"TESTKEY", "default"}, filterArgs(params.Filter{})...))...).
 		WillReturnRows(rows)
 
 	r := setupRouterWithHandler("/api/v1/compare/time-open", CompareTimeOpen)
@@ -112,6 +126,73 @@ func TestCompareTimeOpen_MissingKey(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "missing ?key")
 }
 
+func TestCompareTimeOpen_IncludeDeleted(t *testing.T) {
+	mock, closeDB := setupDB(t)
+	defer closeDB()
+
+	expectSource(mock, "TESTKEY", "default")
+	mock.ExpectQuery(`i.deletedTime IS NULL`).
+		WithArgs(toDriverValues(append([]any{"TESTKEY", "default"}, filterArgs(params.Filter{IncludeDeleted: true})...))...).
+		WillReturnRows(sqlmock.NewRows([]string{"range", "count"}).AddRow("0-1", 4))
+
+	r := setupRouterWithHandler("/api/v1/compare/time-open", CompareTimeOpen)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/time-open?key=TESTKEY&includeDeleted=true", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompareTimeOpen_InvalidIncludeDeleted(t *testing.T) {
+	mock, closeDB := setupDB(t)
+	defer closeDB()
+
+	expectSource(mock, "TESTKEY", "default")
+
+	r := setupRouterWithHandler("/api/v1/compare/time-open", CompareTimeOpen)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/time-open?key=TESTKEY&includeDeleted=yes-please", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCompareTimeOpen_Filters(t *testing.T) {
+	mock, closeDB := setupDB(t)
+	defer closeDB()
+
+	filter := params.Filter{CustomField: "team", CustomValue: "Core", Label: "backend", Component: "API", FixVersion: "1.0"}
+	expectSource(mock, "TESTKEY", "default")
+	mock.ExpectQuery(`IssueCustomField cf.*IssueLabel il.*IssueComponent ic.*IssueFixVersion ifv`).
+		WithArgs(toDriverValues(append([]any{"TESTKEY", "default"}, filterArgs(filter)...))...).
+		WillReturnRows(sqlmock.NewRows([]string{"range", "count"}).AddRow("0-1", 1))
+
+	r := setupRouterWithHandler("/api/v1/compare/time-open", CompareTimeOpen)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/time-open?key=TESTKEY&customField=team&customValue=Core&label=backend&component=API&fixVersion=1.0", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompareTimeOpen_CustomValueWithoutField(t *testing.T) {
+	mock, closeDB := setupDB(t)
+	defer closeDB()
+
+	expectSource(mock, "TESTKEY", "default")
+
+	r := setupRouterWithHandler("/api/v1/compare/time-open", CompareTimeOpen)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/time-open?key=TESTKEY&customValue=Core", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "customValue requires customField")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // --- CompareStatusDistribution ---
 
 func TestCompareStatusDistribution(t *testing.T) {
@@ -119,23 +200,26 @@ func TestCompareStatusDistribution(t *testing.T) {
 	defer closeDB()
 
 	rows := sqlmock.NewRows([]string{"project", "status", "count"}).
-		AddRow("PROJ1", "Open", 3).
-		AddRow("PROJ1", "In Progress", 7).
-		AddRow("PROJ2", "Open", 2)
+		AddRow("default/PROJ1", "Open", 3).
+		AddRow("default/PROJ1", "In Progress", 7).
+		AddRow("cloud/PROJ2", "Open", 2)
+
+	// PROJ2 has explicit source, so only PROJ1 is looked up
+	expectSource(mock, "PROJ1", "default")
 
-	keys := []string{"PROJ1", "PROJ2"}
+	keys := []string{"default/PROJ1", "cloud/PROJ2"}
 	query := `
 		SELECT 
-			p.key AS project,
+			p.source || '/' || p.key AS project,
 			i.status,
 			COUNT(*) AS count
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		WHERE p.key IN (?)
-		GROUP BY p.key, i.status
-		ORDER BY p.key, i.status
+		WHERE p.source || '/' || p.key IN (?)` + issueFilter + `
+		GROUP BY p.source, p.key, i.status
+		ORDER BY p.source, p.key, i.status
 	`
-	rebQuery, args, err := sqlx.In(query, keys)
+	rebQuery, args, err := sqlx.In(query, append([]any{keys}, filterArgs(params.Filter{})...)...)
 	assert.NoError(t, err)
 	rebQuery = repository.DB.Rebind(rebQuery)
 
@@ -145,7 +229,7 @@ func TestCompareStatusDistribution(t *testing.T) {
 
 	r := setupRouterWithHandler("/api/v1/compare/status-distribution", CompareStatusDistribution)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/status-distribution?key=PROJ1,PROJ2", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/status-distribution?key=PROJ1,cloud/PROJ2", nil)
 	w := httptest.NewRecorder()
 
 	r.ServeHTTP(w, req)
@@ -158,7 +242,7 @@ func TestCompareStatusDistribution(t *testing.T) {
 
 	assert.Equal(t, 3, resp["PROJ1"]["Open"])
 	assert.Equal(t, 7, resp["PROJ1"]["In Progress"])
-	assert.Equal(t, 2, resp["PROJ2"]["Open"])
+	assert.Equal(t, 2, resp["cloud/PROJ2"]["Open"])
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -167,18 +251,20 @@ func TestCompareStatusDistribution_DBError(t *testing.T) {
 	mock, closeDB := setupDB(t)
 	defer closeDB()
 
+	expectSource(mock, "PROJ1", "default")
+
 	query := `
 		SELECT 
-			p.key AS project,
+			p.source || '/' || p.key AS project,
 			i.status,
 			COUNT(*) AS count
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		WHERE p.key IN (?)
-		GROUP BY p.key, i.status
-		ORDER BY p.key, i.status
+		WHERE p.source || '/' || p.key IN (?)` + issueFilter + `
+		GROUP BY p.source, p.key, i.status
+		ORDER BY p.source, p.key, i.status
 	`
-	rebQuery, _, err := sqlx.In(query, []string{"PROJ1"})
+	rebQuery, _, err := sqlx.In(query, append([]any{[]string{"default/PROJ1"}}, filterArgs(params.Filter{})...)...)
 	assert.NoError(t, err)
 	rebQuery = repository.DB.Rebind(rebQuery)
 
@@ -204,30 +290,16 @@ func TestCompareTimeSpent(t *testing.T) {
 	mock, closeDB := setupDB(t)
 	defer closeDB()
 
-	rows := sqlmock.NewRows([]string{"project", "author", "total_time_spent"}).
-		AddRow("PROJ1", "Alice", 100).
-		AddRow("PROJ1", "Bob", 50).
-		AddRow("PROJ2", "Charlie", 75)
+	rows := sqlmock.NewRows([]string{"project", "author", "author_id", "total_time_spent"}).
+		AddRow("default/PROJ1", "Alice", "5b10a1", 100).
+		AddRow("default/PROJ1", "Bob", "5b10b1", 50).
+		AddRow("default/PROJ2", "Charlie", "5b10c1", 75)
 
-	keys := []string{"PROJ1", "PROJ2"}
-	query := `
-		SELECT 
-			p.key AS project,
-			a.name AS author,
-			SUM(i.timeSpent) AS total_time_spent
-		FROM Projects p
-		JOIN Issue i ON p.id = i.projectId
-		JOIN Author a ON a.id = i.authorId
-		WHERE p.key IN (?) AND i.timeSpent IS NOT NULL
-		GROUP BY p.key, a.name
-		ORDER BY p.key, total_time_spent DESC
-	`
-	rebQuery, args, err := sqlx.In(query, keys)
-	assert.NoError(t, err)
-	rebQuery = repository.DB.Rebind(rebQuery)
+	expectSource(mock, "PROJ1", "default")
+	expectSource(mock, "PROJ2", "default")
 
-	mock.ExpectQuery(regexp.QuoteMeta(rebQuery)).
-		WithArgs(toDriverValues(args)...).
+	mock.ExpectQuery(`SUM\(w.timeSpentSeconds\) AS total_time_spent.*JOIN Worklog w`).
+		WithArgs(toDriverValues(append([]any{"default/PROJ1", "default/PROJ2", nil, nil, nil, nil}, filterArgs(params.Filter{})...))...).
 		WillReturnRows(rows)
 
 	r := setupRouterWithHandler("/api/v1/compare/time-spent", CompareTimeSpent)
@@ -242,19 +314,83 @@ func TestCompareTimeSpent(t *testing.T) {
 	var resp map[string]struct {
 		Authors []struct {
 			Author         string `json:"author"`
+			AuthorId       string `json:"author_id"`
 			TotalTimeSpent int    `json:"total_time_spent"`
 		} `json:"authors"`
 	}
 
-	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	assert.NoError(t, err)
 
 	assert.Len(t, resp["PROJ1"].Authors, 2)
 	assert.Len(t, resp["PROJ2"].Authors, 1)
+	assert.Equal(t, "5b10b1", resp["PROJ1"].Authors[1].AuthorId)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestCompareTimeSpent_Period(t *testing.T) {
+	mock, closeDB := setupDB(t)
+	defer closeDB()
+
+	expectSource(mock, "PROJ1", "default")
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	// the last day is included
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`JOIN Worklog w`).
+		WithArgs(toDriverValues(append([]any{"default/PROJ1", from, from, to, to}, filterArgs(params.Filter{})...))...).
+		WillReturnRows(sqlmock.NewRows([]string{"project", "author", "author_id", "total_time_spent"}).
+			AddRow("default/PROJ1", "Alice", "5b10a1", 3600))
+
+	r := setupRouterWithHandler("/api/v1/compare/time-spent", CompareTimeSpent)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/time-spent?key=PROJ1&from=2024-03-01&to=2024-03-31", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompareTimeSpent_InvalidPeriod(t *testing.T) {
+	for _, period := range []string{"from=01.03.2024", "to=tomorrow", "from=2024-03-02&to=2024-03-01"} {
+		t.Run(period, func(t *testing.T) {
+			mock, closeDB := setupDB(t)
+			defer closeDB()
+
+			expectSource(mock, "PROJ1", "default")
+
+			r := setupRouterWithHandler("/api/v1/compare/time-spent", CompareTimeSpent)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/time-spent?key=PROJ1&"+period, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestCompareTimeSpent_Filters(t *testing.T) {
+	mock, closeDB := setupDB(t)
+	defer closeDB()
+
+	expectSource(mock, "PROJ1", "default")
+
+	filter := params.Filter{Label: "backend", FixVersion: "2.0"}
+	mock.ExpectQuery(`JOIN Worklog w.*IssueLabel il`).
+		WithArgs(toDriverValues(append([]any{"default/PROJ1", nil, nil, nil, nil}, filterArgs(filter)...))...).
+		WillReturnRows(sqlmock.NewRows([]string{"project", "author", "author_id", "total_time_spent"}).
+			AddRow("default/PROJ1", "Alice", "5b10a1", 600))
+
+	r := setupRouterWithHandler("/api/v1/compare/time-spent", CompareTimeSpent)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/time-spent?key=PROJ1&label=backend&fixVersion=2.0", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // --- ComparePriority ---
 
 func TestComparePriority(t *testing.T) {
@@ -262,23 +398,26 @@ func TestComparePriority(t *testing.T) {
 	defer closeDB()
 
 	rows := sqlmock.NewRows([]string{"project", "priority", "count"}).
-		AddRow("PROJ1", "High", 5).
-		AddRow("PROJ1", "Low", 3).
-		AddRow("PROJ2", "Medium", 7)
+		AddRow("default/PROJ1", "High", 5).
+		AddRow("default/PROJ1", "Low", 3).
+		AddRow("default/PROJ2", "Medium", 7)
+
+	expectSource(mock, "PROJ1", "default")
+	expectSource(mock, "PROJ2", "default")
 
-	keys := []string{"PROJ1", "PROJ2"}
+	keys := []string{"default/PROJ1", "default/PROJ2"}
 	query := `
 		SELECT 
-			p.key AS project,
+			p.source || '/' || p.key AS project,
 			i.priority,
 			COUNT(*) AS count
 		FROM Projects p
 		JOIN Issue i ON p.id = i.projectId
-		WHERE p.key IN (?)
-		GROUP BY p.key, i.priority
-		ORDER BY p.key, i.priority
+		WHERE p.source || '/' || p.key IN (?)` + issueFilter + `
+		GROUP BY p.source, p.key, i.priority
+		ORDER BY p.source, p.key, i.priority
 	`
-	rebQuery, args, err := sqlx.In(query, keys)
+	rebQuery, args, err := sqlx.In(query, append([]any{keys}, filterArgs(params.Filter{})...)...)
 	assert.NoError(t, err)
 	rebQuery = repository.DB.Rebind(rebQuery)
 
@@ -306,6 +445,27 @@ func TestComparePriority(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestComparePriority_Filters(t *testing.T) {
+	mock, closeDB := setupDB(t)
+	defer closeDB()
+
+	expectSource(mock, "PROJ1", "default")
+
+	filter := params.Filter{CustomField: "sprint", Component: "UI", IncludeDeleted: true}
+	mock.ExpectQuery(`i.priority.*IssueCustomField cf.*IssueComponent ic`).
+		WithArgs(toDriverValues(append([]any{"default/PROJ1"}, filterArgs(filter)...))...).
+		WillReturnRows(sqlmock.NewRows([]string{"project", "priority", "count"}).AddRow("default/PROJ1", "High", 2))
+
+	r := setupRouterWithHandler("/api/v1/compare/priority", ComparePriority)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/priority?key=PROJ1&customField=sprint&component=UI&includeDeleted=true", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"High":2`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestCompareStatusDistribution_MissingKey(t *testing.T) {
 	r := setupRouterWithHandler("/api/v1/compare/status-distribution", CompareStatusDistribution)
 
@@ -341,3 +501,35 @@ func TestComparePriority_MissingKey(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), "missing ?key")
 }
+
+func TestCompareStatusDistribution_AmbiguousKey(t *testing.T) {
+	mock, closeDB := setupDB(t)
+	defer closeDB()
+
+	expectSource(mock, "PROJ1", "cloud", "default")
+
+	r := setupRouterWithHandler("/api/v1/compare/status-distribution", CompareStatusDistribution)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compare/status-distribution?key=PROJ1", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "several sources")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParseProjectKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?key=PROJ1,%20cloud/PROJ2,,", nil)
+
+	projects, err := parseProjectKeys(c)
+	assert.NoError(t, err)
+	assert.Equal(t, []projectRef{
+		{Label: "PROJ1", Key: "PROJ1"},
+		{Label: "cloud/PROJ2", Source: "cloud", Key: "PROJ2"},
+	}, projects)
+}
@@ -3,11 +3,16 @@ package config
 import (
 	"gopkg.in/yaml.v3"
 	"os"
+	"time"
 )
 
+const defaultShutdownTimeout = 30 * time.Second
+
 type Config struct {
 	Server struct {
 		Port string `yaml:"port"`
+		// in-flight requests are cancelled when the timeout expires on shutdown
+		ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
 	} `yaml:"server"`
 	Database struct {
 		Host     string `yaml:"host"`
@@ -35,5 +40,9 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.Server.ShutdownTimeout <= 0 {
+		cfg.Server.ShutdownTimeout = defaultShutdownTimeout
+	}
+
 	return &cfg, nil
 }
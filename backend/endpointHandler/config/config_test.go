@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig_Success(t *testing.T) {
@@ -49,6 +50,33 @@ connector:
 	if cfg.Connector.BaseURL != "http://localhost:8080/api/v1/connector" {
 		t.Errorf("unexpected connector.baseURL: %s", cfg.Connector.BaseURL)
 	}
+	if cfg.Server.ShutdownTimeout != 30*time.Second {
+		t.Errorf("expected default server.shutdown_timeout=30s, got %s", cfg.Server.ShutdownTimeout)
+	}
+}
+
+func TestLoadConfig_ShutdownTimeout(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString("server:\n  port: \"8000\"\n  shutdown_timeout: 5s\n"); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		t.Fatalf("failed to close temp file: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	if cfg.Server.ShutdownTimeout != 5*time.Second {
+		t.Errorf("expected server.shutdown_timeout=5s, got %s", cfg.Server.ShutdownTimeout)
+	}
 }
 
 func TestLoadConfig_FileNotFound(t *testing.T) {
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"github.com/endpointhandler/config"
 	"net/http"
 	"net/url"
 	"strconv"
 
+	"github.com/endpointhandler/handler/params"
 	"github.com/endpointhandler/service"
 	"github.com/gin-gonic/gin"
 )
@@ -28,7 +30,12 @@ func GetProjectStats(c *gin.Context) {
 		return
 	}
 
-	stats, err := service.GetProjectStats(id)
+	includeDeleted, ok := params.IncludeDeleted(c)
+	if !ok {
+		return
+	}
+
+	stats, err := service.GetProjectStats(id, includeDeleted)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not get project stats"})
 		return
@@ -51,12 +58,14 @@ func GetJiraProjects(c *gin.Context, cfg *config.Config) {
 	limit := c.DefaultQuery("limit", "20")
 	page := c.DefaultQuery("page", "1")
 	search := c.DefaultQuery("search", "")
+	source := c.DefaultQuery("source", "")
 
-	reqURL := fmt.Sprintf("%s/projects?limit=%s&page=%s&search=%s",
+	reqURL := fmt.Sprintf("%s/projects?limit=%s&page=%s&search=%s&source=%s",
 		cfg.Connector.BaseURL,
 		url.QueryEscape(limit),
 		url.QueryEscape(page),
 		url.QueryEscape(search),
+		url.QueryEscape(source),
 	)
 
 	resp, err := http.Get(reqURL)
@@ -76,10 +85,65 @@ func GetJiraProjects(c *gin.Context, cfg *config.Config) {
 
 func UpdateJiraProject(c *gin.Context, cfg *config.Config) {
 	key := c.Query("project")
-	result, err := service.UpdateJiraProject(cfg, key)
+	mode := c.Query("mode")
+	if mode != "" && mode != "incremental" && mode != "full" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be incremental or full"})
+		return
+	}
+
+	result, err := service.UpdateJiraProject(cfg, c.Query("source"), key, mode)
 	if err != nil {
+		var connErr *service.ConnectorError
+		if errors.As(err, &connErr) {
+			c.JSON(connErr.StatusCode, gin.H{"error": connErr.Message})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusAccepted, result)
+}
+
+func GetConnectorJobs(c *gin.Context, cfg *config.Config) {
+	reqURL := fmt.Sprintf("%s/jobs?limit=%s", cfg.Connector.BaseURL, url.QueryEscape(c.DefaultQuery("limit", "20")))
+	proxyConnector(c, http.MethodGet, reqURL)
+}
+
+func GetConnectorJob(c *gin.Context, cfg *config.Config) {
+	reqURL := fmt.Sprintf("%s/jobs/%s", cfg.Connector.BaseURL, url.PathEscape(c.Param("id")))
+	proxyConnector(c, http.MethodGet, reqURL)
+}
+
+func CancelConnectorJob(c *gin.Context, cfg *config.Config) {
+	reqURL := fmt.Sprintf("%s/jobs/%s/cancel", cfg.Connector.BaseURL, url.PathEscape(c.Param("id")))
+	proxyConnector(c, http.MethodPost, reqURL)
+}
+
+// RetransformJiraProject starts rebuilding the project from the archive of raw
+// issues in jiraConnector, Jira isn't contacted
+func RetransformJiraProject(c *gin.Context, cfg *config.Config) {
+	params := url.Values{"project": {c.Query("project")}}
+	if source := c.Query("source"); source != "" {
+		params.Set("source", source)
+	}
+	reqURL := fmt.Sprintf("%s/retransform?%s", cfg.Connector.BaseURL, params.Encode())
+	proxyConnector(c, http.MethodPost, reqURL)
+}
+
+// proxyConnector passes the connector answer as is, including its error status
+func proxyConnector(c *gin.Context, method, reqURL string) {
+	req, err := http.NewRequestWithContext(c.Request.Context(), method, reqURL, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to contact connector"})
+		return
+	}
+	defer resp.Body.Close()
+
+	c.DataFromReader(resp.StatusCode, resp.ContentLength, resp.Header.Get("Content-Type"), resp.Body, nil)
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -26,6 +27,18 @@ func setupRouter(cfg *config.Config) *gin.Engine {
 		connector.POST("/updateProject", func(c *gin.Context) {
 			UpdateJiraProject(c, cfg)
 		})
+		connector.POST("/retransform", func(c *gin.Context) {
+			RetransformJiraProject(c, cfg)
+		})
+		connector.GET("/jobs", func(c *gin.Context) {
+			GetConnectorJobs(c, cfg)
+		})
+		connector.GET("/jobs/:id", func(c *gin.Context) {
+			GetConnectorJob(c, cfg)
+		})
+		connector.POST("/jobs/:id/cancel", func(c *gin.Context) {
+			CancelConnectorJob(c, cfg)
+		})
 	}
 
 	analytics := api.Group("/analytics")
@@ -115,6 +128,16 @@ func TestGetProjectStats_BadRequest(t *testing.T) {
 	}
 }
 
+func TestGetProjectStats_InvalidIncludeDeleted(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/projects/1?includeDeleted=maybe", nil)
+	setupRouter(nil).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
 func TestUpdateJiraProject_EmptyParam(t *testing.T) {
 	cfg := &config.Config{}
 	w := httptest.NewRecorder()
@@ -136,3 +159,199 @@ func TestGetJiraProjects_MissingParams(t *testing.T) {
 		t.Errorf("expected 400 or 500, got %d", w.Code)
 	}
 }
+
+func TestUpdateJiraProject_ConnectorStatus(t *testing.T) {
+	connector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("project") == "UNKNOWN" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"jira doesn't have such project"}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":3,"project":"PRJ","state":"queued"}`))
+	}))
+	defer connector.Close()
+
+	cfg := &config.Config{}
+	cfg.Connector.BaseURL = connector.URL
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/connector/updateProject?project=PRJ", nil)
+	setupRouter(cfg).ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted || !strings.Contains(w.Body.String(), `"id":3`) {
+		t.Errorf("expected accepted job, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/connector/updateProject?project=UNKNOWN", nil)
+	setupRouter(cfg).ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestUpdateJiraProject_Mode(t *testing.T) {
+	var gotMode string
+	connector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMode = r.URL.Query().Get("mode")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":4,"project":"PRJ","mode":"full","state":"queued"}`))
+	}))
+	defer connector.Close()
+
+	cfg := &config.Config{}
+	cfg.Connector.BaseURL = connector.URL
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/connector/updateProject?project=PRJ&mode=full", nil)
+	setupRouter(cfg).ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted || gotMode != "full" {
+		t.Errorf("expected mode full forwarded, got %d mode %q", w.Code, gotMode)
+	}
+
+	gotMode = ""
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/connector/updateProject?project=PRJ&mode=partial", nil)
+	setupRouter(cfg).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest || gotMode != "" {
+		t.Errorf("expected 400 without connector call, got %d mode %q", w.Code, gotMode)
+	}
+}
+
+func TestGetConnectorJobs(t *testing.T) {
+	connector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/jobs":
+			if r.URL.Query().Get("limit") != "5" {
+				t.Errorf("unexpected limit: %s", r.URL.Query().Get("limit"))
+			}
+			w.Write([]byte(`[{"id":2},{"id":1}]`))
+		case "/jobs/1":
+			w.Write([]byte(`{"id":1,"state":"running"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"there is no such update job"}`))
+		}
+	}))
+	defer connector.Close()
+
+	cfg := &config.Config{}
+	cfg.Connector.BaseURL = connector.URL
+
+	tests := []struct {
+		url    string
+		status int
+		body   string
+	}{
+		{"/api/connector/jobs?limit=5", http.StatusOK, `[{"id":2},{"id":1}]`},
+		{"/api/connector/jobs/1", http.StatusOK, `{"id":1,"state":"running"}`},
+		{"/api/connector/jobs/2", http.StatusNotFound, `{"error":"there is no such update job"}`},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", tt.url, nil)
+		setupRouter(cfg).ServeHTTP(w, req)
+
+		if w.Code != tt.status || w.Body.String() != tt.body {
+			t.Errorf("%s: unexpected answer %d: %s", tt.url, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestCancelConnectorJob(t *testing.T) {
+	connector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/jobs/1/cancel":
+			w.Write([]byte(`{"id":1,"state":"cancelled"}`))
+		default:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":"update job is already finished"}`))
+		}
+	}))
+	defer connector.Close()
+
+	cfg := &config.Config{}
+	cfg.Connector.BaseURL = connector.URL
+
+	tests := []struct {
+		url    string
+		status int
+		body   string
+	}{
+		{"/api/connector/jobs/1/cancel", http.StatusOK, `{"id":1,"state":"cancelled"}`},
+		{"/api/connector/jobs/2/cancel", http.StatusConflict, `{"error":"update job is already finished"}`},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", tt.url, nil)
+		setupRouter(cfg).ServeHTTP(w, req)
+
+		if w.Code != tt.status || w.Body.String() != tt.body {
+			t.Errorf("%s: unexpected answer %d: %s", tt.url, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestRetransformJiraProject(t *testing.T) {
+	connector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || r.URL.Path != "/retransform" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		switch r.URL.RawQuery {
+		case "project=AAR&source=cloud":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":1,"mode":"retransform","state":"queued"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"project isn't archived"}`))
+		}
+	}))
+	defer connector.Close()
+
+	cfg := &config.Config{}
+	cfg.Connector.BaseURL = connector.URL
+
+	tests := []struct {
+		url    string
+		status int
+		body   string
+	}{
+		{"/api/connector/retransform?project=AAR&source=cloud", http.StatusAccepted, `{"id":1,"mode":"retransform","state":"queued"}`},
+		{"/api/connector/retransform?project=OTHER", http.StatusNotFound, `{"error":"project isn't archived"}`},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", tt.url, nil)
+		setupRouter(cfg).ServeHTTP(w, req)
+
+		if w.Code != tt.status || w.Body.String() != tt.body {
+			t.Errorf("%s: unexpected answer %d: %s", tt.url, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGetConnectorJobs_ConnectorDown(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Connector.BaseURL = "http://invalid.url"
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/connector/jobs/1", nil)
+	setupRouter(cfg).ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
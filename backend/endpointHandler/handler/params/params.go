@@ -0,0 +1,90 @@
+package params
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Filter is the optional filter of issues by a custom field value (story_points,
+// sprint, epic_link, team or a field from custom_fields of jiraConnector), a label,
+// a component and a fix version. Issues deleted in Jira are skipped unless
+// IncludeDeleted is set
+type Filter struct {
+	CustomField    string
+	CustomValue    string
+	Label          string
+	Component      string
+	FixVersion     string
+	IncludeDeleted bool
+}
+
+// IssueFilter reads the filter of issues. On error the response is already written
+func IssueFilter(c *gin.Context) (Filter, bool) {
+	customField, customValue := c.Query("customField"), c.Query("customValue")
+	if customField == "" && customValue != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "customValue requires customField"})
+		return Filter{}, false
+	}
+
+	includeDeleted, ok := IncludeDeleted(c)
+	if !ok {
+		return Filter{}, false
+	}
+
+	return Filter{
+		CustomField:    customField,
+		CustomValue:    customValue,
+		Label:          c.Query("label"),
+		Component:      c.Query("component"),
+		FixVersion:     c.Query("fixVersion"),
+		IncludeDeleted: includeDeleted,
+	}, true
+}
+
+// IncludeDeleted reads the switch to count issues deleted in Jira, they are
+// skipped by default. On error the response is already written
+func IncludeDeleted(c *gin.Context) (bool, bool) {
+	raw := c.Query("includeDeleted")
+	if raw == "" {
+		return false, true
+	}
+	includeDeleted, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "includeDeleted must be true or false"})
+		return false, false
+	}
+	return includeDeleted, true
+}
+
+// Period reads the optional period of worklogs: from and to are dates
+// YYYY-MM-DD, both days are included. On error the response is already written
+func Period(c *gin.Context) (from, to sql.NullTime, ok bool) {
+	for _, param := range []struct {
+		name  string
+		value *sql.NullTime
+	}{{"from", &from}, {"to", &to}} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		day, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": param.name + " must be a date YYYY-MM-DD"})
+			return from, to, false
+		}
+		*param.value = sql.NullTime{Time: day, Valid: true}
+	}
+
+	if from.Valid && to.Valid && to.Time.Before(from.Time) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from is after to"})
+		return from, to, false
+	}
+	if to.Valid {
+		to.Time = to.Time.AddDate(0, 0, 1)
+	}
+	return from, to, true
+}
@@ -0,0 +1,72 @@
+package params
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func testContext(target string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c, w
+}
+
+func TestIncludeDeleted(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   bool
+		ok     bool
+	}{
+		{"default", "/", false, true},
+		{"true", "/?includeDeleted=true", true, true},
+		{"false", "/?includeDeleted=0", false, true},
+		{"invalid", "/?includeDeleted=maybe", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := testContext(tt.target)
+			got, ok := IncludeDeleted(c)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.ok, ok)
+			if !tt.ok {
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+
+	c, _ := testContext("/?from=2024-01-01&to=2024-01-31")
+	from, to, ok := Period(c)
+	assert.True(t, ok)
+	assert.Equal(t, day("2024-01-01"), from.Time)
+	// to includes the whole day
+	assert.Equal(t, day("2024-02-01"), to.Time)
+
+	c, _ = testContext("/")
+	from, to, ok = Period(c)
+	assert.True(t, ok)
+	assert.False(t, from.Valid)
+	assert.False(t, to.Valid)
+
+	for _, target := range []string{"/?from=01.01.2024", "/?to=tomorrow", "/?from=2024-02-01&to=2024-01-01"} {
+		c, w := testContext(target)
+		_, _, ok := Period(c)
+		assert.False(t, ok, target)
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+}
@@ -1,17 +1,22 @@
 package model
 
+import "time"
+
 type Project struct {
-	ID   string `json:"id"`
-	Key  string `json:"key"`
-	Name string `json:"name"`
-	Self string `json:"self"`
+	ID     string `json:"id"`
+	Source string `json:"source,omitempty"`
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Self   string `json:"self"`
 }
 
+// DBProject is unique by Source and Key, the same key may exist in several Jira sources
 type DBProject struct {
-	ID    int    `db:"id" json:"id"`
-	Title string `db:"title" json:"title"`
-	Key   string `db:"key" json:"key"`
-	Self  string `db:"url" json:"url"`
+	ID     int    `db:"id" json:"id"`
+	Source string `db:"source" json:"source"`
+	Title  string `db:"title" json:"title"`
+	Key    string `db:"key" json:"key"`
+	Self   string `db:"url" json:"url"`
 }
 
 type UIProject struct {
@@ -40,6 +45,22 @@ type PageInfo struct {
 }
 
 type ProjectsResponse struct {
+	Source   string    `json:"source"`
 	Projects []Project `json:"projects"`
 	PageInfo PageInfo  `json:"pageInfo"`
 }
+
+type ConnectorJob struct {
+	ID            int        `json:"id"`
+	Source        string     `json:"source"`
+	Project       string     `json:"project"`
+	Mode          string     `json:"mode"`
+	Trigger       string     `json:"trigger"`
+	State         string     `json:"state"`
+	IssuesFetched int        `json:"issuesFetched"`
+	IssuesTotal   int        `json:"issuesTotal"`
+	Error         string     `json:"error,omitempty"`
+	CreatedTime   time.Time  `json:"createdTime"`
+	StartedTime   *time.Time `json:"startedTime,omitempty"`
+	FinishedTime  *time.Time `json:"finishedTime,omitempty"`
+}
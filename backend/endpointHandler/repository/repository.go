@@ -15,6 +15,9 @@ import (
 
 var DB *sqlx.DB
 
+// ErrAmbiguousProject is returned when the key is used by projects of several Jira sources
+var ErrAmbiguousProject = errors.New("project key exists in several sources, set source")
+
 func InitDB(cfg *config.Config) error {
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
@@ -30,6 +33,14 @@ func InitDB(cfg *config.Config) error {
 	return nil
 }
 
+// CloseDB waits for running queries and closes the connection pool
+func CloseDB() error {
+	if DB == nil {
+		return nil
+	}
+	return DB.Close()
+}
+
 func GetFilteredProjects(limit, offset int, search string) ([]model.UIProject, int, error) {
 	if DB == nil {
 		return nil, 0, errors.New("database not initialized")
@@ -84,7 +95,7 @@ func GetAllProjects() ([]model.Project, error) {
 	}
 
 	var dbProjects []model.DBProject
-	err := DB.Select(&dbProjects, "SELECT * FROM Projects")
+	err := DB.Select(&dbProjects, "SELECT id, source, title, key, url FROM Projects")
 	if err != nil {
 		return nil, err
 	}
@@ -92,50 +103,81 @@ func GetAllProjects() ([]model.Project, error) {
 	var result []model.Project
 	for _, p := range dbProjects {
 		result = append(result, model.Project{
-			ID:   strconv.Itoa(p.ID), // Преобразование int → string
-			Key:  p.Key,
-			Name: p.Title,
-			Self: p.Self,
+			ID:     strconv.Itoa(p.ID), // Преобразование int → string
+			Source: p.Source,
+			Key:    p.Key,
+			Name:   p.Title,
+			Self:   p.Self,
 		})
 	}
 	return result, nil
 }
 
-func GetStats(projectID int) (model.ProjectStats, error) {
+// ResolveProjectSource returns the Jira source of the project. The source may be
+// omitted while only one source has a project with the key
+func ResolveProjectSource(key, source string) (string, error) {
+	if source != "" {
+		return source, nil
+	}
+	if DB == nil {
+		return "", errors.New("database not initialized")
+	}
+
+	var sources []string
+	err := DB.Select(&sources, "SELECT DISTINCT source FROM Projects WHERE key = $1 ORDER BY source", key)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(sources) {
+	case 0:
+		return "", nil
+	case 1:
+		return sources[0], nil
+	}
+	return "", fmt.Errorf("%w: %s is in %s", ErrAmbiguousProject, key, strings.Join(sources, ", "))
+}
+
+// liveIssues skips issues deleted in Jira unless the second argument is true
+const liveIssues = " AND ($2 OR deletedTime IS NULL)"
+
+// GetStats returns counters of the project issues, includeDeleted counts
+// issues deleted in Jira as well
+func GetStats(projectID int, includeDeleted bool) (model.ProjectStats, error) {
 	var stats model.ProjectStats
 
 	if DB == nil {
 		return stats, errors.New("database not initialized")
 	}
 
-	err := DB.Get(&stats.TotalIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1", projectID)
+	err := DB.Get(&stats.TotalIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1"+liveIssues, projectID, includeDeleted)
 	if err != nil {
 		return stats, err
 	}
 
-	err = DB.Get(&stats.OpenIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1 AND status NOT IN ('Closed','Resolved')", projectID)
+	err = DB.Get(&stats.OpenIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1 AND status NOT IN ('Closed','Resolved')"+liveIssues, projectID, includeDeleted)
 	if err != nil {
 		return stats, err
 	}
 
-	err = DB.Get(&stats.ClosedIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1 AND status='Closed'", projectID)
+	err = DB.Get(&stats.ClosedIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1 AND status='Closed'"+liveIssues, projectID, includeDeleted)
 	if err != nil {
 		return stats, err
 	}
 
 	err = DB.Get(&stats.ReopenedIssues, `
-		SELECT COUNT(*) FROM StatusChanges 
-		WHERE issueId IN (SELECT id FROM Issue WHERE projectId=$1) AND toStatus='Reopened'`, projectID)
+		SELECT COUNT(DISTINCT issueId) FROM StatusChanges 
+		WHERE issueId IN (SELECT id FROM Issue WHERE projectId=$1`+liveIssues+`) AND toStatus='Reopened'`, projectID, includeDeleted)
 	if err != nil {
 		return stats, err
 	}
 
-	err = DB.Get(&stats.ResolvedIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1 AND status='Resolved'", projectID)
+	err = DB.Get(&stats.ResolvedIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1 AND status='Resolved'"+liveIssues, projectID, includeDeleted)
 	if err != nil {
 		return stats, err
 	}
 
-	err = DB.Get(&stats.InProgressIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1 AND status='In progress'", projectID)
+	err = DB.Get(&stats.InProgressIssues, "SELECT COUNT(*) FROM Issue WHERE projectId=$1 AND status='In progress'"+liveIssues, projectID, includeDeleted)
 	if err != nil {
 		return stats, err
 	}
@@ -143,8 +185,8 @@ func GetStats(projectID int) (model.ProjectStats, error) {
 	err = DB.Get(&stats.AvgResolutionTimeH, `
 		SELECT COALESCE(AVG(EXTRACT(EPOCH FROM closedTime - createdTime)/3600), 0)
 		FROM Issue
-		WHERE projectId=$1 AND closedTime IS NOT NULL AND closedTime > createdTime
-	`, projectID)
+		WHERE projectId=$1 AND closedTime IS NOT NULL AND closedTime > createdTime`+liveIssues+`
+	`, projectID, includeDeleted)
 
 	if err != nil {
 		return stats, err
@@ -152,7 +194,7 @@ func GetStats(projectID int) (model.ProjectStats, error) {
 
 	err = DB.Get(&stats.AvgCreatedPerDay7d, `
 		SELECT COUNT(*) / 7.0 
-		FROM Issue WHERE projectId=$1 AND createdTime > $2`, projectID, time.Now().AddDate(0, 0, -7))
+		FROM Issue WHERE projectId=$1 AND createdTime > $3`+liveIssues, projectID, includeDeleted, time.Now().AddDate(0, 0, -7))
 	if err != nil {
 		return stats, err
 	}
@@ -173,10 +215,10 @@ func SaveProject(p model.Project) error {
 		return errors.New("database not initialized")
 	}
 	_, err := DB.Exec(`
-        INSERT INTO Projects (key, title, url)
-        VALUES ($1, $2, $3)
-        ON CONFLICT (title) DO UPDATE 
-        SET key = EXCLUDED.key, url = EXCLUDED.url
-    `, p.Key, p.Name, p.Self)
+        INSERT INTO Projects (source, key, title, url)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (source, key) DO UPDATE 
+        SET title = EXCLUDED.title, url = EXCLUDED.url
+    `, p.Source, p.Key, p.Name, p.Self)
 	return err
 }
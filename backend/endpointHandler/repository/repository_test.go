@@ -48,31 +48,31 @@ func TestGetStats(t *testing.T) {
 	projectID := 1
 
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1 AND status NOT IN").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1 AND status='Closed'").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM StatusChanges WHERE issueId IN").
-		WithArgs(projectID).
+	mock.ExpectQuery("SELECT COUNT\\(DISTINCT issueId\\) FROM StatusChanges WHERE issueId IN").
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1 AND status='Resolved'").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1 AND status='In progress'").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 	mock.ExpectQuery("SELECT COALESCE\\(AVG\\(EXTRACT\\(EPOCH FROM closedTime - createdTime\\)/3600\\), 0\\) FROM Issue WHERE projectId=\\$1 AND closedTime IS NOT NULL AND closedTime > createdTime").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(24.5))
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) / 7.0 FROM Issue WHERE projectId=\\$1 AND createdTime > \\$2").
-		WithArgs(projectID, sqlmock.AnyArg()).
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) / 7.0 FROM Issue WHERE projectId=\\$1 AND createdTime > \\$3").
+		WithArgs(projectID, false, sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1.5))
 
-	stats, err := GetStats(projectID)
+	stats, err := GetStats(projectID, false)
 	assert.NoError(t, err)
 	assert.Equal(t, 10, stats.TotalIssues)
 	assert.Equal(t, 3, stats.OpenIssues)
@@ -100,10 +100,10 @@ func TestSaveProject(t *testing.T) {
 	mock, closeDB := setupMockDB(t)
 	defer closeDB()
 
-	project := model.Project{Key: "KEY1", Name: "Project 1", Self: "http://url"}
+	project := model.Project{Source: "default", Key: "KEY1", Name: "Project 1", Self: "http://url"}
 
 	mock.ExpectExec("INSERT INTO Projects").
-		WithArgs(project.Key, project.Name, project.Self).
+		WithArgs(project.Source, project.Key, project.Name, project.Self).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err := SaveProject(project)
@@ -118,17 +118,17 @@ func TestGetAllProjects(t *testing.T) {
 	defer db.Close()
 	DB = sqlx.NewDb(db, "postgres")
 
-	rows := sqlmock.NewRows([]string{"id", "key", "title", "url"}).
-		AddRow(1, "PRJ1", "Project One", "http://example.com/prj1")
+	rows := sqlmock.NewRows([]string{"id", "source", "key", "title", "url"}).
+		AddRow(1, "cloud", "PRJ1", "Project One", "http://example.com/prj1")
 
-	mock.ExpectQuery("SELECT \\* FROM Projects").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT id, source, title, key, url FROM Projects").WillReturnRows(rows)
 
 	projects, err := GetAllProjects()
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if len(projects) != 1 || projects[0].Name != "Project One" {
+	if len(projects) != 1 || projects[0].Name != "Project One" || projects[0].Source != "cloud" {
 		t.Fatalf("unexpected result: %+v", projects)
 	}
 }
@@ -149,10 +149,10 @@ func TestSaveProject_Error(t *testing.T) {
 	mock, closeDB := setupMockDB(t)
 	defer closeDB()
 
-	project := model.Project{Key: "KEY1", Name: "Project 1", Self: "http://url"}
+	project := model.Project{Source: "default", Key: "KEY1", Name: "Project 1", Self: "http://url"}
 
 	mock.ExpectExec("INSERT INTO Projects").
-		WithArgs(project.Key, project.Name, project.Self).
+		WithArgs(project.Source, project.Key, project.Name, project.Self).
 		WillReturnError(assert.AnError)
 
 	err := SaveProject(project)
@@ -167,7 +167,7 @@ func TestGetAllProjects_Empty(t *testing.T) {
 	defer db.Close()
 	DB = sqlx.NewDb(db, "postgres")
 
-	mock.ExpectQuery("SELECT \\* FROM Projects").WillReturnRows(sqlmock.NewRows([]string{"id", "key", "title", "url"}))
+	mock.ExpectQuery("SELECT id, source, title, key, url FROM Projects").WillReturnRows(sqlmock.NewRows([]string{"id", "source", "key", "title", "url"}))
 
 	projects, err := GetAllProjects()
 	assert.NoError(t, err)
@@ -197,28 +197,63 @@ func TestGetStats_AvgQueryError(t *testing.T) {
 	projectID := 1
 
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
 
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1 AND status NOT IN").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1 AND status='Closed'").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM StatusChanges WHERE issueId IN").
-		WithArgs(projectID).
+	mock.ExpectQuery("SELECT COUNT\\(DISTINCT issueId\\) FROM StatusChanges WHERE issueId IN").
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1 AND status='Resolved'").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Issue WHERE projectId=\\$1 AND status='In progress'").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 	mock.ExpectQuery("SELECT COALESCE\\(AVG\\(EXTRACT\\(EPOCH FROM closedTime - createdTime\\)/3600\\), 0\\) FROM Issue WHERE projectId=\\$1 AND closedTime IS NOT NULL AND closedTime > createdTime").
-		WithArgs(projectID).
+		WithArgs(projectID, false).
 		WillReturnError(assert.AnError)
 
-	_, err := GetStats(projectID)
+	_, err := GetStats(projectID, false)
 	assert.Error(t, err)
 }
+
+func TestResolveProjectSource(t *testing.T) {
+	mock, closeDB := setupMockDB(t)
+	defer closeDB()
+
+	// explicit source is used as is
+	source, err := ResolveProjectSource("PRJ", "cloud")
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud", source)
+
+	mock.ExpectQuery("SELECT DISTINCT source FROM Projects WHERE key = \\$1").
+		WithArgs("PRJ").
+		WillReturnRows(sqlmock.NewRows([]string{"source"}).AddRow("default"))
+
+	source, err = ResolveProjectSource("PRJ", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", source)
+
+	mock.ExpectQuery("SELECT DISTINCT source FROM Projects WHERE key = \\$1").
+		WithArgs("PRJ").
+		WillReturnRows(sqlmock.NewRows([]string{"source"}).AddRow("cloud").AddRow("default"))
+
+	_, err = ResolveProjectSource("PRJ", "")
+	assert.ErrorIs(t, err, ErrAmbiguousProject)
+
+	mock.ExpectQuery("SELECT DISTINCT source FROM Projects WHERE key = \\$1").
+		WithArgs("NONE").
+		WillReturnRows(sqlmock.NewRows([]string{"source"}))
+
+	source, err = ResolveProjectSource("NONE", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "", source)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -39,6 +39,18 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 			connector.POST("/updateProject", func(c *gin.Context) {
 				handler.UpdateJiraProject(c, cfg)
 			})
+			connector.POST("/retransform", func(c *gin.Context) {
+				handler.RetransformJiraProject(c, cfg)
+			})
+			connector.GET("/jobs", func(c *gin.Context) {
+				handler.GetConnectorJobs(c, cfg)
+			})
+			connector.GET("/jobs/:id", func(c *gin.Context) {
+				handler.GetConnectorJob(c, cfg)
+			})
+			connector.POST("/jobs/:id/cancel", func(c *gin.Context) {
+				handler.CancelConnectorJob(c, cfg)
+			})
 		}
 
 		analytics := api.Group("/analytics")
@@ -46,8 +58,17 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 			analytics.GET("/time-open", analyticsHandler.TimeOpenAnalytics)
 			analytics.GET("/status-distribution", analyticsHandler.StatusDistribution)
 			analytics.GET("/time-spent", analyticsHandler.TimeSpentAnalytics)
+			analytics.GET("/time-spent-daily", analyticsHandler.TimeSpentDailyAnalytics)
 			analytics.GET("/priority", analyticsHandler.PriorityAnalytics)
 			analytics.GET("/throughput", analyticsHandler.ThroughputAnalytics)
+			analytics.GET("/priority-changes", analyticsHandler.PriorityChangesAnalytics)
+			analytics.GET("/reassignments", analyticsHandler.ReassignmentAnalytics)
+			analytics.GET("/custom-field", analyticsHandler.CustomFieldAnalytics)
+			analytics.GET("/velocity", analyticsHandler.VelocityAnalytics)
+			analytics.GET("/sprint-commitment", analyticsHandler.SprintCommitmentAnalytics)
+			analytics.GET("/carry-over", analyticsHandler.CarryOverAnalytics)
+			analytics.GET("/epic", analyticsHandler.EpicAnalytics)
+			analytics.GET("/dependencies", analyticsHandler.DependencyAnalytics)
 		}
 
 		compare := api.Group("/compare")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/endpointhandler/config"
 	"net/http"
+	"net/url"
 
 	"github.com/endpointhandler/model"
 	"github.com/endpointhandler/repository"
@@ -23,15 +24,43 @@ func FetchJiraProjects(cfg *config.Config) (model.ProjectsResponse, error) {
 	return result, err
 }
 
-func UpdateJiraProject(cfg *config.Config, project string) (map[string]string, error) {
-	url := fmt.Sprintf("%s/updateProject?project=%s", cfg.Connector.BaseURL, project)
-	resp, err := http.Post(url, "application/json", nil)
+// ConnectorError is an error answer of jiraConnector, the status is passed on to the client
+type ConnectorError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ConnectorError) Error() string {
+	return fmt.Sprintf("connector returned %d: %s", e.StatusCode, e.Message)
+}
+
+// UpdateJiraProject only starts the update in jiraConnector, the returned job
+// can be polled with the connector jobs endpoint. Empty source is the default
+// Jira source of the connector, empty mode lets the connector choose it
+func UpdateJiraProject(cfg *config.Config, source, project, mode string) (model.ConnectorJob, error) {
+	params := url.Values{"project": {project}}
+	if source != "" {
+		params.Set("source", source)
+	}
+	if mode != "" {
+		params.Set("mode", mode)
+	}
+	reqURL := fmt.Sprintf("%s/updateProject?%s", cfg.Connector.BaseURL, params.Encode())
+	resp, err := http.Post(reqURL, "application/json", nil)
 	if err != nil {
-		return nil, err
+		return model.ConnectorJob{}, err
 	}
 	defer resp.Body.Close()
 
-	var result map[string]string
+	if resp.StatusCode != http.StatusAccepted {
+		var errResp struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return model.ConnectorJob{}, &ConnectorError{StatusCode: resp.StatusCode, Message: errResp.Error}
+	}
+
+	var result model.ConnectorJob
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	return result, err
 }
@@ -40,8 +69,8 @@ func GetAllProjects() ([]model.Project, error) {
 	return repository.GetAllProjects()
 }
 
-func GetProjectStats(id int) (model.ProjectStats, error) {
-	return repository.GetStats(id)
+func GetProjectStats(id int, includeDeleted bool) (model.ProjectStats, error) {
+	return repository.GetStats(id, includeDeleted)
 }
 
 func DeleteProject(id int) error {
@@ -55,7 +84,7 @@ func FetchAndStoreProjects(cfg *config.Config) (model.ProjectsResponse, error) {
 	}
 
 	for _, p := range projectsResp.Projects {
-		_, err := UpdateJiraProject(cfg, p.Key)
+		_, err := UpdateJiraProject(cfg, projectsResp.Source, p.Key, "")
 		if err != nil {
 			return model.ProjectsResponse{}, fmt.Errorf("updateProject failed for key=%s: %w", p.Key, err)
 		}
@@ -41,33 +41,71 @@ func TestFetchJiraProjects_Failure(t *testing.T) {
 }
 
 func TestUpdateJiraProject_Success(t *testing.T) {
-	responseMap := map[string]string{"status": "updated"}
+	job := model.ConnectorJob{ID: 1, Project: "PROJ1", Mode: "incremental", State: "queued"}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/updateProject", r.URL.Path)
 		assert.Equal(t, "project=PROJ1", r.URL.RawQuery)
-		json.NewEncoder(w).Encode(responseMap)
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(job)
 	}))
 	defer server.Close()
 
 	cfg := &config.Config{}
 	cfg.Connector.BaseURL = server.URL
 
-	result, err := UpdateJiraProject(cfg, "PROJ1")
+	result, err := UpdateJiraProject(cfg, "", "PROJ1", "")
 	assert.NoError(t, err)
-	assert.Equal(t, responseMap, result)
+	assert.Equal(t, job, result)
+}
+
+func TestUpdateJiraProject_WithSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PROJ1", r.URL.Query().Get("project"))
+		assert.Equal(t, "cloud", r.URL.Query().Get("source"))
+		assert.Equal(t, "full", r.URL.Query().Get("mode"))
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(model.ConnectorJob{ID: 1, Source: "cloud", Project: "PROJ1", State: "queued"})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Connector.BaseURL = server.URL
+
+	result, err := UpdateJiraProject(cfg, "cloud", "PROJ1", "full")
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud", result.Source)
+}
+
+func TestUpdateJiraProject_ConnectorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "jira doesn't have such project"})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Connector.BaseURL = server.URL
+
+	_, err := UpdateJiraProject(cfg, "", "UNKNOWN", "")
+
+	var connErr *ConnectorError
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, http.StatusNotFound, connErr.StatusCode)
+	assert.Equal(t, "jira doesn't have such project", connErr.Message)
 }
 
 func TestUpdateJiraProject_Failure(t *testing.T) {
 	cfg := &config.Config{}
 	cfg.Connector.BaseURL = "http://invalid.url"
 
-	_, err := UpdateJiraProject(cfg, "PROJ1")
+	_, err := UpdateJiraProject(cfg, "", "PROJ1", "")
 	assert.Error(t, err)
 }
 
 func TestFetchAndStoreProjects(t *testing.T) {
 	projectsResp := model.ProjectsResponse{
+		Source: "default",
 		Projects: []model.Project{
 			{ID: "1", Key: "PROJ1", Name: "Project One", Self: "url1"},
 		},
@@ -89,7 +127,9 @@ func TestFetchAndStoreProjects(t *testing.T) {
 		if r.URL.Path == "/projects" {
 			json.NewEncoder(w).Encode(projectsResp)
 		} else if r.URL.Path == "/updateProject" {
-			json.NewEncoder(w).Encode(map[string]string{"status": "updated"})
+			assert.Equal(t, "default", r.URL.Query().Get("source"))
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(model.ConnectorJob{ID: 1, Project: "PROJ1", State: "queued"})
 		} else {
 			http.Error(w, "not found", http.StatusNotFound)
 		}
@@ -128,6 +168,7 @@ func TestFetchAndStoreProjects_UpdateError(t *testing.T) {
 		case "/projects":
 			json.NewEncoder(w).Encode(projectsResp)
 		case "/updateProject":
+			w.WriteHeader(http.StatusAccepted)
 			w.Write([]byte("invalid json"))
 		default:
 			http.NotFound(w, r)
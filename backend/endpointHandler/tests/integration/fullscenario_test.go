@@ -46,11 +46,7 @@ func TestFullScenarioFirst(t *testing.T) {
 
 	// load project to DB
 	t.Run("POST /connector/updateProject", func(t *testing.T) {
-		resp, err := client.Post(fmt.Sprintf("%s/connector/updateProject?project=%s", baseURL, p1.Key), "", nil)
-		require.NoError(t, err)
-		defer resp.Body.Close()
-
-		assert.Equal(t, 200, resp.StatusCode)
+		updateProject(t, &client, baseURL, p1.Key)
 	})
 
 	// check that project in DB
@@ -138,11 +134,7 @@ func TestFullscenarioSecond(t *testing.T) {
 	// load two projects to DB
 	t.Run("POST /connector/updateProject [two]", func(t *testing.T) {
 		for _, key := range []string{p1.Key, p2.Key} {
-			resp, err := client.Post(fmt.Sprintf("%s/connector/updateProject?project=%s", baseURL, key), "", nil)
-			require.NoError(t, err)
-			defer resp.Body.Close()
-
-			assert.Equal(t, 200, resp.StatusCode)
+			updateProject(t, &client, baseURL, key)
 		}
 	})
 
@@ -59,11 +59,7 @@ func TestJiraConnectorUpdate(t *testing.T) {
 
 	// load project to DB
 	t.Run("POST /connector/updateProject", func(t *testing.T) {
-		resp, err := client.Post(fmt.Sprintf("%s/connector/updateProject?project=%s", baseURL, p1.Key), "", nil)
-		require.NoError(t, err)
-		defer resp.Body.Close()
-
-		assert.Equal(t, 200, resp.StatusCode)
+		updateProject(t, &client, baseURL, p1.Key)
 	})
 
 	// check that project in DB
@@ -0,0 +1,42 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateProject starts the project update and waits until its job is finished
+func updateProject(t *testing.T, client *http.Client, baseURL, key string) {
+	resp, err := client.Post(fmt.Sprintf("%s/connector/updateProject?project=%s", baseURL, key), "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var job ConnectorJob
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+
+	require.Eventually(t, func() bool {
+		jobResp, err := client.Get(fmt.Sprintf("%s/connector/jobs/%d", baseURL, job.Id))
+		if err != nil {
+			return false
+		}
+		defer jobResp.Body.Close()
+
+		if err := json.NewDecoder(jobResp.Body).Decode(&job); err != nil {
+			return false
+		}
+		return job.State == "succeeded" || job.State == "failed"
+	}, 2*time.Minute, time.Second)
+
+	assert.Equal(t, "succeeded", job.State, job.Error)
+}
@@ -18,9 +18,13 @@ type PageInfo struct {
 	ProjectsCount int `json:"projectsCount"`
 }
 
-type ResponseUpdate struct {
-	Project string `json:"project"`
-	Status  string `json:"status"`
+type ConnectorJob struct {
+	Id            int    `json:"id"`
+	Project       string `json:"project"`
+	State         string `json:"state"`
+	IssuesFetched int    `json:"issuesFetched"`
+	IssuesTotal   int    `json:"issuesTotal"`
+	Error         string `json:"error"`
 }
 
 type ProjectStats struct {
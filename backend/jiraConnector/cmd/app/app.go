@@ -1,49 +1,97 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	jirahandlers "github.com/jiraconnector/internal/apiJiraConnector/jiraHandlers"
 	jiraservice "github.com/jiraconnector/internal/apiJiraConnector/jiraService"
+	"github.com/jiraconnector/internal/archive"
+	archiveErr "github.com/jiraconnector/internal/archive/errors"
 	"github.com/jiraconnector/internal/connector"
 	datatransformer "github.com/jiraconnector/internal/dataTransformer"
 	dbpusher "github.com/jiraconnector/internal/dbPusher"
+	jobqueue "github.com/jiraconnector/internal/jobQueue"
+	"github.com/jiraconnector/internal/migrations"
+	"github.com/jiraconnector/internal/scheduler"
 	"github.com/jiraconnector/pkg/config"
+	"github.com/jiraconnector/pkg/logger"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 type JiraApp struct {
-	server        *http.Server
-	jiraConnector *connector.JiraConnector
-	db            *dbpusher.DbPusher
-	log           *slog.Logger
+	server    *http.Server
+	db        *dbpusher.DbPusher
+	jobs      *jobqueue.JobQueue
+	scheduler *scheduler.Scheduler
+	log       *slog.Logger
+
+	shutdownTimeout time.Duration
+	closeOnce       sync.Once
 }
 
 func NewApp(cfg *config.Config, log *slog.Logger) (*JiraApp, error) {
-	con := connector.NewJiraConnector(cfg, log)
-	log.Info("created jira connection")
+	sourcesCfg, err := cfg.Sources()
+	if err != nil {
+		ansErr := fmt.Errorf("error read jira sources: %w", err)
+		log.Error(ansErr.Error())
+		return nil, ansErr
+	}
 
 	dbPusher, err := dbpusher.NewDbPusher(cfg, log)
 	if err != nil {
 		return nil, err
 	}
 
-	datatransformer := datatransformer.NewDataTransformer(cfg.JiraCfg.Url)
+	// the schema is created and updated by the connector, not by the postgres container
+	migrator, err := migrations.NewMigrator(dbPusher.Db(), log)
+	if err != nil {
+		dbPusher.Close()
+		return nil, err
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		dbPusher.Close()
+		return nil, err
+	}
+	log.Info("migrated database")
 
-	service, err := jiraservice.NewJiraService(cfg, con, datatransformer, dbPusher, log)
+	issueArchive, err := newArchive(cfg, dbPusher, log)
 	if err != nil {
-		ansErr := fmt.Errorf("error create service: %w", err)
-		log.Error(ansErr.Error())
-		return nil, ansErr
+		dbPusher.Close()
+		return nil, err
+	}
+
+	sources, err := newSources(cfg, sourcesCfg, dbPusher, issueArchive, log)
+	if err != nil {
+		dbPusher.Close()
+		return nil, err
+	}
+	log.Info("created jira service", "default source", cfg.DefaultSourceName())
+
+	jobs := jobqueue.NewJobQueue(cfg, dbPusher, sources, log)
+	log.Info("created job queue")
+
+	var sched *scheduler.Scheduler
+	if cfg.SchedCfg.Schedule != "" || len(cfg.SchedCfg.Projects) != 0 {
+		sched, err = scheduler.NewScheduler(cfg, dbPusher, jobs, log)
+		if err != nil {
+			ansErr := fmt.Errorf("error create scheduler: %w", err)
+			log.Error(ansErr.Error())
+			dbPusher.Close()
+			return nil, ansErr
+		}
+		log.Info("created scheduler")
 	}
-	log.Info("created jira service")
 
 	router := mux.NewRouter()
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
-	jiraHandler := jirahandlers.NewHandler(service, router, log)
+	jiraHandler := jirahandlers.NewHandler(sources, jobs, router, log)
 	log.Info("created jira handlers")
 
 	server := &http.Server{
@@ -53,22 +101,135 @@ func NewApp(cfg *config.Config, log *slog.Logger) (*JiraApp, error) {
 	log.Info("create jira server")
 
 	return &JiraApp{
-		server:        server,
-		jiraConnector: con,
-		db:            dbPusher,
-		log:           log,
+		server:    server,
+		db:        dbPusher,
+		jobs:      jobs,
+		scheduler: sched,
+		log:       log,
+
+		shutdownTimeout: cfg.ServerCfg.ShutdownTimeout,
 	}, nil
 }
 
-func (a *JiraApp) Run() error {
+// newSources creates a JiraService for every jira instance, every one has
+// its own connector with own auth and rate limit
+func newSources(cfg *config.Config, sourcesCfg map[string]config.JiraConfig, dbPusher *dbpusher.DbPusher, issueArchive jiraservice.ArchiveInterface, log *slog.Logger) (*jiraservice.Sources, error) {
+	services := make(map[string]*jiraservice.JiraService, len(sourcesCfg))
+	for name, sourceCfg := range sourcesCfg {
+		sourceLog := log.With("source", name)
+		con, err := connector.NewJiraConnector(&sourceCfg, sourceLog)
+		if err != nil {
+			return nil, err
+		}
+
+		datatransformer := datatransformer.NewDataTransformer(sourceCfg.Url)
+
+		service, err := jiraservice.NewJiraService(&sourceCfg, name, con, datatransformer, dbPusher, issueArchive, sourceLog)
+		if err != nil {
+			ansErr := fmt.Errorf("error create service: %w", err)
+			log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		services[name] = service
+		log.Info("created jira source", "source", name, "url", sourceCfg.Url)
+	}
+	return jiraservice.NewSources(services, cfg.DefaultSourceName(), log), nil
+}
+
+// newArchive returns the storage of raw issues set in the config, nil when the archive is off
+func newArchive(cfg *config.Config, dbPusher *dbpusher.DbPusher, log *slog.Logger) (jiraservice.ArchiveInterface, error) {
+	switch cfg.ArchiveCfg.Storage {
+	case "":
+		return nil, nil
+	case archive.StoragePostgres:
+		log.Info("archive raw issues", "storage", cfg.ArchiveCfg.Storage)
+		return dbPusher, nil
+	case archive.StorageDir:
+		dirArchive, err := archive.NewDirArchive(cfg.ArchiveCfg.Dir, log)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("archive raw issues", "storage", cfg.ArchiveCfg.Storage, "dir", cfg.ArchiveCfg.Dir)
+		return dirArchive, nil
+	}
+
+	ansErr := fmt.Errorf("%w: %s", archiveErr.ErrStorage, cfg.ArchiveCfg.Storage)
+	log.Error(ansErr.Error())
+	return nil, ansErr
+}
+
+// Run serves requests until ctx is done or the server fails, then shuts the app down
+func (a *JiraApp) Run(ctx context.Context) error {
 	a.log.Info("run app")
-	return fmt.Errorf("run app err: %v", a.server.ListenAndServe())
+	if err := a.jobs.Start(ctx); err != nil {
+		return fmt.Errorf("run app err: %w", err)
+	}
+	if a.scheduler != nil {
+		a.scheduler.Start()
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	select {
+	case err := <-serverErr:
+		a.Close()
+		return fmt.Errorf("run app err: %w", err)
+	case <-ctx.Done():
+		a.log.Info("got stop signal")
+	}
+
+	return a.Shutdown()
+}
+
+// Shutdown stops accepting requests and new jobs, waits for in-flight requests
+// and running jobs until shutdown timeout, then closes the database.
+// Jobs interrupted by the timeout stay queued and are restarted with the app
+func (a *JiraApp) Shutdown() error {
+	var err error
+	a.closeOnce.Do(func() {
+		a.log.Info("shutdown app", "timeout", a.shutdownTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+		defer cancel()
+
+		if srvErr := a.server.Shutdown(ctx); srvErr != nil {
+			a.log.Error("error shutdown server", logger.Err(srvErr))
+			a.server.Close()
+			err = errors.Join(err, srvErr)
+		}
+		if a.scheduler != nil {
+			a.scheduler.Stop()
+		}
+		if jobsErr := a.jobs.Shutdown(ctx); jobsErr != nil {
+			err = errors.Join(err, jobsErr)
+		}
+		a.db.Close()
+	})
+
+	if err != nil {
+		return fmt.Errorf("shutdown app err: %w", err)
+	}
+	return nil
 }
 
+// Close stops the app without waiting for requests and running jobs
 func (a *JiraApp) Close() {
-	a.log.Info("close app")
-	a.db.Close()
-	a.server.Close()
+	a.closeOnce.Do(func() {
+		a.log.Info("close app")
+		a.server.Close()
+		if a.scheduler != nil {
+			a.scheduler.Stop()
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		a.jobs.Shutdown(ctx)
+		a.db.Close()
+	})
 }
 
 func (a *JiraApp) GetDB() *dbpusher.DbPusher {
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"text/tabwriter"
+
+	dbpusher "github.com/jiraconnector/internal/dbPusher"
+	"github.com/jiraconnector/internal/migrations"
+	myerr "github.com/jiraconnector/internal/migrations/errors"
+	"github.com/jiraconnector/pkg/config"
+)
+
+// Migrate runs the migrate subcommand: up, down [steps] or status.
+// Status is printed to out
+func Migrate(ctx context.Context, cfg *config.Config, log *slog.Logger, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return myerr.ErrCommand
+	}
+
+	steps := 1
+	switch {
+	case args[0] == "down" && len(args) == 2:
+		var err error
+		if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+			return fmt.Errorf("%w - steps %s", myerr.ErrCommand, args[1])
+		}
+	case len(args) != 1:
+		return myerr.ErrCommand
+	}
+
+	dbPusher, err := dbpusher.NewDbPusher(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer dbPusher.Close()
+
+	migrator, err := migrations.NewMigrator(dbPusher.Db(), log)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx, steps)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printStatus(out, statuses)
+	}
+	return myerr.ErrCommand
+}
+
+func printStatus(out io.Writer, statuses []migrations.Status) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED")
+	for _, status := range statuses {
+		state, applied := "pending", ""
+		if status.Applied {
+			state = "applied"
+			applied = status.AppliedTime.Format("2006-01-02 15:04:05")
+		}
+		switch {
+		case status.Unknown:
+			state = "unknown"
+		case status.Changed:
+			state = "changed"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, applied)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	myErr "github.com/jiraconnector/internal/apiJiraConnector/jiraService/errors"
+	dbpusher "github.com/jiraconnector/internal/dbPusher"
+	"github.com/jiraconnector/internal/migrations"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/config"
+)
+
+// Retransform runs the retransform subcommand: projects set as "KEY" for the default
+// source or "source/KEY" are rebuilt from the archive without contacting Jira, all
+// archived projects without args. A failed project doesn't stop the others,
+// cancel of ctx does. Rebuilt projects are printed to out
+func Retransform(ctx context.Context, cfg *config.Config, log *slog.Logger, args []string, out io.Writer) error {
+	sourcesCfg, err := cfg.Sources()
+	if err != nil {
+		return fmt.Errorf("error read jira sources: %w", err)
+	}
+
+	dbPusher, err := dbpusher.NewDbPusher(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer dbPusher.Close()
+
+	migrator, err := migrations.NewMigrator(dbPusher.Db(), log)
+	if err != nil {
+		return err
+	}
+	if err := migrator.Up(ctx); err != nil {
+		return err
+	}
+
+	issueArchive, err := newArchive(cfg, dbPusher, log)
+	if err != nil {
+		return err
+	}
+	if issueArchive == nil {
+		return myErr.ErrArchiveOff
+	}
+
+	sources, err := newSources(cfg, sourcesCfg, dbPusher, issueArchive, log)
+	if err != nil {
+		return err
+	}
+
+	var projects []structures.ProjectRef
+	for _, arg := range args {
+		projects = append(projects, structures.ParseProjectRef(arg, cfg.DefaultSourceName()))
+	}
+	if len(args) == 0 {
+		if projects, err = issueArchive.ArchivedProjects(ctx); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, project := range projects {
+		// interrupted by a signal, the rest of projects aren't started
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+		count := 0
+		err := sources.SyncProject(ctx, project, structures.SyncRetransform, func(fetched, total int) {
+			count = total
+		})
+		if err != nil {
+			fmt.Fprintf(out, "%s\tfailed: %v\n", project, err)
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+			continue
+		}
+		fmt.Fprintf(out, "%s\t%d issues\n", project, count)
+	}
+	return errors.Join(errs...)
+}
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/jiraconnector/cmd/app"
 	"github.com/jiraconnector/pkg/config"
@@ -20,6 +25,28 @@ func main() {
 
 	//setting logger
 	log := logger.SetupLogger(cfg.Env, cfg.LogFile)
+
+	//stop app and subcommands gracefully on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	//migrate up|down [steps]|status runs only migrations of the database
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(ctx, cfg, log, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	//retransform [source/KEY ...] rebuilds projects from the archive of raw issues
+	if len(os.Args) > 1 && os.Args[1] == "retransform" {
+		if err := app.Retransform(ctx, cfg, log, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	log.Info("starting url-shortener", slog.String("env", cfg.Env))
 
 	//create connector app
@@ -31,9 +58,9 @@ func main() {
 	log.Info("created app")
 
 	//start app
-	if err := a.Run(); err != nil {
+	if err := a.Run(ctx); err != nil {
 		log.Error("error run app")
 		panic(err)
 	}
-	defer a.Close()
+	log.Info("app stopped")
 }
@@ -15,6 +15,149 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/connector/jobs": {
+            "get": {
+                "description": "Получение последних задач обновления проектов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get latest update jobs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Count of jobs (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/structures.Job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/connector/jobs/{id}": {
+            "get": {
+                "description": "Получение состояния задачи обновления проекта",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get update job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structures.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/connector/jobs/{id}/cancel": {
+            "post": {
+                "description": "Отмена задачи обновления проекта. Задача в очереди отменяется сразу, выполняющаяся - прерывается и переходит в состояние cancelled после остановки",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Cancel update job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/structures.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/connector/projects": {
             "get": {
                 "description": "Получение проектов с пагинацией",
@@ -46,6 +189,12 @@ const docTemplate = `{
                         "description": "Search filter",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jira source from config, default source if empty",
+                        "name": "source",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -61,18 +210,95 @@ const docTemplate = `{
                             "$ref": "#/definitions/responseutils.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/connector/retransform": {
+            "post": {
+                "description": "Ставит в очередь задачу на пересборку задач проекта в базе данных из архива исходных данных Jira без обращения к Jira",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Start retransform of Jira project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project Key (required)",
+                        "name": "project",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jira source from config, default source if empty",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/structures.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responseutils.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
                     }
                 }
             }
         },
         "/api/v1/connector/updateProject": {
             "post": {
-                "description": "Обновляет проект в Jira, загружает задачи и сохраняет их в базу данных",
+                "description": "Ставит в очередь задачу на загрузку задач проекта из Jira и сохранение их в базу данных",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,7 +308,7 @@ const docTemplate = `{
                 "tags": [
                     "projects"
                 ],
-                "summary": "Update Jira project and push issues to DB",
+                "summary": "Start update of Jira project",
                 "parameters": [
                     {
                         "type": "string",
@@ -90,13 +316,25 @@ const docTemplate = `{
                         "name": "project",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sync mode: incremental (default) or full",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jira source from config, default source if empty",
+                        "name": "source",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/structures.ResponseUpdate"
+                            "$ref": "#/definitions/structures.Job"
                         }
                     },
                     "400": {
@@ -105,6 +343,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/responseutils.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -116,6 +360,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseutils.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/responseutils.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -148,6 +404,75 @@ const docTemplate = `{
                 }
             }
         },
+        "structures.Job": {
+            "type": "object",
+            "properties": {
+                "createdTime": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issuesFetched": {
+                    "type": "integer"
+                },
+                "issuesTotal": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "$ref": "#/definitions/structures.SyncMode"
+                },
+                "project": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "startedTime": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/structures.JobState"
+                },
+                "trigger": {
+                    "$ref": "#/definitions/structures.JobTrigger"
+                }
+            }
+        },
+        "structures.JobState": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "running",
+                "succeeded",
+                "failed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "JobQueued",
+                "JobRunning",
+                "JobSucceeded",
+                "JobFailed",
+                "JobCancelled"
+            ]
+        },
+        "structures.JobTrigger": {
+            "type": "string",
+            "enum": [
+                "manual",
+                "scheduled"
+            ],
+            "x-enum-varnames": [
+                "TriggerManual",
+                "TriggerScheduled"
+            ]
+        },
         "structures.PageInfo": {
             "type": "object",
             "properties": {
@@ -173,19 +498,24 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/structures.JiraProject"
                     }
-                }
-            }
-        },
-        "structures.ResponseUpdate": {
-            "type": "object",
-            "properties": {
-                "project": {
-                    "type": "string"
                 },
-                "status": {
+                "source": {
                     "type": "string"
                 }
             }
+        },
+        "structures.SyncMode": {
+            "type": "string",
+            "enum": [
+                "full",
+                "incremental",
+                "retransform"
+            ],
+            "x-enum-varnames": [
+                "SyncFull",
+                "SyncIncremental",
+                "SyncRetransform"
+            ]
         }
     }
 }`
@@ -6,9 +6,13 @@ require github.com/lib/pq v1.10.9
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/dghubble/oauth1 v0.7.3
 	github.com/gorilla/mux v1.8.1
 	github.com/ilyakaznacheev/cleanenv v1.5.0
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/oauth2 v0.30.0
+	golang.org/x/time v0.11.0
 )
 
 require (
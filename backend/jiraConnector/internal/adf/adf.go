@@ -0,0 +1,271 @@
+// Package adf converts Atlassian Document Format (rich text of Jira Cloud REST v3) to Markdown.
+package adf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Node struct {
+	Type    string         `json:"type"`
+	Text    string         `json:"text"`
+	Attrs   map[string]any `json:"attrs"`
+	Marks   []Mark         `json:"marks"`
+	Content []Node         `json:"content"`
+}
+
+type Mark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs"`
+}
+
+// ToMarkdown converts ADF document. Unknown nodes are replaced by their content,
+// so new node types don't lose the text
+func ToMarkdown(doc []byte) (string, error) {
+	var root Node
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return "", fmt.Errorf("can't parse adf document: %w", err)
+	}
+
+	var b strings.Builder
+	writeBlocks(&b, root.Content, "")
+	return strings.TrimSpace(b.String()), nil
+}
+
+// writeBlocks writes block nodes separated by empty lines, every line starts with prefix
+func writeBlocks(b *strings.Builder, nodes []Node, prefix string) {
+	for i := range nodes {
+		if i > 0 {
+			b.WriteString(strings.TrimRight(prefix, " ") + "\n")
+		}
+		writeBlock(b, &nodes[i], prefix)
+	}
+}
+
+func writeBlock(b *strings.Builder, node *Node, prefix string) {
+	switch node.Type {
+	case "paragraph":
+		writeLines(b, inline(node.Content), prefix)
+
+	case "heading":
+		level := min(max(intAttr(node, "level"), 1), 6)
+		writeLines(b, strings.Repeat("#", level)+" "+inline(node.Content), prefix)
+
+	case "bulletList", "orderedList":
+		start := max(intAttr(node, "order"), 1)
+		for i := range node.Content {
+			marker := "- "
+			if node.Type == "orderedList" {
+				marker = strconv.Itoa(start+i) + ". "
+			}
+			writeListItem(b, &node.Content[i], prefix, marker)
+		}
+
+	case "taskList":
+		for i := range node.Content {
+			marker := "- [ ] "
+			if attr(&node.Content[i], "state") == "DONE" {
+				marker = "- [x] "
+			}
+			writeListItem(b, &node.Content[i], prefix, marker)
+		}
+
+	case "codeBlock":
+		writeLines(b, "```"+attr(node, "language"), prefix)
+		writeLines(b, plainText(node.Content), prefix)
+		writeLines(b, "```", prefix)
+
+	case "blockquote", "panel":
+		writeBlocks(b, node.Content, prefix+"> ")
+
+	case "rule":
+		writeLines(b, "---", prefix)
+
+	case "table":
+		writeTable(b, node, prefix)
+
+	case "mediaSingle", "mediaGroup":
+		for i := range node.Content {
+			writeLines(b, media(&node.Content[i]), prefix)
+		}
+
+	case "blockCard", "embedCard":
+		writeLines(b, fmt.Sprintf("<%s>", attr(node, "url")), prefix)
+
+	default:
+		if isInline(node) {
+			writeLines(b, inline([]Node{*node}), prefix)
+			return
+		}
+		writeBlocks(b, node.Content, prefix)
+	}
+}
+
+// writeListItem puts marker before the first line of the item, the rest are indented
+func writeListItem(b *strings.Builder, item *Node, prefix, marker string) {
+	// blocks of the item aren't separated by empty lines to keep the list tight
+	var itemText strings.Builder
+	if item.Type == "taskItem" {
+		itemText.WriteString(inline(item.Content))
+	} else {
+		for i := range item.Content {
+			writeBlock(&itemText, &item.Content[i], "")
+		}
+	}
+
+	indent := strings.Repeat(" ", len(marker))
+	for i, line := range strings.Split(strings.TrimRight(itemText.String(), "\n"), "\n") {
+		if i == 0 {
+			b.WriteString(prefix + marker + line + "\n")
+			continue
+		}
+		b.WriteString(strings.TrimRight(prefix+indent+line, " ") + "\n")
+	}
+}
+
+func writeTable(b *strings.Builder, table *Node, prefix string) {
+	for i, row := range table.Content {
+		cells := make([]string, 0, len(row.Content))
+		for _, cell := range row.Content {
+			var cellText strings.Builder
+			writeBlocks(&cellText, cell.Content, "")
+			text := strings.TrimSpace(cellText.String())
+			cells = append(cells, strings.ReplaceAll(strings.ReplaceAll(text, "\n", " "), "|", "\\|"))
+		}
+		writeLines(b, "| "+strings.Join(cells, " | ")+" |", prefix)
+
+		if i == 0 {
+			separator := make([]string, len(cells))
+			for j := range separator {
+				separator[j] = "---"
+			}
+			writeLines(b, "| "+strings.Join(separator, " | ")+" |", prefix)
+		}
+	}
+}
+
+func writeLines(b *strings.Builder, text, prefix string) {
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(strings.TrimRight(prefix+line, " ") + "\n")
+	}
+}
+
+func inline(nodes []Node) string {
+	var b strings.Builder
+	for i := range nodes {
+		node := &nodes[i]
+		switch node.Type {
+		case "text":
+			b.WriteString(applyMarks(node.Text, node.Marks))
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			b.WriteString(attrOr(node, "text", "@"+attr(node, "id")))
+		case "emoji":
+			b.WriteString(attrOr(node, "text", attr(node, "shortName")))
+		case "status":
+			b.WriteString("[" + attr(node, "text") + "]")
+		case "date":
+			b.WriteString(date(attr(node, "timestamp")))
+		case "inlineCard":
+			b.WriteString("<" + attr(node, "url") + ">")
+		case "media":
+			b.WriteString(media(node))
+		default:
+			b.WriteString(inline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+func applyMarks(text string, marks []Mark) string {
+	if text == "" {
+		return text
+	}
+
+	var link string
+	for _, mark := range marks {
+		switch mark.Type {
+		case "code":
+			text = "`" + text + "`"
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "link":
+			link, _ = mark.Attrs["href"].(string)
+		}
+	}
+
+	if link != "" {
+		return "[" + text + "](" + link + ")"
+	}
+	return text
+}
+
+func plainText(nodes []Node) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		if node.Type == "hardBreak" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(node.Text)
+		b.WriteString(plainText(node.Content))
+	}
+	return b.String()
+}
+
+func media(node *Node) string {
+	name := attrOr(node, "alt", attr(node, "id"))
+	if url := attr(node, "url"); url != "" {
+		return "![" + name + "](" + url + ")"
+	}
+	return "[attachment: " + name + "]"
+}
+
+// date attr is a unix timestamp in milliseconds
+func date(timestamp string) string {
+	ms, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return timestamp
+	}
+	return time.UnixMilli(ms).UTC().Format("2006-01-02")
+}
+
+func isInline(node *Node) bool {
+	switch node.Type {
+	case "text", "hardBreak", "mention", "emoji", "status", "date", "inlineCard":
+		return true
+	}
+	return false
+}
+
+func attr(node *Node, name string) string {
+	switch value := node.Attrs[name].(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return ""
+}
+
+func attrOr(node *Node, name, fallback string) string {
+	if value := attr(node, name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func intAttr(node *Node, name string) int {
+	value, _ := strconv.Atoi(attr(node, name))
+	return value
+}
@@ -0,0 +1,73 @@
+package adf
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToMarkdown_Fixture(t *testing.T) {
+	doc, err := os.ReadFile("testdata/description.json")
+	require.NoError(t, err)
+	expected, err := os.ReadFile("testdata/description.md")
+	require.NoError(t, err)
+
+	result, err := ToMarkdown(doc)
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(expected)), result)
+}
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		expected string
+	}{
+		{
+			name:     "empty document",
+			doc:      `{"version":1,"type":"doc","content":[]}`,
+			expected: "",
+		},
+		{
+			name:     "plain paragraphs",
+			doc:      `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]},{"type":"paragraph","content":[{"type":"text","text":"second"}]}]}`,
+			expected: "first\n\nsecond",
+		},
+		{
+			name:     "empty paragraph",
+			doc:      `{"type":"doc","content":[{"type":"paragraph"}]}`,
+			expected: "",
+		},
+		{
+			name:     "nested marks",
+			doc:      `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"go","marks":[{"type":"strong"},{"type":"link","attrs":{"href":"https://go.dev"}}]}]}]}`,
+			expected: "[**go**](https://go.dev)",
+		},
+		{
+			name:     "ordered list start",
+			doc:      `{"type":"doc","content":[{"type":"orderedList","attrs":{"order":3},"content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"third"}]}]}]}]}`,
+			expected: "3. third",
+		},
+		{
+			name:     "inline card",
+			doc:      `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"inlineCard","attrs":{"url":"https://jira.example.com/browse/PRJ-1"}}]}]}`,
+			expected: "<https://jira.example.com/browse/PRJ-1>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToMarkdown([]byte(tt.doc))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestToMarkdown_InvalidJson(t *testing.T) {
+	_, err := ToMarkdown([]byte(`{"type":"doc"`))
+	assert.Error(t, err)
+}
@@ -9,14 +9,43 @@ type errMap map[error]int
 
 var (
 	ErrorsUpdate = errMap{
-		ErrNoProject:    http.StatusNotFound,
+		ErrNoProject:     http.StatusNotFound,
+		ErrJiraAuth:      http.StatusBadGateway,
+		ErrJiraForbidden: http.StatusForbidden,
+		ErrParamProject:  http.StatusBadRequest,
+		ErrParamMode:     http.StatusBadRequest,
+		ErrParamSource:   http.StatusBadRequest,
+		ErrUpdProject:    http.StatusInternalServerError,
+		ErrEnqueueJob:    http.StatusInternalServerError,
+		ErrQueueFull:     http.StatusServiceUnavailable,
+		ErrProjectBusy:   http.StatusConflict,
+	}
+
+	ErrorsRetransform = errMap{
+		ErrNotArchived:  http.StatusNotFound,
+		ErrArchiveOff:   http.StatusConflict,
 		ErrParamProject: http.StatusBadRequest,
-		ErrUpdProject:   http.StatusInternalServerError,
-		ErrPushProject:  http.StatusInternalServerError,
+		ErrParamSource:  http.StatusBadRequest,
+		ErrRetransform:  http.StatusInternalServerError,
+		ErrEnqueueJob:   http.StatusInternalServerError,
+		ErrQueueFull:    http.StatusServiceUnavailable,
+		ErrProjectBusy:  http.StatusConflict,
+	}
+
+	ErrorsJob = errMap{
+		ErrParamJobId:     http.StatusBadRequest,
+		ErrParamLimitPage: http.StatusBadRequest,
+		ErrNoJob:          http.StatusNotFound,
+		ErrJobFinished:    http.StatusConflict,
+		ErrGetJob:         http.StatusInternalServerError,
+		ErrCancelJob:      http.StatusInternalServerError,
 	}
 
 	ErrorsProject = errMap{
 		ErrParamLimitPage: http.StatusBadRequest,
+		ErrParamSource:    http.StatusBadRequest,
+		ErrJiraAuth:       http.StatusBadGateway,
+		ErrJiraForbidden:  http.StatusForbidden,
 		ErrGetProjectPage: http.StatusInternalServerError,
 		ErrEncodeAns:      http.StatusInternalServerError,
 	}
@@ -5,13 +5,29 @@ import "errors"
 var (
 	ErrParamLimitPage = errors.New("incorrect limit or page param - need integer > 0")
 	ErrParamProject   = errors.New("incorrect project name param")
+	ErrParamMode      = errors.New("incorrect mode param - need full or incremental")
+	ErrParamSource    = errors.New("incorrect source param - need one of jira sources from config")
+
+	ErrParamJobId = errors.New("incorrect job id param - need integer > 0")
 
 	ErrUpdProject  = errors.New("something went wrong and i can't update project")
-	ErrPushProject = errors.New("something went wrong and i can't save issues")
+	ErrEnqueueJob  = errors.New("something went wrong and i can't start project update")
+	ErrQueueFull   = errors.New("too many project updates in queue, try again later")
+	ErrProjectBusy = errors.New("project is already queued or being updated")
+	ErrGetJob      = errors.New("something went wrong and i can't get update jobs")
+	ErrCancelJob   = errors.New("something went wrong and i can't cancel update job")
+	ErrRetransform = errors.New("something went wrong and i can't start project retransform")
 
 	ErrGetProjectPage = errors.New("something went wrong and i can't get page of projects")
 
 	ErrEncodeAns = errors.New("something went wrong and i can't encode ans for this request")
 
-	ErrNoProject = errors.New("jira doesn't have such project")
+	ErrNoProject     = errors.New("jira doesn't have such project")
+	ErrJiraAuth      = errors.New("jira rejected connector credentials")
+	ErrJiraForbidden = errors.New("connector has no access to this project in jira")
+	ErrNoJob         = errors.New("there is no such update job")
+	ErrNotArchived   = errors.New("project isn't archived - run full update with the archive on first")
+	ErrArchiveOff    = errors.New("archive of raw issues is off in connector config")
+
+	ErrJobFinished = errors.New("update job is already finished")
 )
@@ -1,7 +1,9 @@
 package jirahandlers
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -11,14 +13,19 @@ import (
 
 	"github.com/gorilla/mux"
 	myErr "github.com/jiraconnector/internal/apiJiraConnector/jiraHandlers/errors"
+	svcErr "github.com/jiraconnector/internal/apiJiraConnector/jiraService/errors"
+	conErr "github.com/jiraconnector/internal/connector/errors"
+	jobErr "github.com/jiraconnector/internal/jobQueue/errors"
 	"github.com/jiraconnector/internal/structures"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandler_Projects(t *testing.T) {
 	tests := []struct {
 		name           string
 		queryParams    map[string]string
+		sourceError    error
 		mockReturn     *structures.ResponseProject
 		mockError      error
 		expectedStatus int
@@ -80,6 +87,27 @@ func TestHandler_Projects(t *testing.T) {
 			expectedStatus: myErr.GetStatusCode(myErr.ErrorsProject, myErr.ErrGetProjectPage),
 			expectedError:  myErr.ErrGetProjectPage,
 		},
+		{
+			name:           "jira rejects credentials",
+			queryParams:    map[string]string{},
+			mockReturn:     nil,
+			mockError:      conErr.ErrUnauthorized,
+			expectedStatus: http.StatusBadGateway,
+			expectedError:  myErr.ErrJiraAuth,
+		},
+		{
+			name:           "projects of named source",
+			queryParams:    map[string]string{"source": "cloud"},
+			mockReturn:     &structures.ResponseProject{Source: "cloud"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown source",
+			queryParams:    map[string]string{"source": "other"},
+			sourceError:    svcErr.ErrUnknownSource,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  myErr.ErrParamSource,
+		},
 	}
 
 	for _, tt := range tests {
@@ -95,11 +123,16 @@ func TestHandler_Projects(t *testing.T) {
 				page = 1
 			}
 			search := tt.queryParams["search"]
+			source := tt.queryParams["source"]
+			if source == "" {
+				source = "default"
+			}
 
-			mockService.On("GetProjectsPage", search, limit, page).Return(tt.mockReturn, tt.mockError)
+			mockService.On("ResolveSource", tt.queryParams["source"]).Return(source, tt.sourceError)
+			mockService.On("GetProjectsPage", mock.Anything, source, search, limit, page).Return(tt.mockReturn, tt.mockError)
 
 			router := mux.NewRouter()
-			_ = NewHandler(mockService, router, slog.Default())
+			_ = NewHandler(mockService, new(MockJobQueueInterface), router, slog.Default())
 
 			req, err := http.NewRequest("GET", "/api/v1/connector/projects", nil)
 			assert.NoError(t, err)
@@ -115,6 +148,9 @@ func TestHandler_Projects(t *testing.T) {
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.mockError != nil || tt.sourceError != nil {
+				assert.Contains(t, rr.Body.String(), tt.expectedError.Error())
+			}
 
 			if tt.expectedError == nil && tt.mockError == nil {
 				mockService.AssertExpectations(t)
@@ -127,73 +163,140 @@ func TestHandler_UpdateProject(t *testing.T) {
 	tests := []struct {
 		name           string
 		queryParam     string
-		mockIssues     []structures.JiraIssue
-		mockError      error
-		pushError      error
+		mode           string
+		source         string
+		sourceError    error
+		projectError   error
+		enqueueError   error
 		expectedStatus int
 		expectedError  error
 	}{
 		{
 			name:           "successful update",
 			queryParam:     "AAR",
-			mockIssues:     []structures.JiraIssue{},
-			mockError:      nil,
-			pushError:      nil,
-			expectedStatus: http.StatusOK,
+			expectedStatus: http.StatusAccepted,
 			expectedError:  nil,
 		},
 		{
 			name:           "missing project parameter",
 			queryParam:     "",
-			mockIssues:     nil,
-			mockError:      nil,
-			pushError:      nil,
 			expectedStatus: myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrParamProject),
 			expectedError:  myErr.ErrParamProject,
 		},
 		{
 			name:           "project not found",
 			queryParam:     "UNKNOWN",
-			mockIssues:     nil,
-			mockError:      myErr.ErrNoProject,
-			pushError:      nil,
+			projectError:   myErr.ErrNoProject,
 			expectedStatus: myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrNoProject),
 			expectedError:  myErr.ErrNoProject,
 		},
 		{
-			name:           "update project error",
+			name:           "jira rejects credentials",
 			queryParam:     "TESTPROJ",
-			mockIssues:     nil,
-			mockError:      errors.New("update error"),
-			pushError:      nil,
+			projectError:   fmt.Errorf("%w: %w", conErr.ErrMaxTimeRequest, conErr.ErrUnauthorized),
+			expectedStatus: http.StatusBadGateway,
+			expectedError:  myErr.ErrJiraAuth,
+		},
+		{
+			name:           "no access to project",
+			queryParam:     "TESTPROJ",
+			projectError:   conErr.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+			expectedError:  myErr.ErrJiraForbidden,
+		},
+		{
+			name:           "get project error",
+			queryParam:     "TESTPROJ",
+			projectError:   errors.New("jira error"),
 			expectedStatus: myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrUpdProject),
 			expectedError:  myErr.ErrUpdProject,
 		},
 		{
-			name:           "push to db error",
+			name:           "successful full update",
+			queryParam:     "AAR",
+			mode:           "full",
+			expectedStatus: http.StatusAccepted,
+			expectedError:  nil,
+		},
+		{
+			name:           "invalid mode parameter",
+			queryParam:     "AAR",
+			mode:           "partial",
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrParamMode),
+			expectedError:  myErr.ErrParamMode,
+		},
+		{
+			name:           "queue is full",
 			queryParam:     "TESTPROJ",
-			mockIssues:     []structures.JiraIssue{},
-			mockError:      nil,
-			pushError:      errors.New("push error"),
-			expectedStatus: myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrPushProject),
-			expectedError:  myErr.ErrPushProject,
+			enqueueError:   jobErr.ErrQueueFull,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedError:  myErr.ErrQueueFull,
+		},
+		{
+			name:           "project is already updating",
+			queryParam:     "TESTPROJ",
+			enqueueError:   jobErr.ErrProjectBusy,
+			expectedStatus: http.StatusConflict,
+			expectedError:  myErr.ErrProjectBusy,
+		},
+		{
+			name:           "update of named source",
+			queryParam:     "AAR",
+			source:         "cloud",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "unknown source",
+			queryParam:     "AAR",
+			source:         "other",
+			sourceError:    svcErr.ErrUnknownSource,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  myErr.ErrParamSource,
+		},
+		{
+			name:           "source required",
+			queryParam:     "AAR",
+			sourceError:    svcErr.ErrSourceRequired,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  myErr.ErrParamSource,
+		},
+		{
+			name:           "enqueue error",
+			queryParam:     "TESTPROJ",
+			enqueueError:   errors.New("db error"),
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrEnqueueJob),
+			expectedError:  myErr.ErrEnqueueJob,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := new(MockJiraServiceInterface)
+			mockJobs := new(MockJobQueueInterface)
 
-			if tt.queryParam != "" {
-				mockService.On("UpdateProjects", tt.queryParam).Return(tt.mockIssues, tt.mockError)
+			mode, modeErr := getSyncMode(&http.Request{URL: &url.URL{RawQuery: url.Values{"mode": {tt.mode}}.Encode()}})
+			source := tt.source
+			if source == "" {
+				source = "default"
+			}
+			if tt.queryParam != "" && modeErr == nil {
+				mockService.On("ResolveSource", tt.source).Return(source, tt.sourceError)
+			}
+			if tt.queryParam != "" && modeErr == nil && tt.sourceError == nil {
+				mockService.On("GetProjectByKey", mock.Anything, source, tt.queryParam).Return(&structures.JiraProject{Key: tt.queryParam}, tt.projectError)
 
-				if tt.mockError == nil {
-					mockService.On("PushDataToDb", tt.queryParam, tt.mockIssues).Return(tt.pushError)
+				if tt.projectError == nil {
+					ref := structures.ProjectRef{Source: source, Key: tt.queryParam}
+					job := &structures.Job{Id: 1, Source: source, Project: tt.queryParam, Mode: mode, State: structures.JobQueued}
+					if tt.enqueueError != nil {
+						job = nil
+					}
+					mockJobs.On("EnqueueIfIdle", mock.Anything, ref, mode, structures.TriggerManual).Return(job, tt.enqueueError)
 				}
 			}
 
 			router := mux.NewRouter()
-			_ = NewHandler(mockService, router, slog.Default())
+			_ = NewHandler(mockService, mockJobs, router, slog.Default())
 
 			req, err := http.NewRequest("POST", "/api/v1/connector/updateProject", nil)
 			assert.NoError(t, err)
@@ -202,6 +305,12 @@ func TestHandler_UpdateProject(t *testing.T) {
 			if tt.queryParam != "" {
 				q.Add("project", tt.queryParam)
 			}
+			if tt.mode != "" {
+				q.Add("mode", tt.mode)
+			}
+			if tt.source != "" {
+				q.Add("source", tt.source)
+			}
 			req.URL.RawQuery = q.Encode()
 
 			rr := httptest.NewRecorder()
@@ -209,8 +318,341 @@ func TestHandler_UpdateProject(t *testing.T) {
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedError != nil {
+				assert.Contains(t, rr.Body.String(), tt.expectedError.Error())
+			} else {
+				var job structures.Job
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &job))
+				assert.Equal(t, 1, job.Id)
+				assert.Equal(t, structures.JobQueued, job.State)
+			}
+
+			mockService.AssertExpectations(t)
+			mockJobs.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_Retransform(t *testing.T) {
+	tests := []struct {
+		name           string
+		project        string
+		sourceError    error
+		archived       bool
+		archiveError   error
+		enqueueError   error
+		expectedStatus int
+		expectedError  error
+	}{
+		{
+			name:           "successful retransform",
+			project:        "AAR",
+			archived:       true,
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "missing project parameter",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  myErr.ErrParamProject,
+		},
+		{
+			name:           "unknown source",
+			project:        "AAR",
+			sourceError:    svcErr.ErrUnknownSource,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  myErr.ErrParamSource,
+		},
+		{
+			name:           "project isn't archived",
+			project:        "AAR",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  myErr.ErrNotArchived,
+		},
+		{
+			name:           "archive is off",
+			project:        "AAR",
+			archiveError:   svcErr.ErrArchiveOff,
+			expectedStatus: http.StatusConflict,
+			expectedError:  myErr.ErrArchiveOff,
+		},
+		{
+			name:           "archive error",
+			project:        "AAR",
+			archiveError:   errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  myErr.ErrRetransform,
+		},
+		{
+			name:           "queue is full",
+			project:        "AAR",
+			archived:       true,
+			enqueueError:   jobErr.ErrQueueFull,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedError:  myErr.ErrQueueFull,
+		},
+		{
+			name:           "project is already updating",
+			project:        "AAR",
+			archived:       true,
+			enqueueError:   jobErr.ErrProjectBusy,
+			expectedStatus: http.StatusConflict,
+			expectedError:  myErr.ErrProjectBusy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockJiraServiceInterface)
+			mockJobs := new(MockJobQueueInterface)
+
+			if tt.project != "" {
+				mockService.On("ResolveSource", "").Return("default", tt.sourceError)
+			}
+			if tt.project != "" && tt.sourceError == nil {
+				mockService.On("HasArchive", mock.Anything, "default", tt.project).Return(tt.archived, tt.archiveError)
+			}
+			if tt.archived {
+				ref := structures.ProjectRef{Source: "default", Key: tt.project}
+				job := &structures.Job{Id: 1, Source: "default", Project: tt.project, Mode: structures.SyncRetransform, State: structures.JobQueued}
+				if tt.enqueueError != nil {
+					job = nil
+				}
+				mockJobs.On("EnqueueIfIdle", mock.Anything, ref, structures.SyncRetransform, structures.TriggerManual).Return(job, tt.enqueueError)
+			}
+
+			router := mux.NewRouter()
+			_ = NewHandler(mockService, mockJobs, router, slog.Default())
+
+			req, err := http.NewRequest("POST", "/api/v1/connector/retransform", nil)
+			assert.NoError(t, err)
+			if tt.project != "" {
+				req.URL.RawQuery = url.Values{"project": {tt.project}}.Encode()
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedError != nil {
+				assert.Contains(t, rr.Body.String(), tt.expectedError.Error())
+			} else {
+				var job structures.Job
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &job))
+				assert.Equal(t, structures.SyncRetransform, job.Mode)
+			}
 
 			mockService.AssertExpectations(t)
+			mockJobs.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		mockCall       bool
+		mockJob        *structures.Job
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "successful request",
+			id:             "5",
+			mockCall:       true,
+			mockJob:        &structures.Job{Id: 5, Project: "AAR", State: structures.JobRunning, IssuesFetched: 10, IssuesTotal: 20},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid id",
+			id:             "abc",
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrParamJobId),
+		},
+		{
+			name:           "negative id",
+			id:             "-1",
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrParamJobId),
+		},
+		{
+			name:           "job not found",
+			id:             "7",
+			mockCall:       true,
+			mockError:      jobErr.ErrJobNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "store error",
+			id:             "7",
+			mockCall:       true,
+			mockError:      errors.New("db error"),
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrGetJob),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJobs := new(MockJobQueueInterface)
+			if tt.mockCall {
+				id, _ := strconv.Atoi(tt.id)
+				mockJobs.On("GetJob", mock.Anything, id).Return(tt.mockJob, tt.mockError)
+			}
+
+			router := mux.NewRouter()
+			_ = NewHandler(new(MockJiraServiceInterface), mockJobs, router, slog.Default())
+
+			req, err := http.NewRequest("GET", "/api/v1/connector/jobs/"+tt.id, nil)
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.mockJob != nil {
+				var job structures.Job
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &job))
+				assert.Equal(t, *tt.mockJob, job)
+			}
+
+			mockJobs.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_CancelJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		mockCall       bool
+		mockJob        *structures.Job
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			id:             "5",
+			mockCall:       true,
+			mockJob:        &structures.Job{Id: 5, Project: "AAR", State: structures.JobCancelled},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid id",
+			id:             "abc",
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrParamJobId),
+		},
+		{
+			name:           "job not found",
+			id:             "7",
+			mockCall:       true,
+			mockError:      jobErr.ErrJobNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "job finished",
+			id:             "7",
+			mockCall:       true,
+			mockError:      jobErr.ErrJobFinished,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "store error",
+			id:             "7",
+			mockCall:       true,
+			mockError:      errors.New("db error"),
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrCancelJob),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJobs := new(MockJobQueueInterface)
+			if tt.mockCall {
+				id, _ := strconv.Atoi(tt.id)
+				mockJobs.On("Cancel", mock.Anything, id).Return(tt.mockJob, tt.mockError)
+			}
+
+			router := mux.NewRouter()
+			_ = NewHandler(new(MockJiraServiceInterface), mockJobs, router, slog.Default())
+
+			req, err := http.NewRequest("POST", "/api/v1/connector/jobs/"+tt.id+"/cancel", nil)
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.mockJob != nil {
+				var job structures.Job
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &job))
+				assert.Equal(t, *tt.mockJob, job)
+			}
+
+			mockJobs.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetJobs(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          string
+		expectedLimit  int
+		mockJobs       []structures.Job
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "default limit",
+			expectedLimit:  20,
+			mockJobs:       []structures.Job{{Id: 2}, {Id: 1}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "custom limit",
+			limit:          "5",
+			expectedLimit:  5,
+			mockJobs:       []structures.Job{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid limit",
+			limit:          "0",
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrParamLimitPage),
+		},
+		{
+			name:           "store error",
+			expectedLimit:  20,
+			mockError:      errors.New("db error"),
+			expectedStatus: myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrGetJob),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJobs := new(MockJobQueueInterface)
+			if tt.expectedLimit != 0 {
+				mockJobs.On("GetJobs", mock.Anything, tt.expectedLimit).Return(tt.mockJobs, tt.mockError)
+			}
+
+			router := mux.NewRouter()
+			_ = NewHandler(new(MockJiraServiceInterface), mockJobs, router, slog.Default())
+
+			req, err := http.NewRequest("GET", "/api/v1/connector/jobs", nil)
+			assert.NoError(t, err)
+			if tt.limit != "" {
+				req.URL.RawQuery = url.Values{"limit": {tt.limit}}.Encode()
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.mockJobs != nil {
+				var jobs []structures.Job
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &jobs))
+				assert.Equal(t, tt.mockJobs, jobs)
+			}
+
+			mockJobs.AssertExpectations(t)
 		})
 	}
 }
@@ -347,3 +789,32 @@ func TestGetProjectParams(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSyncMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		expected structures.SyncMode
+		err      error
+	}{
+		{"default is incremental", "", structures.SyncIncremental, nil},
+		{"incremental", "incremental", structures.SyncIncremental, nil},
+		{"full", "full", structures.SyncFull, nil},
+		{"unknown", "partial", "", myErr.ErrParamMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{
+				URL: &url.URL{
+					RawQuery: url.Values{"mode": {tt.mode}}.Encode(),
+				},
+			}
+
+			mode, err := getSyncMode(req)
+
+			assert.Equal(t, tt.expected, mode)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
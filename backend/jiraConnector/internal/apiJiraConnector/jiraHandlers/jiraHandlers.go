@@ -1,6 +1,7 @@
 package jirahandlers
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -9,7 +10,9 @@ import (
 	"github.com/gorilla/mux"
 	myErr "github.com/jiraconnector/internal/apiJiraConnector/jiraHandlers/errors"
 	"github.com/jiraconnector/internal/apiJiraConnector/jiraHandlers/responseutils"
-	datatransformer "github.com/jiraconnector/internal/dataTransformer"
+	svcErr "github.com/jiraconnector/internal/apiJiraConnector/jiraService/errors"
+	conErr "github.com/jiraconnector/internal/connector/errors"
+	jobErr "github.com/jiraconnector/internal/jobQueue/errors"
 	"github.com/jiraconnector/internal/structures"
 	"github.com/jiraconnector/pkg/middleware"
 )
@@ -17,25 +20,36 @@ import (
 //go:generate mockery
 
 type JiraServiceInterface interface {
-	GetProjectsPage(search string, limit, page int) (*structures.ResponseProject, error)
-	UpdateProjects(projectId string) ([]structures.JiraIssue, error)
+	ResolveSource(source string) (string, error)
+	GetProjectsPage(ctx context.Context, source, search string, limit, page int) (*structures.ResponseProject, error)
+	GetProjectByKey(ctx context.Context, source, projectKey string) (*structures.JiraProject, error)
+	HasArchive(ctx context.Context, source, projectKey string) (bool, error)
+}
 
-	PushDataToDb(project string, issues []structures.JiraIssue) error
-	TransformDataToDb(project *structures.JiraProject, issues []structures.JiraIssue) []datatransformer.DataTransformer
+type JobQueueInterface interface {
+	EnqueueIfIdle(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, trigger structures.JobTrigger) (*structures.Job, error)
+	GetJob(ctx context.Context, jobId int) (*structures.Job, error)
+	GetJobs(ctx context.Context, limit int) ([]structures.Job, error)
+	Cancel(ctx context.Context, jobId int) (*structures.Job, error)
 }
 
 type handler struct {
 	service JiraServiceInterface
+	jobs    JobQueueInterface
 	log     *slog.Logger
 }
 
-func NewHandler(service JiraServiceInterface, router *mux.Router, log *slog.Logger) *mux.Router {
-	h := handler{service: service, log: log}
+func NewHandler(service JiraServiceInterface, jobs JobQueueInterface, router *mux.Router, log *slog.Logger) *mux.Router {
+	h := handler{service: service, jobs: jobs, log: log}
 
 	router.Use(middleware.NewLoggerMiddleware(log))
 
 	router.HandleFunc("/api/v1/connector/projects", h.projects).Methods(http.MethodOptions, http.MethodGet)
 	router.HandleFunc("/api/v1/connector/updateProject", h.updateProject).Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api/v1/connector/retransform", h.retransform).Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api/v1/connector/jobs", h.getJobs).Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v1/connector/jobs/{id}", h.getJob).Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v1/connector/jobs/{id}/cancel", h.cancelJob).Methods(http.MethodOptions, http.MethodPost)
 	log.Info("create router")
 	return router
 }
@@ -48,9 +62,12 @@ func NewHandler(service JiraServiceInterface, router *mux.Router, log *slog.Logg
 // @Param   limit  query  int     false  "Items per page"
 // @Param   page   query  int     false  "Page number"
 // @Param   search query  string  false  "Search filter"
+// @Param   source query  string  false  "Jira source from config, default source if empty"
 // @Success 200 {object} structures.ResponseProject
 // @Failure 400 {object} responseutils.ErrorResponse
+// @Failure 403 {object} responseutils.ErrorResponse
 // @Failure 500 {object} responseutils.ErrorResponse
+// @Failure 502 {object} responseutils.ErrorResponse
 // @Router /api/v1/connector/projects [get]
 func (h *handler) projects(w http.ResponseWriter, r *http.Request) {
 	limit, page, search, err := getProjectParams(r)
@@ -59,26 +76,39 @@ func (h *handler) projects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	projects, err := h.service.GetProjectsPage(search, limit, page)
+	source, err := h.service.ResolveSource(r.URL.Query().Get("source"))
+	if err != nil {
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsProject, myErr.ErrParamSource), myErr.ErrParamSource.Error(), err)
+		return
+	}
+
+	projects, err := h.service.GetProjectsPage(r.Context(), source, search, limit, page)
 	if err != nil {
-		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsProject, myErr.ErrGetProjectPage), myErr.ErrGetProjectPage.Error(), err)
+		ansErr := jiraAccessError(err, myErr.ErrGetProjectPage)
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsProject, ansErr), ansErr.Error(), err)
 		return
 	}
 
 	responseutils.WriteSuccess(w, h.log, http.StatusOK, projects)
-	h.log.Info("Got project page", "page", page)
+	h.log.Info("Got project page", "source", source, "page", page)
 }
 
-// @Summary Update Jira project and push issues to DB
-// @Description Обновляет проект в Jira, загружает задачи и сохраняет их в базу данных
+// @Summary Start update of Jira project
+// @Description Ставит в очередь задачу на загрузку задач проекта из Jira и сохранение их в базу данных
 // @Tags projects
 // @Accept  json
 // @Produce  json
 // @Param   project  query  string  true  "Project Key or ID (required)"
-// @Success 200 {object} structures.ResponseUpdate
+// @Param   mode     query  string  false "Sync mode: incremental (default) or full"
+// @Param   source   query  string  false "Jira source from config, default source if empty"
+// @Success 202 {object} structures.Job
 // @Failure 400 {object} responseutils.ErrorResponse
+// @Failure 403 {object} responseutils.ErrorResponse
 // @Failure 404 {object} responseutils.ErrorResponse
+// @Failure 409 {object} responseutils.ErrorResponse
 // @Failure 500 {object} responseutils.ErrorResponse
+// @Failure 502 {object} responseutils.ErrorResponse
+// @Failure 503 {object} responseutils.ErrorResponse
 // @Router /api/v1/connector/updateProject [post]
 func (h *handler) updateProject(w http.ResponseWriter, r *http.Request) {
 	project := r.URL.Query().Get("project")
@@ -87,23 +117,210 @@ func (h *handler) updateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	issues, err := h.service.UpdateProjects(project)
+	mode, err := getSyncMode(r)
+	if err != nil {
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrParamMode), myErr.ErrParamMode.Error(), err)
+		return
+	}
+
+	source, err := h.service.ResolveSource(r.URL.Query().Get("source"))
 	if err != nil {
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrParamSource), myErr.ErrParamSource.Error(), err)
+		return
+	}
+
+	// unknown project is reported right away instead of a failed job
+	if _, err := h.service.GetProjectByKey(r.Context(), source, project); err != nil {
 		if errors.Is(err, myErr.ErrNoProject) {
 			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrNoProject), myErr.ErrNoProject.Error(), err)
 		} else {
-			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrUpdProject), myErr.ErrUpdProject.Error(), err)
+			ansErr := jiraAccessError(err, myErr.ErrUpdProject)
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, ansErr), ansErr.Error(), err)
 		}
 		return
 	}
 
-	if err := h.service.PushDataToDb(project, issues); err != nil {
-		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrPushProject), myErr.ErrPushProject.Error(), err)
+	ref := structures.ProjectRef{Source: source, Key: project}
+	// a project which is already queued or running isn't synced twice
+	job, err := h.jobs.EnqueueIfIdle(r.Context(), ref, mode, structures.TriggerManual)
+	if err != nil {
+		if errors.Is(err, jobErr.ErrProjectBusy) {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrProjectBusy), myErr.ErrProjectBusy.Error(), err)
+		} else if errors.Is(err, jobErr.ErrQueueFull) {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrQueueFull), myErr.ErrQueueFull.Error(), err)
+		} else {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsUpdate, myErr.ErrEnqueueJob), myErr.ErrEnqueueJob.Error(), err)
+		}
 		return
 	}
 
-	responseutils.WriteSuccess(w, h.log, http.StatusOK, structures.ResponseUpdate{Project: project, Status: "updated"})
-	h.log.Info("Update issues", "project", project)
+	responseutils.WriteSuccess(w, h.log, http.StatusAccepted, job)
+	h.log.Info("Enqueue update", "project", ref.String(), "mode", mode, "job", job.Id)
+}
+
+// @Summary Start retransform of Jira project
+// @Description Ставит в очередь задачу на пересборку задач проекта в базе данных из архива исходных данных Jira без обращения к Jira
+// @Tags projects
+// @Produce  json
+// @Param   project  query  string  true  "Project Key (required)"
+// @Param   source   query  string  false "Jira source from config, default source if empty"
+// @Success 202 {object} structures.Job
+// @Failure 400 {object} responseutils.ErrorResponse
+// @Failure 404 {object} responseutils.ErrorResponse
+// @Failure 409 {object} responseutils.ErrorResponse
+// @Failure 500 {object} responseutils.ErrorResponse
+// @Failure 503 {object} responseutils.ErrorResponse
+// @Router /api/v1/connector/retransform [post]
+func (h *handler) retransform(w http.ResponseWriter, r *http.Request) {
+	project := r.URL.Query().Get("project")
+	if project == "" {
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsRetransform, myErr.ErrParamProject), myErr.ErrParamProject.Error(), nil)
+		return
+	}
+
+	source, err := h.service.ResolveSource(r.URL.Query().Get("source"))
+	if err != nil {
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsRetransform, myErr.ErrParamSource), myErr.ErrParamSource.Error(), err)
+		return
+	}
+
+	// project without archive is reported right away instead of a failed job
+	archived, err := h.service.HasArchive(r.Context(), source, project)
+	switch {
+	case errors.Is(err, svcErr.ErrArchiveOff):
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsRetransform, myErr.ErrArchiveOff), myErr.ErrArchiveOff.Error(), err)
+		return
+	case err != nil:
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsRetransform, myErr.ErrRetransform), myErr.ErrRetransform.Error(), err)
+		return
+	case !archived:
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsRetransform, myErr.ErrNotArchived), myErr.ErrNotArchived.Error(), nil)
+		return
+	}
+
+	ref := structures.ProjectRef{Source: source, Key: project}
+	// a project which is already queued or running isn't synced twice
+	job, err := h.jobs.EnqueueIfIdle(r.Context(), ref, structures.SyncRetransform, structures.TriggerManual)
+	if err != nil {
+		if errors.Is(err, jobErr.ErrProjectBusy) {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsRetransform, myErr.ErrProjectBusy), myErr.ErrProjectBusy.Error(), err)
+		} else if errors.Is(err, jobErr.ErrQueueFull) {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsRetransform, myErr.ErrQueueFull), myErr.ErrQueueFull.Error(), err)
+		} else {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsRetransform, myErr.ErrEnqueueJob), myErr.ErrEnqueueJob.Error(), err)
+		}
+		return
+	}
+
+	responseutils.WriteSuccess(w, h.log, http.StatusAccepted, job)
+	h.log.Info("Enqueue retransform", "project", ref.String(), "job", job.Id)
+}
+
+// @Summary Get update job
+// @Description Получение состояния задачи обновления проекта
+// @Tags jobs
+// @Produce  json
+// @Param   id  path  int  true  "Job ID"
+// @Success 200 {object} structures.Job
+// @Failure 400 {object} responseutils.ErrorResponse
+// @Failure 404 {object} responseutils.ErrorResponse
+// @Failure 500 {object} responseutils.ErrorResponse
+// @Router /api/v1/connector/jobs/{id} [get]
+func (h *handler) getJob(w http.ResponseWriter, r *http.Request) {
+	jobId, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || jobId <= 0 {
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrParamJobId), myErr.ErrParamJobId.Error(), err)
+		return
+	}
+
+	job, err := h.jobs.GetJob(r.Context(), jobId)
+	if err != nil {
+		if errors.Is(err, jobErr.ErrJobNotFound) {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrNoJob), myErr.ErrNoJob.Error(), err)
+		} else {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrGetJob), myErr.ErrGetJob.Error(), err)
+		}
+		return
+	}
+
+	responseutils.WriteSuccess(w, h.log, http.StatusOK, job)
+	h.log.Info("Got job", "job", jobId)
+}
+
+// @Summary Cancel update job
+// @Description Отмена задачи обновления проекта. Задача в очереди отменяется сразу, выполняющаяся - прерывается и переходит в состояние cancelled после остановки
+// @Tags jobs
+// @Produce  json
+// @Param   id  path  int  true  "Job ID"
+// @Success 200 {object} structures.Job
+// @Failure 400 {object} responseutils.ErrorResponse
+// @Failure 404 {object} responseutils.ErrorResponse
+// @Failure 409 {object} responseutils.ErrorResponse
+// @Failure 500 {object} responseutils.ErrorResponse
+// @Router /api/v1/connector/jobs/{id}/cancel [post]
+func (h *handler) cancelJob(w http.ResponseWriter, r *http.Request) {
+	jobId, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || jobId <= 0 {
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrParamJobId), myErr.ErrParamJobId.Error(), err)
+		return
+	}
+
+	job, err := h.jobs.Cancel(r.Context(), jobId)
+	if err != nil {
+		switch {
+		case errors.Is(err, jobErr.ErrJobNotFound):
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrNoJob), myErr.ErrNoJob.Error(), err)
+		case errors.Is(err, jobErr.ErrJobFinished):
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrJobFinished), myErr.ErrJobFinished.Error(), err)
+		default:
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrCancelJob), myErr.ErrCancelJob.Error(), err)
+		}
+		return
+	}
+
+	responseutils.WriteSuccess(w, h.log, http.StatusOK, job)
+	h.log.Info("Cancel job", "job", jobId)
+}
+
+// @Summary Get latest update jobs
+// @Description Получение последних задач обновления проектов
+// @Tags jobs
+// @Produce  json
+// @Param   limit  query  int  false  "Count of jobs (default 20)"
+// @Success 200 {array} structures.Job
+// @Failure 400 {object} responseutils.ErrorResponse
+// @Failure 500 {object} responseutils.ErrorResponse
+// @Router /api/v1/connector/jobs [get]
+func (h *handler) getJobs(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if r.URL.Query().Get("limit") != "" {
+		var err error
+		limit, err = strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrParamLimitPage), myErr.ErrParamLimitPage.Error(), err)
+			return
+		}
+	}
+
+	jobs, err := h.jobs.GetJobs(r.Context(), limit)
+	if err != nil {
+		responseutils.WriteError(w, h.log, myErr.GetStatusCode(myErr.ErrorsJob, myErr.ErrGetJob), myErr.ErrGetJob.Error(), err)
+		return
+	}
+
+	responseutils.WriteSuccess(w, h.log, http.StatusOK, jobs)
+	h.log.Info("Got jobs", "limit", limit)
+}
+
+// jiraAccessError separates rejected credentials and missing permissions from other jira errors
+func jiraAccessError(err, fallback error) error {
+	switch {
+	case errors.Is(err, conErr.ErrUnauthorized):
+		return myErr.ErrJiraAuth
+	case errors.Is(err, conErr.ErrForbidden):
+		return myErr.ErrJiraForbidden
+	}
+	return fallback
 }
 
 func getProjectParams(r *http.Request) (int, int, string, error) {
@@ -132,3 +349,14 @@ func getProjectParams(r *http.Request) (int, int, string, error) {
 
 	return limit, page, search, nil
 }
+
+func getSyncMode(r *http.Request) (structures.SyncMode, error) {
+	switch mode := structures.SyncMode(r.URL.Query().Get("mode")); mode {
+	case "", structures.SyncIncremental:
+		return structures.SyncIncremental, nil
+	case structures.SyncFull:
+		return structures.SyncFull, nil
+	default:
+		return "", myErr.ErrParamMode
+	}
+}
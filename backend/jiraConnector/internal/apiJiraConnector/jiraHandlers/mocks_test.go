@@ -5,7 +5,7 @@
 package jirahandlers
 
 import (
-	"github.com/jiraconnector/internal/dataTransformer"
+	"context"
 	"github.com/jiraconnector/internal/structures"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,9 +37,67 @@ func (_m *MockJiraServiceInterface) EXPECT() *MockJiraServiceInterface_Expecter
 	return &MockJiraServiceInterface_Expecter{mock: &_m.Mock}
 }
 
+// GetProjectByKey provides a mock function for the type MockJiraServiceInterface
+func (_mock *MockJiraServiceInterface) GetProjectByKey(ctx context.Context, source string, projectKey string) (*structures.JiraProject, error) {
+	ret := _mock.Called(ctx, source, projectKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectByKey")
+	}
+
+	var r0 *structures.JiraProject
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*structures.JiraProject, error)); ok {
+		return returnFunc(ctx, source, projectKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *structures.JiraProject); ok {
+		r0 = returnFunc(ctx, source, projectKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structures.JiraProject)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, source, projectKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJiraServiceInterface_GetProjectByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectByKey'
+type MockJiraServiceInterface_GetProjectByKey_Call struct {
+	*mock.Call
+}
+
+// GetProjectByKey is a helper method to define mock.On call
+//   - ctx
+//   - source
+//   - projectKey
+func (_e *MockJiraServiceInterface_Expecter) GetProjectByKey(ctx interface{}, source interface{}, projectKey interface{}) *MockJiraServiceInterface_GetProjectByKey_Call {
+	return &MockJiraServiceInterface_GetProjectByKey_Call{Call: _e.mock.On("GetProjectByKey", ctx, source, projectKey)}
+}
+
+func (_c *MockJiraServiceInterface_GetProjectByKey_Call) Run(run func(ctx context.Context, source string, projectKey string)) *MockJiraServiceInterface_GetProjectByKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockJiraServiceInterface_GetProjectByKey_Call) Return(jiraProject *structures.JiraProject, err error) *MockJiraServiceInterface_GetProjectByKey_Call {
+	_c.Call.Return(jiraProject, err)
+	return _c
+}
+
+func (_c *MockJiraServiceInterface_GetProjectByKey_Call) RunAndReturn(run func(ctx context.Context, source string, projectKey string) (*structures.JiraProject, error)) *MockJiraServiceInterface_GetProjectByKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProjectsPage provides a mock function for the type MockJiraServiceInterface
-func (_mock *MockJiraServiceInterface) GetProjectsPage(search string, limit int, page int) (*structures.ResponseProject, error) {
-	ret := _mock.Called(search, limit, page)
+func (_mock *MockJiraServiceInterface) GetProjectsPage(ctx context.Context, source string, search string, limit int, page int) (*structures.ResponseProject, error) {
+	ret := _mock.Called(ctx, source, search, limit, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetProjectsPage")
@@ -47,18 +105,18 @@ func (_mock *MockJiraServiceInterface) GetProjectsPage(search string, limit int,
 
 	var r0 *structures.ResponseProject
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, int, int) (*structures.ResponseProject, error)); ok {
-		return returnFunc(search, limit, page)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) (*structures.ResponseProject, error)); ok {
+		return returnFunc(ctx, source, search, limit, page)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, int, int) *structures.ResponseProject); ok {
-		r0 = returnFunc(search, limit, page)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) *structures.ResponseProject); ok {
+		r0 = returnFunc(ctx, source, search, limit, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*structures.ResponseProject)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, int, int) error); ok {
-		r1 = returnFunc(search, limit, page)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, int) error); ok {
+		r1 = returnFunc(ctx, source, search, limit, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -71,16 +129,18 @@ type MockJiraServiceInterface_GetProjectsPage_Call struct {
 }
 
 // GetProjectsPage is a helper method to define mock.On call
+//   - ctx
+//   - source
 //   - search
 //   - limit
 //   - page
-func (_e *MockJiraServiceInterface_Expecter) GetProjectsPage(search interface{}, limit interface{}, page interface{}) *MockJiraServiceInterface_GetProjectsPage_Call {
-	return &MockJiraServiceInterface_GetProjectsPage_Call{Call: _e.mock.On("GetProjectsPage", search, limit, page)}
+func (_e *MockJiraServiceInterface_Expecter) GetProjectsPage(ctx interface{}, source interface{}, search interface{}, limit interface{}, page interface{}) *MockJiraServiceInterface_GetProjectsPage_Call {
+	return &MockJiraServiceInterface_GetProjectsPage_Call{Call: _e.mock.On("GetProjectsPage", ctx, source, search, limit, page)}
 }
 
-func (_c *MockJiraServiceInterface_GetProjectsPage_Call) Run(run func(search string, limit int, page int)) *MockJiraServiceInterface_GetProjectsPage_Call {
+func (_c *MockJiraServiceInterface_GetProjectsPage_Call) Run(run func(ctx context.Context, source string, search string, limit int, page int)) *MockJiraServiceInterface_GetProjectsPage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].(int))
 	})
 	return _c
 }
@@ -90,157 +150,374 @@ func (_c *MockJiraServiceInterface_GetProjectsPage_Call) Return(responseProject
 	return _c
 }
 
-func (_c *MockJiraServiceInterface_GetProjectsPage_Call) RunAndReturn(run func(search string, limit int, page int) (*structures.ResponseProject, error)) *MockJiraServiceInterface_GetProjectsPage_Call {
+func (_c *MockJiraServiceInterface_GetProjectsPage_Call) RunAndReturn(run func(ctx context.Context, source string, search string, limit int, page int) (*structures.ResponseProject, error)) *MockJiraServiceInterface_GetProjectsPage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PushDataToDb provides a mock function for the type MockJiraServiceInterface
-func (_mock *MockJiraServiceInterface) PushDataToDb(project string, issues []structures.JiraIssue) error {
-	ret := _mock.Called(project, issues)
+// HasArchive provides a mock function for the type MockJiraServiceInterface
+func (_mock *MockJiraServiceInterface) HasArchive(ctx context.Context, source string, projectKey string) (bool, error) {
+	ret := _mock.Called(ctx, source, projectKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PushDataToDb")
+		panic("no return value specified for HasArchive")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, []structures.JiraIssue) error); ok {
-		r0 = returnFunc(project, issues)
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, source, projectKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, source, projectKey)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, source, projectKey)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
-	return r0
+	return r0, r1
 }
 
-// MockJiraServiceInterface_PushDataToDb_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushDataToDb'
-type MockJiraServiceInterface_PushDataToDb_Call struct {
+// MockJiraServiceInterface_HasArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasArchive'
+type MockJiraServiceInterface_HasArchive_Call struct {
 	*mock.Call
 }
 
-// PushDataToDb is a helper method to define mock.On call
-//   - project
-//   - issues
-func (_e *MockJiraServiceInterface_Expecter) PushDataToDb(project interface{}, issues interface{}) *MockJiraServiceInterface_PushDataToDb_Call {
-	return &MockJiraServiceInterface_PushDataToDb_Call{Call: _e.mock.On("PushDataToDb", project, issues)}
+// HasArchive is a helper method to define mock.On call
+//   - ctx
+//   - source
+//   - projectKey
+func (_e *MockJiraServiceInterface_Expecter) HasArchive(ctx interface{}, source interface{}, projectKey interface{}) *MockJiraServiceInterface_HasArchive_Call {
+	return &MockJiraServiceInterface_HasArchive_Call{Call: _e.mock.On("HasArchive", ctx, source, projectKey)}
+}
+
+func (_c *MockJiraServiceInterface_HasArchive_Call) Run(run func(ctx context.Context, source string, projectKey string)) *MockJiraServiceInterface_HasArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockJiraServiceInterface_HasArchive_Call) Return(b bool, err error) *MockJiraServiceInterface_HasArchive_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockJiraServiceInterface_HasArchive_Call) RunAndReturn(run func(ctx context.Context, source string, projectKey string) (bool, error)) *MockJiraServiceInterface_HasArchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveSource provides a mock function for the type MockJiraServiceInterface
+func (_mock *MockJiraServiceInterface) ResolveSource(source string) (string, error) {
+	ret := _mock.Called(source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveSource")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(source)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(source)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(source)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJiraServiceInterface_ResolveSource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveSource'
+type MockJiraServiceInterface_ResolveSource_Call struct {
+	*mock.Call
+}
+
+// ResolveSource is a helper method to define mock.On call
+//   - source
+func (_e *MockJiraServiceInterface_Expecter) ResolveSource(source interface{}) *MockJiraServiceInterface_ResolveSource_Call {
+	return &MockJiraServiceInterface_ResolveSource_Call{Call: _e.mock.On("ResolveSource", source)}
+}
+
+func (_c *MockJiraServiceInterface_ResolveSource_Call) Run(run func(source string)) *MockJiraServiceInterface_ResolveSource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockJiraServiceInterface_ResolveSource_Call) Return(s string, err error) *MockJiraServiceInterface_ResolveSource_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockJiraServiceInterface_ResolveSource_Call) RunAndReturn(run func(source string) (string, error)) *MockJiraServiceInterface_ResolveSource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockJobQueueInterface creates a new instance of MockJobQueueInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobQueueInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobQueueInterface {
+	mock := &MockJobQueueInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobQueueInterface is an autogenerated mock type for the JobQueueInterface type
+type MockJobQueueInterface struct {
+	mock.Mock
+}
+
+type MockJobQueueInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobQueueInterface) EXPECT() *MockJobQueueInterface_Expecter {
+	return &MockJobQueueInterface_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function for the type MockJobQueueInterface
+func (_mock *MockJobQueueInterface) Cancel(ctx context.Context, jobId int) (*structures.Job, error) {
+	ret := _mock.Called(ctx, jobId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *structures.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*structures.Job, error)); ok {
+		return returnFunc(ctx, jobId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *structures.Job); ok {
+		r0 = returnFunc(ctx, jobId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structures.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, jobId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobQueueInterface_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockJobQueueInterface_Cancel_Call struct {
+	*mock.Call
 }
 
-func (_c *MockJiraServiceInterface_PushDataToDb_Call) Run(run func(project string, issues []structures.JiraIssue)) *MockJiraServiceInterface_PushDataToDb_Call {
+// Cancel is a helper method to define mock.On call
+//   - ctx
+//   - jobId
+func (_e *MockJobQueueInterface_Expecter) Cancel(ctx interface{}, jobId interface{}) *MockJobQueueInterface_Cancel_Call {
+	return &MockJobQueueInterface_Cancel_Call{Call: _e.mock.On("Cancel", ctx, jobId)}
+}
+
+func (_c *MockJobQueueInterface_Cancel_Call) Run(run func(ctx context.Context, jobId int)) *MockJobQueueInterface_Cancel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]structures.JiraIssue))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockJiraServiceInterface_PushDataToDb_Call) Return(err error) *MockJiraServiceInterface_PushDataToDb_Call {
-	_c.Call.Return(err)
+func (_c *MockJobQueueInterface_Cancel_Call) Return(job *structures.Job, err error) *MockJobQueueInterface_Cancel_Call {
+	_c.Call.Return(job, err)
 	return _c
 }
 
-func (_c *MockJiraServiceInterface_PushDataToDb_Call) RunAndReturn(run func(project string, issues []structures.JiraIssue) error) *MockJiraServiceInterface_PushDataToDb_Call {
+func (_c *MockJobQueueInterface_Cancel_Call) RunAndReturn(run func(ctx context.Context, jobId int) (*structures.Job, error)) *MockJobQueueInterface_Cancel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TransformDataToDb provides a mock function for the type MockJiraServiceInterface
-func (_mock *MockJiraServiceInterface) TransformDataToDb(project *structures.JiraProject, issues []structures.JiraIssue) []datatransformer.DataTransformer {
-	ret := _mock.Called(project, issues)
+// EnqueueIfIdle provides a mock function for the type MockJobQueueInterface
+func (_mock *MockJobQueueInterface) EnqueueIfIdle(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, trigger structures.JobTrigger) (*structures.Job, error) {
+	ret := _mock.Called(ctx, project, mode, trigger)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TransformDataToDb")
+		panic("no return value specified for EnqueueIfIdle")
 	}
 
-	var r0 []datatransformer.DataTransformer
-	if returnFunc, ok := ret.Get(0).(func(*structures.JiraProject, []structures.JiraIssue) []datatransformer.DataTransformer); ok {
-		r0 = returnFunc(project, issues)
+	var r0 *structures.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef, structures.SyncMode, structures.JobTrigger) (*structures.Job, error)); ok {
+		return returnFunc(ctx, project, mode, trigger)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef, structures.SyncMode, structures.JobTrigger) *structures.Job); ok {
+		r0 = returnFunc(ctx, project, mode, trigger)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]datatransformer.DataTransformer)
+			r0 = ret.Get(0).(*structures.Job)
 		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, structures.ProjectRef, structures.SyncMode, structures.JobTrigger) error); ok {
+		r1 = returnFunc(ctx, project, mode, trigger)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockJiraServiceInterface_TransformDataToDb_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformDataToDb'
-type MockJiraServiceInterface_TransformDataToDb_Call struct {
+// MockJobQueueInterface_EnqueueIfIdle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueIfIdle'
+type MockJobQueueInterface_EnqueueIfIdle_Call struct {
 	*mock.Call
 }
 
-// TransformDataToDb is a helper method to define mock.On call
+// EnqueueIfIdle is a helper method to define mock.On call
+//   - ctx
 //   - project
-//   - issues
-func (_e *MockJiraServiceInterface_Expecter) TransformDataToDb(project interface{}, issues interface{}) *MockJiraServiceInterface_TransformDataToDb_Call {
-	return &MockJiraServiceInterface_TransformDataToDb_Call{Call: _e.mock.On("TransformDataToDb", project, issues)}
+//   - mode
+//   - trigger
+func (_e *MockJobQueueInterface_Expecter) EnqueueIfIdle(ctx interface{}, project interface{}, mode interface{}, trigger interface{}) *MockJobQueueInterface_EnqueueIfIdle_Call {
+	return &MockJobQueueInterface_EnqueueIfIdle_Call{Call: _e.mock.On("EnqueueIfIdle", ctx, project, mode, trigger)}
 }
 
-func (_c *MockJiraServiceInterface_TransformDataToDb_Call) Run(run func(project *structures.JiraProject, issues []structures.JiraIssue)) *MockJiraServiceInterface_TransformDataToDb_Call {
+func (_c *MockJobQueueInterface_EnqueueIfIdle_Call) Run(run func(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, trigger structures.JobTrigger)) *MockJobQueueInterface_EnqueueIfIdle_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*structures.JiraProject), args[1].([]structures.JiraIssue))
+		run(args[0].(context.Context), args[1].(structures.ProjectRef), args[2].(structures.SyncMode), args[3].(structures.JobTrigger))
 	})
 	return _c
 }
 
-func (_c *MockJiraServiceInterface_TransformDataToDb_Call) Return(dataTransformers []datatransformer.DataTransformer) *MockJiraServiceInterface_TransformDataToDb_Call {
-	_c.Call.Return(dataTransformers)
+func (_c *MockJobQueueInterface_EnqueueIfIdle_Call) Return(job *structures.Job, err error) *MockJobQueueInterface_EnqueueIfIdle_Call {
+	_c.Call.Return(job, err)
 	return _c
 }
 
-func (_c *MockJiraServiceInterface_TransformDataToDb_Call) RunAndReturn(run func(project *structures.JiraProject, issues []structures.JiraIssue) []datatransformer.DataTransformer) *MockJiraServiceInterface_TransformDataToDb_Call {
+func (_c *MockJobQueueInterface_EnqueueIfIdle_Call) RunAndReturn(run func(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, trigger structures.JobTrigger) (*structures.Job, error)) *MockJobQueueInterface_EnqueueIfIdle_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateProjects provides a mock function for the type MockJiraServiceInterface
-func (_mock *MockJiraServiceInterface) UpdateProjects(projectId string) ([]structures.JiraIssue, error) {
-	ret := _mock.Called(projectId)
+// GetJob provides a mock function for the type MockJobQueueInterface
+func (_mock *MockJobQueueInterface) GetJob(ctx context.Context, jobId int) (*structures.Job, error) {
+	ret := _mock.Called(ctx, jobId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateProjects")
+		panic("no return value specified for GetJob")
 	}
 
-	var r0 []structures.JiraIssue
+	var r0 *structures.Job
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) ([]structures.JiraIssue, error)); ok {
-		return returnFunc(projectId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*structures.Job, error)); ok {
+		return returnFunc(ctx, jobId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) []structures.JiraIssue); ok {
-		r0 = returnFunc(projectId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *structures.Job); ok {
+		r0 = returnFunc(ctx, jobId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]structures.JiraIssue)
+			r0 = ret.Get(0).(*structures.Job)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(projectId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, jobId)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockJiraServiceInterface_UpdateProjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProjects'
-type MockJiraServiceInterface_UpdateProjects_Call struct {
+// MockJobQueueInterface_GetJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJob'
+type MockJobQueueInterface_GetJob_Call struct {
 	*mock.Call
 }
 
-// UpdateProjects is a helper method to define mock.On call
-//   - projectId
-func (_e *MockJiraServiceInterface_Expecter) UpdateProjects(projectId interface{}) *MockJiraServiceInterface_UpdateProjects_Call {
-	return &MockJiraServiceInterface_UpdateProjects_Call{Call: _e.mock.On("UpdateProjects", projectId)}
+// GetJob is a helper method to define mock.On call
+//   - ctx
+//   - jobId
+func (_e *MockJobQueueInterface_Expecter) GetJob(ctx interface{}, jobId interface{}) *MockJobQueueInterface_GetJob_Call {
+	return &MockJobQueueInterface_GetJob_Call{Call: _e.mock.On("GetJob", ctx, jobId)}
 }
 
-func (_c *MockJiraServiceInterface_UpdateProjects_Call) Run(run func(projectId string)) *MockJiraServiceInterface_UpdateProjects_Call {
+func (_c *MockJobQueueInterface_GetJob_Call) Run(run func(ctx context.Context, jobId int)) *MockJobQueueInterface_GetJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockJobQueueInterface_GetJob_Call) Return(job *structures.Job, err error) *MockJobQueueInterface_GetJob_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobQueueInterface_GetJob_Call) RunAndReturn(run func(ctx context.Context, jobId int) (*structures.Job, error)) *MockJobQueueInterface_GetJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJobs provides a mock function for the type MockJobQueueInterface
+func (_mock *MockJobQueueInterface) GetJobs(ctx context.Context, limit int) ([]structures.Job, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobs")
+	}
+
+	var r0 []structures.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]structures.Job, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []structures.Job); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobQueueInterface_GetJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobs'
+type MockJobQueueInterface_GetJobs_Call struct {
+	*mock.Call
+}
+
+// GetJobs is a helper method to define mock.On call
+//   - ctx
+//   - limit
+func (_e *MockJobQueueInterface_Expecter) GetJobs(ctx interface{}, limit interface{}) *MockJobQueueInterface_GetJobs_Call {
+	return &MockJobQueueInterface_GetJobs_Call{Call: _e.mock.On("GetJobs", ctx, limit)}
+}
+
+func (_c *MockJobQueueInterface_GetJobs_Call) Run(run func(ctx context.Context, limit int)) *MockJobQueueInterface_GetJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockJiraServiceInterface_UpdateProjects_Call) Return(jiraIssues []structures.JiraIssue, err error) *MockJiraServiceInterface_UpdateProjects_Call {
-	_c.Call.Return(jiraIssues, err)
+func (_c *MockJobQueueInterface_GetJobs_Call) Return(jobs []structures.Job, err error) *MockJobQueueInterface_GetJobs_Call {
+	_c.Call.Return(jobs, err)
 	return _c
 }
 
-func (_c *MockJiraServiceInterface_UpdateProjects_Call) RunAndReturn(run func(projectId string) ([]structures.JiraIssue, error)) *MockJiraServiceInterface_UpdateProjects_Call {
+func (_c *MockJobQueueInterface_GetJobs_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]structures.Job, error)) *MockJobQueueInterface_GetJobs_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,36 @@
+package jiraservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	conErr "github.com/jiraconnector/internal/connector/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/logger"
+)
+
+// loadBoards loads scrum boards and sprints of the project, they are saved after
+// the issues. Boards are nil when they are skipped: agile is disabled or Jira
+// without Jira Software has no agile api
+func (js *JiraService) loadBoards(ctx context.Context, project *structures.DBProject) ([]structures.DBBoard, error) {
+	if !js.agile {
+		return nil, nil
+	}
+
+	jiraBoards, err := js.jiraConnector.GetProjectBoards(ctx, project.Key)
+	if errors.Is(err, conErr.ErrNotFound) {
+		js.log.Warn("agile api isn't available, boards are skipped", logger.Err(err), "source", js.source, "project", project.Key)
+		return nil, nil
+	}
+	if err != nil {
+		js.log.Error("error get project boards", logger.Err(err), "source", js.source, "project", project.Key)
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	boards := js.dataTransformer.TransformBoardsDB(jiraBoards)
+	if boards == nil {
+		boards = []structures.DBBoard{}
+	}
+	return boards, nil
+}
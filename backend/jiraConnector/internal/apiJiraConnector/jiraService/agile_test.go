@@ -0,0 +1,95 @@
+package jiraservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	conErr "github.com/jiraconnector/internal/connector/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLoadBoards(t *testing.T) {
+	project := &structures.DBProject{Source: "default", Key: "TEST"}
+	jiraBoards := []structures.JiraAgileBoard{{Board: structures.JiraBoard{Id: 1, Type: "scrum"}}}
+	boards := []structures.DBBoard{{JiraId: 1, Type: "scrum"}}
+
+	tests := []struct {
+		name      string
+		agile     bool
+		mockSetup func(*MockJiraConnectorInterface, *MockDataTransformerInterface)
+		want      []structures.DBBoard
+		wantErr   error
+	}{
+		{
+			name:  "boards are loaded",
+			agile: true,
+			mockSetup: func(conn *MockJiraConnectorInterface, dt *MockDataTransformerInterface) {
+				conn.On("GetProjectBoards", mock.Anything, "TEST").Return(jiraBoards, nil)
+				dt.On("TransformBoardsDB", jiraBoards).Return(boards)
+			},
+			want: boards,
+		},
+		{
+			name:  "project without boards",
+			agile: true,
+			mockSetup: func(conn *MockJiraConnectorInterface, dt *MockDataTransformerInterface) {
+				conn.On("GetProjectBoards", mock.Anything, "TEST").Return([]structures.JiraAgileBoard{}, nil)
+				dt.On("TransformBoardsDB", []structures.JiraAgileBoard{}).Return(nil)
+			},
+			// boards are saved to replace the old ones
+			want: []structures.DBBoard{},
+		},
+		{
+			name:      "agile disabled",
+			mockSetup: func(*MockJiraConnectorInterface, *MockDataTransformerInterface) {},
+		},
+		{
+			name:  "agile api isn't available",
+			agile: true,
+			mockSetup: func(conn *MockJiraConnectorInterface, dt *MockDataTransformerInterface) {
+				conn.On("GetProjectBoards", mock.Anything, "TEST").
+					Return(nil, fmt.Errorf("can't get boards: %w", conErr.ErrNotFound))
+			},
+		},
+		{
+			name:  "jira error",
+			agile: true,
+			mockSetup: func(conn *MockJiraConnectorInterface, dt *MockDataTransformerInterface) {
+				conn.On("GetProjectBoards", mock.Anything, "TEST").Return(nil, errors.New("forbidden"))
+			},
+			wantErr: errors.New("forbidden"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJiraConn := new(MockJiraConnectorInterface)
+			mockTransformer := new(MockDataTransformerInterface)
+			tt.mockSetup(mockJiraConn, mockTransformer)
+
+			service := JiraService{
+				source:          "default",
+				agile:           tt.agile,
+				jiraConnector:   mockJiraConn,
+				dataTransformer: mockTransformer,
+				log:             slog.Default(),
+			}
+
+			boards, err := service.loadBoards(context.Background(), project)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, boards)
+
+			mockJiraConn.AssertExpectations(t)
+			mockTransformer.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package jiraservice
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/logger"
+)
+
+// logical names of the well-known custom fields, ids of these fields differ
+// between Jira instances, so they are found by the plugin type or the name
+const (
+	FieldStoryPoints = "story_points"
+	FieldSprint      = "sprint"
+	FieldEpicLink    = "epic_link"
+	FieldTeam        = "team"
+)
+
+// CustomFields returns the mapping of custom field ids to logical names. Well-known
+// fields which aren't set in the config are discovered once. If discovery fails,
+// only the config mapping is used and discovery is retried on the next sync
+func (js *JiraService) CustomFields(ctx context.Context) map[string]string {
+	js.fieldsMu.Lock()
+	defer js.fieldsMu.Unlock()
+
+	if !js.discoverFields || js.fieldsDiscovered {
+		return js.customFields
+	}
+
+	fields, err := js.jiraConnector.GetFields(ctx)
+	if err != nil {
+		js.log.Warn("can't discover custom fields", logger.Err(err), "source", js.source)
+		return js.customFields
+	}
+
+	// names set in the config aren't discovered
+	configured := make(map[string]bool)
+	for _, name := range js.customFields {
+		configured[name] = true
+	}
+
+	discovered := maps.Clone(js.customFields)
+	if discovered == nil {
+		discovered = make(map[string]string)
+	}
+	for i := range fields {
+		name := knownField(&fields[i])
+		if name == "" || configured[name] {
+			continue
+		}
+		if _, ok := discovered[fields[i].Id]; ok {
+			continue
+		}
+		discovered[fields[i].Id] = name
+		js.log.Info("discovered custom field", "source", js.source, "id", fields[i].Id, "field", fields[i].Name, "name", name)
+	}
+
+	// the map is replaced, not changed, so the returned maps are safe to read
+	js.customFields = discovered
+	js.fieldsDiscovered = true
+	return discovered
+}
+
+// archivedFields returns the mapping saved with the archive updated by the current
+// one, so a fixed mapping in the config is applied by retransform. Fields aren't
+// discovered, retransform doesn't contact Jira
+func (js *JiraService) archivedFields(archived map[string]string) map[string]string {
+	js.fieldsMu.Lock()
+	current := js.customFields
+	js.fieldsMu.Unlock()
+
+	// a name mapped now replaces the one mapped by the archived sync
+	names := make(map[string]bool, len(current))
+	for _, name := range current {
+		names[name] = true
+	}
+
+	fields := make(map[string]string, len(archived)+len(current))
+	for id, name := range archived {
+		if !names[name] {
+			fields[id] = name
+		}
+	}
+	maps.Copy(fields, current)
+	return fields
+}
+
+// knownField recognizes a well-known custom field, "" for other fields
+func knownField(field *structures.JiraField) string {
+	if !field.Custom {
+		return ""
+	}
+
+	switch field.Schema.Custom {
+	case "com.pyxis.greenhopper.jira:gh-sprint":
+		return FieldSprint
+	case "com.pyxis.greenhopper.jira:gh-epic-link":
+		return FieldEpicLink
+	case "com.atlassian.jira.plugin.system.customfieldtypes:jsw-story-points":
+		return FieldStoryPoints
+	case "com.atlassian.jira.plugin.system.customfieldtypes:atlassian-team",
+		"com.atlassian.teams:rm-teams-custom-field-team":
+		return FieldTeam
+	}
+
+	// Story Points of Jira Server is a plain number field
+	switch strings.ToLower(field.Name) {
+	case "story points", "story point estimate":
+		return FieldStoryPoints
+	case "team":
+		return FieldTeam
+	}
+	return ""
+}
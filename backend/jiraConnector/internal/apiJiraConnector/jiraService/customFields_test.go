@@ -0,0 +1,109 @@
+package jiraservice
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var jiraFields = []structures.JiraField{
+	{Id: "summary", Name: "Summary"},
+	{Id: "customfield_10002", Name: "Story Points", Custom: true, Schema: structures.JiraFieldSchema{Type: "number"}},
+	{Id: "customfield_10016", Name: "Story point estimate", Custom: true,
+		Schema: structures.JiraFieldSchema{Type: "number", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:jsw-story-points"}},
+	{Id: "customfield_10020", Name: "Sprint", Custom: true,
+		Schema: structures.JiraFieldSchema{Type: "array", Custom: "com.pyxis.greenhopper.jira:gh-sprint"}},
+	{Id: "customfield_10014", Name: "Epic Link", Custom: true,
+		Schema: structures.JiraFieldSchema{Type: "any", Custom: "com.pyxis.greenhopper.jira:gh-epic-link"}},
+	{Id: "customfield_10001", Name: "Team", Custom: true,
+		Schema: structures.JiraFieldSchema{Type: "team", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:atlassian-team"}},
+	{Id: "customfield_10300", Name: "Department", Custom: true, Schema: structures.JiraFieldSchema{Type: "option"}},
+}
+
+func TestCustomFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]string
+		discover bool
+		fields   []structures.JiraField
+		fieldErr error
+		expected map[string]string
+	}{
+		{
+			name:     "discover all well-known fields",
+			discover: true,
+			fields:   jiraFields,
+			expected: map[string]string{
+				"customfield_10002": FieldStoryPoints,
+				"customfield_10016": FieldStoryPoints,
+				"customfield_10020": FieldSprint,
+				"customfield_10014": FieldEpicLink,
+				"customfield_10001": FieldTeam,
+			},
+		},
+		{
+			name:     "config mapping has priority",
+			config:   map[string]string{"customfield_10002": FieldStoryPoints, "customfield_10300": "department"},
+			discover: true,
+			fields:   jiraFields,
+			expected: map[string]string{
+				"customfield_10002": FieldStoryPoints,
+				"customfield_10300": "department",
+				"customfield_10020": FieldSprint,
+				"customfield_10014": FieldEpicLink,
+				"customfield_10001": FieldTeam,
+			},
+		},
+		{
+			name:     "discovery disabled",
+			config:   map[string]string{"customfield_10020": FieldSprint},
+			expected: map[string]string{"customfield_10020": FieldSprint},
+		},
+		{
+			name:     "discovery error falls back to config",
+			config:   map[string]string{"customfield_10020": FieldSprint},
+			discover: true,
+			fieldErr: errors.New("forbidden"),
+			expected: map[string]string{"customfield_10020": FieldSprint},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJiraConn := new(MockJiraConnectorInterface)
+			if tt.discover {
+				mockJiraConn.On("GetFields", mock.Anything).Return(tt.fields, tt.fieldErr).Once()
+			}
+
+			service := JiraService{
+				source:         "default",
+				customFields:   tt.config,
+				discoverFields: tt.discover,
+				jiraConnector:  mockJiraConn,
+				log:            slog.Default(),
+			}
+
+			assert.Equal(t, tt.expected, service.CustomFields(context.Background()))
+			mockJiraConn.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCustomFields_DiscoveredOnce(t *testing.T) {
+	mockJiraConn := new(MockJiraConnectorInterface)
+	mockJiraConn.On("GetFields", mock.Anything).Return(nil, errors.New("timeout")).Once()
+	mockJiraConn.On("GetFields", mock.Anything).Return(jiraFields[3:4], nil).Once()
+
+	service := JiraService{discoverFields: true, jiraConnector: mockJiraConn, log: slog.Default()}
+
+	// failed discovery is retried by the next sync, successful one is cached
+	assert.Empty(t, service.CustomFields(context.Background()))
+	assert.Equal(t, map[string]string{"customfield_10020": FieldSprint}, service.CustomFields(context.Background()))
+	assert.Equal(t, map[string]string{"customfield_10020": FieldSprint}, service.CustomFields(context.Background()))
+	mockJiraConn.AssertExpectations(t)
+}
@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrUnknownSource  = errors.New("unknown jira source")
+	ErrSourceRequired = errors.New("jira source isn't set - there are several sources without default")
+	ErrArchiveOff     = errors.New("archive of raw issues is off - set archive storage in the config")
+)
@@ -1,9 +1,14 @@
 package jiraservice
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"sync"
+	"time"
 
+	myErr "github.com/jiraconnector/internal/apiJiraConnector/jiraService/errors"
 	datatransformer "github.com/jiraconnector/internal/dataTransformer"
 	"github.com/jiraconnector/internal/structures"
 	"github.com/jiraconnector/pkg/config"
@@ -13,86 +18,353 @@ import (
 //go:generate mockery
 
 type JiraConnectorInterface interface {
-	GetAllProjects() ([]structures.JiraProject, error)
-	GetProjectsPage(search string, limit, page int) (*structures.ResponseProject, error)
-	GetProjectIssues(project string) ([]structures.JiraIssue, error)
-	GetProjectByKey(projectKey string) (*structures.JiraProject, error)
+	GetAllProjects(ctx context.Context) ([]structures.JiraProject, error)
+	GetProjectsPage(ctx context.Context, search string, limit, page int) (*structures.ResponseProject, error)
+	GetProjectIssues(ctx context.Context, project string, progress structures.ProgressFunc) ([]structures.JiraIssue, error)
+	GetProjectIssuesUpdatedSince(ctx context.Context, project string, since time.Time, progress structures.ProgressFunc) ([]structures.JiraIssue, error)
+	GetProjectByKey(ctx context.Context, projectKey string) (*structures.JiraProject, error)
+	GetFields(ctx context.Context) ([]structures.JiraField, error)
+	GetPriorities(ctx context.Context) ([]structures.JiraPriority, error)
+	GetProjectBoards(ctx context.Context, project string) ([]structures.JiraAgileBoard, error)
 }
 
 type DataTransformerInterface interface {
-	TransformStatusDB(jiraChanges *structures.Changelog) map[string]structures.DBStatusChanges
+	TransformStatusDB(jiraChanges *structures.Changelog) []structures.DBStatusTransition
+	TransformFieldChangesDB(jiraChanges *structures.Changelog) []structures.DBFieldChange
 	TransformAuthorDB(jiraAuthor *structures.User) *structures.DBAuthor
+	TransformOptionalAuthorDB(jiraUser *structures.User) *structures.DBAuthor
 	TransformProjectDB(jiraProject *structures.JiraProject) *structures.DBProject
 	TransformIssueDB(jiraIssue *structures.JiraIssue) *structures.DBIssue
+	TransformCustomFieldsDB(jiraIssue *structures.JiraIssue, fields map[string]string) []structures.DBCustomField
+	TransformIssueLinksDB(jiraIssue *structures.JiraIssue) []structures.DBIssueLink
+	TransformWorklogsDB(jiraWorklogs *structures.Worklogs) []structures.DBWorklog
+	TransformCommentsDB(jiraComments *structures.Comments) []structures.DBComment
+	TransformLabelsDB(jiraIssue *structures.JiraIssue) []string
+	TransformComponentsDB(jiraIssue *structures.JiraIssue) []structures.DBComponent
+	TransformFixVersionsDB(jiraIssue *structures.JiraIssue) []structures.DBVersion
+	TransformPrioritiesDB(jiraPriorities []structures.JiraPriority) []structures.DBPriority
+	TransformBoardsDB(jiraBoards []structures.JiraAgileBoard) []structures.DBBoard
 	TransformToDbIssueSet(project *structures.JiraProject, jiraIssue *structures.JiraIssue) *datatransformer.DataTransformer
 }
 
 type DbPusherInterface interface {
-	PushProject(project *structures.DBProject) (int, error)
-	PushProjects(projects []structures.DBProject) error
-	PushStatusChanges(issue int, changes *datatransformer.DataTransformer) error
-	PushIssue(project *structures.DBProject, issue *datatransformer.DataTransformer) (int, error)
-	PushIssues(project *structures.DBProject, issues []datatransformer.DataTransformer) error
+	PushProject(ctx context.Context, project *structures.DBProject) (int, error)
+	PushProjects(ctx context.Context, projects []structures.DBProject) error
+	PushStatusChanges(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushFieldChanges(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushCustomFields(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushIssueLinks(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushWorklogs(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushComments(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushLabels(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushComponents(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushFixVersions(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error
+	PushIssue(ctx context.Context, project *structures.DBProject, issue *datatransformer.DataTransformer) (int, error)
+	PushIssues(ctx context.Context, project *structures.DBProject, issues []datatransformer.DataTransformer) error
+	PushPriorities(ctx context.Context, source string, priorities []structures.DBPriority) error
+	PushBoards(ctx context.Context, project *structures.DBProject, boards []structures.DBBoard) error
+	GetSyncWatermark(ctx context.Context, project structures.ProjectRef) (time.Time, error)
+	PushSyncWatermark(ctx context.Context, project structures.ProjectRef, watermark time.Time) error
+	MarkDeletedIssues(ctx context.Context, project structures.ProjectRef, keys []string) (int, error)
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
 	Close()
 }
 
+// ArchiveInterface keeps raw issues of Jira, it is implemented by DbPusher
+// (postgres storage) and archive.DirArchive (local directory)
+type ArchiveInterface interface {
+	ArchiveIssues(ctx context.Context, project structures.ProjectRef, prj *structures.JiraProject, fields map[string]string, issues []structures.JiraIssue) error
+	PruneArchive(ctx context.Context, project structures.ProjectRef, keys []string) error
+	LoadArchive(ctx context.Context, project structures.ProjectRef) (*structures.ArchivedProject, error)
+	HasArchive(ctx context.Context, project structures.ProjectRef) (bool, error)
+	ArchivedProjects(ctx context.Context) ([]structures.ProjectRef, error)
+}
+
+// JiraService works with one Jira instance, Sources routes requests between them
 type JiraService struct {
+	source string
+
+	// custom field id -> logical name, discovered fields are added on the first sync
+	customFields     map[string]string
+	discoverFields   bool
+	fieldsDiscovered bool
+	fieldsMu         sync.Mutex
+
+	// boards and sprints are loaded after the issues of the project
+	agile bool
+
 	jiraConnector   JiraConnectorInterface
 	dataTransformer DataTransformerInterface
 	dbPusher        DbPusherInterface
 	log             *slog.Logger
+
+	// raw issues are archived on sync, nil when the archive is off
+	archive ArchiveInterface
 }
 
 func NewJiraService(
-	config *config.Config,
+	cfg *config.JiraConfig,
+	source string,
 	jiraConnector JiraConnectorInterface,
 	dataTransformer DataTransformerInterface,
 	dbPusher DbPusherInterface,
+	archive ArchiveInterface,
 	log *slog.Logger) (*JiraService, error) {
 	return &JiraService{
+		source:          source,
+		customFields:    maps.Clone(cfg.CustomFields),
+		discoverFields:  !cfg.DisableFieldDiscovery,
+		agile:           !cfg.DisableAgile,
 		jiraConnector:   jiraConnector,
 		dataTransformer: dataTransformer,
 		dbPusher:        dbPusher,
+		archive:         archive,
 		log:             log,
 	}, nil
 }
 
-func (js *JiraService) GetProjectsPage(search string, limit, page int) (*structures.ResponseProject, error) {
-	js.log.Info("get project page", "page", page, "search", search, "limit", limit)
-	return js.jiraConnector.GetProjectsPage(search, limit, page)
+func (js *JiraService) GetProjectsPage(ctx context.Context, search string, limit, page int) (*structures.ResponseProject, error) {
+	js.log.Info("get project page", "source", js.source, "page", page, "search", search, "limit", limit)
+	projects, err := js.jiraConnector.GetProjectsPage(ctx, search, limit, page)
+	if err != nil {
+		return nil, err
+	}
+
+	projects.Source = js.source
+	return projects, nil
+}
+
+func (js *JiraService) GetProjectByKey(ctx context.Context, projectKey string) (*structures.JiraProject, error) {
+	js.log.Info("get project by key", "source", js.source, "key", projectKey)
+	return js.jiraConnector.GetProjectByKey(ctx, projectKey)
+}
+
+// SyncProject downloads the project issues and saves them, it is run by the job queue.
+// SyncRetransform rebuilds the project from the archive instead
+func (js *JiraService) SyncProject(ctx context.Context, project string, mode structures.SyncMode, progress structures.ProgressFunc) error {
+	if mode == structures.SyncRetransform {
+		return js.Retransform(ctx, project, progress)
+	}
+
+	issues, err := js.UpdateProjects(ctx, project, mode, progress)
+	if err != nil {
+		js.log.Error("error update project", logger.Err(err), "source", js.source, "project", project)
+		return fmt.Errorf("%w", err)
+	}
+
+	if err := js.PushDataToDb(ctx, project, mode, issues); err != nil {
+		js.log.Error("error push data to db", logger.Err(err), "source", js.source, "project", project)
+		return fmt.Errorf("%w", err)
+	}
+
+	js.log.Info("sync project", "source", js.source, "project", project, "mode", mode)
+	return nil
 }
-func (js *JiraService) UpdateProjects(projectId string) ([]structures.JiraIssue, error) {
-	js.log.Info("upd project page", "projectId", projectId)
-	return js.jiraConnector.GetProjectIssues(projectId)
+
+func (js *JiraService) UpdateProjects(ctx context.Context, projectId string, mode structures.SyncMode, progress structures.ProgressFunc) ([]structures.JiraIssue, error) {
+	js.log.Info("upd project page", "source", js.source, "projectId", projectId, "mode", mode)
+
+	if mode == structures.SyncIncremental {
+		watermark, err := js.dbPusher.GetSyncWatermark(ctx, js.ref(projectId))
+		if err != nil {
+			js.log.Error("error get sync watermark", logger.Err(err))
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		// project was never synced - nothing to be incremental about
+		if !watermark.IsZero() {
+			return js.jiraConnector.GetProjectIssuesUpdatedSince(ctx, projectId, watermark, progress)
+		}
+	}
+
+	return js.jiraConnector.GetProjectIssues(ctx, projectId, progress)
 }
 
-func (js *JiraService) PushDataToDb(project string, issues []structures.JiraIssue) error {
-	prj, err := js.jiraConnector.GetProjectByKey(project)
+// PushDataToDb saves issues, boards, priorities of the source and the sync watermark
+// of the project in one transaction, a failure leaves the database as it was before
+// the sync. Boards and priorities are loaded from Jira before the transaction is
+// started. A full sync has every issue of the project, so stored issues missing
+// from it are marked as deleted. Raw issues are archived when the archive is on
+func (js *JiraService) PushDataToDb(ctx context.Context, project string, mode structures.SyncMode, issues []structures.JiraIssue) error {
+	prj, err := js.jiraConnector.GetProjectByKey(ctx, project)
 	if err != nil {
 		js.log.Error("error Get Project By Key", logger.Err(err))
 		return fmt.Errorf("%w", err)
 	}
-	data := js.TransformDataToDb(prj, issues)
+	fields := js.CustomFields(ctx)
+	data := js.TransformDataToDb(prj, issues, fields)
 	prjDB := js.dataTransformer.TransformProjectDB(prj)
-	if err := js.dbPusher.PushIssues(prjDB, data); err != nil {
-		js.log.Error("error push issues", logger.Err(err))
+	prjDB.Source = js.source
+
+	boards, err := js.loadBoards(ctx, prjDB)
+	if err != nil {
+		return err
+	}
+	priorities := js.loadPriorities(ctx)
+
+	err = js.dbPusher.InTx(ctx, func(ctx context.Context) error {
+		if err := js.dbPusher.PushIssues(ctx, prjDB, data); err != nil {
+			js.log.Error("error push issues", logger.Err(err))
+			return fmt.Errorf("%w", err)
+		}
+
+		if js.archive != nil {
+			if err := js.archive.ArchiveIssues(ctx, js.ref(project), prj, fields, issues); err != nil {
+				js.log.Error("error archive issues", logger.Err(err), "source", js.source, "project", project)
+				return fmt.Errorf("%w", err)
+			}
+		}
+
+		if mode == structures.SyncFull {
+			if err := js.markDeletedIssues(ctx, project, data); err != nil {
+				return err
+			}
+		}
+
+		if priorities != nil {
+			if err := js.dbPusher.PushPriorities(ctx, js.source, priorities); err != nil {
+				js.log.Error("error push priorities", logger.Err(err), "source", js.source)
+				return fmt.Errorf("%w", err)
+			}
+		}
+
+		if boards != nil {
+			if err := js.dbPusher.PushBoards(ctx, prjDB, boards); err != nil {
+				js.log.Error("error push boards", logger.Err(err), "source", js.source, "project", prjDB.Key)
+				return fmt.Errorf("%w", err)
+			}
+		}
+
+		if err := js.dbPusher.PushSyncWatermark(ctx, js.ref(project), lastUpdated(data)); err != nil {
+			js.log.Error("error push sync watermark", logger.Err(err))
+			return fmt.Errorf("%w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	js.log.Info("push data to db", "source", js.source, "project", project)
+
+	return nil
+
+}
+
+// loadPriorities loads the priority list of Jira, it orders priority changes in
+// analytics. The list isn't needed to save issues, so it is skipped on error
+func (js *JiraService) loadPriorities(ctx context.Context) []structures.DBPriority {
+	jiraPriorities, err := js.jiraConnector.GetPriorities(ctx)
+	if err != nil {
+		js.log.Warn("can't get priorities, they are skipped", logger.Err(err), "source", js.source)
+		return nil
+	}
+	return js.dataTransformer.TransformPrioritiesDB(jiraPriorities)
+}
+
+// markDeletedIssues tombstones stored issues of the project that are not in the full
+// sync. An empty sync is more likely a permission problem than a removed project,
+// so nothing is tombstoned then
+func (js *JiraService) markDeletedIssues(ctx context.Context, project string, data []datatransformer.DataTransformer) error {
+	if len(data) == 0 {
+		js.log.Warn("full sync returned no issues, deleted issues are not checked", "source", js.source, "project", project)
+		return nil
+	}
+
+	keys := make([]string, 0, len(data))
+	for _, issue := range data {
+		keys = append(keys, issue.Issue.Key)
+	}
+
+	deleted, err := js.dbPusher.MarkDeletedIssues(ctx, js.ref(project), keys)
+	if err != nil {
+		js.log.Error("error mark deleted issues", logger.Err(err), "source", js.source, "project", project)
 		return fmt.Errorf("%w", err)
 	}
 
-	js.log.Info("push data to db", "project", project)
+	if deleted > 0 {
+		js.log.Info("issues deleted in jira", "source", js.source, "project", project, "count", deleted)
+	}
 
+	// deleted issues are removed from the archive, so retransform doesn't bring them back
+	if js.archive != nil {
+		if err := js.archive.PruneArchive(ctx, js.ref(project), keys); err != nil {
+			js.log.Error("error prune archive", logger.Err(err), "source", js.source, "project", project)
+			return fmt.Errorf("%w", err)
+		}
+	}
 	return nil
+}
 
+// Retransform rebuilds stored issues of the project from the archive without
+// contacting Jira, so a fix of the data transformer is applied to old issues.
+// Boards and the sync watermark are left as they are
+func (js *JiraService) Retransform(ctx context.Context, project string, progress structures.ProgressFunc) error {
+	if js.archive == nil {
+		js.log.Error(myErr.ErrArchiveOff.Error(), "source", js.source, "project", project)
+		return myErr.ErrArchiveOff
+	}
+
+	archived, err := js.archive.LoadArchive(ctx, js.ref(project))
+	if err != nil {
+		js.log.Error("error load archive", logger.Err(err), "source", js.source, "project", project)
+		return fmt.Errorf("%w", err)
+	}
+
+	data := js.TransformDataToDb(&archived.Project, archived.Issues, js.archivedFields(archived.Fields))
+	prjDB := js.dataTransformer.TransformProjectDB(&archived.Project)
+	prjDB.Source = js.source
+
+	if err := js.dbPusher.PushIssues(ctx, prjDB, data); err != nil {
+		js.log.Error("error push issues", logger.Err(err), "source", js.source, "project", project)
+		return fmt.Errorf("%w", err)
+	}
+
+	if progress != nil {
+		progress(len(data), len(data))
+	}
+
+	js.log.Info("retransform project", "source", js.source, "project", project, "count", len(data))
+	return nil
 }
 
-func (js *JiraService) TransformDataToDb(project *structures.JiraProject, issues []structures.JiraIssue) []datatransformer.DataTransformer {
+// HasArchive reports whether the project can be retransformed
+func (js *JiraService) HasArchive(ctx context.Context, project string) (bool, error) {
+	if js.archive == nil {
+		return false, myErr.ErrArchiveOff
+	}
+	return js.archive.HasArchive(ctx, js.ref(project))
+}
+
+// TransformDataToDb converts the issues, fields maps custom field ids to logical names
+func (js *JiraService) TransformDataToDb(project *structures.JiraProject, issues []structures.JiraIssue, fields map[string]string) []datatransformer.DataTransformer {
 	var issuesDb []datatransformer.DataTransformer
 
 	for _, issue := range issues {
-		issuesDb = append(issuesDb, *js.dataTransformer.TransformToDbIssueSet(project, &issue))
+		issueDb := js.dataTransformer.TransformToDbIssueSet(project, &issue)
+		if len(fields) > 0 {
+			issueDb.CustomFields = js.dataTransformer.TransformCustomFieldsDB(&issue, fields)
+		}
+		issuesDb = append(issuesDb, *issueDb)
 	}
 
 	js.log.Info("transform data for db", "project", project)
 
 	return issuesDb
 }
+
+func (js *JiraService) ref(project string) structures.ProjectRef {
+	return structures.ProjectRef{Source: js.source, Key: project}
+}
+
+// lastUpdated returns the latest update time among the issues. Jira's own
+// clock is used as the watermark, so clock skew with Jira doesn't matter.
+func lastUpdated(issues []datatransformer.DataTransformer) time.Time {
+	var last time.Time
+	for _, issue := range issues {
+		if issue.Issue.UpdatedTime.After(last) {
+			last = issue.Issue.UpdatedTime
+		}
+	}
+	return last
+}
@@ -1,13 +1,17 @@
 package jiraservice
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"testing"
+	"time"
 
+	myErr "github.com/jiraconnector/internal/apiJiraConnector/jiraService/errors"
 	datatransformer "github.com/jiraconnector/internal/dataTransformer"
 	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,15 +22,21 @@ func TestNewJiraService(t *testing.T) {
 	mockDbPusher := new(MockDbPusherInterface)
 
 	service, err := NewJiraService(
-		nil,
+		&config.JiraConfig{CustomFields: map[string]string{"customfield_10016": "story_points"}},
+		"default",
 		mockJiraConn,
 		mockTransformer,
 		mockDbPusher,
+		nil,
 		slog.Default(),
 	)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, service)
+	assert.Nil(t, service.archive)
+	assert.True(t, service.discoverFields)
+	assert.True(t, service.agile)
+	assert.Equal(t, map[string]string{"customfield_10016": "story_points"}, service.customFields)
 }
 
 func TestGetProjectsPage(t *testing.T) {
@@ -62,20 +72,22 @@ func TestGetProjectsPage(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockJiraConn := new(MockJiraConnectorInterface)
-			mockJiraConn.On("GetProjectsPage", tt.search, tt.limit, tt.page).Return(tt.mockReturn, tt.mockError)
+			mockJiraConn.On("GetProjectsPage", mock.Anything, tt.search, tt.limit, tt.page).Return(tt.mockReturn, tt.mockError)
 
 			service := JiraService{
+				source:        "cloud",
 				jiraConnector: mockJiraConn,
 				log:           slog.Default(),
 			}
 
-			result, err := service.GetProjectsPage(tt.search, tt.limit, tt.page)
+			result, err := service.GetProjectsPage(context.Background(), tt.search, tt.limit, tt.page)
 
 			assert.Equal(t, tt.mockReturn, result)
 			if tt.expectedError != nil {
 				assert.EqualError(t, err, tt.expectedError.Error())
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, "cloud", result.Source)
 			}
 
 			mockJiraConn.AssertExpectations(t)
@@ -84,16 +96,23 @@ func TestGetProjectsPage(t *testing.T) {
 }
 
 func TestUpdateProjects(t *testing.T) {
+	watermark := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name          string
 		projectId     string
+		mode          structures.SyncMode
+		watermark     time.Time
+		watermarkErr  error
+		expectSince   bool
 		mockReturn    []structures.JiraIssue
 		mockError     error
 		expectedError error
 	}{
 		{
-			name:          "success",
+			name:          "success full",
 			projectId:     "TEST",
+			mode:          structures.SyncFull,
 			mockReturn:    []structures.JiraIssue{},
 			mockError:     nil,
 			expectedError: nil,
@@ -101,23 +120,61 @@ func TestUpdateProjects(t *testing.T) {
 		{
 			name:          "connector error",
 			projectId:     "TEST",
+			mode:          structures.SyncFull,
 			mockReturn:    nil,
 			mockError:     errors.New("connector error"),
 			expectedError: errors.New("connector error"),
 		},
+		{
+			name:          "incremental without watermark falls back to full",
+			projectId:     "TEST",
+			mode:          structures.SyncIncremental,
+			mockReturn:    []structures.JiraIssue{{Key: "TEST-1"}},
+			expectedError: nil,
+		},
+		{
+			name:          "incremental with watermark",
+			projectId:     "TEST",
+			mode:          structures.SyncIncremental,
+			watermark:     watermark,
+			expectSince:   true,
+			mockReturn:    []structures.JiraIssue{{Key: "TEST-2"}},
+			expectedError: nil,
+		},
+		{
+			name:          "watermark error",
+			projectId:     "TEST",
+			mode:          structures.SyncIncremental,
+			watermarkErr:  errors.New("db error"),
+			mockReturn:    nil,
+			expectedError: errors.New("db error"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockJiraConn := new(MockJiraConnectorInterface)
-			mockJiraConn.On("GetProjectIssues", tt.projectId).Return(tt.mockReturn, tt.mockError)
+			mockDbPusher := new(MockDbPusherInterface)
+
+			if tt.mode == structures.SyncIncremental {
+				mockDbPusher.On("GetSyncWatermark", mock.Anything, structures.ProjectRef{Source: "cloud", Key: tt.projectId}).Return(tt.watermark, tt.watermarkErr)
+			}
+			if tt.watermarkErr == nil {
+				if tt.expectSince {
+					mockJiraConn.On("GetProjectIssuesUpdatedSince", mock.Anything, tt.projectId, tt.watermark, mock.Anything).Return(tt.mockReturn, tt.mockError)
+				} else {
+					mockJiraConn.On("GetProjectIssues", mock.Anything, tt.projectId, mock.Anything).Return(tt.mockReturn, tt.mockError)
+				}
+			}
 
 			service := JiraService{
+				source:        "cloud",
 				jiraConnector: mockJiraConn,
+				dbPusher:      mockDbPusher,
 				log:           slog.Default(),
 			}
 
-			result, err := service.UpdateProjects(tt.projectId)
+			result, err := service.UpdateProjects(context.Background(), tt.projectId, tt.mode, nil)
 
 			assert.Equal(t, tt.mockReturn, result)
 			if tt.expectedError != nil {
@@ -127,6 +184,95 @@ func TestUpdateProjects(t *testing.T) {
 			}
 
 			mockJiraConn.AssertExpectations(t)
+			mockDbPusher.AssertExpectations(t)
+		})
+	}
+}
+
+// runInTx makes the mocked InTx call the function like the real one does
+func runInTx(m *MockDbPusherInterface) {
+	m.On("InTx", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+}
+
+// noPriorities makes the priority list of Jira unavailable, it is skipped by the sync
+func noPriorities(m *MockJiraConnectorInterface) {
+	m.On("GetPriorities", mock.Anything).Return(nil, errors.New("forbidden"))
+}
+
+func TestSyncProject(t *testing.T) {
+	project := structures.JiraProject{Name: "TEST", Key: "TEST"}
+	issues := []structures.JiraIssue{{Id: "1"}}
+
+	tests := []struct {
+		name          string
+		issuesErr     error
+		pushErr       error
+		expectedError string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:          "get issues error",
+			issuesErr:     errors.New("jira error"),
+			expectedError: "jira error",
+		},
+		{
+			name:          "push error",
+			pushErr:       errors.New("db error"),
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransformer := new(MockDataTransformerInterface)
+			mockDbPusher := new(MockDbPusherInterface)
+			mockJiraConn := new(MockJiraConnectorInterface)
+
+			var progressCalls int
+			progress := func(fetched, total int) { progressCalls++ }
+
+			mockJiraConn.On("GetProjectIssues", mock.Anything, project.Key, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(2).(structures.ProgressFunc)(1, 1)
+				}).
+				Return(issues, tt.issuesErr)
+
+			if tt.issuesErr == nil {
+				mockJiraConn.On("GetProjectByKey", mock.Anything, project.Key).Return(&project, nil)
+				noPriorities(mockJiraConn)
+				mockTransformer.On("TransformProjectDB", &project).Return(&structures.DBProject{Title: project.Name})
+				mockTransformer.On("TransformToDbIssueSet", &project, mock.Anything).Return(&datatransformer.DataTransformer{})
+				runInTx(mockDbPusher)
+				mockDbPusher.On("PushIssues", mock.Anything, &structures.DBProject{Source: "cloud", Title: project.Name}, mock.Anything).Return(tt.pushErr)
+				if tt.pushErr == nil {
+					mockDbPusher.On("MarkDeletedIssues", mock.Anything, structures.ProjectRef{Source: "cloud", Key: project.Key}, []string{""}).Return(0, nil)
+					mockDbPusher.On("PushSyncWatermark", mock.Anything, structures.ProjectRef{Source: "cloud", Key: project.Key}, mock.AnythingOfType("time.Time")).Return(nil)
+				}
+			}
+
+			service := JiraService{
+				source:          "cloud",
+				dataTransformer: mockTransformer,
+				jiraConnector:   mockJiraConn,
+				dbPusher:        mockDbPusher,
+				log:             slog.Default(),
+			}
+
+			err := service.SyncProject(context.Background(), project.Key, structures.SyncFull, progress)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, 1, progressCalls)
+
+			mockJiraConn.AssertExpectations(t)
+			mockTransformer.AssertExpectations(t)
+			mockDbPusher.AssertExpectations(t)
 		})
 	}
 }
@@ -138,6 +284,12 @@ func TestPushDataToDb(t *testing.T) {
 		issues        []structures.JiraIssue
 		mockTransform []*datatransformer.DataTransformer
 		mockError     error
+		agile         bool
+		mode          structures.SyncMode
+		deleteErr     error
+		prioritiesErr error
+		pushPriorErr  error
+		watermarkErr  error
 		expectedError string
 	}{
 		{
@@ -171,6 +323,63 @@ func TestPushDataToDb(t *testing.T) {
 			mockError:     errors.New("db error"),
 			expectedError: "db error",
 		},
+		{
+			name:          "boards are saved with the issues",
+			project:       structures.JiraProject{Name: "TEST"},
+			issues:        []structures.JiraIssue{{Id: "1"}},
+			mockTransform: []*datatransformer.DataTransformer{{}},
+			agile:         true,
+		},
+		{
+			// the transaction is rolled back, issues aren't kept without the watermark
+			name:          "watermark error",
+			project:       structures.JiraProject{Name: "TEST"},
+			issues:        []structures.JiraIssue{{Id: "1"}},
+			mockTransform: []*datatransformer.DataTransformer{{}},
+			watermarkErr:  errors.New("db error"),
+			expectedError: "db error",
+		},
+		{
+			name:    "full sync marks deleted issues",
+			project: structures.JiraProject{Name: "TEST"},
+			issues:  []structures.JiraIssue{{Id: "1"}, {Id: "2"}},
+			mockTransform: []*datatransformer.DataTransformer{
+				{Issue: structures.DBIssue{Key: "TEST-1"}},
+				{Issue: structures.DBIssue{Key: "TEST-2"}},
+			},
+			mode: structures.SyncFull,
+		},
+		{
+			// nothing is tombstoned by an empty sync
+			name:    "full sync without issues",
+			project: structures.JiraProject{Name: "TEST"},
+			mode:    structures.SyncFull,
+		},
+		{
+			// the list of priorities isn't needed to save issues
+			name:          "priorities are skipped on error",
+			project:       structures.JiraProject{Name: "TEST"},
+			issues:        []structures.JiraIssue{{Id: "1"}},
+			mockTransform: []*datatransformer.DataTransformer{{}},
+			prioritiesErr: errors.New("forbidden"),
+		},
+		{
+			name:          "push priorities error",
+			project:       structures.JiraProject{Name: "TEST"},
+			issues:        []structures.JiraIssue{{Id: "1"}},
+			mockTransform: []*datatransformer.DataTransformer{{}},
+			pushPriorErr:  errors.New("db error"),
+			expectedError: "db error",
+		},
+		{
+			name:          "mark deleted error",
+			project:       structures.JiraProject{Name: "TEST"},
+			issues:        []structures.JiraIssue{{Id: "1"}},
+			mockTransform: []*datatransformer.DataTransformer{{Issue: structures.DBIssue{Key: "TEST-1"}}},
+			mode:          structures.SyncFull,
+			deleteErr:     errors.New("db error"),
+			expectedError: "db error",
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,7 +388,7 @@ func TestPushDataToDb(t *testing.T) {
 			mockDbPusher := new(MockDbPusherInterface)
 			mockJiraConn := new(MockJiraConnectorInterface)
 
-			mockJiraConn.On("GetProjectByKey", tt.project.Name).
+			mockJiraConn.On("GetProjectByKey", mock.Anything, tt.project.Name).
 				Return(&tt.project, nil)
 
 			mockTransformer.On("TransformProjectDB", &tt.project).
@@ -189,31 +398,250 @@ func TestPushDataToDb(t *testing.T) {
 				mockTransformer.On("TransformToDbIssueSet", &tt.project, &issue).Return(tt.mockTransform[i])
 			}
 
-			mockDbPusher.On("PushIssues",
-				&structures.DBProject{Title: tt.project.Name, Url: fmt.Sprintf("/projects/%s", tt.project.Name)},
+			prjDB := &structures.DBProject{Source: "default", Title: tt.project.Name, Url: fmt.Sprintf("/projects/%s", tt.project.Name)}
+			boards := []structures.DBBoard{{JiraId: 1, Type: "scrum"}}
+			if tt.agile {
+				mockJiraConn.On("GetProjectBoards", mock.Anything, "").Return([]structures.JiraAgileBoard{}, nil)
+				mockTransformer.On("TransformBoardsDB", []structures.JiraAgileBoard{}).Return(boards)
+			}
+
+			jiraPriorities := []structures.JiraPriority{{Id: "1", Name: "Highest"}}
+			priorities := []structures.DBPriority{{JiraId: "1", Name: "Highest", Rank: 1}}
+			if tt.prioritiesErr != nil {
+				mockJiraConn.On("GetPriorities", mock.Anything).Return(nil, tt.prioritiesErr)
+			} else {
+				mockJiraConn.On("GetPriorities", mock.Anything).Return(jiraPriorities, nil)
+				mockTransformer.On("TransformPrioritiesDB", jiraPriorities).Return(priorities)
+			}
+
+			runInTx(mockDbPusher)
+			mockDbPusher.On("PushIssues", mock.Anything, prjDB,
 				mock.AnythingOfType("[]datatransformer.DataTransformer")).Return(tt.mockError)
+			if tt.mode == "" {
+				tt.mode = structures.SyncIncremental
+			}
+			if tt.mockError == nil && tt.mode == structures.SyncFull && len(tt.issues) > 0 {
+				var keys []string
+				for _, issue := range tt.mockTransform {
+					keys = append(keys, issue.Issue.Key)
+				}
+				mockDbPusher.On("MarkDeletedIssues", mock.Anything, structures.ProjectRef{Source: "default", Key: tt.project.Name}, keys).
+					Return(len(keys), tt.deleteErr)
+			}
+			if tt.mockError == nil && tt.deleteErr == nil && tt.prioritiesErr == nil {
+				mockDbPusher.On("PushPriorities", mock.Anything, "default", priorities).Return(tt.pushPriorErr)
+			}
+			if tt.mockError == nil && tt.deleteErr == nil && tt.pushPriorErr == nil {
+				if tt.agile {
+					mockDbPusher.On("PushBoards", mock.Anything, prjDB, boards).Return(nil)
+				}
+				mockDbPusher.On("PushSyncWatermark", mock.Anything, structures.ProjectRef{Source: "default", Key: tt.project.Name}, mock.AnythingOfType("time.Time")).Return(tt.watermarkErr)
+			}
+
+			service := JiraService{
+				source:          "default",
+				agile:           tt.agile,
+				dataTransformer: mockTransformer,
+				jiraConnector:   mockJiraConn,
+				dbPusher:        mockDbPusher,
+				log:             slog.Default(),
+			}
+
+			err := service.PushDataToDb(context.Background(), tt.project.Name, tt.mode, tt.issues)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockTransformer.AssertExpectations(t)
+			mockDbPusher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPushDataToDb_Archive(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          structures.SyncMode
+		archiveErr    error
+		pruneErr      error
+		expectedError string
+	}{
+		{
+			name: "incremental sync archives issues",
+			mode: structures.SyncIncremental,
+		},
+		{
+			name: "full sync prunes archive",
+			mode: structures.SyncFull,
+		},
+		{
+			// the transaction is rolled back, the database and the archive don't diverge
+			name:          "archive error",
+			mode:          structures.SyncIncremental,
+			archiveErr:    errors.New("archive error"),
+			expectedError: "archive error",
+		},
+		{
+			name:          "prune error",
+			mode:          structures.SyncFull,
+			pruneErr:      errors.New("archive error"),
+			expectedError: "archive error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransformer := new(MockDataTransformerInterface)
+			mockDbPusher := new(MockDbPusherInterface)
+			mockJiraConn := new(MockJiraConnectorInterface)
+			mockArchive := new(MockArchiveInterface)
+
+			project := structures.JiraProject{Id: "1", Key: "TEST", Name: "Test"}
+			issues := []structures.JiraIssue{{Id: "10001", Key: "TEST-1"}}
+			fields := map[string]string{"customfield_10016": "story_points"}
+			ref := structures.ProjectRef{Source: "default", Key: "TEST"}
+
+			mockJiraConn.On("GetProjectByKey", mock.Anything, "TEST").Return(&project, nil)
+			noPriorities(mockJiraConn)
+			mockTransformer.On("TransformProjectDB", &project).Return(&structures.DBProject{Key: "TEST"})
+			mockTransformer.On("TransformToDbIssueSet", &project, mock.Anything).
+				Return(&datatransformer.DataTransformer{Issue: structures.DBIssue{Key: "TEST-1"}})
+			mockTransformer.On("TransformCustomFieldsDB", mock.Anything, fields).Return([]structures.DBCustomField(nil))
+
+			runInTx(mockDbPusher)
+			mockDbPusher.On("PushIssues", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockArchive.On("ArchiveIssues", mock.Anything, ref, &project, fields, issues).Return(tt.archiveErr)
+			if tt.archiveErr == nil && tt.mode == structures.SyncFull {
+				mockDbPusher.On("MarkDeletedIssues", mock.Anything, ref, []string{"TEST-1"}).Return(0, nil)
+				mockArchive.On("PruneArchive", mock.Anything, ref, []string{"TEST-1"}).Return(tt.pruneErr)
+			}
+			if tt.expectedError == "" {
+				mockDbPusher.On("PushSyncWatermark", mock.Anything, ref, mock.AnythingOfType("time.Time")).Return(nil)
+			}
+
+			service := JiraService{
+				source:          "default",
+				customFields:    fields,
+				dataTransformer: mockTransformer,
+				jiraConnector:   mockJiraConn,
+				dbPusher:        mockDbPusher,
+				archive:         mockArchive,
+				log:             slog.Default(),
+			}
+
+			err := service.PushDataToDb(context.Background(), "TEST", tt.mode, issues)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockDbPusher.AssertExpectations(t)
+			mockArchive.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRetransform(t *testing.T) {
+	project := structures.JiraProject{Id: "1", Key: "TEST", Name: "Test"}
+	ref := structures.ProjectRef{Source: "default", Key: "TEST"}
+	archived := &structures.ArchivedProject{
+		Project: project,
+		// the team was mapped to another field by the archived sync
+		Fields: map[string]string{"customfield_1": "team", "customfield_2": "sprint"},
+		Issues: []structures.JiraIssue{{Id: "10001", Key: "TEST-1"}, {Id: "10002", Key: "TEST-2"}},
+	}
+
+	tests := []struct {
+		name          string
+		loadErr       error
+		pushErr       error
+		expectedError string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:          "not archived",
+			loadErr:       errors.New("project isn't in the archive"),
+			expectedError: "project isn't in the archive",
+		},
+		{
+			name:          "push error",
+			pushErr:       errors.New("db error"),
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransformer := new(MockDataTransformerInterface)
+			mockDbPusher := new(MockDbPusherInterface)
+			mockJiraConn := new(MockJiraConnectorInterface)
+			mockArchive := new(MockArchiveInterface)
+
+			var progressCalls int
+			progress := func(fetched, total int) {
+				progressCalls++
+				assert.Equal(t, 2, fetched)
+				assert.Equal(t, 2, total)
+			}
+
+			mockArchive.On("LoadArchive", mock.Anything, ref).Return(archived, tt.loadErr)
+			if tt.loadErr == nil {
+				fields := map[string]string{"customfield_2": "sprint", "customfield_3": "team"}
+				mockTransformer.On("TransformToDbIssueSet", &archived.Project, mock.Anything).Return(&datatransformer.DataTransformer{})
+				mockTransformer.On("TransformCustomFieldsDB", mock.Anything, fields).Return([]structures.DBCustomField(nil))
+				mockTransformer.On("TransformProjectDB", &archived.Project).Return(&structures.DBProject{Key: "TEST"})
+				mockDbPusher.On("PushIssues", mock.Anything, &structures.DBProject{Source: "default", Key: "TEST"},
+					mock.AnythingOfType("[]datatransformer.DataTransformer")).Return(tt.pushErr)
+			}
 
 			service := JiraService{
+				source:          "default",
+				customFields:    map[string]string{"customfield_3": "team"},
+				discoverFields:  true,
 				dataTransformer: mockTransformer,
 				jiraConnector:   mockJiraConn,
 				dbPusher:        mockDbPusher,
+				archive:         mockArchive,
 				log:             slog.Default(),
 			}
 
-			err := service.PushDataToDb(tt.project.Name, tt.issues)
+			err := service.SyncProject(context.Background(), "TEST", structures.SyncRetransform, progress)
 
 			if tt.expectedError != "" {
 				assert.EqualError(t, err, tt.expectedError)
+				assert.Equal(t, 0, progressCalls)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, 1, progressCalls)
 			}
 
+			// jira isn't contacted
+			mockJiraConn.AssertNotCalled(t, "GetProjectByKey", mock.Anything, mock.Anything)
+			mockJiraConn.AssertNotCalled(t, "GetFields", mock.Anything)
 			mockTransformer.AssertExpectations(t)
 			mockDbPusher.AssertExpectations(t)
+			mockArchive.AssertExpectations(t)
 		})
 	}
 }
 
+func TestRetransform_ArchiveOff(t *testing.T) {
+	service := JiraService{source: "default", log: slog.Default()}
+
+	err := service.SyncProject(context.Background(), "TEST", structures.SyncRetransform, nil)
+	assert.ErrorIs(t, err, myErr.ErrArchiveOff)
+
+	_, err = service.HasArchive(context.Background(), "TEST")
+	assert.ErrorIs(t, err, myErr.ErrArchiveOff)
+}
+
 func TestTransformDataToDb(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -266,10 +694,62 @@ func TestTransformDataToDb(t *testing.T) {
 				log:             slog.Default(),
 			}
 
-			result := service.TransformDataToDb(&structures.JiraProject{Name: tt.project}, tt.issues)
+			result := service.TransformDataToDb(&structures.JiraProject{Name: tt.project}, tt.issues, nil)
 
 			assert.Equal(t, tt.expectedResult, result)
 			mockTransformer.AssertExpectations(t)
 		})
 	}
 }
+
+func TestTransformDataToDb_CustomFields(t *testing.T) {
+	project := &structures.JiraProject{Name: "TEST"}
+	issue := structures.JiraIssue{Id: "1"}
+	fields := map[string]string{"customfield_10016": "story_points"}
+	customFields := []structures.DBCustomField{{Name: "story_points", FieldId: "customfield_10016", Value: "3"}}
+
+	mockTransformer := new(MockDataTransformerInterface)
+	mockTransformer.On("TransformToDbIssueSet", project, &issue).Return(&datatransformer.DataTransformer{})
+	mockTransformer.On("TransformCustomFieldsDB", &issue, fields).Return(customFields)
+
+	service := JiraService{
+		dataTransformer: mockTransformer,
+		log:             slog.Default(),
+	}
+
+	result := service.TransformDataToDb(project, []structures.JiraIssue{issue}, fields)
+
+	assert.Equal(t, []datatransformer.DataTransformer{{CustomFields: customFields}}, result)
+	mockTransformer.AssertExpectations(t)
+}
+
+func TestLastUpdated(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		issues   []datatransformer.DataTransformer
+		expected time.Time
+	}{
+		{
+			name:     "no issues",
+			issues:   nil,
+			expected: time.Time{},
+		},
+		{
+			name: "latest update wins",
+			issues: []datatransformer.DataTransformer{
+				{Issue: structures.DBIssue{UpdatedTime: second}},
+				{Issue: structures.DBIssue{UpdatedTime: first}},
+			},
+			expected: second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lastUpdated(tt.issues))
+		})
+	}
+}
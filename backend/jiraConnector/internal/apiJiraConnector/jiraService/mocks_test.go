@@ -5,9 +5,11 @@
 package jiraservice
 
 import (
+	"context"
 	"github.com/jiraconnector/internal/dataTransformer"
 	"github.com/jiraconnector/internal/structures"
 	mock "github.com/stretchr/testify/mock"
+	"time"
 )
 
 // NewMockJiraConnectorInterface creates a new instance of MockJiraConnectorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -38,8 +40,8 @@ func (_m *MockJiraConnectorInterface) EXPECT() *MockJiraConnectorInterface_Expec
 }
 
 // GetAllProjects provides a mock function for the type MockJiraConnectorInterface
-func (_mock *MockJiraConnectorInterface) GetAllProjects() ([]structures.JiraProject, error) {
-	ret := _mock.Called()
+func (_mock *MockJiraConnectorInterface) GetAllProjects(ctx context.Context) ([]structures.JiraProject, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllProjects")
@@ -47,18 +49,18 @@ func (_mock *MockJiraConnectorInterface) GetAllProjects() ([]structures.JiraProj
 
 	var r0 []structures.JiraProject
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]structures.JiraProject, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]structures.JiraProject, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []structures.JiraProject); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []structures.JiraProject); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]structures.JiraProject)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -71,13 +73,14 @@ type MockJiraConnectorInterface_GetAllProjects_Call struct {
 }
 
 // GetAllProjects is a helper method to define mock.On call
-func (_e *MockJiraConnectorInterface_Expecter) GetAllProjects() *MockJiraConnectorInterface_GetAllProjects_Call {
-	return &MockJiraConnectorInterface_GetAllProjects_Call{Call: _e.mock.On("GetAllProjects")}
+//   - ctx
+func (_e *MockJiraConnectorInterface_Expecter) GetAllProjects(ctx interface{}) *MockJiraConnectorInterface_GetAllProjects_Call {
+	return &MockJiraConnectorInterface_GetAllProjects_Call{Call: _e.mock.On("GetAllProjects", ctx)}
 }
 
-func (_c *MockJiraConnectorInterface_GetAllProjects_Call) Run(run func()) *MockJiraConnectorInterface_GetAllProjects_Call {
+func (_c *MockJiraConnectorInterface_GetAllProjects_Call) Run(run func(ctx context.Context)) *MockJiraConnectorInterface_GetAllProjects_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -87,14 +90,183 @@ func (_c *MockJiraConnectorInterface_GetAllProjects_Call) Return(jiraProjects []
 	return _c
 }
 
-func (_c *MockJiraConnectorInterface_GetAllProjects_Call) RunAndReturn(run func() ([]structures.JiraProject, error)) *MockJiraConnectorInterface_GetAllProjects_Call {
+func (_c *MockJiraConnectorInterface_GetAllProjects_Call) RunAndReturn(run func(ctx context.Context) ([]structures.JiraProject, error)) *MockJiraConnectorInterface_GetAllProjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFields provides a mock function for the type MockJiraConnectorInterface
+func (_mock *MockJiraConnectorInterface) GetFields(ctx context.Context) ([]structures.JiraField, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFields")
+	}
+
+	var r0 []structures.JiraField
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]structures.JiraField, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []structures.JiraField); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.JiraField)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJiraConnectorInterface_GetFields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFields'
+type MockJiraConnectorInterface_GetFields_Call struct {
+	*mock.Call
+}
+
+// GetFields is a helper method to define mock.On call
+//   - ctx
+func (_e *MockJiraConnectorInterface_Expecter) GetFields(ctx interface{}) *MockJiraConnectorInterface_GetFields_Call {
+	return &MockJiraConnectorInterface_GetFields_Call{Call: _e.mock.On("GetFields", ctx)}
+}
+
+func (_c *MockJiraConnectorInterface_GetFields_Call) Run(run func(ctx context.Context)) *MockJiraConnectorInterface_GetFields_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockJiraConnectorInterface_GetFields_Call) Return(jiraFields []structures.JiraField, err error) *MockJiraConnectorInterface_GetFields_Call {
+	_c.Call.Return(jiraFields, err)
+	return _c
+}
+
+func (_c *MockJiraConnectorInterface_GetFields_Call) RunAndReturn(run func(ctx context.Context) ([]structures.JiraField, error)) *MockJiraConnectorInterface_GetFields_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPriorities provides a mock function for the type MockJiraConnectorInterface
+func (_mock *MockJiraConnectorInterface) GetPriorities(ctx context.Context) ([]structures.JiraPriority, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriorities")
+	}
+
+	var r0 []structures.JiraPriority
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]structures.JiraPriority, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []structures.JiraPriority); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.JiraPriority)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJiraConnectorInterface_GetPriorities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriorities'
+type MockJiraConnectorInterface_GetPriorities_Call struct {
+	*mock.Call
+}
+
+// GetPriorities is a helper method to define mock.On call
+//   - ctx
+func (_e *MockJiraConnectorInterface_Expecter) GetPriorities(ctx interface{}) *MockJiraConnectorInterface_GetPriorities_Call {
+	return &MockJiraConnectorInterface_GetPriorities_Call{Call: _e.mock.On("GetPriorities", ctx)}
+}
+
+func (_c *MockJiraConnectorInterface_GetPriorities_Call) Run(run func(ctx context.Context)) *MockJiraConnectorInterface_GetPriorities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockJiraConnectorInterface_GetPriorities_Call) Return(jiraPrioritys []structures.JiraPriority, err error) *MockJiraConnectorInterface_GetPriorities_Call {
+	_c.Call.Return(jiraPrioritys, err)
+	return _c
+}
+
+func (_c *MockJiraConnectorInterface_GetPriorities_Call) RunAndReturn(run func(ctx context.Context) ([]structures.JiraPriority, error)) *MockJiraConnectorInterface_GetPriorities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProjectBoards provides a mock function for the type MockJiraConnectorInterface
+func (_mock *MockJiraConnectorInterface) GetProjectBoards(ctx context.Context, project string) ([]structures.JiraAgileBoard, error) {
+	ret := _mock.Called(ctx, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectBoards")
+	}
+
+	var r0 []structures.JiraAgileBoard
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]structures.JiraAgileBoard, error)); ok {
+		return returnFunc(ctx, project)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []structures.JiraAgileBoard); ok {
+		r0 = returnFunc(ctx, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.JiraAgileBoard)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJiraConnectorInterface_GetProjectBoards_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectBoards'
+type MockJiraConnectorInterface_GetProjectBoards_Call struct {
+	*mock.Call
+}
+
+// GetProjectBoards is a helper method to define mock.On call
+//   - ctx
+//   - project
+func (_e *MockJiraConnectorInterface_Expecter) GetProjectBoards(ctx interface{}, project interface{}) *MockJiraConnectorInterface_GetProjectBoards_Call {
+	return &MockJiraConnectorInterface_GetProjectBoards_Call{Call: _e.mock.On("GetProjectBoards", ctx, project)}
+}
+
+func (_c *MockJiraConnectorInterface_GetProjectBoards_Call) Run(run func(ctx context.Context, project string)) *MockJiraConnectorInterface_GetProjectBoards_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockJiraConnectorInterface_GetProjectBoards_Call) Return(jiraAgileBoards []structures.JiraAgileBoard, err error) *MockJiraConnectorInterface_GetProjectBoards_Call {
+	_c.Call.Return(jiraAgileBoards, err)
+	return _c
+}
+
+func (_c *MockJiraConnectorInterface_GetProjectBoards_Call) RunAndReturn(run func(ctx context.Context, project string) ([]structures.JiraAgileBoard, error)) *MockJiraConnectorInterface_GetProjectBoards_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetProjectByKey provides a mock function for the type MockJiraConnectorInterface
-func (_mock *MockJiraConnectorInterface) GetProjectByKey(projectKey string) (*structures.JiraProject, error) {
-	ret := _mock.Called(projectKey)
+func (_mock *MockJiraConnectorInterface) GetProjectByKey(ctx context.Context, projectKey string) (*structures.JiraProject, error) {
+	ret := _mock.Called(ctx, projectKey)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetProjectByKey")
@@ -102,18 +274,18 @@ func (_mock *MockJiraConnectorInterface) GetProjectByKey(projectKey string) (*st
 
 	var r0 *structures.JiraProject
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*structures.JiraProject, error)); ok {
-		return returnFunc(projectKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*structures.JiraProject, error)); ok {
+		return returnFunc(ctx, projectKey)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *structures.JiraProject); ok {
-		r0 = returnFunc(projectKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *structures.JiraProject); ok {
+		r0 = returnFunc(ctx, projectKey)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*structures.JiraProject)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(projectKey)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, projectKey)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -126,14 +298,15 @@ type MockJiraConnectorInterface_GetProjectByKey_Call struct {
 }
 
 // GetProjectByKey is a helper method to define mock.On call
+//   - ctx
 //   - projectKey
-func (_e *MockJiraConnectorInterface_Expecter) GetProjectByKey(projectKey interface{}) *MockJiraConnectorInterface_GetProjectByKey_Call {
-	return &MockJiraConnectorInterface_GetProjectByKey_Call{Call: _e.mock.On("GetProjectByKey", projectKey)}
+func (_e *MockJiraConnectorInterface_Expecter) GetProjectByKey(ctx interface{}, projectKey interface{}) *MockJiraConnectorInterface_GetProjectByKey_Call {
+	return &MockJiraConnectorInterface_GetProjectByKey_Call{Call: _e.mock.On("GetProjectByKey", ctx, projectKey)}
 }
 
-func (_c *MockJiraConnectorInterface_GetProjectByKey_Call) Run(run func(projectKey string)) *MockJiraConnectorInterface_GetProjectByKey_Call {
+func (_c *MockJiraConnectorInterface_GetProjectByKey_Call) Run(run func(ctx context.Context, projectKey string)) *MockJiraConnectorInterface_GetProjectByKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -143,14 +316,14 @@ func (_c *MockJiraConnectorInterface_GetProjectByKey_Call) Return(jiraProject *s
 	return _c
 }
 
-func (_c *MockJiraConnectorInterface_GetProjectByKey_Call) RunAndReturn(run func(projectKey string) (*structures.JiraProject, error)) *MockJiraConnectorInterface_GetProjectByKey_Call {
+func (_c *MockJiraConnectorInterface_GetProjectByKey_Call) RunAndReturn(run func(ctx context.Context, projectKey string) (*structures.JiraProject, error)) *MockJiraConnectorInterface_GetProjectByKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetProjectIssues provides a mock function for the type MockJiraConnectorInterface
-func (_mock *MockJiraConnectorInterface) GetProjectIssues(project string) ([]structures.JiraIssue, error) {
-	ret := _mock.Called(project)
+func (_mock *MockJiraConnectorInterface) GetProjectIssues(ctx context.Context, project string, progress structures.ProgressFunc) ([]structures.JiraIssue, error) {
+	ret := _mock.Called(ctx, project, progress)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetProjectIssues")
@@ -158,18 +331,18 @@ func (_mock *MockJiraConnectorInterface) GetProjectIssues(project string) ([]str
 
 	var r0 []structures.JiraIssue
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) ([]structures.JiraIssue, error)); ok {
-		return returnFunc(project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, structures.ProgressFunc) ([]structures.JiraIssue, error)); ok {
+		return returnFunc(ctx, project, progress)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) []structures.JiraIssue); ok {
-		r0 = returnFunc(project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, structures.ProgressFunc) []structures.JiraIssue); ok {
+		r0 = returnFunc(ctx, project, progress)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]structures.JiraIssue)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(project)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, structures.ProgressFunc) error); ok {
+		r1 = returnFunc(ctx, project, progress)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -182,14 +355,16 @@ type MockJiraConnectorInterface_GetProjectIssues_Call struct {
 }
 
 // GetProjectIssues is a helper method to define mock.On call
+//   - ctx
 //   - project
-func (_e *MockJiraConnectorInterface_Expecter) GetProjectIssues(project interface{}) *MockJiraConnectorInterface_GetProjectIssues_Call {
-	return &MockJiraConnectorInterface_GetProjectIssues_Call{Call: _e.mock.On("GetProjectIssues", project)}
+//   - progress
+func (_e *MockJiraConnectorInterface_Expecter) GetProjectIssues(ctx interface{}, project interface{}, progress interface{}) *MockJiraConnectorInterface_GetProjectIssues_Call {
+	return &MockJiraConnectorInterface_GetProjectIssues_Call{Call: _e.mock.On("GetProjectIssues", ctx, project, progress)}
 }
 
-func (_c *MockJiraConnectorInterface_GetProjectIssues_Call) Run(run func(project string)) *MockJiraConnectorInterface_GetProjectIssues_Call {
+func (_c *MockJiraConnectorInterface_GetProjectIssues_Call) Run(run func(ctx context.Context, project string, progress structures.ProgressFunc)) *MockJiraConnectorInterface_GetProjectIssues_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(structures.ProgressFunc))
 	})
 	return _c
 }
@@ -199,14 +374,73 @@ func (_c *MockJiraConnectorInterface_GetProjectIssues_Call) Return(jiraIssues []
 	return _c
 }
 
-func (_c *MockJiraConnectorInterface_GetProjectIssues_Call) RunAndReturn(run func(project string) ([]structures.JiraIssue, error)) *MockJiraConnectorInterface_GetProjectIssues_Call {
+func (_c *MockJiraConnectorInterface_GetProjectIssues_Call) RunAndReturn(run func(ctx context.Context, project string, progress structures.ProgressFunc) ([]structures.JiraIssue, error)) *MockJiraConnectorInterface_GetProjectIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProjectIssuesUpdatedSince provides a mock function for the type MockJiraConnectorInterface
+func (_mock *MockJiraConnectorInterface) GetProjectIssuesUpdatedSince(ctx context.Context, project string, since time.Time, progress structures.ProgressFunc) ([]structures.JiraIssue, error) {
+	ret := _mock.Called(ctx, project, since, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectIssuesUpdatedSince")
+	}
+
+	var r0 []structures.JiraIssue
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, structures.ProgressFunc) ([]structures.JiraIssue, error)); ok {
+		return returnFunc(ctx, project, since, progress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, structures.ProgressFunc) []structures.JiraIssue); ok {
+		r0 = returnFunc(ctx, project, since, progress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.JiraIssue)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, structures.ProgressFunc) error); ok {
+		r1 = returnFunc(ctx, project, since, progress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectIssuesUpdatedSince'
+type MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call struct {
+	*mock.Call
+}
+
+// GetProjectIssuesUpdatedSince is a helper method to define mock.On call
+//   - ctx
+//   - project
+//   - since
+//   - progress
+func (_e *MockJiraConnectorInterface_Expecter) GetProjectIssuesUpdatedSince(ctx interface{}, project interface{}, since interface{}, progress interface{}) *MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call {
+	return &MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call{Call: _e.mock.On("GetProjectIssuesUpdatedSince", ctx, project, since, progress)}
+}
+
+func (_c *MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call) Run(run func(ctx context.Context, project string, since time.Time, progress structures.ProgressFunc)) *MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(structures.ProgressFunc))
+	})
+	return _c
+}
+
+func (_c *MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call) Return(jiraIssues []structures.JiraIssue, err error) *MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call {
+	_c.Call.Return(jiraIssues, err)
+	return _c
+}
+
+func (_c *MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call) RunAndReturn(run func(ctx context.Context, project string, since time.Time, progress structures.ProgressFunc) ([]structures.JiraIssue, error)) *MockJiraConnectorInterface_GetProjectIssuesUpdatedSince_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetProjectsPage provides a mock function for the type MockJiraConnectorInterface
-func (_mock *MockJiraConnectorInterface) GetProjectsPage(search string, limit int, page int) (*structures.ResponseProject, error) {
-	ret := _mock.Called(search, limit, page)
+func (_mock *MockJiraConnectorInterface) GetProjectsPage(ctx context.Context, search string, limit int, page int) (*structures.ResponseProject, error) {
+	ret := _mock.Called(ctx, search, limit, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetProjectsPage")
@@ -214,18 +448,18 @@ func (_mock *MockJiraConnectorInterface) GetProjectsPage(search string, limit in
 
 	var r0 *structures.ResponseProject
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, int, int) (*structures.ResponseProject, error)); ok {
-		return returnFunc(search, limit, page)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) (*structures.ResponseProject, error)); ok {
+		return returnFunc(ctx, search, limit, page)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, int, int) *structures.ResponseProject); ok {
-		r0 = returnFunc(search, limit, page)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) *structures.ResponseProject); ok {
+		r0 = returnFunc(ctx, search, limit, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*structures.ResponseProject)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, int, int) error); ok {
-		r1 = returnFunc(search, limit, page)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, search, limit, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -238,16 +472,17 @@ type MockJiraConnectorInterface_GetProjectsPage_Call struct {
 }
 
 // GetProjectsPage is a helper method to define mock.On call
+//   - ctx
 //   - search
 //   - limit
 //   - page
-func (_e *MockJiraConnectorInterface_Expecter) GetProjectsPage(search interface{}, limit interface{}, page interface{}) *MockJiraConnectorInterface_GetProjectsPage_Call {
-	return &MockJiraConnectorInterface_GetProjectsPage_Call{Call: _e.mock.On("GetProjectsPage", search, limit, page)}
+func (_e *MockJiraConnectorInterface_Expecter) GetProjectsPage(ctx interface{}, search interface{}, limit interface{}, page interface{}) *MockJiraConnectorInterface_GetProjectsPage_Call {
+	return &MockJiraConnectorInterface_GetProjectsPage_Call{Call: _e.mock.On("GetProjectsPage", ctx, search, limit, page)}
 }
 
-func (_c *MockJiraConnectorInterface_GetProjectsPage_Call) Run(run func(search string, limit int, page int)) *MockJiraConnectorInterface_GetProjectsPage_Call {
+func (_c *MockJiraConnectorInterface_GetProjectsPage_Call) Run(run func(ctx context.Context, search string, limit int, page int)) *MockJiraConnectorInterface_GetProjectsPage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
 	})
 	return _c
 }
@@ -257,7 +492,7 @@ func (_c *MockJiraConnectorInterface_GetProjectsPage_Call) Return(responseProjec
 	return _c
 }
 
-func (_c *MockJiraConnectorInterface_GetProjectsPage_Call) RunAndReturn(run func(search string, limit int, page int) (*structures.ResponseProject, error)) *MockJiraConnectorInterface_GetProjectsPage_Call {
+func (_c *MockJiraConnectorInterface_GetProjectsPage_Call) RunAndReturn(run func(ctx context.Context, search string, limit int, page int) (*structures.ResponseProject, error)) *MockJiraConnectorInterface_GetProjectsPage_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -336,497 +571,1985 @@ func (_c *MockDataTransformerInterface_TransformAuthorDB_Call) RunAndReturn(run
 	return _c
 }
 
-// TransformIssueDB provides a mock function for the type MockDataTransformerInterface
-func (_mock *MockDataTransformerInterface) TransformIssueDB(jiraIssue *structures.JiraIssue) *structures.DBIssue {
-	ret := _mock.Called(jiraIssue)
+// TransformBoardsDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformBoardsDB(jiraBoards []structures.JiraAgileBoard) []structures.DBBoard {
+	ret := _mock.Called(jiraBoards)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TransformIssueDB")
+		panic("no return value specified for TransformBoardsDB")
 	}
 
-	var r0 *structures.DBIssue
-	if returnFunc, ok := ret.Get(0).(func(*structures.JiraIssue) *structures.DBIssue); ok {
-		r0 = returnFunc(jiraIssue)
+	var r0 []structures.DBBoard
+	if returnFunc, ok := ret.Get(0).(func([]structures.JiraAgileBoard) []structures.DBBoard); ok {
+		r0 = returnFunc(jiraBoards)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*structures.DBIssue)
+			r0 = ret.Get(0).([]structures.DBBoard)
 		}
 	}
 	return r0
 }
 
-// MockDataTransformerInterface_TransformIssueDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformIssueDB'
-type MockDataTransformerInterface_TransformIssueDB_Call struct {
+// MockDataTransformerInterface_TransformBoardsDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformBoardsDB'
+type MockDataTransformerInterface_TransformBoardsDB_Call struct {
 	*mock.Call
 }
 
-// TransformIssueDB is a helper method to define mock.On call
-//   - jiraIssue
-func (_e *MockDataTransformerInterface_Expecter) TransformIssueDB(jiraIssue interface{}) *MockDataTransformerInterface_TransformIssueDB_Call {
-	return &MockDataTransformerInterface_TransformIssueDB_Call{Call: _e.mock.On("TransformIssueDB", jiraIssue)}
+// TransformBoardsDB is a helper method to define mock.On call
+//   - jiraBoards
+func (_e *MockDataTransformerInterface_Expecter) TransformBoardsDB(jiraBoards interface{}) *MockDataTransformerInterface_TransformBoardsDB_Call {
+	return &MockDataTransformerInterface_TransformBoardsDB_Call{Call: _e.mock.On("TransformBoardsDB", jiraBoards)}
 }
 
-func (_c *MockDataTransformerInterface_TransformIssueDB_Call) Run(run func(jiraIssue *structures.JiraIssue)) *MockDataTransformerInterface_TransformIssueDB_Call {
+func (_c *MockDataTransformerInterface_TransformBoardsDB_Call) Run(run func(jiraBoards []structures.JiraAgileBoard)) *MockDataTransformerInterface_TransformBoardsDB_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*structures.JiraIssue))
+		run(args[0].([]structures.JiraAgileBoard))
 	})
 	return _c
 }
 
-func (_c *MockDataTransformerInterface_TransformIssueDB_Call) Return(dBIssue *structures.DBIssue) *MockDataTransformerInterface_TransformIssueDB_Call {
-	_c.Call.Return(dBIssue)
+func (_c *MockDataTransformerInterface_TransformBoardsDB_Call) Return(dBBoards []structures.DBBoard) *MockDataTransformerInterface_TransformBoardsDB_Call {
+	_c.Call.Return(dBBoards)
 	return _c
 }
 
-func (_c *MockDataTransformerInterface_TransformIssueDB_Call) RunAndReturn(run func(jiraIssue *structures.JiraIssue) *structures.DBIssue) *MockDataTransformerInterface_TransformIssueDB_Call {
+func (_c *MockDataTransformerInterface_TransformBoardsDB_Call) RunAndReturn(run func(jiraBoards []structures.JiraAgileBoard) []structures.DBBoard) *MockDataTransformerInterface_TransformBoardsDB_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TransformProjectDB provides a mock function for the type MockDataTransformerInterface
-func (_mock *MockDataTransformerInterface) TransformProjectDB(jiraProject *structures.JiraProject) *structures.DBProject {
-	ret := _mock.Called(jiraProject)
+// TransformCommentsDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformCommentsDB(jiraComments *structures.Comments) []structures.DBComment {
+	ret := _mock.Called(jiraComments)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TransformProjectDB")
+		panic("no return value specified for TransformCommentsDB")
 	}
 
-	var r0 *structures.DBProject
-	if returnFunc, ok := ret.Get(0).(func(*structures.JiraProject) *structures.DBProject); ok {
-		r0 = returnFunc(jiraProject)
+	var r0 []structures.DBComment
+	if returnFunc, ok := ret.Get(0).(func(*structures.Comments) []structures.DBComment); ok {
+		r0 = returnFunc(jiraComments)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*structures.DBProject)
+			r0 = ret.Get(0).([]structures.DBComment)
 		}
 	}
 	return r0
 }
 
-// MockDataTransformerInterface_TransformProjectDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformProjectDB'
-type MockDataTransformerInterface_TransformProjectDB_Call struct {
+// MockDataTransformerInterface_TransformCommentsDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformCommentsDB'
+type MockDataTransformerInterface_TransformCommentsDB_Call struct {
 	*mock.Call
 }
 
-// TransformProjectDB is a helper method to define mock.On call
-//   - jiraProject
-func (_e *MockDataTransformerInterface_Expecter) TransformProjectDB(jiraProject interface{}) *MockDataTransformerInterface_TransformProjectDB_Call {
-	return &MockDataTransformerInterface_TransformProjectDB_Call{Call: _e.mock.On("TransformProjectDB", jiraProject)}
+// TransformCommentsDB is a helper method to define mock.On call
+//   - jiraComments
+func (_e *MockDataTransformerInterface_Expecter) TransformCommentsDB(jiraComments interface{}) *MockDataTransformerInterface_TransformCommentsDB_Call {
+	return &MockDataTransformerInterface_TransformCommentsDB_Call{Call: _e.mock.On("TransformCommentsDB", jiraComments)}
 }
 
-func (_c *MockDataTransformerInterface_TransformProjectDB_Call) Run(run func(jiraProject *structures.JiraProject)) *MockDataTransformerInterface_TransformProjectDB_Call {
+func (_c *MockDataTransformerInterface_TransformCommentsDB_Call) Run(run func(jiraComments *structures.Comments)) *MockDataTransformerInterface_TransformCommentsDB_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*structures.JiraProject))
+		run(args[0].(*structures.Comments))
 	})
 	return _c
 }
 
-func (_c *MockDataTransformerInterface_TransformProjectDB_Call) Return(dBProject *structures.DBProject) *MockDataTransformerInterface_TransformProjectDB_Call {
-	_c.Call.Return(dBProject)
+func (_c *MockDataTransformerInterface_TransformCommentsDB_Call) Return(dBComments []structures.DBComment) *MockDataTransformerInterface_TransformCommentsDB_Call {
+	_c.Call.Return(dBComments)
 	return _c
 }
 
-func (_c *MockDataTransformerInterface_TransformProjectDB_Call) RunAndReturn(run func(jiraProject *structures.JiraProject) *structures.DBProject) *MockDataTransformerInterface_TransformProjectDB_Call {
+func (_c *MockDataTransformerInterface_TransformCommentsDB_Call) RunAndReturn(run func(jiraComments *structures.Comments) []structures.DBComment) *MockDataTransformerInterface_TransformCommentsDB_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TransformStatusDB provides a mock function for the type MockDataTransformerInterface
-func (_mock *MockDataTransformerInterface) TransformStatusDB(jiraChanges *structures.Changelog) map[string]structures.DBStatusChanges {
-	ret := _mock.Called(jiraChanges)
+// TransformComponentsDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformComponentsDB(jiraIssue *structures.JiraIssue) []structures.DBComponent {
+	ret := _mock.Called(jiraIssue)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TransformStatusDB")
+		panic("no return value specified for TransformComponentsDB")
 	}
 
-	var r0 map[string]structures.DBStatusChanges
-	if returnFunc, ok := ret.Get(0).(func(*structures.Changelog) map[string]structures.DBStatusChanges); ok {
-		r0 = returnFunc(jiraChanges)
+	var r0 []structures.DBComponent
+	if returnFunc, ok := ret.Get(0).(func(*structures.JiraIssue) []structures.DBComponent); ok {
+		r0 = returnFunc(jiraIssue)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]structures.DBStatusChanges)
+			r0 = ret.Get(0).([]structures.DBComponent)
 		}
 	}
 	return r0
 }
 
-// MockDataTransformerInterface_TransformStatusDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformStatusDB'
-type MockDataTransformerInterface_TransformStatusDB_Call struct {
+// MockDataTransformerInterface_TransformComponentsDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformComponentsDB'
+type MockDataTransformerInterface_TransformComponentsDB_Call struct {
 	*mock.Call
 }
 
-// TransformStatusDB is a helper method to define mock.On call
-//   - jiraChanges
-func (_e *MockDataTransformerInterface_Expecter) TransformStatusDB(jiraChanges interface{}) *MockDataTransformerInterface_TransformStatusDB_Call {
-	return &MockDataTransformerInterface_TransformStatusDB_Call{Call: _e.mock.On("TransformStatusDB", jiraChanges)}
+// TransformComponentsDB is a helper method to define mock.On call
+//   - jiraIssue
+func (_e *MockDataTransformerInterface_Expecter) TransformComponentsDB(jiraIssue interface{}) *MockDataTransformerInterface_TransformComponentsDB_Call {
+	return &MockDataTransformerInterface_TransformComponentsDB_Call{Call: _e.mock.On("TransformComponentsDB", jiraIssue)}
 }
 
-func (_c *MockDataTransformerInterface_TransformStatusDB_Call) Run(run func(jiraChanges *structures.Changelog)) *MockDataTransformerInterface_TransformStatusDB_Call {
+func (_c *MockDataTransformerInterface_TransformComponentsDB_Call) Run(run func(jiraIssue *structures.JiraIssue)) *MockDataTransformerInterface_TransformComponentsDB_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*structures.Changelog))
+		run(args[0].(*structures.JiraIssue))
 	})
 	return _c
 }
 
-func (_c *MockDataTransformerInterface_TransformStatusDB_Call) Return(stringToDBStatusChanges map[string]structures.DBStatusChanges) *MockDataTransformerInterface_TransformStatusDB_Call {
-	_c.Call.Return(stringToDBStatusChanges)
+func (_c *MockDataTransformerInterface_TransformComponentsDB_Call) Return(dBComponents []structures.DBComponent) *MockDataTransformerInterface_TransformComponentsDB_Call {
+	_c.Call.Return(dBComponents)
 	return _c
 }
 
-func (_c *MockDataTransformerInterface_TransformStatusDB_Call) RunAndReturn(run func(jiraChanges *structures.Changelog) map[string]structures.DBStatusChanges) *MockDataTransformerInterface_TransformStatusDB_Call {
+func (_c *MockDataTransformerInterface_TransformComponentsDB_Call) RunAndReturn(run func(jiraIssue *structures.JiraIssue) []structures.DBComponent) *MockDataTransformerInterface_TransformComponentsDB_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TransformToDbIssueSet provides a mock function for the type MockDataTransformerInterface
-func (_mock *MockDataTransformerInterface) TransformToDbIssueSet(project *structures.JiraProject, jiraIssue *structures.JiraIssue) *datatransformer.DataTransformer {
-	ret := _mock.Called(project, jiraIssue)
+// TransformCustomFieldsDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformCustomFieldsDB(jiraIssue *structures.JiraIssue, fields map[string]string) []structures.DBCustomField {
+	ret := _mock.Called(jiraIssue, fields)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TransformToDbIssueSet")
+		panic("no return value specified for TransformCustomFieldsDB")
 	}
 
-	var r0 *datatransformer.DataTransformer
-	if returnFunc, ok := ret.Get(0).(func(*structures.JiraProject, *structures.JiraIssue) *datatransformer.DataTransformer); ok {
-		r0 = returnFunc(project, jiraIssue)
+	var r0 []structures.DBCustomField
+	if returnFunc, ok := ret.Get(0).(func(*structures.JiraIssue, map[string]string) []structures.DBCustomField); ok {
+		r0 = returnFunc(jiraIssue, fields)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*datatransformer.DataTransformer)
+			r0 = ret.Get(0).([]structures.DBCustomField)
 		}
 	}
 	return r0
 }
 
-// MockDataTransformerInterface_TransformToDbIssueSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformToDbIssueSet'
-type MockDataTransformerInterface_TransformToDbIssueSet_Call struct {
+// MockDataTransformerInterface_TransformCustomFieldsDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformCustomFieldsDB'
+type MockDataTransformerInterface_TransformCustomFieldsDB_Call struct {
 	*mock.Call
 }
 
-// TransformToDbIssueSet is a helper method to define mock.On call
-//   - project
+// TransformCustomFieldsDB is a helper method to define mock.On call
 //   - jiraIssue
-func (_e *MockDataTransformerInterface_Expecter) TransformToDbIssueSet(project interface{}, jiraIssue interface{}) *MockDataTransformerInterface_TransformToDbIssueSet_Call {
-	return &MockDataTransformerInterface_TransformToDbIssueSet_Call{Call: _e.mock.On("TransformToDbIssueSet", project, jiraIssue)}
+//   - fields
+func (_e *MockDataTransformerInterface_Expecter) TransformCustomFieldsDB(jiraIssue interface{}, fields interface{}) *MockDataTransformerInterface_TransformCustomFieldsDB_Call {
+	return &MockDataTransformerInterface_TransformCustomFieldsDB_Call{Call: _e.mock.On("TransformCustomFieldsDB", jiraIssue, fields)}
 }
 
-func (_c *MockDataTransformerInterface_TransformToDbIssueSet_Call) Run(run func(project *structures.JiraProject, jiraIssue *structures.JiraIssue)) *MockDataTransformerInterface_TransformToDbIssueSet_Call {
+func (_c *MockDataTransformerInterface_TransformCustomFieldsDB_Call) Run(run func(jiraIssue *structures.JiraIssue, fields map[string]string)) *MockDataTransformerInterface_TransformCustomFieldsDB_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*structures.JiraProject), args[1].(*structures.JiraIssue))
+		run(args[0].(*structures.JiraIssue), args[1].(map[string]string))
 	})
 	return _c
 }
 
-func (_c *MockDataTransformerInterface_TransformToDbIssueSet_Call) Return(dataTransformer *datatransformer.DataTransformer) *MockDataTransformerInterface_TransformToDbIssueSet_Call {
-	_c.Call.Return(dataTransformer)
+func (_c *MockDataTransformerInterface_TransformCustomFieldsDB_Call) Return(dBCustomFields []structures.DBCustomField) *MockDataTransformerInterface_TransformCustomFieldsDB_Call {
+	_c.Call.Return(dBCustomFields)
 	return _c
 }
 
-func (_c *MockDataTransformerInterface_TransformToDbIssueSet_Call) RunAndReturn(run func(project *structures.JiraProject, jiraIssue *structures.JiraIssue) *datatransformer.DataTransformer) *MockDataTransformerInterface_TransformToDbIssueSet_Call {
+func (_c *MockDataTransformerInterface_TransformCustomFieldsDB_Call) RunAndReturn(run func(jiraIssue *structures.JiraIssue, fields map[string]string) []structures.DBCustomField) *MockDataTransformerInterface_TransformCustomFieldsDB_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockDbPusherInterface creates a new instance of MockDbPusherInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockDbPusherInterface(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockDbPusherInterface {
-	mock := &MockDbPusherInterface{}
-	mock.Mock.Test(t)
+// TransformFieldChangesDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformFieldChangesDB(jiraChanges *structures.Changelog) []structures.DBFieldChange {
+	ret := _mock.Called(jiraChanges)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for TransformFieldChangesDB")
+	}
 
-	return mock
+	var r0 []structures.DBFieldChange
+	if returnFunc, ok := ret.Get(0).(func(*structures.Changelog) []structures.DBFieldChange); ok {
+		r0 = returnFunc(jiraChanges)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.DBFieldChange)
+		}
+	}
+	return r0
 }
 
-// MockDbPusherInterface is an autogenerated mock type for the DbPusherInterface type
-type MockDbPusherInterface struct {
-	mock.Mock
+// MockDataTransformerInterface_TransformFieldChangesDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformFieldChangesDB'
+type MockDataTransformerInterface_TransformFieldChangesDB_Call struct {
+	*mock.Call
 }
 
-type MockDbPusherInterface_Expecter struct {
-	mock *mock.Mock
+// TransformFieldChangesDB is a helper method to define mock.On call
+//   - jiraChanges
+func (_e *MockDataTransformerInterface_Expecter) TransformFieldChangesDB(jiraChanges interface{}) *MockDataTransformerInterface_TransformFieldChangesDB_Call {
+	return &MockDataTransformerInterface_TransformFieldChangesDB_Call{Call: _e.mock.On("TransformFieldChangesDB", jiraChanges)}
 }
 
-func (_m *MockDbPusherInterface) EXPECT() *MockDbPusherInterface_Expecter {
-	return &MockDbPusherInterface_Expecter{mock: &_m.Mock}
+func (_c *MockDataTransformerInterface_TransformFieldChangesDB_Call) Run(run func(jiraChanges *structures.Changelog)) *MockDataTransformerInterface_TransformFieldChangesDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.Changelog))
+	})
+	return _c
 }
 
-// Close provides a mock function for the type MockDbPusherInterface
-func (_mock *MockDbPusherInterface) Close() {
-	_mock.Called()
-	return
+func (_c *MockDataTransformerInterface_TransformFieldChangesDB_Call) Return(dBFieldChanges []structures.DBFieldChange) *MockDataTransformerInterface_TransformFieldChangesDB_Call {
+	_c.Call.Return(dBFieldChanges)
+	return _c
 }
 
-// MockDbPusherInterface_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
-type MockDbPusherInterface_Close_Call struct {
-	*mock.Call
+func (_c *MockDataTransformerInterface_TransformFieldChangesDB_Call) RunAndReturn(run func(jiraChanges *structures.Changelog) []structures.DBFieldChange) *MockDataTransformerInterface_TransformFieldChangesDB_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-// Close is a helper method to define mock.On call
-func (_e *MockDbPusherInterface_Expecter) Close() *MockDbPusherInterface_Close_Call {
-	return &MockDbPusherInterface_Close_Call{Call: _e.mock.On("Close")}
+// TransformFixVersionsDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformFixVersionsDB(jiraIssue *structures.JiraIssue) []structures.DBVersion {
+	ret := _mock.Called(jiraIssue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformFixVersionsDB")
+	}
+
+	var r0 []structures.DBVersion
+	if returnFunc, ok := ret.Get(0).(func(*structures.JiraIssue) []structures.DBVersion); ok {
+		r0 = returnFunc(jiraIssue)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.DBVersion)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformFixVersionsDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformFixVersionsDB'
+type MockDataTransformerInterface_TransformFixVersionsDB_Call struct {
+	*mock.Call
+}
+
+// TransformFixVersionsDB is a helper method to define mock.On call
+//   - jiraIssue
+func (_e *MockDataTransformerInterface_Expecter) TransformFixVersionsDB(jiraIssue interface{}) *MockDataTransformerInterface_TransformFixVersionsDB_Call {
+	return &MockDataTransformerInterface_TransformFixVersionsDB_Call{Call: _e.mock.On("TransformFixVersionsDB", jiraIssue)}
+}
+
+func (_c *MockDataTransformerInterface_TransformFixVersionsDB_Call) Run(run func(jiraIssue *structures.JiraIssue)) *MockDataTransformerInterface_TransformFixVersionsDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.JiraIssue))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformFixVersionsDB_Call) Return(dBVersions []structures.DBVersion) *MockDataTransformerInterface_TransformFixVersionsDB_Call {
+	_c.Call.Return(dBVersions)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformFixVersionsDB_Call) RunAndReturn(run func(jiraIssue *structures.JiraIssue) []structures.DBVersion) *MockDataTransformerInterface_TransformFixVersionsDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformIssueDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformIssueDB(jiraIssue *structures.JiraIssue) *structures.DBIssue {
+	ret := _mock.Called(jiraIssue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformIssueDB")
+	}
+
+	var r0 *structures.DBIssue
+	if returnFunc, ok := ret.Get(0).(func(*structures.JiraIssue) *structures.DBIssue); ok {
+		r0 = returnFunc(jiraIssue)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structures.DBIssue)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformIssueDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformIssueDB'
+type MockDataTransformerInterface_TransformIssueDB_Call struct {
+	*mock.Call
+}
+
+// TransformIssueDB is a helper method to define mock.On call
+//   - jiraIssue
+func (_e *MockDataTransformerInterface_Expecter) TransformIssueDB(jiraIssue interface{}) *MockDataTransformerInterface_TransformIssueDB_Call {
+	return &MockDataTransformerInterface_TransformIssueDB_Call{Call: _e.mock.On("TransformIssueDB", jiraIssue)}
+}
+
+func (_c *MockDataTransformerInterface_TransformIssueDB_Call) Run(run func(jiraIssue *structures.JiraIssue)) *MockDataTransformerInterface_TransformIssueDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.JiraIssue))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformIssueDB_Call) Return(dBIssue *structures.DBIssue) *MockDataTransformerInterface_TransformIssueDB_Call {
+	_c.Call.Return(dBIssue)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformIssueDB_Call) RunAndReturn(run func(jiraIssue *structures.JiraIssue) *structures.DBIssue) *MockDataTransformerInterface_TransformIssueDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformIssueLinksDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformIssueLinksDB(jiraIssue *structures.JiraIssue) []structures.DBIssueLink {
+	ret := _mock.Called(jiraIssue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformIssueLinksDB")
+	}
+
+	var r0 []structures.DBIssueLink
+	if returnFunc, ok := ret.Get(0).(func(*structures.JiraIssue) []structures.DBIssueLink); ok {
+		r0 = returnFunc(jiraIssue)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.DBIssueLink)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformIssueLinksDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformIssueLinksDB'
+type MockDataTransformerInterface_TransformIssueLinksDB_Call struct {
+	*mock.Call
+}
+
+// TransformIssueLinksDB is a helper method to define mock.On call
+//   - jiraIssue
+func (_e *MockDataTransformerInterface_Expecter) TransformIssueLinksDB(jiraIssue interface{}) *MockDataTransformerInterface_TransformIssueLinksDB_Call {
+	return &MockDataTransformerInterface_TransformIssueLinksDB_Call{Call: _e.mock.On("TransformIssueLinksDB", jiraIssue)}
+}
+
+func (_c *MockDataTransformerInterface_TransformIssueLinksDB_Call) Run(run func(jiraIssue *structures.JiraIssue)) *MockDataTransformerInterface_TransformIssueLinksDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.JiraIssue))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformIssueLinksDB_Call) Return(dBIssueLinks []structures.DBIssueLink) *MockDataTransformerInterface_TransformIssueLinksDB_Call {
+	_c.Call.Return(dBIssueLinks)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformIssueLinksDB_Call) RunAndReturn(run func(jiraIssue *structures.JiraIssue) []structures.DBIssueLink) *MockDataTransformerInterface_TransformIssueLinksDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformLabelsDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformLabelsDB(jiraIssue *structures.JiraIssue) []string {
+	ret := _mock.Called(jiraIssue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformLabelsDB")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func(*structures.JiraIssue) []string); ok {
+		r0 = returnFunc(jiraIssue)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformLabelsDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformLabelsDB'
+type MockDataTransformerInterface_TransformLabelsDB_Call struct {
+	*mock.Call
+}
+
+// TransformLabelsDB is a helper method to define mock.On call
+//   - jiraIssue
+func (_e *MockDataTransformerInterface_Expecter) TransformLabelsDB(jiraIssue interface{}) *MockDataTransformerInterface_TransformLabelsDB_Call {
+	return &MockDataTransformerInterface_TransformLabelsDB_Call{Call: _e.mock.On("TransformLabelsDB", jiraIssue)}
+}
+
+func (_c *MockDataTransformerInterface_TransformLabelsDB_Call) Run(run func(jiraIssue *structures.JiraIssue)) *MockDataTransformerInterface_TransformLabelsDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.JiraIssue))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformLabelsDB_Call) Return(strings []string) *MockDataTransformerInterface_TransformLabelsDB_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformLabelsDB_Call) RunAndReturn(run func(jiraIssue *structures.JiraIssue) []string) *MockDataTransformerInterface_TransformLabelsDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformOptionalAuthorDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformOptionalAuthorDB(jiraUser *structures.User) *structures.DBAuthor {
+	ret := _mock.Called(jiraUser)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformOptionalAuthorDB")
+	}
+
+	var r0 *structures.DBAuthor
+	if returnFunc, ok := ret.Get(0).(func(*structures.User) *structures.DBAuthor); ok {
+		r0 = returnFunc(jiraUser)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structures.DBAuthor)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformOptionalAuthorDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformOptionalAuthorDB'
+type MockDataTransformerInterface_TransformOptionalAuthorDB_Call struct {
+	*mock.Call
+}
+
+// TransformOptionalAuthorDB is a helper method to define mock.On call
+//   - jiraUser
+func (_e *MockDataTransformerInterface_Expecter) TransformOptionalAuthorDB(jiraUser interface{}) *MockDataTransformerInterface_TransformOptionalAuthorDB_Call {
+	return &MockDataTransformerInterface_TransformOptionalAuthorDB_Call{Call: _e.mock.On("TransformOptionalAuthorDB", jiraUser)}
+}
+
+func (_c *MockDataTransformerInterface_TransformOptionalAuthorDB_Call) Run(run func(jiraUser *structures.User)) *MockDataTransformerInterface_TransformOptionalAuthorDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.User))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformOptionalAuthorDB_Call) Return(dBAuthor *structures.DBAuthor) *MockDataTransformerInterface_TransformOptionalAuthorDB_Call {
+	_c.Call.Return(dBAuthor)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformOptionalAuthorDB_Call) RunAndReturn(run func(jiraUser *structures.User) *structures.DBAuthor) *MockDataTransformerInterface_TransformOptionalAuthorDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformPrioritiesDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformPrioritiesDB(jiraPriorities []structures.JiraPriority) []structures.DBPriority {
+	ret := _mock.Called(jiraPriorities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformPrioritiesDB")
+	}
+
+	var r0 []structures.DBPriority
+	if returnFunc, ok := ret.Get(0).(func([]structures.JiraPriority) []structures.DBPriority); ok {
+		r0 = returnFunc(jiraPriorities)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.DBPriority)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformPrioritiesDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformPrioritiesDB'
+type MockDataTransformerInterface_TransformPrioritiesDB_Call struct {
+	*mock.Call
+}
+
+// TransformPrioritiesDB is a helper method to define mock.On call
+//   - jiraPriorities
+func (_e *MockDataTransformerInterface_Expecter) TransformPrioritiesDB(jiraPriorities interface{}) *MockDataTransformerInterface_TransformPrioritiesDB_Call {
+	return &MockDataTransformerInterface_TransformPrioritiesDB_Call{Call: _e.mock.On("TransformPrioritiesDB", jiraPriorities)}
+}
+
+func (_c *MockDataTransformerInterface_TransformPrioritiesDB_Call) Run(run func(jiraPriorities []structures.JiraPriority)) *MockDataTransformerInterface_TransformPrioritiesDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]structures.JiraPriority))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformPrioritiesDB_Call) Return(dBPrioritys []structures.DBPriority) *MockDataTransformerInterface_TransformPrioritiesDB_Call {
+	_c.Call.Return(dBPrioritys)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformPrioritiesDB_Call) RunAndReturn(run func(jiraPriorities []structures.JiraPriority) []structures.DBPriority) *MockDataTransformerInterface_TransformPrioritiesDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformProjectDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformProjectDB(jiraProject *structures.JiraProject) *structures.DBProject {
+	ret := _mock.Called(jiraProject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformProjectDB")
+	}
+
+	var r0 *structures.DBProject
+	if returnFunc, ok := ret.Get(0).(func(*structures.JiraProject) *structures.DBProject); ok {
+		r0 = returnFunc(jiraProject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structures.DBProject)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformProjectDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformProjectDB'
+type MockDataTransformerInterface_TransformProjectDB_Call struct {
+	*mock.Call
+}
+
+// TransformProjectDB is a helper method to define mock.On call
+//   - jiraProject
+func (_e *MockDataTransformerInterface_Expecter) TransformProjectDB(jiraProject interface{}) *MockDataTransformerInterface_TransformProjectDB_Call {
+	return &MockDataTransformerInterface_TransformProjectDB_Call{Call: _e.mock.On("TransformProjectDB", jiraProject)}
+}
+
+func (_c *MockDataTransformerInterface_TransformProjectDB_Call) Run(run func(jiraProject *structures.JiraProject)) *MockDataTransformerInterface_TransformProjectDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.JiraProject))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformProjectDB_Call) Return(dBProject *structures.DBProject) *MockDataTransformerInterface_TransformProjectDB_Call {
+	_c.Call.Return(dBProject)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformProjectDB_Call) RunAndReturn(run func(jiraProject *structures.JiraProject) *structures.DBProject) *MockDataTransformerInterface_TransformProjectDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformStatusDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformStatusDB(jiraChanges *structures.Changelog) []structures.DBStatusTransition {
+	ret := _mock.Called(jiraChanges)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformStatusDB")
+	}
+
+	var r0 []structures.DBStatusTransition
+	if returnFunc, ok := ret.Get(0).(func(*structures.Changelog) []structures.DBStatusTransition); ok {
+		r0 = returnFunc(jiraChanges)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.DBStatusTransition)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformStatusDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformStatusDB'
+type MockDataTransformerInterface_TransformStatusDB_Call struct {
+	*mock.Call
+}
+
+// TransformStatusDB is a helper method to define mock.On call
+//   - jiraChanges
+func (_e *MockDataTransformerInterface_Expecter) TransformStatusDB(jiraChanges interface{}) *MockDataTransformerInterface_TransformStatusDB_Call {
+	return &MockDataTransformerInterface_TransformStatusDB_Call{Call: _e.mock.On("TransformStatusDB", jiraChanges)}
+}
+
+func (_c *MockDataTransformerInterface_TransformStatusDB_Call) Run(run func(jiraChanges *structures.Changelog)) *MockDataTransformerInterface_TransformStatusDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.Changelog))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformStatusDB_Call) Return(dBStatusTransitions []structures.DBStatusTransition) *MockDataTransformerInterface_TransformStatusDB_Call {
+	_c.Call.Return(dBStatusTransitions)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformStatusDB_Call) RunAndReturn(run func(jiraChanges *structures.Changelog) []structures.DBStatusTransition) *MockDataTransformerInterface_TransformStatusDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformToDbIssueSet provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformToDbIssueSet(project *structures.JiraProject, jiraIssue *structures.JiraIssue) *datatransformer.DataTransformer {
+	ret := _mock.Called(project, jiraIssue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformToDbIssueSet")
+	}
+
+	var r0 *datatransformer.DataTransformer
+	if returnFunc, ok := ret.Get(0).(func(*structures.JiraProject, *structures.JiraIssue) *datatransformer.DataTransformer); ok {
+		r0 = returnFunc(project, jiraIssue)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datatransformer.DataTransformer)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformToDbIssueSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformToDbIssueSet'
+type MockDataTransformerInterface_TransformToDbIssueSet_Call struct {
+	*mock.Call
+}
+
+// TransformToDbIssueSet is a helper method to define mock.On call
+//   - project
+//   - jiraIssue
+func (_e *MockDataTransformerInterface_Expecter) TransformToDbIssueSet(project interface{}, jiraIssue interface{}) *MockDataTransformerInterface_TransformToDbIssueSet_Call {
+	return &MockDataTransformerInterface_TransformToDbIssueSet_Call{Call: _e.mock.On("TransformToDbIssueSet", project, jiraIssue)}
+}
+
+func (_c *MockDataTransformerInterface_TransformToDbIssueSet_Call) Run(run func(project *structures.JiraProject, jiraIssue *structures.JiraIssue)) *MockDataTransformerInterface_TransformToDbIssueSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.JiraProject), args[1].(*structures.JiraIssue))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformToDbIssueSet_Call) Return(dataTransformer *datatransformer.DataTransformer) *MockDataTransformerInterface_TransformToDbIssueSet_Call {
+	_c.Call.Return(dataTransformer)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformToDbIssueSet_Call) RunAndReturn(run func(project *structures.JiraProject, jiraIssue *structures.JiraIssue) *datatransformer.DataTransformer) *MockDataTransformerInterface_TransformToDbIssueSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransformWorklogsDB provides a mock function for the type MockDataTransformerInterface
+func (_mock *MockDataTransformerInterface) TransformWorklogsDB(jiraWorklogs *structures.Worklogs) []structures.DBWorklog {
+	ret := _mock.Called(jiraWorklogs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransformWorklogsDB")
+	}
+
+	var r0 []structures.DBWorklog
+	if returnFunc, ok := ret.Get(0).(func(*structures.Worklogs) []structures.DBWorklog); ok {
+		r0 = returnFunc(jiraWorklogs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.DBWorklog)
+		}
+	}
+	return r0
+}
+
+// MockDataTransformerInterface_TransformWorklogsDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransformWorklogsDB'
+type MockDataTransformerInterface_TransformWorklogsDB_Call struct {
+	*mock.Call
+}
+
+// TransformWorklogsDB is a helper method to define mock.On call
+//   - jiraWorklogs
+func (_e *MockDataTransformerInterface_Expecter) TransformWorklogsDB(jiraWorklogs interface{}) *MockDataTransformerInterface_TransformWorklogsDB_Call {
+	return &MockDataTransformerInterface_TransformWorklogsDB_Call{Call: _e.mock.On("TransformWorklogsDB", jiraWorklogs)}
+}
+
+func (_c *MockDataTransformerInterface_TransformWorklogsDB_Call) Run(run func(jiraWorklogs *structures.Worklogs)) *MockDataTransformerInterface_TransformWorklogsDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*structures.Worklogs))
+	})
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformWorklogsDB_Call) Return(dBWorklogs []structures.DBWorklog) *MockDataTransformerInterface_TransformWorklogsDB_Call {
+	_c.Call.Return(dBWorklogs)
+	return _c
+}
+
+func (_c *MockDataTransformerInterface_TransformWorklogsDB_Call) RunAndReturn(run func(jiraWorklogs *structures.Worklogs) []structures.DBWorklog) *MockDataTransformerInterface_TransformWorklogsDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDbPusherInterface creates a new instance of MockDbPusherInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDbPusherInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDbPusherInterface {
+	mock := &MockDbPusherInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDbPusherInterface is an autogenerated mock type for the DbPusherInterface type
+type MockDbPusherInterface struct {
+	mock.Mock
+}
+
+type MockDbPusherInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDbPusherInterface) EXPECT() *MockDbPusherInterface_Expecter {
+	return &MockDbPusherInterface_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) Close() {
+	_mock.Called()
+	return
+}
+
+// MockDbPusherInterface_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockDbPusherInterface_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockDbPusherInterface_Expecter) Close() *MockDbPusherInterface_Close_Call {
+	return &MockDbPusherInterface_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockDbPusherInterface_Close_Call) Run(run func()) *MockDbPusherInterface_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_Close_Call) Return() *MockDbPusherInterface_Close_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDbPusherInterface_Close_Call) RunAndReturn(run func()) *MockDbPusherInterface_Close_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetSyncWatermark provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) GetSyncWatermark(ctx context.Context, project structures.ProjectRef) (time.Time, error) {
+	ret := _mock.Called(ctx, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyncWatermark")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef) (time.Time, error)); ok {
+		return returnFunc(ctx, project)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef) time.Time); ok {
+		r0 = returnFunc(ctx, project)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, structures.ProjectRef) error); ok {
+		r1 = returnFunc(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDbPusherInterface_GetSyncWatermark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSyncWatermark'
+type MockDbPusherInterface_GetSyncWatermark_Call struct {
+	*mock.Call
+}
+
+// GetSyncWatermark is a helper method to define mock.On call
+//   - ctx
+//   - project
+func (_e *MockDbPusherInterface_Expecter) GetSyncWatermark(ctx interface{}, project interface{}) *MockDbPusherInterface_GetSyncWatermark_Call {
+	return &MockDbPusherInterface_GetSyncWatermark_Call{Call: _e.mock.On("GetSyncWatermark", ctx, project)}
+}
+
+func (_c *MockDbPusherInterface_GetSyncWatermark_Call) Run(run func(ctx context.Context, project structures.ProjectRef)) *MockDbPusherInterface_GetSyncWatermark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(structures.ProjectRef))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_GetSyncWatermark_Call) Return(time1 time.Time, err error) *MockDbPusherInterface_GetSyncWatermark_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_GetSyncWatermark_Call) RunAndReturn(run func(ctx context.Context, project structures.ProjectRef) (time.Time, error)) *MockDbPusherInterface_GetSyncWatermark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InTx provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) InTx(ctx context.Context, fn func(context.Context) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InTx")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_InTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTx'
+type MockDbPusherInterface_InTx_Call struct {
+	*mock.Call
+}
+
+// InTx is a helper method to define mock.On call
+//   - ctx
+//   - fn
+func (_e *MockDbPusherInterface_Expecter) InTx(ctx interface{}, fn interface{}) *MockDbPusherInterface_InTx_Call {
+	return &MockDbPusherInterface_InTx_Call{Call: _e.mock.On("InTx", ctx, fn)}
+}
+
+func (_c *MockDbPusherInterface_InTx_Call) Run(run func(ctx context.Context, fn func(context.Context) error)) *MockDbPusherInterface_InTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_InTx_Call) Return(err error) *MockDbPusherInterface_InTx_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_InTx_Call) RunAndReturn(run func(ctx context.Context, fn func(context.Context) error) error) *MockDbPusherInterface_InTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDeletedIssues provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) MarkDeletedIssues(ctx context.Context, project structures.ProjectRef, keys []string) (int, error) {
+	ret := _mock.Called(ctx, project, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDeletedIssues")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef, []string) (int, error)); ok {
+		return returnFunc(ctx, project, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef, []string) int); ok {
+		r0 = returnFunc(ctx, project, keys)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, structures.ProjectRef, []string) error); ok {
+		r1 = returnFunc(ctx, project, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDbPusherInterface_MarkDeletedIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDeletedIssues'
+type MockDbPusherInterface_MarkDeletedIssues_Call struct {
+	*mock.Call
+}
+
+// MarkDeletedIssues is a helper method to define mock.On call
+//   - ctx
+//   - project
+//   - keys
+func (_e *MockDbPusherInterface_Expecter) MarkDeletedIssues(ctx interface{}, project interface{}, keys interface{}) *MockDbPusherInterface_MarkDeletedIssues_Call {
+	return &MockDbPusherInterface_MarkDeletedIssues_Call{Call: _e.mock.On("MarkDeletedIssues", ctx, project, keys)}
+}
+
+func (_c *MockDbPusherInterface_MarkDeletedIssues_Call) Run(run func(ctx context.Context, project structures.ProjectRef, keys []string)) *MockDbPusherInterface_MarkDeletedIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(structures.ProjectRef), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_MarkDeletedIssues_Call) Return(n int, err error) *MockDbPusherInterface_MarkDeletedIssues_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_MarkDeletedIssues_Call) RunAndReturn(run func(ctx context.Context, project structures.ProjectRef, keys []string) (int, error)) *MockDbPusherInterface_MarkDeletedIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushBoards provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushBoards(ctx context.Context, project *structures.DBProject, boards []structures.DBBoard) error {
+	ret := _mock.Called(ctx, project, boards)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushBoards")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *structures.DBProject, []structures.DBBoard) error); ok {
+		r0 = returnFunc(ctx, project, boards)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushBoards_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushBoards'
+type MockDbPusherInterface_PushBoards_Call struct {
+	*mock.Call
+}
+
+// PushBoards is a helper method to define mock.On call
+//   - ctx
+//   - project
+//   - boards
+func (_e *MockDbPusherInterface_Expecter) PushBoards(ctx interface{}, project interface{}, boards interface{}) *MockDbPusherInterface_PushBoards_Call {
+	return &MockDbPusherInterface_PushBoards_Call{Call: _e.mock.On("PushBoards", ctx, project, boards)}
+}
+
+func (_c *MockDbPusherInterface_PushBoards_Call) Run(run func(ctx context.Context, project *structures.DBProject, boards []structures.DBBoard)) *MockDbPusherInterface_PushBoards_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*structures.DBProject), args[2].([]structures.DBBoard))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushBoards_Call) Return(err error) *MockDbPusherInterface_PushBoards_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushBoards_Call) RunAndReturn(run func(ctx context.Context, project *structures.DBProject, boards []structures.DBBoard) error) *MockDbPusherInterface_PushBoards_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushComments provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushComments(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushComments")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushComments'
+type MockDbPusherInterface_PushComments_Call struct {
+	*mock.Call
+}
+
+// PushComments is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushComments(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushComments_Call {
+	return &MockDbPusherInterface_PushComments_Call{Call: _e.mock.On("PushComments", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushComments_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushComments_Call) Return(err error) *MockDbPusherInterface_PushComments_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushComments_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushComponents provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushComponents(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushComponents")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushComponents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushComponents'
+type MockDbPusherInterface_PushComponents_Call struct {
+	*mock.Call
+}
+
+// PushComponents is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushComponents(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushComponents_Call {
+	return &MockDbPusherInterface_PushComponents_Call{Call: _e.mock.On("PushComponents", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushComponents_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushComponents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushComponents_Call) Return(err error) *MockDbPusherInterface_PushComponents_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushComponents_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushComponents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushCustomFields provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushCustomFields(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushCustomFields")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushCustomFields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushCustomFields'
+type MockDbPusherInterface_PushCustomFields_Call struct {
+	*mock.Call
+}
+
+// PushCustomFields is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushCustomFields(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushCustomFields_Call {
+	return &MockDbPusherInterface_PushCustomFields_Call{Call: _e.mock.On("PushCustomFields", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushCustomFields_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushCustomFields_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushCustomFields_Call) Return(err error) *MockDbPusherInterface_PushCustomFields_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushCustomFields_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushCustomFields_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushFieldChanges provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushFieldChanges(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushFieldChanges")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushFieldChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushFieldChanges'
+type MockDbPusherInterface_PushFieldChanges_Call struct {
+	*mock.Call
+}
+
+// PushFieldChanges is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushFieldChanges(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushFieldChanges_Call {
+	return &MockDbPusherInterface_PushFieldChanges_Call{Call: _e.mock.On("PushFieldChanges", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushFieldChanges_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushFieldChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushFieldChanges_Call) Return(err error) *MockDbPusherInterface_PushFieldChanges_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushFieldChanges_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushFieldChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushFixVersions provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushFixVersions(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushFixVersions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushFixVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushFixVersions'
+type MockDbPusherInterface_PushFixVersions_Call struct {
+	*mock.Call
+}
+
+// PushFixVersions is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushFixVersions(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushFixVersions_Call {
+	return &MockDbPusherInterface_PushFixVersions_Call{Call: _e.mock.On("PushFixVersions", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushFixVersions_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushFixVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushFixVersions_Call) Return(err error) *MockDbPusherInterface_PushFixVersions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushFixVersions_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushFixVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushIssue provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushIssue(ctx context.Context, project *structures.DBProject, issue *datatransformer.DataTransformer) (int, error) {
+	ret := _mock.Called(ctx, project, issue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushIssue")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *structures.DBProject, *datatransformer.DataTransformer) (int, error)); ok {
+		return returnFunc(ctx, project, issue)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *structures.DBProject, *datatransformer.DataTransformer) int); ok {
+		r0 = returnFunc(ctx, project, issue)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *structures.DBProject, *datatransformer.DataTransformer) error); ok {
+		r1 = returnFunc(ctx, project, issue)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDbPusherInterface_PushIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushIssue'
+type MockDbPusherInterface_PushIssue_Call struct {
+	*mock.Call
+}
+
+// PushIssue is a helper method to define mock.On call
+//   - ctx
+//   - project
+//   - issue
+func (_e *MockDbPusherInterface_Expecter) PushIssue(ctx interface{}, project interface{}, issue interface{}) *MockDbPusherInterface_PushIssue_Call {
+	return &MockDbPusherInterface_PushIssue_Call{Call: _e.mock.On("PushIssue", ctx, project, issue)}
+}
+
+func (_c *MockDbPusherInterface_PushIssue_Call) Run(run func(ctx context.Context, project *structures.DBProject, issue *datatransformer.DataTransformer)) *MockDbPusherInterface_PushIssue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*structures.DBProject), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushIssue_Call) Return(n int, err error) *MockDbPusherInterface_PushIssue_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushIssue_Call) RunAndReturn(run func(ctx context.Context, project *structures.DBProject, issue *datatransformer.DataTransformer) (int, error)) *MockDbPusherInterface_PushIssue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushIssueLinks provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushIssueLinks(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushIssueLinks")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushIssueLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushIssueLinks'
+type MockDbPusherInterface_PushIssueLinks_Call struct {
+	*mock.Call
+}
+
+// PushIssueLinks is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushIssueLinks(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushIssueLinks_Call {
+	return &MockDbPusherInterface_PushIssueLinks_Call{Call: _e.mock.On("PushIssueLinks", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushIssueLinks_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushIssueLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushIssueLinks_Call) Return(err error) *MockDbPusherInterface_PushIssueLinks_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushIssueLinks_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushIssueLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushIssues provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushIssues(ctx context.Context, project *structures.DBProject, issues []datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, project, issues)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushIssues")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *structures.DBProject, []datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, project, issues)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushIssues'
+type MockDbPusherInterface_PushIssues_Call struct {
+	*mock.Call
+}
+
+// PushIssues is a helper method to define mock.On call
+//   - ctx
+//   - project
+//   - issues
+func (_e *MockDbPusherInterface_Expecter) PushIssues(ctx interface{}, project interface{}, issues interface{}) *MockDbPusherInterface_PushIssues_Call {
+	return &MockDbPusherInterface_PushIssues_Call{Call: _e.mock.On("PushIssues", ctx, project, issues)}
+}
+
+func (_c *MockDbPusherInterface_PushIssues_Call) Run(run func(ctx context.Context, project *structures.DBProject, issues []datatransformer.DataTransformer)) *MockDbPusherInterface_PushIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*structures.DBProject), args[2].([]datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushIssues_Call) Return(err error) *MockDbPusherInterface_PushIssues_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushIssues_Call) RunAndReturn(run func(ctx context.Context, project *structures.DBProject, issues []datatransformer.DataTransformer) error) *MockDbPusherInterface_PushIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushLabels provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushLabels(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushLabels")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushLabels'
+type MockDbPusherInterface_PushLabels_Call struct {
+	*mock.Call
+}
+
+// PushLabels is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushLabels(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushLabels_Call {
+	return &MockDbPusherInterface_PushLabels_Call{Call: _e.mock.On("PushLabels", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushLabels_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushLabels_Call) Return(err error) *MockDbPusherInterface_PushLabels_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushLabels_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushPriorities provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushPriorities(ctx context.Context, source string, priorities []structures.DBPriority) error {
+	ret := _mock.Called(ctx, source, priorities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushPriorities")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []structures.DBPriority) error); ok {
+		r0 = returnFunc(ctx, source, priorities)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-func (_c *MockDbPusherInterface_Close_Call) Run(run func()) *MockDbPusherInterface_Close_Call {
+// MockDbPusherInterface_PushPriorities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushPriorities'
+type MockDbPusherInterface_PushPriorities_Call struct {
+	*mock.Call
+}
+
+// PushPriorities is a helper method to define mock.On call
+//   - ctx
+//   - source
+//   - priorities
+func (_e *MockDbPusherInterface_Expecter) PushPriorities(ctx interface{}, source interface{}, priorities interface{}) *MockDbPusherInterface_PushPriorities_Call {
+	return &MockDbPusherInterface_PushPriorities_Call{Call: _e.mock.On("PushPriorities", ctx, source, priorities)}
+}
+
+func (_c *MockDbPusherInterface_PushPriorities_Call) Run(run func(ctx context.Context, source string, priorities []structures.DBPriority)) *MockDbPusherInterface_PushPriorities_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(string), args[2].([]structures.DBPriority))
 	})
 	return _c
 }
 
-func (_c *MockDbPusherInterface_Close_Call) Return() *MockDbPusherInterface_Close_Call {
-	_c.Call.Return()
+func (_c *MockDbPusherInterface_PushPriorities_Call) Return(err error) *MockDbPusherInterface_PushPriorities_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDbPusherInterface_Close_Call) RunAndReturn(run func()) *MockDbPusherInterface_Close_Call {
-	_c.Run(run)
+func (_c *MockDbPusherInterface_PushPriorities_Call) RunAndReturn(run func(ctx context.Context, source string, priorities []structures.DBPriority) error) *MockDbPusherInterface_PushPriorities_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// PushIssue provides a mock function for the type MockDbPusherInterface
-func (_mock *MockDbPusherInterface) PushIssue(project *structures.DBProject, issue *datatransformer.DataTransformer) (int, error) {
-	ret := _mock.Called(project, issue)
+// PushProject provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushProject(ctx context.Context, project *structures.DBProject) (int, error) {
+	ret := _mock.Called(ctx, project)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PushIssue")
+		panic("no return value specified for PushProject")
 	}
 
 	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*structures.DBProject, *datatransformer.DataTransformer) (int, error)); ok {
-		return returnFunc(project, issue)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *structures.DBProject) (int, error)); ok {
+		return returnFunc(ctx, project)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*structures.DBProject, *datatransformer.DataTransformer) int); ok {
-		r0 = returnFunc(project, issue)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *structures.DBProject) int); ok {
+		r0 = returnFunc(ctx, project)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(*structures.DBProject, *datatransformer.DataTransformer) error); ok {
-		r1 = returnFunc(project, issue)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *structures.DBProject) error); ok {
+		r1 = returnFunc(ctx, project)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDbPusherInterface_PushIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushIssue'
-type MockDbPusherInterface_PushIssue_Call struct {
+// MockDbPusherInterface_PushProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushProject'
+type MockDbPusherInterface_PushProject_Call struct {
 	*mock.Call
 }
 
-// PushIssue is a helper method to define mock.On call
+// PushProject is a helper method to define mock.On call
+//   - ctx
 //   - project
-//   - issue
-func (_e *MockDbPusherInterface_Expecter) PushIssue(project interface{}, issue interface{}) *MockDbPusherInterface_PushIssue_Call {
-	return &MockDbPusherInterface_PushIssue_Call{Call: _e.mock.On("PushIssue", project, issue)}
+func (_e *MockDbPusherInterface_Expecter) PushProject(ctx interface{}, project interface{}) *MockDbPusherInterface_PushProject_Call {
+	return &MockDbPusherInterface_PushProject_Call{Call: _e.mock.On("PushProject", ctx, project)}
 }
 
-func (_c *MockDbPusherInterface_PushIssue_Call) Run(run func(project *structures.DBProject, issue *datatransformer.DataTransformer)) *MockDbPusherInterface_PushIssue_Call {
+func (_c *MockDbPusherInterface_PushProject_Call) Run(run func(ctx context.Context, project *structures.DBProject)) *MockDbPusherInterface_PushProject_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*structures.DBProject), args[1].(*datatransformer.DataTransformer))
+		run(args[0].(context.Context), args[1].(*structures.DBProject))
 	})
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushIssue_Call) Return(n int, err error) *MockDbPusherInterface_PushIssue_Call {
+func (_c *MockDbPusherInterface_PushProject_Call) Return(n int, err error) *MockDbPusherInterface_PushProject_Call {
 	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushIssue_Call) RunAndReturn(run func(project *structures.DBProject, issue *datatransformer.DataTransformer) (int, error)) *MockDbPusherInterface_PushIssue_Call {
+func (_c *MockDbPusherInterface_PushProject_Call) RunAndReturn(run func(ctx context.Context, project *structures.DBProject) (int, error)) *MockDbPusherInterface_PushProject_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PushIssues provides a mock function for the type MockDbPusherInterface
-func (_mock *MockDbPusherInterface) PushIssues(project *structures.DBProject, issues []datatransformer.DataTransformer) error {
-	ret := _mock.Called(project, issues)
+// PushProjects provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushProjects(ctx context.Context, projects []structures.DBProject) error {
+	ret := _mock.Called(ctx, projects)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PushIssues")
+		panic("no return value specified for PushProjects")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*structures.DBProject, []datatransformer.DataTransformer) error); ok {
-		r0 = returnFunc(project, issues)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []structures.DBProject) error); ok {
+		r0 = returnFunc(ctx, projects)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockDbPusherInterface_PushIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushIssues'
-type MockDbPusherInterface_PushIssues_Call struct {
+// MockDbPusherInterface_PushProjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushProjects'
+type MockDbPusherInterface_PushProjects_Call struct {
 	*mock.Call
 }
 
-// PushIssues is a helper method to define mock.On call
+// PushProjects is a helper method to define mock.On call
+//   - ctx
+//   - projects
+func (_e *MockDbPusherInterface_Expecter) PushProjects(ctx interface{}, projects interface{}) *MockDbPusherInterface_PushProjects_Call {
+	return &MockDbPusherInterface_PushProjects_Call{Call: _e.mock.On("PushProjects", ctx, projects)}
+}
+
+func (_c *MockDbPusherInterface_PushProjects_Call) Run(run func(ctx context.Context, projects []structures.DBProject)) *MockDbPusherInterface_PushProjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]structures.DBProject))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushProjects_Call) Return(err error) *MockDbPusherInterface_PushProjects_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushProjects_Call) RunAndReturn(run func(ctx context.Context, projects []structures.DBProject) error) *MockDbPusherInterface_PushProjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushStatusChanges provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushStatusChanges(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushStatusChanges")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushStatusChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushStatusChanges'
+type MockDbPusherInterface_PushStatusChanges_Call struct {
+	*mock.Call
+}
+
+// PushStatusChanges is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushStatusChanges(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushStatusChanges_Call {
+	return &MockDbPusherInterface_PushStatusChanges_Call{Call: _e.mock.On("PushStatusChanges", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushStatusChanges_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushStatusChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushStatusChanges_Call) Return(err error) *MockDbPusherInterface_PushStatusChanges_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushStatusChanges_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushStatusChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushSyncWatermark provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushSyncWatermark(ctx context.Context, project structures.ProjectRef, watermark time.Time) error {
+	ret := _mock.Called(ctx, project, watermark)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushSyncWatermark")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef, time.Time) error); ok {
+		r0 = returnFunc(ctx, project, watermark)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushSyncWatermark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushSyncWatermark'
+type MockDbPusherInterface_PushSyncWatermark_Call struct {
+	*mock.Call
+}
+
+// PushSyncWatermark is a helper method to define mock.On call
+//   - ctx
+//   - project
+//   - watermark
+func (_e *MockDbPusherInterface_Expecter) PushSyncWatermark(ctx interface{}, project interface{}, watermark interface{}) *MockDbPusherInterface_PushSyncWatermark_Call {
+	return &MockDbPusherInterface_PushSyncWatermark_Call{Call: _e.mock.On("PushSyncWatermark", ctx, project, watermark)}
+}
+
+func (_c *MockDbPusherInterface_PushSyncWatermark_Call) Run(run func(ctx context.Context, project structures.ProjectRef, watermark time.Time)) *MockDbPusherInterface_PushSyncWatermark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(structures.ProjectRef), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushSyncWatermark_Call) Return(err error) *MockDbPusherInterface_PushSyncWatermark_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushSyncWatermark_Call) RunAndReturn(run func(ctx context.Context, project structures.ProjectRef, watermark time.Time) error) *MockDbPusherInterface_PushSyncWatermark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushWorklogs provides a mock function for the type MockDbPusherInterface
+func (_mock *MockDbPusherInterface) PushWorklogs(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	ret := _mock.Called(ctx, issue, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushWorklogs")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *datatransformer.DataTransformer) error); ok {
+		r0 = returnFunc(ctx, issue, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDbPusherInterface_PushWorklogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushWorklogs'
+type MockDbPusherInterface_PushWorklogs_Call struct {
+	*mock.Call
+}
+
+// PushWorklogs is a helper method to define mock.On call
+//   - ctx
+//   - issue
+//   - changes
+func (_e *MockDbPusherInterface_Expecter) PushWorklogs(ctx interface{}, issue interface{}, changes interface{}) *MockDbPusherInterface_PushWorklogs_Call {
+	return &MockDbPusherInterface_PushWorklogs_Call{Call: _e.mock.On("PushWorklogs", ctx, issue, changes)}
+}
+
+func (_c *MockDbPusherInterface_PushWorklogs_Call) Run(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushWorklogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*datatransformer.DataTransformer))
+	})
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushWorklogs_Call) Return(err error) *MockDbPusherInterface_PushWorklogs_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDbPusherInterface_PushWorklogs_Call) RunAndReturn(run func(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushWorklogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockArchiveInterface creates a new instance of MockArchiveInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockArchiveInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockArchiveInterface {
+	mock := &MockArchiveInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockArchiveInterface is an autogenerated mock type for the ArchiveInterface type
+type MockArchiveInterface struct {
+	mock.Mock
+}
+
+type MockArchiveInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockArchiveInterface) EXPECT() *MockArchiveInterface_Expecter {
+	return &MockArchiveInterface_Expecter{mock: &_m.Mock}
+}
+
+// ArchiveIssues provides a mock function for the type MockArchiveInterface
+func (_mock *MockArchiveInterface) ArchiveIssues(ctx context.Context, project structures.ProjectRef, prj *structures.JiraProject, fields map[string]string, issues []structures.JiraIssue) error {
+	ret := _mock.Called(ctx, project, prj, fields, issues)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveIssues")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef, *structures.JiraProject, map[string]string, []structures.JiraIssue) error); ok {
+		r0 = returnFunc(ctx, project, prj, fields, issues)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockArchiveInterface_ArchiveIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveIssues'
+type MockArchiveInterface_ArchiveIssues_Call struct {
+	*mock.Call
+}
+
+// ArchiveIssues is a helper method to define mock.On call
+//   - ctx
 //   - project
+//   - prj
+//   - fields
 //   - issues
-func (_e *MockDbPusherInterface_Expecter) PushIssues(project interface{}, issues interface{}) *MockDbPusherInterface_PushIssues_Call {
-	return &MockDbPusherInterface_PushIssues_Call{Call: _e.mock.On("PushIssues", project, issues)}
+func (_e *MockArchiveInterface_Expecter) ArchiveIssues(ctx interface{}, project interface{}, prj interface{}, fields interface{}, issues interface{}) *MockArchiveInterface_ArchiveIssues_Call {
+	return &MockArchiveInterface_ArchiveIssues_Call{Call: _e.mock.On("ArchiveIssues", ctx, project, prj, fields, issues)}
 }
 
-func (_c *MockDbPusherInterface_PushIssues_Call) Run(run func(project *structures.DBProject, issues []datatransformer.DataTransformer)) *MockDbPusherInterface_PushIssues_Call {
+func (_c *MockArchiveInterface_ArchiveIssues_Call) Run(run func(ctx context.Context, project structures.ProjectRef, prj *structures.JiraProject, fields map[string]string, issues []structures.JiraIssue)) *MockArchiveInterface_ArchiveIssues_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*structures.DBProject), args[1].([]datatransformer.DataTransformer))
+		run(args[0].(context.Context), args[1].(structures.ProjectRef), args[2].(*structures.JiraProject), args[3].(map[string]string), args[4].([]structures.JiraIssue))
 	})
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushIssues_Call) Return(err error) *MockDbPusherInterface_PushIssues_Call {
+func (_c *MockArchiveInterface_ArchiveIssues_Call) Return(err error) *MockArchiveInterface_ArchiveIssues_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushIssues_Call) RunAndReturn(run func(project *structures.DBProject, issues []datatransformer.DataTransformer) error) *MockDbPusherInterface_PushIssues_Call {
+func (_c *MockArchiveInterface_ArchiveIssues_Call) RunAndReturn(run func(ctx context.Context, project structures.ProjectRef, prj *structures.JiraProject, fields map[string]string, issues []structures.JiraIssue) error) *MockArchiveInterface_ArchiveIssues_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PushProject provides a mock function for the type MockDbPusherInterface
-func (_mock *MockDbPusherInterface) PushProject(project *structures.DBProject) (int, error) {
-	ret := _mock.Called(project)
+// ArchivedProjects provides a mock function for the type MockArchiveInterface
+func (_mock *MockArchiveInterface) ArchivedProjects(ctx context.Context) ([]structures.ProjectRef, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PushProject")
+		panic("no return value specified for ArchivedProjects")
 	}
 
-	var r0 int
+	var r0 []structures.ProjectRef
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*structures.DBProject) (int, error)); ok {
-		return returnFunc(project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]structures.ProjectRef, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*structures.DBProject) int); ok {
-		r0 = returnFunc(project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []structures.ProjectRef); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]structures.ProjectRef)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*structures.DBProject) error); ok {
-		r1 = returnFunc(project)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDbPusherInterface_PushProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushProject'
-type MockDbPusherInterface_PushProject_Call struct {
+// MockArchiveInterface_ArchivedProjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchivedProjects'
+type MockArchiveInterface_ArchivedProjects_Call struct {
 	*mock.Call
 }
 
-// PushProject is a helper method to define mock.On call
+// ArchivedProjects is a helper method to define mock.On call
+//   - ctx
+func (_e *MockArchiveInterface_Expecter) ArchivedProjects(ctx interface{}) *MockArchiveInterface_ArchivedProjects_Call {
+	return &MockArchiveInterface_ArchivedProjects_Call{Call: _e.mock.On("ArchivedProjects", ctx)}
+}
+
+func (_c *MockArchiveInterface_ArchivedProjects_Call) Run(run func(ctx context.Context)) *MockArchiveInterface_ArchivedProjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockArchiveInterface_ArchivedProjects_Call) Return(projectRefs []structures.ProjectRef, err error) *MockArchiveInterface_ArchivedProjects_Call {
+	_c.Call.Return(projectRefs, err)
+	return _c
+}
+
+func (_c *MockArchiveInterface_ArchivedProjects_Call) RunAndReturn(run func(ctx context.Context) ([]structures.ProjectRef, error)) *MockArchiveInterface_ArchivedProjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasArchive provides a mock function for the type MockArchiveInterface
+func (_mock *MockArchiveInterface) HasArchive(ctx context.Context, project structures.ProjectRef) (bool, error) {
+	ret := _mock.Called(ctx, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasArchive")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef) (bool, error)); ok {
+		return returnFunc(ctx, project)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef) bool); ok {
+		r0 = returnFunc(ctx, project)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, structures.ProjectRef) error); ok {
+		r1 = returnFunc(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockArchiveInterface_HasArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasArchive'
+type MockArchiveInterface_HasArchive_Call struct {
+	*mock.Call
+}
+
+// HasArchive is a helper method to define mock.On call
+//   - ctx
 //   - project
-func (_e *MockDbPusherInterface_Expecter) PushProject(project interface{}) *MockDbPusherInterface_PushProject_Call {
-	return &MockDbPusherInterface_PushProject_Call{Call: _e.mock.On("PushProject", project)}
+func (_e *MockArchiveInterface_Expecter) HasArchive(ctx interface{}, project interface{}) *MockArchiveInterface_HasArchive_Call {
+	return &MockArchiveInterface_HasArchive_Call{Call: _e.mock.On("HasArchive", ctx, project)}
 }
 
-func (_c *MockDbPusherInterface_PushProject_Call) Run(run func(project *structures.DBProject)) *MockDbPusherInterface_PushProject_Call {
+func (_c *MockArchiveInterface_HasArchive_Call) Run(run func(ctx context.Context, project structures.ProjectRef)) *MockArchiveInterface_HasArchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*structures.DBProject))
+		run(args[0].(context.Context), args[1].(structures.ProjectRef))
 	})
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushProject_Call) Return(n int, err error) *MockDbPusherInterface_PushProject_Call {
-	_c.Call.Return(n, err)
+func (_c *MockArchiveInterface_HasArchive_Call) Return(b bool, err error) *MockArchiveInterface_HasArchive_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushProject_Call) RunAndReturn(run func(project *structures.DBProject) (int, error)) *MockDbPusherInterface_PushProject_Call {
+func (_c *MockArchiveInterface_HasArchive_Call) RunAndReturn(run func(ctx context.Context, project structures.ProjectRef) (bool, error)) *MockArchiveInterface_HasArchive_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PushProjects provides a mock function for the type MockDbPusherInterface
-func (_mock *MockDbPusherInterface) PushProjects(projects []structures.DBProject) error {
-	ret := _mock.Called(projects)
+// LoadArchive provides a mock function for the type MockArchiveInterface
+func (_mock *MockArchiveInterface) LoadArchive(ctx context.Context, project structures.ProjectRef) (*structures.ArchivedProject, error) {
+	ret := _mock.Called(ctx, project)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PushProjects")
+		panic("no return value specified for LoadArchive")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func([]structures.DBProject) error); ok {
-		r0 = returnFunc(projects)
+	var r0 *structures.ArchivedProject
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef) (*structures.ArchivedProject, error)); ok {
+		return returnFunc(ctx, project)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef) *structures.ArchivedProject); ok {
+		r0 = returnFunc(ctx, project)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structures.ArchivedProject)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, structures.ProjectRef) error); ok {
+		r1 = returnFunc(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDbPusherInterface_PushProjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushProjects'
-type MockDbPusherInterface_PushProjects_Call struct {
+// MockArchiveInterface_LoadArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadArchive'
+type MockArchiveInterface_LoadArchive_Call struct {
 	*mock.Call
 }
 
-// PushProjects is a helper method to define mock.On call
-//   - projects
-func (_e *MockDbPusherInterface_Expecter) PushProjects(projects interface{}) *MockDbPusherInterface_PushProjects_Call {
-	return &MockDbPusherInterface_PushProjects_Call{Call: _e.mock.On("PushProjects", projects)}
+// LoadArchive is a helper method to define mock.On call
+//   - ctx
+//   - project
+func (_e *MockArchiveInterface_Expecter) LoadArchive(ctx interface{}, project interface{}) *MockArchiveInterface_LoadArchive_Call {
+	return &MockArchiveInterface_LoadArchive_Call{Call: _e.mock.On("LoadArchive", ctx, project)}
 }
 
-func (_c *MockDbPusherInterface_PushProjects_Call) Run(run func(projects []structures.DBProject)) *MockDbPusherInterface_PushProjects_Call {
+func (_c *MockArchiveInterface_LoadArchive_Call) Run(run func(ctx context.Context, project structures.ProjectRef)) *MockArchiveInterface_LoadArchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]structures.DBProject))
+		run(args[0].(context.Context), args[1].(structures.ProjectRef))
 	})
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushProjects_Call) Return(err error) *MockDbPusherInterface_PushProjects_Call {
-	_c.Call.Return(err)
+func (_c *MockArchiveInterface_LoadArchive_Call) Return(archivedProject *structures.ArchivedProject, err error) *MockArchiveInterface_LoadArchive_Call {
+	_c.Call.Return(archivedProject, err)
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushProjects_Call) RunAndReturn(run func(projects []structures.DBProject) error) *MockDbPusherInterface_PushProjects_Call {
+func (_c *MockArchiveInterface_LoadArchive_Call) RunAndReturn(run func(ctx context.Context, project structures.ProjectRef) (*structures.ArchivedProject, error)) *MockArchiveInterface_LoadArchive_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PushStatusChanges provides a mock function for the type MockDbPusherInterface
-func (_mock *MockDbPusherInterface) PushStatusChanges(issue int, changes *datatransformer.DataTransformer) error {
-	ret := _mock.Called(issue, changes)
+// PruneArchive provides a mock function for the type MockArchiveInterface
+func (_mock *MockArchiveInterface) PruneArchive(ctx context.Context, project structures.ProjectRef, keys []string) error {
+	ret := _mock.Called(ctx, project, keys)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PushStatusChanges")
+		panic("no return value specified for PruneArchive")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int, *datatransformer.DataTransformer) error); ok {
-		r0 = returnFunc(issue, changes)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, structures.ProjectRef, []string) error); ok {
+		r0 = returnFunc(ctx, project, keys)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockDbPusherInterface_PushStatusChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushStatusChanges'
-type MockDbPusherInterface_PushStatusChanges_Call struct {
+// MockArchiveInterface_PruneArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneArchive'
+type MockArchiveInterface_PruneArchive_Call struct {
 	*mock.Call
 }
 
-// PushStatusChanges is a helper method to define mock.On call
-//   - issue
-//   - changes
-func (_e *MockDbPusherInterface_Expecter) PushStatusChanges(issue interface{}, changes interface{}) *MockDbPusherInterface_PushStatusChanges_Call {
-	return &MockDbPusherInterface_PushStatusChanges_Call{Call: _e.mock.On("PushStatusChanges", issue, changes)}
+// PruneArchive is a helper method to define mock.On call
+//   - ctx
+//   - project
+//   - keys
+func (_e *MockArchiveInterface_Expecter) PruneArchive(ctx interface{}, project interface{}, keys interface{}) *MockArchiveInterface_PruneArchive_Call {
+	return &MockArchiveInterface_PruneArchive_Call{Call: _e.mock.On("PruneArchive", ctx, project, keys)}
 }
 
-func (_c *MockDbPusherInterface_PushStatusChanges_Call) Run(run func(issue int, changes *datatransformer.DataTransformer)) *MockDbPusherInterface_PushStatusChanges_Call {
+func (_c *MockArchiveInterface_PruneArchive_Call) Run(run func(ctx context.Context, project structures.ProjectRef, keys []string)) *MockArchiveInterface_PruneArchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int), args[1].(*datatransformer.DataTransformer))
+		run(args[0].(context.Context), args[1].(structures.ProjectRef), args[2].([]string))
 	})
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushStatusChanges_Call) Return(err error) *MockDbPusherInterface_PushStatusChanges_Call {
+func (_c *MockArchiveInterface_PruneArchive_Call) Return(err error) *MockArchiveInterface_PruneArchive_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDbPusherInterface_PushStatusChanges_Call) RunAndReturn(run func(issue int, changes *datatransformer.DataTransformer) error) *MockDbPusherInterface_PushStatusChanges_Call {
+func (_c *MockArchiveInterface_PruneArchive_Call) RunAndReturn(run func(ctx context.Context, project structures.ProjectRef, keys []string) error) *MockArchiveInterface_PruneArchive_Call {
 	_c.Call.Return(run)
 	return _c
 }
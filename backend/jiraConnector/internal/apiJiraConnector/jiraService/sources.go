@@ -0,0 +1,95 @@
+package jiraservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	myErr "github.com/jiraconnector/internal/apiJiraConnector/jiraService/errors"
+	"github.com/jiraconnector/internal/structures"
+)
+
+// Sources routes requests to the JiraService of the requested Jira instance.
+// Empty source means the default one
+type Sources struct {
+	services      map[string]*JiraService
+	defaultSource string
+	log           *slog.Logger
+}
+
+func NewSources(services map[string]*JiraService, defaultSource string, log *slog.Logger) *Sources {
+	return &Sources{
+		services:      services,
+		defaultSource: defaultSource,
+		log:           log,
+	}
+}
+
+// ResolveSource returns the name of the source which serves requests with the given source
+func (s *Sources) ResolveSource(source string) (string, error) {
+	if source == "" {
+		if s.defaultSource == "" {
+			return "", myErr.ErrSourceRequired
+		}
+		source = s.defaultSource
+	}
+
+	if _, ok := s.services[source]; !ok {
+		return "", fmt.Errorf("%w - %s, known: %s", myErr.ErrUnknownSource, source, strings.Join(s.Names(), ", "))
+	}
+	return source, nil
+}
+
+func (s *Sources) Names() []string {
+	names := make([]string, 0, len(s.services))
+	for name := range s.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Sources) service(source string) (*JiraService, error) {
+	name, err := s.ResolveSource(source)
+	if err != nil {
+		s.log.Error(err.Error())
+		return nil, err
+	}
+	return s.services[name], nil
+}
+
+func (s *Sources) GetProjectsPage(ctx context.Context, source, search string, limit, page int) (*structures.ResponseProject, error) {
+	service, err := s.service(source)
+	if err != nil {
+		return nil, err
+	}
+	return service.GetProjectsPage(ctx, search, limit, page)
+}
+
+func (s *Sources) GetProjectByKey(ctx context.Context, source, projectKey string) (*structures.JiraProject, error) {
+	service, err := s.service(source)
+	if err != nil {
+		return nil, err
+	}
+	return service.GetProjectByKey(ctx, projectKey)
+}
+
+// SyncProject is run by the job queue, the job keeps the resolved source
+func (s *Sources) SyncProject(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, progress structures.ProgressFunc) error {
+	service, err := s.service(project.Source)
+	if err != nil {
+		return err
+	}
+	return service.SyncProject(ctx, project.Key, mode, progress)
+}
+
+// HasArchive reports whether the project of the source can be retransformed
+func (s *Sources) HasArchive(ctx context.Context, source, projectKey string) (bool, error) {
+	service, err := s.service(source)
+	if err != nil {
+		return false, err
+	}
+	return service.HasArchive(ctx, projectKey)
+}
@@ -0,0 +1,98 @@
+package jiraservice
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	myErr "github.com/jiraconnector/internal/apiJiraConnector/jiraService/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSources(defaultSource string) (*Sources, map[string]*MockJiraConnectorInterface) {
+	connectors := map[string]*MockJiraConnectorInterface{
+		"onprem": new(MockJiraConnectorInterface),
+		"cloud":  new(MockJiraConnectorInterface),
+	}
+
+	services := map[string]*JiraService{}
+	for name, con := range connectors {
+		services[name] = &JiraService{source: name, jiraConnector: con, log: slog.Default()}
+	}
+	return NewSources(services, defaultSource, slog.Default()), connectors
+}
+
+func TestSources_ResolveSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultSource string
+		source        string
+		expected      string
+		expectedErr   error
+	}{
+		{name: "explicit source", defaultSource: "onprem", source: "cloud", expected: "cloud"},
+		{name: "default source", defaultSource: "onprem", source: "", expected: "onprem"},
+		{name: "unknown source", defaultSource: "onprem", source: "other", expectedErr: myErr.ErrUnknownSource},
+		{name: "no default", defaultSource: "", source: "", expectedErr: myErr.ErrSourceRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources, _ := newTestSources(tt.defaultSource)
+
+			result, err := sources.ResolveSource(tt.source)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSources_RoutesToSource(t *testing.T) {
+	sources, connectors := newTestSources("onprem")
+
+	connectors["cloud"].On("GetProjectByKey", mock.Anything, "PRJ").Return(&structures.JiraProject{Key: "PRJ", Name: "cloud"}, nil)
+	connectors["onprem"].On("GetProjectByKey", mock.Anything, "PRJ").Return(&structures.JiraProject{Key: "PRJ", Name: "onprem"}, nil)
+	connectors["cloud"].On("GetProjectsPage", mock.Anything, "", 10, 1).Return(&structures.ResponseProject{}, nil)
+
+	project, err := sources.GetProjectByKey(context.Background(), "cloud", "PRJ")
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud", project.Name)
+
+	project, err = sources.GetProjectByKey(context.Background(), "", "PRJ")
+	assert.NoError(t, err)
+	assert.Equal(t, "onprem", project.Name)
+
+	page, err := sources.GetProjectsPage(context.Background(), "cloud", "", 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud", page.Source)
+
+	for _, con := range connectors {
+		con.AssertExpectations(t)
+	}
+}
+
+func TestSources_SyncUnknownSource(t *testing.T) {
+	sources, _ := newTestSources("onprem")
+
+	err := sources.SyncProject(context.Background(), structures.ProjectRef{Source: "removed", Key: "PRJ"}, structures.SyncFull, nil)
+	assert.ErrorIs(t, err, myErr.ErrUnknownSource)
+}
+
+func TestSources_HasArchive(t *testing.T) {
+	sources, _ := newTestSources("onprem")
+	archive := new(MockArchiveInterface)
+	sources.services["cloud"].archive = archive
+
+	archive.On("HasArchive", mock.Anything, structures.ProjectRef{Source: "cloud", Key: "PRJ"}).Return(true, nil)
+
+	exists, err := sources.HasArchive(context.Background(), "cloud", "PRJ")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = sources.HasArchive(context.Background(), "", "PRJ")
+	assert.ErrorIs(t, err, myErr.ErrArchiveOff)
+
+	archive.AssertExpectations(t)
+}
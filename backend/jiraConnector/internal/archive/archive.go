@@ -0,0 +1,92 @@
+// Package archive keeps raw issues of Jira, so the normalized tables can be
+// rebuilt after a fix of the data transformer without downloading them again.
+// Payloads are gzip compressed JSON, an issue is kept as it came from Jira.
+// They are stored in Postgres by DbPusher or in a local directory by DirArchive
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	myerr "github.com/jiraconnector/internal/archive/errors"
+	"github.com/jiraconnector/internal/structures"
+)
+
+// storages of the archive config
+const (
+	StoragePostgres = "postgres"
+	StorageDir      = "dir"
+)
+
+// Encode returns v as gzip compressed JSON
+func Encode(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", myerr.ErrEncode, err)
+	}
+	return compress(raw)
+}
+
+// Decode reads gzip compressed JSON of Encode into v
+func Decode(data []byte, v any) error {
+	raw, err := decompress(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: %w", myerr.ErrDecode, err)
+	}
+	return nil
+}
+
+// EncodeIssue compresses the JSON of the issue received from Jira without changes,
+// the struct isn't marshaled, so fields unknown to it are kept
+func EncodeIssue(issue *structures.JiraIssue) ([]byte, error) {
+	if len(issue.Raw) == 0 {
+		return nil, fmt.Errorf("%w - %s", myerr.ErrNoRaw, issue.Key)
+	}
+	return compress(issue.Raw)
+}
+
+// DecodeIssue reads the issue of EncodeIssue, Raw is set to the archived JSON
+func DecodeIssue(data []byte) (structures.JiraIssue, error) {
+	var issue structures.JiraIssue
+	raw, err := decompress(data)
+	if err != nil {
+		return issue, err
+	}
+	if err := json.Unmarshal(raw, &issue); err != nil {
+		return issue, fmt.Errorf("%w: %w", myerr.ErrDecode, err)
+	}
+	issue.Raw = raw
+	return issue, nil
+}
+
+func compress(raw []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", myerr.ErrEncode, err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("%w: %w", myerr.ErrEncode, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", myerr.ErrDecode, err)
+	}
+	defer zr.Close()
+
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", myerr.ErrDecode, err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,67 @@
+package archive
+
+import (
+	"encoding/json"
+	"testing"
+
+	myerr "github.com/jiraconnector/internal/archive/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	// custom fields are kept only by MarshalJSON of Field
+	var issue structures.JiraIssue
+	err := json.Unmarshal([]byte(`{
+		"id": "10001",
+		"key": "PRJ-1",
+		"fields": {
+			"summary": "Issue",
+			"description": "text",
+			"labels": ["backend"],
+			"customfield_10010": {"value": "Team A"},
+			"customfield_10020": 5
+		}
+	}`), &issue)
+	assert.NoError(t, err)
+
+	data, err := Encode(issue)
+	assert.NoError(t, err)
+
+	var decoded structures.JiraIssue
+	assert.NoError(t, Decode(data, &decoded))
+	assert.Equal(t, issue.Key, decoded.Key)
+	assert.Equal(t, issue.Fields.Summary, decoded.Fields.Summary)
+	assert.Equal(t, issue.Fields.Labels, decoded.Fields.Labels)
+	assert.JSONEq(t, `{"value": "Team A"}`, string(decoded.Fields.Custom["customfield_10010"]))
+	assert.JSONEq(t, `5`, string(decoded.Fields.Custom["customfield_10020"]))
+}
+
+func TestEncodeIssue(t *testing.T) {
+	// the JSON of Jira is archived byte for byte, not the struct
+	raw := json.RawMessage(`{"id": "10001", "key": "PRJ-1",
+		"fields": {"summary": "Issue", "customfield_10010": {"value": "Team A"}},
+		"renderedFields": {"summary": "<p>Issue</p>"}}`)
+	var issue structures.JiraIssue
+	assert.NoError(t, json.Unmarshal(raw, &issue))
+	issue.Raw = raw
+
+	data, err := EncodeIssue(&issue)
+	assert.NoError(t, err)
+
+	decoded, err := DecodeIssue(data)
+	assert.NoError(t, err)
+	assert.Equal(t, raw, decoded.Raw)
+	assert.Equal(t, "PRJ-1", decoded.Key)
+	assert.Equal(t, "Issue", decoded.Fields.Summary)
+	assert.JSONEq(t, `{"value": "Team A"}`, string(decoded.Fields.Custom["customfield_10010"]))
+
+	_, err = EncodeIssue(&structures.JiraIssue{Key: "PRJ-2"})
+	assert.ErrorIs(t, err, myerr.ErrNoRaw)
+}
+
+func TestDecode_Broken(t *testing.T) {
+	var issue structures.JiraIssue
+	err := Decode([]byte(`{"key": "PRJ-1"}`), &issue)
+	assert.ErrorIs(t, err, myerr.ErrDecode)
+}
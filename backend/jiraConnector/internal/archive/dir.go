@@ -0,0 +1,270 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	myerr "github.com/jiraconnector/internal/archive/errors"
+	"github.com/jiraconnector/internal/structures"
+)
+
+const (
+	projectFile = "project.json.gz"
+	issuesDir   = "issues"
+	issueExt    = ".json.gz"
+)
+
+// DirArchive keeps the archive in a local directory: dir/source/KEY/project.json.gz
+// and dir/source/KEY/issues/ISSUE-KEY.json.gz, one file with the latest version
+// of every issue. Files are replaced by rename, so a reader never sees a half written one
+type DirArchive struct {
+	dir string
+	log *slog.Logger
+}
+
+func NewDirArchive(dir string, log *slog.Logger) (*DirArchive, error) {
+	if dir == "" {
+		return nil, myerr.ErrNoDir
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrWrite, dir, err)
+		log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	return &DirArchive{dir: dir, log: log}, nil
+}
+
+func (a *DirArchive) ArchiveIssues(ctx context.Context, project structures.ProjectRef, prj *structures.JiraProject, fields map[string]string, issues []structures.JiraIssue) error {
+	projectDir, err := a.projectDir(project)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(projectDir, issuesDir), 0o755); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrWrite, project, err)
+		a.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	snapshot, err := Encode(structures.ArchivedProject{Project: *prj, Fields: fields})
+	if err != nil {
+		a.log.Error(err.Error())
+		return err
+	}
+	if err := a.writeFile(filepath.Join(projectDir, projectFile), snapshot); err != nil {
+		return err
+	}
+
+	for i := range issues {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !safeName(issues[i].Key) {
+			ansErr := fmt.Errorf("%w - %s", myerr.ErrName, issues[i].Key)
+			a.log.Error(ansErr.Error())
+			return ansErr
+		}
+		payload, err := EncodeIssue(&issues[i])
+		if err != nil {
+			a.log.Error(err.Error())
+			return err
+		}
+		if err := a.writeFile(filepath.Join(projectDir, issuesDir, issues[i].Key+issueExt), payload); err != nil {
+			return err
+		}
+	}
+
+	a.log.Info("success archive issues", "project", project.String(), "count", len(issues))
+	return nil
+}
+
+// PruneArchive removes archived issues of the project missing from keys
+func (a *DirArchive) PruneArchive(ctx context.Context, project structures.ProjectRef, keys []string) error {
+	projectDir, err := a.projectDir(project)
+	if err != nil {
+		return err
+	}
+
+	files, err := a.issueFiles(projectDir)
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrRead, project, err)
+		a.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	keep := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		keep[key] = true
+	}
+
+	removed := 0
+	for _, file := range files {
+		if keep[strings.TrimSuffix(file, issueExt)] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(projectDir, issuesDir, file)); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrWrite, project, err)
+			a.log.Error(ansErr.Error())
+			return ansErr
+		}
+		removed++
+	}
+
+	a.log.Info("success prune archive", "project", project.String(), "count", removed)
+	return nil
+}
+
+func (a *DirArchive) LoadArchive(ctx context.Context, project structures.ProjectRef) (*structures.ArchivedProject, error) {
+	projectDir, err := a.projectDir(project)
+	if err != nil {
+		return nil, err
+	}
+
+	var archived structures.ArchivedProject
+	data, err := os.ReadFile(filepath.Join(projectDir, projectFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w - %s", myerr.ErrNotArchived, project)
+	}
+	if err == nil {
+		err = Decode(data, &archived)
+	}
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrRead, project, err)
+		a.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	files, err := a.issueFiles(projectDir)
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrRead, project, err)
+		a.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	archived.Issues = make([]structures.JiraIssue, len(files))
+	for i, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(filepath.Join(projectDir, issuesDir, file))
+		if err == nil {
+			archived.Issues[i], err = DecodeIssue(data)
+		}
+		if err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrRead, file, err)
+			a.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+	}
+
+	a.log.Info("success load archive", "project", project.String(), "count", len(archived.Issues))
+	return &archived, nil
+}
+
+func (a *DirArchive) HasArchive(ctx context.Context, project structures.ProjectRef) (bool, error) {
+	projectDir, err := a.projectDir(project)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(filepath.Join(projectDir, projectFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrRead, project, err)
+		a.log.Error(ansErr.Error())
+		return false, ansErr
+	}
+	return true, nil
+}
+
+func (a *DirArchive) ArchivedProjects(ctx context.Context) ([]structures.ProjectRef, error) {
+	matches, err := filepath.Glob(filepath.Join(a.dir, "*", "*", projectFile))
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrRead, err)
+		a.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	projects := []structures.ProjectRef{}
+	for _, match := range matches {
+		projectDir := filepath.Dir(match)
+		projects = append(projects, structures.ProjectRef{
+			Source: filepath.Base(filepath.Dir(projectDir)),
+			Key:    filepath.Base(projectDir),
+		})
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].String() < projects[j].String() })
+	return projects, nil
+}
+
+func (a *DirArchive) projectDir(project structures.ProjectRef) (string, error) {
+	if !safeName(project.Source) || !safeName(project.Key) {
+		ansErr := fmt.Errorf("%w - %s", myerr.ErrName, project)
+		a.log.Error(ansErr.Error())
+		return "", ansErr
+	}
+	return filepath.Join(a.dir, project.Source, project.Key), nil
+}
+
+// issueFiles returns file names of the archived issues sorted by name
+func (a *DirArchive) issueFiles(projectDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(projectDir, issuesDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), issueExt) {
+			files = append(files, entry.Name())
+		}
+	}
+	return files, nil
+}
+
+func (a *DirArchive) writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrWrite, path, err)
+		a.log.Error(ansErr.Error())
+		return ansErr
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrWrite, path, err)
+		a.log.Error(ansErr.Error())
+		return ansErr
+	}
+	if err := tmp.Close(); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrWrite, path, err)
+		a.log.Error(ansErr.Error())
+		return ansErr
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrWrite, path, err)
+		a.log.Error(ansErr.Error())
+		return ansErr
+	}
+	return nil
+}
+
+// safeName rejects names which would leave the directory of the archive
+func safeName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
@@ -0,0 +1,99 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	myerr "github.com/jiraconnector/internal/archive/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirArchive(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a, err := NewDirArchive(dir, slog.Default())
+	assert.NoError(t, err)
+
+	prj := structures.ProjectRef{Source: "default", Key: "PRJ"}
+	jiraPrj := &structures.JiraProject{Id: "1", Key: "PRJ", Name: "Project"}
+	fields := map[string]string{"Team": "customfield_10010"}
+
+	exists, err := a.HasArchive(ctx, prj)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = a.LoadArchive(ctx, prj)
+	assert.ErrorIs(t, err, myerr.ErrNotArchived)
+
+	err = a.ArchiveIssues(ctx, prj, jiraPrj, fields, []structures.JiraIssue{
+		{Id: "10001", Key: "PRJ-1", Raw: json.RawMessage(`{"id":"10001","key":"PRJ-1","fields":{"summary":"old"}}`)},
+		{Id: "10002", Key: "PRJ-2", Raw: json.RawMessage(`{"id":"10002","key":"PRJ-2"}`)},
+	})
+	assert.NoError(t, err)
+
+	// an incremental sync replaces only updated issues
+	updated := json.RawMessage(`{"id":"10001","key":"PRJ-1","fields":{"summary":"new"},"renderedFields":{}}`)
+	err = a.ArchiveIssues(ctx, prj, jiraPrj, fields, []structures.JiraIssue{
+		{Id: "10001", Key: "PRJ-1", Raw: updated},
+	})
+	assert.NoError(t, err)
+
+	exists, err = a.HasArchive(ctx, prj)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	archived, err := a.LoadArchive(ctx, prj)
+	assert.NoError(t, err)
+	assert.Equal(t, *jiraPrj, archived.Project)
+	assert.Equal(t, fields, archived.Fields)
+	if assert.Len(t, archived.Issues, 2) {
+		assert.Equal(t, "PRJ-1", archived.Issues[0].Key)
+		assert.Equal(t, "new", archived.Issues[0].Fields.Summary)
+		assert.Equal(t, updated, archived.Issues[0].Raw)
+		assert.Equal(t, "PRJ-2", archived.Issues[1].Key)
+	}
+
+	assert.NoError(t, a.PruneArchive(ctx, prj, []string{"PRJ-2"}))
+	archived, err = a.LoadArchive(ctx, prj)
+	assert.NoError(t, err)
+	if assert.Len(t, archived.Issues, 1) {
+		assert.Equal(t, "PRJ-2", archived.Issues[0].Key)
+	}
+
+	projects, err := a.ArchivedProjects(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []structures.ProjectRef{prj}, projects)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(dir, "default", "PRJ", "issues"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestDirArchive_BadName(t *testing.T) {
+	a, err := NewDirArchive(t.TempDir(), slog.Default())
+	assert.NoError(t, err)
+
+	for _, prj := range []structures.ProjectRef{
+		{Source: "default", Key: "../PRJ"},
+		{Source: "..", Key: "PRJ"},
+		{Source: "default", Key: ""},
+	} {
+		_, err := a.HasArchive(context.Background(), prj)
+		assert.ErrorIs(t, err, myerr.ErrName, prj.String())
+	}
+
+	err = a.ArchiveIssues(context.Background(), structures.ProjectRef{Source: "default", Key: "PRJ"},
+		&structures.JiraProject{Key: "PRJ"}, nil, []structures.JiraIssue{{Key: "PRJ/1"}})
+	assert.ErrorIs(t, err, myerr.ErrName)
+}
+
+func TestNewDirArchive_NoDir(t *testing.T) {
+	_, err := NewDirArchive("", slog.Default())
+	assert.ErrorIs(t, err, myerr.ErrNoDir)
+}
@@ -0,0 +1,15 @@
+package errors
+
+import "errors"
+
+var (
+	ErrStorage     = errors.New("incorrect archive storage - need postgres or dir")
+	ErrNoDir       = errors.New("archive dir is required for dir storage")
+	ErrName        = errors.New("project or issue key can't be used as a file name")
+	ErrEncode      = errors.New("can't encode archived data")
+	ErrDecode      = errors.New("can't decode archived data")
+	ErrNoRaw       = errors.New("issue has no raw JSON of Jira")
+	ErrWrite       = errors.New("can't write archive")
+	ErrRead        = errors.New("can't read archive")
+	ErrNotArchived = errors.New("project isn't in the archive")
+)
@@ -0,0 +1,129 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+
+	myErr "github.com/jiraconnector/internal/connector/errors"
+	"github.com/jiraconnector/internal/structures"
+)
+
+// max page size of the agile api
+const agilePageSize = 50
+
+// GetProjectBoards returns scrum boards of the project with their sprints and
+// keys of the sprint issues. Sprints shown on the board but created on another
+// board are skipped, so a sprint belongs to one board only
+func (con *JiraConnector) GetProjectBoards(ctx context.Context, project string) ([]structures.JiraAgileBoard, error) {
+	boards, err := con.getBoards(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]structures.JiraAgileBoard, 0, len(boards))
+	for _, board := range boards {
+		sprints, err := con.getBoardSprints(ctx, board.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		agileBoard := structures.JiraAgileBoard{Board: board}
+		for _, sprint := range sprints {
+			// old Jira Server doesn't return originBoardId
+			if sprint.OriginBoardId != 0 && sprint.OriginBoardId != board.Id {
+				continue
+			}
+
+			keys, err := con.getSprintIssueKeys(ctx, sprint.Id)
+			if err != nil {
+				return nil, err
+			}
+			agileBoard.Sprints = append(agileBoard.Sprints, structures.JiraAgileSprint{Sprint: sprint, IssueKeys: keys})
+		}
+		result = append(result, agileBoard)
+	}
+
+	con.log.Info("success get project boards", "project", project, "boards", len(result))
+	return result, nil
+}
+
+func (con *JiraConnector) getBoards(ctx context.Context, project string) ([]structures.JiraBoard, error) {
+	var boards []structures.JiraBoard
+	for {
+		var page structures.JiraBoardsPage
+		path := fmt.Sprintf("/board?projectKeyOrId=%s&type=scrum&startAt=%d&maxResults=%d",
+			url.QueryEscape(project), len(boards), agilePageSize)
+		if err := con.agileGet(ctx, path, &page); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myErr.ErrGetBoards, project, err)
+			con.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		boards = append(boards, page.Values...)
+
+		if page.IsLast || len(page.Values) == 0 {
+			return boards, nil
+		}
+	}
+}
+
+func (con *JiraConnector) getBoardSprints(ctx context.Context, board int) ([]structures.JiraSprint, error) {
+	var sprints []structures.JiraSprint
+	for {
+		var page structures.JiraSprintsPage
+		path := fmt.Sprintf("/board/%d/sprint?startAt=%d&maxResults=%d", board, len(sprints), agilePageSize)
+		if err := con.agileGet(ctx, path, &page); err != nil {
+			ansErr := fmt.Errorf("%w - board %d: %w", myErr.ErrGetSprints, board, err)
+			con.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		sprints = append(sprints, page.Values...)
+
+		if page.IsLast || len(page.Values) == 0 {
+			return sprints, nil
+		}
+	}
+}
+
+func (con *JiraConnector) getSprintIssueKeys(ctx context.Context, sprint int) ([]string, error) {
+	var keys []string
+	for {
+		var page structures.JiraIssues
+		path := fmt.Sprintf("/sprint/%d/issue?fields=key&startAt=%d&maxResults=%d", sprint, len(keys), agilePageSize)
+		if err := con.agileGet(ctx, path, &page); err != nil {
+			ansErr := fmt.Errorf("%w - sprint %d: %w", myErr.ErrGetSprintIssues, sprint, err)
+			con.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		for _, issue := range page.Issues {
+			keys = append(keys, issue.Key)
+		}
+
+		if len(page.Issues) == 0 || len(keys) >= page.Total {
+			return keys, nil
+		}
+	}
+}
+
+// agileGet requests the agile api method and decodes the response into target
+func (con *JiraConnector) agileGet(ctx context.Context, path string, target any) error {
+	url := con.cfg.Url + "/rest/agile/1.0" + path
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+	}
+	return nil
+}
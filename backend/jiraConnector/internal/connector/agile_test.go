@@ -0,0 +1,135 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlerErr "github.com/jiraconnector/internal/apiJiraConnector/jiraHandlers/errors"
+	myErr "github.com/jiraconnector/internal/connector/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func agileServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board":
+			assert.Equal(t, "TEST", query.Get("projectKeyOrId"))
+			assert.Equal(t, "scrum", query.Get("type"))
+			if query.Get("startAt") == "0" {
+				io.WriteString(w, `{"startAt":0,"isLast":false,"values":[{"id":1,"name":"Team A","type":"scrum"}]}`)
+				return
+			}
+			io.WriteString(w, `{"startAt":1,"isLast":true,"values":[{"id":2,"name":"Team B","type":"scrum"}]}`)
+
+		case "/rest/agile/1.0/board/1/sprint":
+			io.WriteString(w, `{"isLast":true,"values":[
+				{"id":10,"state":"closed","name":"A 1","startDate":"2024-01-01T09:00:00.000Z",
+				 "endDate":"2024-01-15T09:00:00.000Z","completeDate":"2024-01-15T10:00:00.000Z","originBoardId":1},
+				{"id":20,"state":"active","name":"B 1","originBoardId":2}
+			]}`)
+
+		case "/rest/agile/1.0/board/2/sprint":
+			io.WriteString(w, `{"isLast":true,"values":[{"id":20,"state":"active","name":"B 1","originBoardId":2}]}`)
+
+		case "/rest/agile/1.0/sprint/10/issue":
+			assert.Equal(t, "key", query.Get("fields"))
+			if query.Get("startAt") == "0" {
+				io.WriteString(w, `{"startAt":0,"total":3,"issues":[{"key":"TEST-1"},{"key":"TEST-2"}]}`)
+				return
+			}
+			io.WriteString(w, `{"startAt":2,"total":3,"issues":[{"key":"TEST-3"}]}`)
+
+		case "/rest/agile/1.0/sprint/20/issue":
+			io.WriteString(w, `{"startAt":0,"total":1,"issues":[{"key":"TEST-3"}]}`)
+
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetProjectBoards(t *testing.T) {
+	conn := mockConnectorWithURL(agileServer(t).URL)
+
+	boards, err := conn.GetProjectBoards(context.Background(), "TEST")
+	require.NoError(t, err)
+	require.Len(t, boards, 2)
+
+	assert.Equal(t, structures.JiraBoard{Id: 1, Name: "Team A", Type: "scrum"}, boards[0].Board)
+	// sprint of the second board is skipped on the first one
+	require.Len(t, boards[0].Sprints, 1)
+	assert.Equal(t, "A 1", boards[0].Sprints[0].Sprint.Name)
+	assert.Equal(t, "2024-01-15T10:00:00.000Z", boards[0].Sprints[0].Sprint.CompleteDate)
+	assert.Equal(t, []string{"TEST-1", "TEST-2", "TEST-3"}, boards[0].Sprints[0].IssueKeys)
+
+	require.Len(t, boards[1].Sprints, 1)
+	assert.Equal(t, 20, boards[1].Sprints[0].Sprint.Id)
+	assert.Equal(t, []string{"TEST-3"}, boards[1].Sprints[0].IssueKeys)
+}
+
+func TestGetProjectBoards_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		expectErr []error
+	}{
+		{
+			name: "agile api isn't available",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			expectErr: []error{myErr.ErrGetBoards, myErr.ErrNotFound},
+		},
+		{
+			name: "sprints error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/rest/agile/1.0/board" {
+					io.WriteString(w, `{"isLast":true,"values":[{"id":1,"type":"scrum"}]}`)
+					return
+				}
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expectErr: []error{myErr.ErrGetSprints, myErr.ErrForbidden},
+		},
+		{
+			name: "invalid sprint issues",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/rest/agile/1.0/board":
+					io.WriteString(w, `{"isLast":true,"values":[{"id":1,"type":"scrum"}]}`)
+				case "/rest/agile/1.0/board/1/sprint":
+					io.WriteString(w, `{"isLast":true,"values":[{"id":5,"originBoardId":1}]}`)
+				default:
+					fmt.Fprint(w, `{"issues":`)
+				}
+			},
+			expectErr: []error{myErr.ErrGetSprintIssues, myErr.ErrUnmarshalAns},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			conn := mockConnectorWithURL(server.URL)
+			boards, err := conn.GetProjectBoards(context.Background(), "TEST")
+			for _, expectErr := range tt.expectErr {
+				assert.ErrorIs(t, err, expectErr)
+			}
+			// agile errors aren't reported as an unknown project
+			assert.NotErrorIs(t, err, handlerErr.ErrNoProject)
+			assert.Nil(t, boards)
+		})
+	}
+}
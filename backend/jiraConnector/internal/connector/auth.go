@@ -0,0 +1,195 @@
+package connector
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dghubble/oauth1"
+	myErr "github.com/jiraconnector/internal/connector/errors"
+	"github.com/jiraconnector/pkg/config"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	authNone   = "none"
+	authBasic  = "basic"
+	authBearer = "bearer"
+	authOAuth1 = "oauth1"
+	authOAuth2 = "oauth2"
+)
+
+// newAuthTransport wraps base with the RoundTripper which authorizes every request
+func newAuthTransport(cfg *config.JiraAuthConfig, base http.RoundTripper) (http.RoundTripper, error) {
+	switch strings.ToLower(cfg.Type) {
+	case "", authNone:
+		return base, nil
+
+	case authBasic:
+		token, err := readSecret(cfg.Token, cfg.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.User == "" || token == "" {
+			return nil, fmt.Errorf("%w: basic auth needs user and token", myErr.ErrAuthConfig)
+		}
+		return &basicAuthTransport{user: cfg.User, token: token, base: base}, nil
+
+	case authBearer:
+		token, err := readSecret(cfg.Token, cfg.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		if token == "" {
+			return nil, fmt.Errorf("%w: bearer auth needs token", myErr.ErrAuthConfig)
+		}
+		return &bearerTransport{token: token, base: base}, nil
+
+	case authOAuth1:
+		return newOAuth1Transport(cfg, base)
+
+	case authOAuth2:
+		return newOAuth2Transport(cfg, base)
+	}
+
+	return nil, fmt.Errorf("%w: unknown auth type %q", myErr.ErrAuthConfig, cfg.Type)
+}
+
+type basicAuthTransport struct {
+	user  string
+	token string
+	base  http.RoundTripper
+}
+
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTripper mustn't modify the request
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.user, t.token)
+	return t.base.RoundTrip(req)
+}
+
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
+func newOAuth1Transport(cfg *config.JiraAuthConfig, base http.RoundTripper) (http.RoundTripper, error) {
+	keyPem, err := readSecret(cfg.PrivateKey, cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := readSecret(cfg.AccessToken, cfg.AccessTokenFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ConsumerKey == "" || keyPem == "" || accessToken == "" {
+		return nil, fmt.Errorf("%w: oauth1 needs consumer key, private key and access token", myErr.ErrAuthConfig)
+	}
+
+	key, err := parsePrivateKey(keyPem)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthCfg := &oauth1.Config{
+		ConsumerKey: cfg.ConsumerKey,
+		Signer:      &oauth1.RSASigner{PrivateKey: key},
+	}
+	// Jira doesn't use the token secret with RSA-SHA1
+	ctx := context.WithValue(context.Background(), oauth1.HTTPClient, &http.Client{Transport: base})
+	return oauthCfg.Client(ctx, oauth1.NewToken(accessToken, "")).Transport, nil
+}
+
+func newOAuth2Transport(cfg *config.JiraAuthConfig, base http.RoundTripper) (http.RoundTripper, error) {
+	clientSecret, err := readSecret(cfg.ClientSecret, cfg.ClientSecretFile)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := readSecret(cfg.RefreshToken, cfg.RefreshTokenFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ClientId == "" || clientSecret == "" || cfg.TokenUrl == "" {
+		return nil, fmt.Errorf("%w: oauth2 needs client id, client secret and token url", myErr.ErrAuthConfig)
+	}
+
+	// tokens are requested through the same base transport, client
+	// credentials are sent in the body as the Atlassian token endpoint expects
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: base})
+
+	var source oauth2.TokenSource
+	if refreshToken != "" {
+		oauthCfg := &oauth2.Config{
+			ClientID:     cfg.ClientId,
+			ClientSecret: clientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: cfg.TokenUrl, AuthStyle: oauth2.AuthStyleInParams},
+			Scopes:       cfg.Scopes,
+		}
+		source = oauthCfg.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken})
+	} else {
+		oauthCfg := &clientcredentials.Config{
+			ClientID:     cfg.ClientId,
+			ClientSecret: clientSecret,
+			TokenURL:     cfg.TokenUrl,
+			Scopes:       cfg.Scopes,
+			AuthStyle:    oauth2.AuthStyleInParams,
+		}
+		source = oauthCfg.TokenSource(ctx)
+	}
+
+	return &oauth2.Transport{Source: source, Base: base}, nil
+}
+
+// readSecret prefers the file if it is set
+func readSecret(value, file string) (string, error) {
+	if file == "" {
+		return strings.TrimSpace(value), nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("%w: can't read secret file: %w", myErr.ErrAuthConfig, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// parsePrivateKey accepts PKCS#1 and PKCS#8 PEM keys
+func parsePrivateKey(keyPem string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPem))
+	if block == nil {
+		return nil, fmt.Errorf("%w: private key isn't PEM encoded", myErr.ErrAuthConfig)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: can't parse private key: %w", myErr.ErrAuthConfig, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: private key isn't RSA", myErr.ErrAuthConfig)
+	}
+	return rsaKey, nil
+}
+
+// isAuthError reports that the OAuth token can't be obtained, it isn't fixed by retries
+func isAuthError(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	return errors.As(err, &retrieveErr)
+}
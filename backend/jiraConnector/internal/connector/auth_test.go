@@ -0,0 +1,213 @@
+package connector
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	myErr "github.com/jiraconnector/internal/connector/errors"
+	"github.com/jiraconnector/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authServer returns Authorization header of the last request
+func authServer(t *testing.T) (*httptest.Server, func() string) {
+	var header atomic.Value
+	header.Store("")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header.Store(r.Header.Get("Authorization"))
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() string { return header.Load().(string) }
+}
+
+func connectorWithAuth(t *testing.T, url string, auth config.JiraAuthConfig) (*JiraConnector, error) {
+	cfg := config.Config{JiraCfg: config.JiraConfig{Url: url, MinSleep: 1, MaxSleep: 1, Auth: auth}}
+	return NewJiraConnector(&cfg.JiraCfg, slog.Default())
+}
+
+func writeSecret(t *testing.T, value string) string {
+	file := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(file, []byte(value+"\n"), 0o600))
+	return file
+}
+
+func TestAuth_Headers(t *testing.T) {
+	tests := []struct {
+		name       string
+		auth       config.JiraAuthConfig
+		wantHeader string
+	}{
+		{
+			name:       "no auth",
+			wantHeader: "",
+		},
+		{
+			name:       "basic with api token",
+			auth:       config.JiraAuthConfig{Type: "basic", User: "user@example.com", Token: "api-token"},
+			wantHeader: "Basic dXNlckBleGFtcGxlLmNvbTphcGktdG9rZW4=",
+		},
+		{
+			name:       "basic with token from file",
+			auth:       config.JiraAuthConfig{Type: "basic", User: "user@example.com", Token: "ignored", TokenFile: writeSecret(t, "api-token")},
+			wantHeader: "Basic dXNlckBleGFtcGxlLmNvbTphcGktdG9rZW4=",
+		},
+		{
+			name:       "bearer personal access token",
+			auth:       config.JiraAuthConfig{Type: "Bearer", Token: "pat"},
+			wantHeader: "Bearer pat",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, lastHeader := authServer(t)
+
+			conn, err := connectorWithAuth(t, server.URL, tt.auth)
+			require.NoError(t, err)
+
+			_, err = conn.GetAllProjects(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHeader, lastHeader())
+		})
+	}
+}
+
+func TestAuth_ConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		auth config.JiraAuthConfig
+	}{
+		{name: "unknown type", auth: config.JiraAuthConfig{Type: "kerberos"}},
+		{name: "basic without user", auth: config.JiraAuthConfig{Type: "basic", Token: "token"}},
+		{name: "bearer without token", auth: config.JiraAuthConfig{Type: "bearer"}},
+		{name: "missing secret file", auth: config.JiraAuthConfig{Type: "bearer", TokenFile: "/nonexistent/token"}},
+		{name: "oauth1 without key", auth: config.JiraAuthConfig{Type: "oauth1", ConsumerKey: "key", AccessToken: "token"}},
+		{name: "oauth1 invalid key", auth: config.JiraAuthConfig{Type: "oauth1", ConsumerKey: "key", AccessToken: "token", PrivateKey: "not a key"}},
+		{name: "oauth2 without secret", auth: config.JiraAuthConfig{Type: "oauth2", ClientId: "id", TokenUrl: "http://localhost"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := connectorWithAuth(t, "http://localhost", tt.auth)
+			assert.ErrorIs(t, err, myErr.ErrAuthConfig)
+		})
+	}
+}
+
+func TestAuth_OAuth1(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	server, lastHeader := authServer(t)
+	conn, err := connectorWithAuth(t, server.URL, config.JiraAuthConfig{
+		Type:           "oauth1",
+		ConsumerKey:    "jira-analyzer",
+		PrivateKeyFile: writeSecret(t, string(keyPem)),
+		AccessToken:    "access-token",
+	})
+	require.NoError(t, err)
+
+	_, err = conn.GetAllProjects(context.Background())
+	require.NoError(t, err)
+
+	header := lastHeader()
+	assert.True(t, strings.HasPrefix(header, "OAuth "))
+	assert.Contains(t, header, `oauth_consumer_key="jira-analyzer"`)
+	assert.Contains(t, header, `oauth_token="access-token"`)
+	assert.Contains(t, header, `oauth_signature_method="RSA-SHA1"`)
+}
+
+func TestAuth_OAuth2(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+		r.ParseForm()
+		assert.Equal(t, "refresh_token", r.Form.Get("grant_type"))
+		assert.Equal(t, "refresh", r.Form.Get("refresh_token"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	server, lastHeader := authServer(t)
+	conn, err := connectorWithAuth(t, server.URL, config.JiraAuthConfig{
+		Type:         "oauth2",
+		ClientId:     "id",
+		ClientSecret: "secret",
+		RefreshToken: "refresh",
+		TokenUrl:     tokenServer.URL,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = conn.GetAllProjects(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "Bearer access", lastHeader())
+	// token is reused until it expires
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+}
+
+func TestAuth_OAuth2ClientCredentials(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"service","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	server, lastHeader := authServer(t)
+	conn, err := connectorWithAuth(t, server.URL, config.JiraAuthConfig{
+		Type:             "oauth2",
+		ClientId:         "id",
+		ClientSecretFile: writeSecret(t, "secret"),
+		TokenUrl:         tokenServer.URL,
+	})
+	require.NoError(t, err)
+
+	_, err = conn.GetAllProjects(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer service", lastHeader())
+}
+
+func TestAuth_OAuth2TokenRejected(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer tokenServer.Close()
+
+	server, _ := authServer(t)
+	conn, err := connectorWithAuth(t, server.URL, config.JiraAuthConfig{
+		Type:         "oauth2",
+		ClientId:     "id",
+		ClientSecret: "secret",
+		RefreshToken: "expired",
+		TokenUrl:     tokenServer.URL,
+	})
+	require.NoError(t, err)
+
+	_, err = conn.GetAllProjects(context.Background())
+	assert.ErrorIs(t, err, myErr.ErrUnauthorized)
+	assert.NotErrorIs(t, err, myErr.ErrTransport)
+	// rejected token isn't retried
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+}
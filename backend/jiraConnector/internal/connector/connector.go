@@ -1,13 +1,17 @@
 package connector
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"math"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -19,27 +23,75 @@ import (
 	"github.com/jiraconnector/pkg/logger"
 )
 
+// JQL compares dates in the timezone of the Jira user, which is unknown to us,
+// so incremental requests look back a bit further than the stored watermark.
+// Re-fetched issues are simply upserted again.
+const incrementalOverlap = 24 * time.Hour
+
+// search embeds only the first page of the changelog, the rest is requested
+// from the changelog endpoint by pages of this size (max allowed by Jira)
+const changelogPageSize = 100
+
+// search embeds up to 20 worklogs of the issue, the rest are requested by pages of this size
+const worklogPageSize = 1000
+
+// comments are requested by pages of this size if search doesn't embed all of them
+const commentPageSize = 1000
+
+const (
+	apiV2 = "2"
+	apiV3 = "3"
+)
+
 type JiraConnector struct {
-	cfg    *config.JiraConfig
-	client *http.Client
-	log    *slog.Logger
+	cfg        *config.JiraConfig
+	apiVersion string
+	client     *http.Client
+	retry      RetryPolicy
+	limiter    *requestLimiter
+	log        *slog.Logger
 }
 
-func NewJiraConnector(config *config.Config, log *slog.Logger) *JiraConnector {
-	return &JiraConnector{
-		cfg:    &config.JiraCfg,
-		client: &http.Client{},
-		log:    log,
+func NewJiraConnector(cfg *config.JiraConfig, log *slog.Logger) (*JiraConnector, error) {
+	apiVersion := strings.TrimPrefix(cfg.ApiVersion, "v")
+	if apiVersion == "" {
+		apiVersion = apiV2
+	}
+	if apiVersion != apiV2 && apiVersion != apiV3 {
+		ansErr := fmt.Errorf("error create jira connector: %w: %s", myErr.ErrApiVersion, cfg.ApiVersion)
+		log.Error(ansErr.Error())
+		return nil, ansErr
 	}
+
+	transport, err := newAuthTransport(&cfg.Auth, http.DefaultTransport)
+	if err != nil {
+		ansErr := fmt.Errorf("error create jira connector: %w", err)
+		log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	return &JiraConnector{
+		cfg:        cfg,
+		apiVersion: apiVersion,
+		client:     &http.Client{Transport: transport},
+		retry:      NewBackoffPolicy(cfg),
+		limiter:    newRequestLimiter(cfg),
+		log:        log,
+	}, nil
+}
+
+// SetRetryPolicy replaces the default BackoffPolicy
+func (con *JiraConnector) SetRetryPolicy(policy RetryPolicy) {
+	con.retry = policy
 }
 
-func (con *JiraConnector) GetProjectByKey(projectKey string) (*structures.JiraProject, error) {
-	url := fmt.Sprintf("%s/rest/api/2/project/%s", con.cfg.Url, projectKey)
+func (con *JiraConnector) GetProjectByKey(ctx context.Context, projectKey string) (*structures.JiraProject, error) {
+	url := con.apiUrl("/project/%s", projectKey)
 
-	resp, err := con.retryRequest("GET", url)
+	resp, err := con.retryRequest(ctx, "GET", url)
 	if err != nil {
 		con.log.Error("err retry request", logger.Err(err), "url", url)
-		return nil, err
+		return nil, projectError(err)
 	}
 	defer resp.Body.Close()
 
@@ -61,10 +113,10 @@ func (con *JiraConnector) GetProjectByKey(projectKey string) (*structures.JiraPr
 	return &project, nil
 }
 
-func (con *JiraConnector) GetAllProjects() ([]structures.JiraProject, error) {
-	url := fmt.Sprintf("%s/rest/api/2/project", con.cfg.Url)
+func (con *JiraConnector) GetAllProjects(ctx context.Context) ([]structures.JiraProject, error) {
+	url := con.apiUrl("/project")
 
-	resp, err := con.retryRequest("GET", url)
+	resp, err := con.retryRequest(ctx, "GET", url)
 	if err != nil {
 		con.log.Error("err retry request", logger.Err(err), "url", url)
 		return nil, err
@@ -89,8 +141,68 @@ func (con *JiraConnector) GetAllProjects() ([]structures.JiraProject, error) {
 	return projects, nil
 }
 
-func (con *JiraConnector) GetProjectsPage(search string, limit, page int) (*structures.ResponseProject, error) {
-	allProjects, err := con.GetAllProjects()
+// GetFields returns all system and custom fields of the Jira instance
+func (con *JiraConnector) GetFields(ctx context.Context) ([]structures.JiraField, error) {
+	url := con.apiUrl("/field")
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetFields, err)
+		con.log.Error(ansErr.Error(), "url", url)
+		return nil, ansErr
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
+		con.log.Error(ansErr.Error(), "url", url)
+		return nil, ansErr
+	}
+
+	var fields []structures.JiraField
+	if err = json.Unmarshal(body, &fields); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+		con.log.Error(ansErr.Error(), "url", url)
+		return nil, ansErr
+	}
+
+	con.log.Info("success get fields", "count", len(fields))
+	return fields, nil
+}
+
+// GetPriorities returns priorities of the Jira instance from the highest to the lowest
+func (con *JiraConnector) GetPriorities(ctx context.Context) ([]structures.JiraPriority, error) {
+	url := con.apiUrl("/priority")
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetPriority, err)
+		con.log.Error(ansErr.Error(), "url", url)
+		return nil, ansErr
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
+		con.log.Error(ansErr.Error(), "url", url)
+		return nil, ansErr
+	}
+
+	var priorities []structures.JiraPriority
+	if err = json.Unmarshal(body, &priorities); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+		con.log.Error(ansErr.Error(), "url", url)
+		return nil, ansErr
+	}
+
+	con.log.Info("success get priorities", "count", len(priorities))
+	return priorities, nil
+}
+
+func (con *JiraConnector) GetProjectsPage(ctx context.Context, search string, limit, page int) (*structures.ResponseProject, error) {
+	allProjects, err := con.GetAllProjects(ctx)
 	if err != nil {
 		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetProjects, err)
 		con.log.Error(ansErr.Error(), "search", search, "page", page, "limit", limit)
@@ -128,9 +240,26 @@ func (con *JiraConnector) GetProjectsPage(search string, limit, page int) (*stru
 		nil
 }
 
-func (con *JiraConnector) GetProjectIssues(project string) ([]structures.JiraIssue, error) {
+func (con *JiraConnector) GetProjectIssues(ctx context.Context, project string, progress structures.ProgressFunc) ([]structures.JiraIssue, error) {
+	return con.getIssuesByJql(ctx, project, projectJql(project, time.Time{}), progress)
+}
+
+func (con *JiraConnector) GetProjectIssuesUpdatedSince(ctx context.Context, project string, since time.Time, progress structures.ProgressFunc) ([]structures.JiraIssue, error) {
+	return con.getIssuesByJql(ctx, project, projectJql(project, since), progress)
+}
+
+func (con *JiraConnector) getIssuesByJql(ctx context.Context, project, jql string, progress structures.ProgressFunc) ([]structures.JiraIssue, error) {
+	if progress == nil {
+		progress = func(int, int) {}
+	}
+
+	// offset search isn't available in Jira Cloud v3
+	if con.apiVersion == apiV3 {
+		return con.getIssuesByToken(ctx, project, jql, progress)
+	}
+
 	//get all issues for this project
-	totalIssues, err := con.getTotalIssues(project)
+	totalIssues, err := con.getTotalIssues(ctx, jql)
 	if err != nil {
 		ansErr := fmt.Errorf("%w", err)
 		con.log.Error(ansErr.Error(), "project", project)
@@ -138,105 +267,532 @@ func (con *JiraConnector) GetProjectIssues(project string) ([]structures.JiraIss
 	}
 
 	if totalIssues == 0 {
-		con.log.Info("success got all issues", "project", project)
+		con.log.Info("success got all issues", "project", project, "jql", jql)
 		return []structures.JiraIssue{}, nil
 	}
+	progress(0, totalIssues)
+
+	pageSize := max(con.cfg.IssueInOneReq, 1)
+	workers := max(con.cfg.ThreadCount, 1)
 
-	//create common source = map for results
-	var allIssues []structures.JiraIssue
-	threadCount := con.cfg.ThreadCount
-	issueReq := con.cfg.IssueInOneReq
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	//create go routines
-	var wg sync.WaitGroup
-	var issuesMux sync.Mutex
+	// producer: offsets of all pages, stops as soon as work is cancelled
+	offsets := make(chan int)
+	go func() {
+		defer close(offsets)
+		for startAt := 0; startAt < totalIssues; startAt += pageSize {
+			select {
+			case offsets <- startAt:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	errChan := make(chan error, 1)
+	var (
+		wg          sync.WaitGroup
+		issuesMux   sync.Mutex
+		firstErr    error
+		allIssues   []structures.JiraIssue
+		issueIdx    = make(map[string]int, totalIssues)
+		progressMux sync.Mutex
+		reported    int
+	)
 
-	for i := 0; i < threadCount; i++ {
-		wg.Add(1)
-		//find start index for get issues
-		issueStart := i*issueReq + 1
-		if issueStart > totalIssues {
-			issueStart = totalIssues
+	// progress is called outside of issuesMux and one call at a time: a slow callback
+	// mustn't hold up the workers, so the report is skipped while the previous one
+	// is running. The final count is reported after all pages are downloaded
+	report := func(fetched int) {
+		if !progressMux.TryLock() {
+			return
+		}
+		defer progressMux.Unlock()
+		if fetched > reported {
+			reported = fetched
+			progress(fetched, totalIssues)
 		}
+	}
 
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
 		go func() {
-
 			defer wg.Done()
-			//TODO: add count of request (?)
-			select {
-			case <-ctx.Done():
-				con.log.Error("stop go thread", "project", project)
-				return
-			default:
-				issues, err := con.getIssuesForOneThread(issueStart, project)
-				if err != nil {
-					ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, err)
-					errChan <- ansErr
-					con.log.Error(ansErr.Error(), "project", project)
+			for startAt := range offsets {
+				if ctx.Err() != nil {
 					return
 				}
 
+				issues, err := con.getIssuesPage(ctx, startAt, pageSize, jql)
+
 				issuesMux.Lock()
-				defer issuesMux.Unlock()
-				allIssues = append(allIssues, issues...)
+				if err != nil {
+					// only the first error is returned, the rest are caused by cancel
+					if firstErr == nil {
+						firstErr = fmt.Errorf("%w: %w", myErr.ErrGetIssues, err)
+						con.log.Error(firstErr.Error(), "project", project, "startAt", startAt)
+						cancel()
+					}
+					issuesMux.Unlock()
+					return
+				}
+
+				// issues may move between pages while project is being downloaded
+				for _, issue := range issues {
+					if idx, ok := issueIdx[issue.Key]; ok {
+						allIssues[idx] = issue
+						continue
+					}
+					issueIdx[issue.Key] = len(allIssues)
+					allIssues = append(allIssues, issue)
+				}
+				fetched := len(allIssues)
+				issuesMux.Unlock()
 
+				report(fetched)
 			}
 		}()
 	}
 
-	go func() {
-		if err := <-errChan; err != nil {
-			con.log.Error("err chan", logger.Err(err), "project", project)
-			cancel()
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if reported < len(allIssues) {
+		progress(len(allIssues), totalIssues)
+	}
+
+	con.log.Info("success got all issues", "project", project, "jql", jql, "count", len(allIssues))
+	return allIssues, nil
+}
+
+// getIssuesByToken downloads pages one by one, every page has the token of the next one
+func (con *JiraConnector) getIssuesByToken(ctx context.Context, project, jql string, progress structures.ProgressFunc) ([]structures.JiraIssue, error) {
+	totalIssues, err := con.getApproximateCount(ctx, jql)
+	if err != nil {
+		ansErr := fmt.Errorf("%w", err)
+		con.log.Error(ansErr.Error(), "project", project)
+		return nil, ansErr
+	}
+	progress(0, totalIssues)
+
+	var (
+		allIssues []structures.JiraIssue
+		issueIdx  = make(map[string]int, totalIssues)
+		token     string
+		pageSize  = max(con.cfg.IssueInOneReq, 1)
+	)
+
+	for {
+		issues, page, err := con.getIssuesTokenPage(ctx, jql, token, pageSize)
+		if err != nil {
+			ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, err)
+			con.log.Error(ansErr.Error(), "project", project)
+			return nil, ansErr
 		}
-	}()
 
-	wg.Wait()
+		for _, issue := range issues {
+			if idx, ok := issueIdx[issue.Key]; ok {
+				allIssues[idx] = issue
+				continue
+			}
+			issueIdx[issue.Key] = len(allIssues)
+			allIssues = append(allIssues, issue)
+		}
+		// count is approximate, it mustn't be less than already fetched
+		totalIssues = max(totalIssues, len(allIssues))
+		progress(len(allIssues), totalIssues)
+
+		if page.IsLast || page.NextPageToken == "" {
+			break
+		}
+		token = page.NextPageToken
+	}
 
-	con.log.Info("success got all issues", "project", project)
+	if allIssues == nil {
+		allIssues = []structures.JiraIssue{}
+	}
+	con.log.Info("success got all issues", "project", project, "jql", jql, "count", len(allIssues))
 	return allIssues, nil
 }
 
-func (con *JiraConnector) getIssuesForOneThread(startAt int, project string) ([]structures.JiraIssue, error) {
-	url := fmt.Sprintf(
-		"%s/rest/api/2/search?jql=project=%s&expand=changelog&startAt=%d&maxResult=%d",
-		con.cfg.Url, project, startAt, con.cfg.IssueInOneReq)
+// getIssuesTokenPage returns issues of the page and the page for its next token
+func (con *JiraConnector) getIssuesTokenPage(ctx context.Context, jql, token string, pageSize int) ([]structures.JiraIssue, *structures.JiraIssuesPage, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("fields", "*all")
+	params.Set("expand", "changelog")
+	params.Set("maxResults", strconv.Itoa(pageSize))
+	if token != "" {
+		params.Set("nextPageToken", token)
+	}
+	url := con.apiUrl("/search/jql?%s", params.Encode())
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, projectError(err))
+		con.log.Error(ansErr.Error(), "jql", jql, "token", token)
+		return nil, nil, ansErr
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
+		con.log.Error(ansErr.Error(), "jql", jql, "token", token)
+		return nil, nil, ansErr
+	}
+
+	var page structures.JiraIssuesPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+		con.log.Error(ansErr.Error(), "jql", jql, "token", token)
+		return nil, nil, ansErr
+	}
+
+	issues, err := con.decodeIssues(ctx, page.Issues)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, err)
+		con.log.Error(ansErr.Error(), "jql", jql, "token", token)
+		return nil, nil, ansErr
+	}
+
+	con.log.Info("success get issues page", "jql", jql, "token", token)
+	return issues, &page, nil
+}
+
+// getApproximateCount is used only for the progress of token based search
+func (con *JiraConnector) getApproximateCount(ctx context.Context, jql string) (int, error) {
+	url := con.apiUrl("/search/approximate-count")
+	reqBody, err := json.Marshal(map[string]string{"jql": jql})
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrMakeRequest, err)
+		con.log.Error(ansErr.Error(), "jql", jql)
+		return 0, ansErr
+	}
 
-	resp, err := con.retryRequest("GET", url)
+	resp, err := con.retryRequestBody(ctx, "POST", url, reqBody)
 	if err != nil {
-		con.log.Error("error retry request", logger.Err(err), "project", project, "startAt", startAt)
-		return nil, myErr.ErrGetIssues
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, projectError(err))
+		con.log.Error(ansErr.Error(), "jql", jql)
+		return 0, ansErr
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
-		con.log.Error(ansErr.Error(), "project", project, "startAt", startAt)
+		con.log.Error(ansErr.Error(), "jql", jql)
+		return 0, ansErr
+	}
+
+	var count structures.JiraIssuesCount
+	if err := json.Unmarshal(body, &count); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+		con.log.Error(ansErr.Error(), "jql", jql)
+		return 0, ansErr
+	}
+
+	return count.Count, nil
+}
+
+func (con *JiraConnector) getIssuesPage(ctx context.Context, startAt, pageSize int, jql string) ([]structures.JiraIssue, error) {
+	url := con.apiUrl("/search?jql=%s&expand=changelog&startAt=%d&maxResults=%d",
+		url.QueryEscape(jql), startAt, pageSize)
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, projectError(err))
+		con.log.Error(ansErr.Error(), "jql", jql, "startAt", startAt)
 		return nil, ansErr
 	}
+	defer resp.Body.Close()
 
-	var issues structures.JiraIssues
-	if err := json.Unmarshal(body, &issues); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
+		con.log.Error(ansErr.Error(), "jql", jql, "startAt", startAt)
+		return nil, ansErr
+	}
+
+	var page structures.JiraIssuesRaw
+	if err := json.Unmarshal(body, &page); err != nil {
 		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
-		con.log.Error(ansErr.Error(), "project", project, "startAt", startAt)
+		con.log.Error(ansErr.Error(), "jql", jql, "startAt", startAt)
 		return nil, ansErr
 	}
 
-	con.log.Info("success get issue for thread", "project", project, "startAt", startAt)
-	return issues.Issues, nil
+	issues, err := con.decodeIssues(ctx, page.Issues)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, err)
+		con.log.Error(ansErr.Error(), "jql", jql, "startAt", startAt)
+		return nil, ansErr
+	}
+
+	con.log.Info("success get issues page", "jql", jql, "startAt", startAt)
+	return issues, nil
 }
 
-func (con *JiraConnector) getTotalIssues(project string) (int, error) {
-	url := fmt.Sprintf("%s/rest/api/2/search?jql=project=%s&maxResults=0&", con.cfg.Url, project)
+// decodeIssues unmarshals every issue of the search page and completes it,
+// Raw keeps the JSON of the issue as Jira returned it
+func (con *JiraConnector) decodeIssues(ctx context.Context, raws []json.RawMessage) ([]structures.JiraIssue, error) {
+	issues := make([]structures.JiraIssue, len(raws))
+	for i, raw := range raws {
+		if err := json.Unmarshal(raw, &issues[i]); err != nil {
+			return nil, fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+		}
+		issues[i].Raw = raw
+		if err := con.completeIssue(ctx, &issues[i]); err != nil {
+			return nil, err
+		}
+	}
+	return issues, nil
+}
 
-	resp, err := con.retryRequest("GET", url)
+// completeIssue requests the parts of the issue which search truncates
+func (con *JiraConnector) completeIssue(ctx context.Context, issue *structures.JiraIssue) error {
+	changelog := issue.Changelog.Total > len(issue.Changelog.Histories)
+	worklog := issue.Fields.Worklog.Total > len(issue.Fields.Worklog.Worklogs)
+	comments := issue.Fields.Comment.Total > len(issue.Fields.Comment.Comments)
+	if !changelog && !worklog && !comments {
+		return nil
+	}
+
+	if err := con.completeChangelog(ctx, issue); err != nil {
+		return err
+	}
+	if err := con.completeWorklog(ctx, issue); err != nil {
+		return err
+	}
+	if err := con.completeComments(ctx, issue); err != nil {
+		return err
+	}
+	return completeRaw(issue, changelog, worklog, comments)
+}
+
+// completeRaw puts the completed parts into the raw JSON of the issue,
+// the rest of it is kept as Jira returned it
+func completeRaw(issue *structures.JiraIssue, changelog, worklog, comments bool) error {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(issue.Raw, &all); err != nil {
+		return fmt.Errorf("%w - %s: %w", myErr.ErrCompleteRaw, issue.Key, err)
+	}
+	fields := map[string]json.RawMessage{}
+	if raw, ok := all["fields"]; ok {
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return fmt.Errorf("%w - %s: %w", myErr.ErrCompleteRaw, issue.Key, err)
+		}
+	}
+
+	var err error
+	if changelog {
+		all["changelog"], err = json.Marshal(issue.Changelog)
+	}
+	if worklog && err == nil {
+		fields["worklog"], err = json.Marshal(issue.Fields.Worklog)
+	}
+	if comments && err == nil {
+		fields["comment"], err = json.Marshal(issue.Fields.Comment)
+	}
+	if (worklog || comments) && err == nil {
+		all["fields"], err = json.Marshal(fields)
+	}
+	if err == nil {
+		issue.Raw, err = json.Marshal(all)
+	}
 	if err != nil {
-		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, err)
-		con.log.Error(ansErr.Error(), "project", project)
+		return fmt.Errorf("%w - %s: %w", myErr.ErrCompleteRaw, issue.Key, err)
+	}
+	return nil
+}
+
+// completeChangelog replaces the truncated embedded changelog of the issue
+// with the full one, nothing is requested if all histories are embedded
+func (con *JiraConnector) completeChangelog(ctx context.Context, issue *structures.JiraIssue) error {
+	if issue.Changelog.Total <= len(issue.Changelog.Histories) {
+		return nil
+	}
+
+	histories := make([]structures.History, 0, issue.Changelog.Total)
+	for {
+		page, err := con.getChangelogPage(ctx, issue.Key, len(histories))
+		if err != nil {
+			return err
+		}
+		histories = append(histories, page.Values...)
+
+		if page.IsLast || len(page.Values) == 0 || len(histories) >= page.Total {
+			break
+		}
+	}
+
+	con.log.Info("success get full changelog", "issue", issue.Key,
+		"embedded", len(issue.Changelog.Histories), "total", len(histories))
+	issue.Changelog = structures.Changelog{
+		StartAt:    0,
+		MaxResults: len(histories),
+		Total:      len(histories),
+		Histories:  histories,
+	}
+	return nil
+}
+
+func (con *JiraConnector) getChangelogPage(ctx context.Context, issueKey string, startAt int) (*structures.ChangelogPage, error) {
+	url := con.apiUrl("/issue/%s/changelog?startAt=%d&maxResults=%d",
+		url.PathEscape(issueKey), startAt, changelogPageSize)
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myErr.ErrGetChangelog, issueKey, err)
+		con.log.Error(ansErr.Error(), "startAt", startAt)
+		return nil, ansErr
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
+		con.log.Error(ansErr.Error(), "issue", issueKey, "startAt", startAt)
+		return nil, ansErr
+	}
+
+	var page structures.ChangelogPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+		con.log.Error(ansErr.Error(), "issue", issueKey, "startAt", startAt)
+		return nil, ansErr
+	}
+
+	return &page, nil
+}
+
+// completeWorklog replaces the truncated embedded worklog of the issue with the full one
+func (con *JiraConnector) completeWorklog(ctx context.Context, issue *structures.JiraIssue) error {
+	if issue.Fields.Worklog.Total <= len(issue.Fields.Worklog.Worklogs) {
+		return nil
+	}
+
+	worklogs := make([]structures.Worklog, 0, issue.Fields.Worklog.Total)
+	for {
+		page, err := con.getWorklogPage(ctx, issue.Key, len(worklogs))
+		if err != nil {
+			return err
+		}
+		worklogs = append(worklogs, page.Worklogs...)
+
+		if len(page.Worklogs) == 0 || len(worklogs) >= page.Total {
+			break
+		}
+	}
+
+	con.log.Info("success get full worklog", "issue", issue.Key,
+		"embedded", len(issue.Fields.Worklog.Worklogs), "total", len(worklogs))
+	issue.Fields.Worklog = structures.Worklogs{
+		StartAt:    0,
+		MaxResults: len(worklogs),
+		Total:      len(worklogs),
+		Worklogs:   worklogs,
+	}
+	return nil
+}
+
+func (con *JiraConnector) getWorklogPage(ctx context.Context, issueKey string, startAt int) (*structures.Worklogs, error) {
+	url := con.apiUrl("/issue/%s/worklog?startAt=%d&maxResults=%d",
+		url.PathEscape(issueKey), startAt, worklogPageSize)
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myErr.ErrGetWorklog, issueKey, err)
+		con.log.Error(ansErr.Error(), "startAt", startAt)
+		return nil, ansErr
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
+		con.log.Error(ansErr.Error(), "issue", issueKey, "startAt", startAt)
+		return nil, ansErr
+	}
+
+	var page structures.Worklogs
+	if err := json.Unmarshal(body, &page); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+		con.log.Error(ansErr.Error(), "issue", issueKey, "startAt", startAt)
+		return nil, ansErr
+	}
+
+	return &page, nil
+}
+
+// completeComments replaces the truncated embedded comments of the issue with all of them
+func (con *JiraConnector) completeComments(ctx context.Context, issue *structures.JiraIssue) error {
+	if issue.Fields.Comment.Total <= len(issue.Fields.Comment.Comments) {
+		return nil
+	}
+
+	comments := make([]structures.Comment, 0, issue.Fields.Comment.Total)
+	for {
+		page, err := con.getCommentsPage(ctx, issue.Key, len(comments))
+		if err != nil {
+			return err
+		}
+		comments = append(comments, page.Comments...)
+
+		if len(page.Comments) == 0 || len(comments) >= page.Total {
+			break
+		}
+	}
+
+	con.log.Info("success get all comments", "issue", issue.Key,
+		"embedded", len(issue.Fields.Comment.Comments), "total", len(comments))
+	issue.Fields.Comment = structures.Comments{
+		StartAt:    0,
+		MaxResults: len(comments),
+		Total:      len(comments),
+		Comments:   comments,
+	}
+	return nil
+}
+
+func (con *JiraConnector) getCommentsPage(ctx context.Context, issueKey string, startAt int) (*structures.Comments, error) {
+	url := con.apiUrl("/issue/%s/comment?startAt=%d&maxResults=%d",
+		url.PathEscape(issueKey), startAt, commentPageSize)
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myErr.ErrGetComments, issueKey, err)
+		con.log.Error(ansErr.Error(), "startAt", startAt)
+		return nil, ansErr
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
+		con.log.Error(ansErr.Error(), "issue", issueKey, "startAt", startAt)
+		return nil, ansErr
+	}
+
+	var page structures.Comments
+	if err := json.Unmarshal(body, &page); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
+		con.log.Error(ansErr.Error(), "issue", issueKey, "startAt", startAt)
+		return nil, ansErr
+	}
+
+	return &page, nil
+}
+
+func (con *JiraConnector) getTotalIssues(ctx context.Context, jql string) (int, error) {
+	url := con.apiUrl("/search?jql=%s&maxResults=0", url.QueryEscape(jql))
+
+	resp, err := con.retryRequest(ctx, "GET", url)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrGetIssues, projectError(err))
+		con.log.Error(ansErr.Error(), "jql", jql)
 		return 0, ansErr
 	}
 	defer resp.Body.Close()
@@ -244,62 +800,151 @@ func (con *JiraConnector) getTotalIssues(project string) (int, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		ansErr := fmt.Errorf("%w: %w", myErr.ErrReadResponseBody, err)
-		con.log.Error(ansErr.Error(), "project", project)
+		con.log.Error(ansErr.Error(), "jql", jql)
 		return 0, ansErr
 	}
 
 	var issues structures.JiraIssues
 	if err := json.Unmarshal(body, &issues); err != nil {
 		ansErr := fmt.Errorf("%w: %w", myErr.ErrUnmarshalAns, err)
-		con.log.Error(ansErr.Error(), "project", project)
+		con.log.Error(ansErr.Error(), "jql", jql)
 		return 0, ansErr
 	}
 
-	con.log.Info("success got all issues", "project", project)
+	con.log.Info("success got all issues", "jql", jql)
 	return issues.Total, nil
 }
 
-func (con *JiraConnector) retryRequest(method, url string) (*http.Response, error) {
-	var (
-		resp *http.Response
-		err  error
-	)
+func (con *JiraConnector) retryRequest(ctx context.Context, method, url string) (*http.Response, error) {
+	return con.retryRequestBody(ctx, method, url, nil)
+}
 
-	timeSleep := con.cfg.MinSleep
+// retryRequestBody sends a new request for every attempt, waits for the shared limiter
+// before each of them and asks the retry policy whether a failed attempt is repeated.
+// Not nil body is sent as JSON
+func (con *JiraConnector) retryRequestBody(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := con.limiter.Wait(ctx); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myErr.ErrCancelled, err)
+			con.log.Error(ansErr.Error(), "method", method, "url", url)
+			return nil, ansErr
+		}
 
-	req, err := http.NewRequest(method, url, nil)
-	if err != nil {
-		ansErr := fmt.Errorf("%w: %w", myErr.ErrMakeRequest, err)
-		con.log.Error(ansErr.Error(), "method", method, "url", url)
-		return nil, ansErr
-	}
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			ansErr := fmt.Errorf("%w: %w", myErr.ErrMakeRequest, err)
+			con.log.Error(ansErr.Error(), "method", method, "url", url)
+			return nil, ansErr
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
 
-	for {
-		resp, err = con.client.Do(req)
+		resp, err := con.client.Do(req)
 
-		if resp == nil || resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
-			ansErr := fmt.Errorf("%w", handlerErr.ErrNoProject)
+		// cancelled request isn't retried and doesn't mean that project is missing
+		if ctx.Err() != nil {
+			closeBody(resp)
+			ansErr := fmt.Errorf("%w: %w", myErr.ErrCancelled, ctx.Err())
 			con.log.Error(ansErr.Error(), "method", method, "url", url)
-			return nil, handlerErr.ErrNoProject
+			return nil, ansErr
 		}
 
-		// if everything ok - return resp
-		if err == nil && resp.StatusCode < 300 {
-			return resp, nil
+		if err == nil {
+			con.limiter.Observe(resp)
+			if resp.StatusCode < 300 {
+				return resp, nil
+			}
+			closeBody(resp)
 		}
-		time.Sleep(time.Duration(timeSleep))
-		timeSleep *= 2
 
-		if timeSleep > con.cfg.MaxSleep {
-			break
+		reqErr := responseError(resp, err)
+		delay, retry := con.retry.Backoff(attempt, resp, err)
+		if !retry {
+			if attempt > 0 {
+				reqErr = fmt.Errorf("%w: %w", myErr.ErrMaxTimeRequest, reqErr)
+			}
+			con.log.Error(reqErr.Error(), "method", method, "url", url, "attempts", attempt+1)
+			return nil, reqErr
 		}
+		con.log.Warn("retry request", logger.Err(reqErr), "url", url, "attempt", attempt+1, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			ansErr := fmt.Errorf("%w: %w", myErr.ErrCancelled, ctx.Err())
+			con.log.Error(ansErr.Error(), "method", method, "url", url)
+			return nil, ansErr
+		case <-time.After(delay):
+		}
+	}
+}
+
+// responseError converts a failed attempt into a typed error
+func responseError(resp *http.Response, err error) error {
+	if err != nil && isAuthError(err) {
+		return fmt.Errorf("%w: %w", myErr.ErrUnauthorized, err)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", myErr.ErrTransport, err)
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusNotFound:
+		return myErr.ErrNotFound
+	case code == http.StatusBadRequest:
+		return myErr.ErrBadRequest
+	case code == http.StatusUnauthorized:
+		return myErr.ErrUnauthorized
+	case code == http.StatusForbidden:
+		return myErr.ErrForbidden
+	case code == http.StatusTooManyRequests:
+		return myErr.ErrRateLimited
+	case code >= http.StatusInternalServerError:
+		return fmt.Errorf("%w: %s", myErr.ErrServer, resp.Status)
+	default:
+		return fmt.Errorf("%w: %s", myErr.ErrUnexpectedStatus, resp.Status)
 	}
+}
 
-	// if in cycle we didn't do response - return err
-	return nil, myErr.ErrMaxTimeRequest
+// projectError maps the errors of the project requests: Jira answers 404 for an
+// unknown project and 400 for a search with an unknown project in jql
+func projectError(err error) error {
+	if errors.Is(err, myErr.ErrNotFound) || errors.Is(err, myErr.ErrBadRequest) {
+		return fmt.Errorf("%w: %w", handlerErr.ErrNoProject, err)
+	}
+	return err
+}
+
+// closeBody drains the body, so the connection can be reused
+func closeBody(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
 
+// apiUrl builds the url of the REST api method for the configured version
+func (con *JiraConnector) apiUrl(format string, args ...any) string {
+	return con.cfg.Url + "/rest/api/" + con.apiVersion + fmt.Sprintf(format, args...)
 }
 
 func containsSearchProject(str, substr string) bool {
 	return strings.Contains(strings.ToLower(str), strings.ToLower(substr))
 }
+
+// projectJql builds the search query for a project. A non-zero since limits
+// the search to issues updated after since (minus incrementalOverlap).
+// Pages are requested by offset, so the order must be stable between requests.
+func projectJql(project string, since time.Time) string {
+	if since.IsZero() {
+		return fmt.Sprintf("project=%s ORDER BY key ASC", project)
+	}
+
+	from := since.UTC().Add(-incrementalOverlap).Format("2006/01/02 15:04")
+	return fmt.Sprintf(`project=%s AND updated >= "%s" ORDER BY key ASC`, project, from)
+}
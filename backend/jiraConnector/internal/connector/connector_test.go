@@ -1,13 +1,19 @@
 package connector
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -23,13 +29,17 @@ func mockConnectorWithURL(url string) *JiraConnector {
 	cfg := config.Config{
 		JiraCfg: config.JiraConfig{
 			Url:           url,
-			MinSleep:      int(10 * time.Millisecond),
-			MaxSleep:      int(100 * time.Millisecond),
+			MinSleep:      10,
+			MaxSleep:      100,
 			ThreadCount:   2,
 			IssueInOneReq: 1,
 		},
 	}
-	return NewJiraConnector(&cfg, slog.Default())
+	con, err := NewJiraConnector(&cfg.JiraCfg, slog.Default())
+	if err != nil {
+		panic(err)
+	}
+	return con
 }
 
 func TestGetAllProjects(t *testing.T) {
@@ -68,7 +78,7 @@ func TestGetAllProjects(t *testing.T) {
 			defer server.Close()
 
 			conn := mockConnectorWithURL(server.URL)
-			projects, err := conn.GetAllProjects()
+			projects, err := conn.GetAllProjects(context.Background())
 
 			assert.Equal(t, tt.expectErr, err != nil)
 			assert.Len(t, projects, tt.expectedLength)
@@ -104,7 +114,7 @@ func TestGetProjectsPage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := conn.GetProjectsPage(tt.search, tt.limit, tt.page)
+			result, err := conn.GetProjectsPage(context.Background(), tt.search, tt.limit, tt.page)
 			assert.NoError(t, err)
 			assert.Len(t, result.Projects, tt.expectSize)
 		})
@@ -157,7 +167,7 @@ func TestRetryRequest(t *testing.T) {
 			defer server.Close()
 
 			conn := mockConnectorWithURL(server.URL)
-			resp, err := conn.retryRequest("GET", server.URL)
+			resp, err := conn.retryRequest(context.Background(), "GET", server.URL)
 
 			if resp != nil {
 				resp.Body.Close()
@@ -176,8 +186,8 @@ func TestGetProjectIssues(t *testing.T) {
 	// simulate issue retrievals
 	responses := []mockResponse{
 		{pathContains: "maxResults=0", response: `{"total": 2}`},
-		{pathContains: "startAt=1", response: `{"issues":[{"id":"1","key":"ISSUE-1"}]}`},
-		{pathContains: "startAt=2", response: `{"issues":[{"id":"2","key":"ISSUE-2"}]}`},
+		{pathContains: "startAt=0", response: `{"issues":[{"id":"1","key":"ISSUE-1"}]}`},
+		{pathContains: "startAt=1", response: `{"issues":[{"id":"2","key":"ISSUE-2"}]}`},
 	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -191,15 +201,431 @@ func TestGetProjectIssues(t *testing.T) {
 	}))
 	defer server.Close()
 
+	var mu sync.Mutex
+	var progress [][2]int
 	conn := mockConnectorWithURL(server.URL)
-	issues, err := conn.GetProjectIssues("TEST")
+	issues, err := conn.GetProjectIssues(context.Background(), "TEST", func(fetched, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress = append(progress, [2]int{fetched, total})
+	})
 	assert.NoError(t, err)
 	assert.Len(t, issues, 2)
+	assert.Equal(t, [2]int{0, 2}, progress[0])
+	assert.Equal(t, [2]int{2, 2}, progress[len(progress)-1])
 	keys := []string{issues[0].Key, issues[1].Key}
 	assert.Contains(t, keys, "ISSUE-1")
 	assert.Contains(t, keys, "ISSUE-2")
 }
 
+func TestGetProjectIssues_AllPages(t *testing.T) {
+	const total = 7
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("maxResults") == "0" {
+			fmt.Fprintf(w, `{"total": %d}`, total)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "1", r.URL.Query().Get("maxResults"))
+
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		// the last page repeats the previous issue as if it moved between pages
+		if startAt == total-1 {
+			startAt--
+		}
+		fmt.Fprintf(w, `{"issues":[{"id":"%d","key":"ISSUE-%d"}]}`, startAt, startAt)
+	}))
+	defer server.Close()
+
+	// more pages than workers
+	conn := mockConnectorWithURL(server.URL)
+	issues, err := conn.GetProjectIssues(context.Background(), "TEST", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(total), atomic.LoadInt32(&requests))
+
+	keys := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	assert.ElementsMatch(t, []string{"ISSUE-0", "ISSUE-1", "ISSUE-2", "ISSUE-3", "ISSUE-4", "ISSUE-5"}, keys)
+}
+
+func TestGetProjectIssues_SlowProgress(t *testing.T) {
+	const total = 4
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("maxResults") == "0" {
+			fmt.Fprintf(w, `{"total": %d}`, total)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		fmt.Fprintf(w, `{"issues":[{"id":"%d","key":"ISSUE-%d"}]}`, startAt, startAt)
+	}))
+	defer server.Close()
+
+	var reports [][2]int
+	conn := mockConnectorWithURL(server.URL)
+	issues, err := conn.GetProjectIssues(context.Background(), "TEST", func(fetched, all int) {
+		// the first page report waits until the other workers download the rest
+		if len(reports) == 1 {
+			assert.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == total }, time.Second, time.Millisecond)
+		}
+		reports = append(reports, [2]int{fetched, all})
+	})
+	assert.NoError(t, err)
+	assert.Len(t, issues, total)
+
+	assert.Equal(t, [2]int{0, total}, reports[0])
+	assert.Equal(t, [2]int{total, total}, reports[len(reports)-1])
+	for i := 1; i < len(reports); i++ {
+		assert.Greater(t, reports[i][0], reports[i-1][0])
+	}
+}
+
+func TestGetProjectIssues_ZeroPageSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiVersion string
+	}{
+		{name: "offset pages", apiVersion: "2"},
+		{name: "token pages", apiVersion: "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pageSizes []string
+			var mu sync.Mutex
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/rest/api/3/search/approximate-count":
+					io.WriteString(w, `{"count": 2}`)
+				case r.URL.Query().Get("maxResults") == "0":
+					io.WriteString(w, `{"total": 2}`)
+				default:
+					mu.Lock()
+					pageSizes = append(pageSizes, r.URL.Query().Get("maxResults"))
+					mu.Unlock()
+					startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+					if r.URL.Query().Get("nextPageToken") != "" {
+						startAt = 1
+					}
+					fmt.Fprintf(w, `{"issues":[{"id":"%d","key":"ISSUE-%d"}],"nextPageToken":"next","isLast":%t}`,
+						startAt, startAt, startAt == 1)
+				}
+			}))
+			defer server.Close()
+
+			cfg := config.JiraConfig{Url: server.URL, ApiVersion: tt.apiVersion, ThreadCount: 1, MinSleep: 10, MaxSleep: 100}
+			conn, err := NewJiraConnector(&cfg, slog.Default())
+			assert.NoError(t, err)
+
+			issues, err := conn.GetProjectIssues(context.Background(), "TEST", nil)
+			assert.NoError(t, err)
+			assert.Len(t, issues, 2)
+			// the page size the connector iterates with is the one sent to Jira
+			assert.Equal(t, []string{"1", "1"}, pageSizes)
+		})
+	}
+}
+
+func TestGetProjectIssues_FirstErrorCancels(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("maxResults") == "0" {
+			io.WriteString(w, `{"total": 100}`)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("startAt") == "0" {
+			io.WriteString(w, `invalid json`)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+		io.WriteString(w, `{"issues":[]}`)
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	issues, err := conn.GetProjectIssues(context.Background(), "TEST", nil)
+	assert.ErrorIs(t, err, myErr.ErrGetIssues)
+	assert.ErrorIs(t, err, myErr.ErrUnmarshalAns)
+	assert.Nil(t, issues)
+	assert.Less(t, atomic.LoadInt32(&requests), int32(100))
+}
+
+func TestGetProjectIssuesUpdatedSince(t *testing.T) {
+	since := time.Date(2024, 5, 2, 10, 30, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `project=TEST AND updated >= "2024/05/01 10:30" ORDER BY key ASC`, r.URL.Query().Get("jql"))
+		if r.URL.Query().Get("maxResults") == "0" {
+			io.WriteString(w, `{"total": 1}`)
+			return
+		}
+		io.WriteString(w, `{"issues":[{"id":"1","key":"ISSUE-1"}]}`)
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	issues, err := conn.GetProjectIssuesUpdatedSince(context.Background(), "TEST", since, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, issues)
+	assert.Equal(t, "ISSUE-1", issues[0].Key)
+}
+
+func TestGetProjectIssues_TruncatedChangelog(t *testing.T) {
+	// the raw JSON of an issue isn't changed, only the completed changelog is replaced
+	issue2 := `{"id":"2","key":"ISSUE-2", "renderedFields":{},
+				"changelog":{"startAt":0,"maxResults":1,"total":1,"histories":[{"id":"20"}]}}`
+	var changelogCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/issue/ISSUE-1/changelog":
+			atomic.AddInt32(&changelogCalls, 1)
+			if r.URL.Query().Get("startAt") == "0" {
+				io.WriteString(w, `{"startAt":0,"maxResults":2,"total":3,"isLast":false,
+					"values":[{"id":"10","items":[{"field":"status"}]},{"id":"11","items":[{"field":"status"}]}]}`)
+				return
+			}
+			assert.Equal(t, "2", r.URL.Query().Get("startAt"))
+			io.WriteString(w, `{"startAt":2,"maxResults":2,"total":3,"isLast":true,
+				"values":[{"id":"12","items":[{"field":"priority"}]}]}`)
+		case r.URL.Query().Get("maxResults") == "0":
+			io.WriteString(w, `{"total": 2}`)
+		case r.URL.Query().Get("startAt") == "0":
+			io.WriteString(w, `{"issues":[{"id":"1","key":"ISSUE-1","renderedFields":{},
+				"changelog":{"startAt":0,"maxResults":1,"total":3,"histories":[{"id":"10"}]}}]}`)
+		default:
+			io.WriteString(w, `{"issues":[`+issue2+`]}`)
+		}
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	issues, err := conn.GetProjectIssues(context.Background(), "TEST", nil)
+	assert.NoError(t, err)
+	assert.Len(t, issues, 2)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&changelogCalls))
+
+	for _, issue := range issues {
+		var ids []string
+		for _, history := range issue.Changelog.Histories {
+			ids = append(ids, history.Id)
+		}
+
+		switch issue.Key {
+		case "ISSUE-1":
+			assert.Equal(t, []string{"10", "11", "12"}, ids)
+			assert.Equal(t, 3, issue.Changelog.Total)
+
+			var raw struct {
+				Rendered  json.RawMessage      `json:"renderedFields"`
+				Changelog structures.Changelog `json:"changelog"`
+			}
+			assert.NoError(t, json.Unmarshal(issue.Raw, &raw))
+			assert.JSONEq(t, `{}`, string(raw.Rendered))
+			assert.Equal(t, issue.Changelog, raw.Changelog)
+		case "ISSUE-2":
+			assert.Equal(t, []string{"20"}, ids)
+			assert.Equal(t, issue2, string(issue.Raw))
+		}
+	}
+}
+
+func TestCompleteChangelog_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		expectErr error
+	}{
+		{
+			name: "request error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "Error", http.StatusInternalServerError)
+			},
+			expectErr: myErr.ErrGetChangelog,
+		},
+		{
+			name: "invalid JSON",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`invalid json`))
+			},
+			expectErr: myErr.ErrUnmarshalAns,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			conn := mockConnectorWithURL(server.URL)
+			issue := structures.JiraIssue{
+				Key:       "ISSUE-1",
+				Changelog: structures.Changelog{Total: 2, Histories: []structures.History{{Id: "1"}}},
+			}
+			err := conn.completeChangelog(context.Background(), &issue)
+			assert.ErrorIs(t, err, tt.expectErr)
+			assert.Len(t, issue.Changelog.Histories, 1)
+		})
+	}
+}
+
+func TestGetProjectIssues_TruncatedWorklog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/issue/ISSUE-1/worklog":
+			if r.URL.Query().Get("startAt") == "0" {
+				io.WriteString(w, `{"startAt":0,"maxResults":2,"total":3,
+					"worklogs":[{"id":"100","timeSpentSeconds":60},{"id":"101","timeSpentSeconds":120}]}`)
+				return
+			}
+			assert.Equal(t, "2", r.URL.Query().Get("startAt"))
+			io.WriteString(w, `{"startAt":2,"maxResults":2,"total":3,"worklogs":[{"id":"102","timeSpentSeconds":180}]}`)
+		case r.URL.Query().Get("maxResults") == "0":
+			io.WriteString(w, `{"total": 1}`)
+		default:
+			io.WriteString(w, `{"issues":[{"id":"1","key":"ISSUE-1",
+				"fields":{"customfield_10010":"A","worklog":{"startAt":0,"maxResults":1,"total":3,"worklogs":[{"id":"100","timeSpentSeconds":60}]}}}]}`)
+		}
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	issues, err := conn.GetProjectIssues(context.Background(), "TEST", nil)
+	assert.NoError(t, err)
+	if !assert.Len(t, issues, 1) {
+		return
+	}
+
+	var ids []string
+	for _, worklog := range issues[0].Fields.Worklog.Worklogs {
+		ids = append(ids, worklog.Id)
+	}
+	assert.Equal(t, []string{"100", "101", "102"}, ids)
+	assert.Equal(t, 3, issues[0].Fields.Worklog.Total)
+
+	// the full worklog is put into the raw JSON next to the other fields
+	var raw structures.JiraIssue
+	assert.NoError(t, json.Unmarshal(issues[0].Raw, &raw))
+	ids = nil
+	for _, worklog := range raw.Fields.Worklog.Worklogs {
+		ids = append(ids, worklog.Id)
+	}
+	assert.Equal(t, []string{"100", "101", "102"}, ids)
+	assert.JSONEq(t, `"A"`, string(raw.Fields.Custom["customfield_10010"]))
+}
+
+func TestCompleteWorklog_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		expectErr error
+	}{
+		{
+			name: "request error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "Error", http.StatusForbidden)
+			},
+			expectErr: myErr.ErrGetWorklog,
+		},
+		{
+			name: "deleted issue",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "Not Found", http.StatusNotFound)
+			},
+			expectErr: myErr.ErrNotFound,
+		},
+		{
+			name: "invalid JSON",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`invalid json`))
+			},
+			expectErr: myErr.ErrUnmarshalAns,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			conn := mockConnectorWithURL(server.URL)
+			issue := structures.JiraIssue{Key: "ISSUE-1"}
+			issue.Fields.Worklog = structures.Worklogs{Total: 2, Worklogs: []structures.Worklog{{Id: "1"}}}
+
+			err := conn.completeWorklog(context.Background(), &issue)
+			assert.ErrorIs(t, err, tt.expectErr)
+			assert.NotErrorIs(t, err, handlerErr.ErrNoProject)
+			assert.Len(t, issue.Fields.Worklog.Worklogs, 1)
+		})
+	}
+}
+
+func TestCompleteComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/ISSUE-1/comment", r.URL.Path)
+		if r.URL.Query().Get("startAt") == "0" {
+			io.WriteString(w, `{"startAt":0,"maxResults":2,"total":3,"comments":[{"id":"1"},{"id":"2"}]}`)
+			return
+		}
+		io.WriteString(w, `{"startAt":2,"maxResults":2,"total":3,"comments":[{"id":"3"}]}`)
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	issue := structures.JiraIssue{Key: "ISSUE-1"}
+	issue.Fields.Comment = structures.Comments{Total: 3, Comments: []structures.Comment{{Id: "1"}}}
+
+	assert.NoError(t, conn.completeComments(context.Background(), &issue))
+	var ids []string
+	for _, comment := range issue.Fields.Comment.Comments {
+		ids = append(ids, comment.Id)
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Error", http.StatusForbidden)
+	})
+	issue.Fields.Comment = structures.Comments{Total: 3, Comments: []structures.Comment{{Id: "1"}}}
+	assert.ErrorIs(t, conn.completeComments(context.Background(), &issue), myErr.ErrGetComments)
+	assert.Len(t, issue.Fields.Comment.Comments, 1)
+}
+
+func TestProjectJql(t *testing.T) {
+	tests := []struct {
+		name     string
+		since    time.Time
+		expected string
+	}{
+		{
+			name:     "full",
+			since:    time.Time{},
+			expected: "project=TEST ORDER BY key ASC",
+		},
+		{
+			name:     "incremental in utc",
+			since:    time.Date(2024, 5, 2, 10, 30, 45, 0, time.UTC),
+			expected: `project=TEST AND updated >= "2024/05/01 10:30" ORDER BY key ASC`,
+		},
+		{
+			name:     "incremental with offset",
+			since:    time.Date(2024, 5, 2, 13, 30, 0, 0, time.FixedZone("", 3*3600)),
+			expected: `project=TEST AND updated >= "2024/05/01 10:30" ORDER BY key ASC`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, projectJql("TEST", tt.since))
+		})
+	}
+}
+
 func TestGetAllProjects_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -211,7 +637,7 @@ func TestGetAllProjects_ErrorCases(t *testing.T) {
 			handler: func(w http.ResponseWriter, r *http.Request) {
 				http.Error(w, "Not Found", http.StatusNotFound)
 			},
-			expectErr: handlerErr.ErrNoProject,
+			expectErr: myErr.ErrNotFound,
 		},
 		{
 			name: "read body error",
@@ -228,7 +654,7 @@ func TestGetAllProjects_ErrorCases(t *testing.T) {
 			defer server.Close()
 
 			conn := mockConnectorWithURL(server.URL)
-			_, err := conn.GetAllProjects()
+			_, err := conn.GetAllProjects(context.Background())
 			assert.Error(t, err)
 			if tt.expectErr != nil {
 				assert.True(t, errors.Is(err, tt.expectErr), "expected error %v, got %v", tt.expectErr, err)
@@ -266,7 +692,7 @@ func TestGetProjectsPage_ErrorCases(t *testing.T) {
 			defer server.Close()
 
 			conn := mockConnectorWithURL(server.URL)
-			_, err := conn.GetProjectsPage("", -1, -1) // Invalid page/limit
+			_, err := conn.GetProjectsPage(context.Background(), "", -1, -1) // Invalid page/limit
 			if tt.expectErr != nil {
 				assert.Error(t, err)
 				assert.True(t, errors.Is(err, tt.expectErr))
@@ -277,6 +703,18 @@ func TestGetProjectsPage_ErrorCases(t *testing.T) {
 	}
 }
 
+func TestGetProjectByKey_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	_, err := conn.GetProjectByKey(context.Background(), "TEST")
+	assert.ErrorIs(t, err, handlerErr.ErrNoProject)
+	assert.ErrorIs(t, err, myErr.ErrNotFound)
+}
+
 func TestGetProjectIssues_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -294,6 +732,13 @@ func TestGetProjectIssues_ErrorCases(t *testing.T) {
 			},
 			expectErr: myErr.ErrGetIssues,
 		},
+		{
+			name: "unknown project in jql",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+			},
+			expectErr: handlerErr.ErrNoProject,
+		},
 	}
 
 	for _, tt := range tests {
@@ -302,7 +747,7 @@ func TestGetProjectIssues_ErrorCases(t *testing.T) {
 			defer server.Close()
 
 			conn := mockConnectorWithURL(server.URL)
-			_, err := conn.GetProjectIssues("TEST")
+			_, err := conn.GetProjectIssues(context.Background(), "TEST", nil)
 			assert.Error(t, err)
 			if tt.expectErr != nil {
 				assert.True(t, errors.Is(err, tt.expectErr))
@@ -311,7 +756,7 @@ func TestGetProjectIssues_ErrorCases(t *testing.T) {
 	}
 }
 
-func TestGetIssuesForOneThread_ErrorCases(t *testing.T) {
+func TestGetIssuesPage_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name      string
 		handler   http.HandlerFunc
@@ -347,7 +792,7 @@ func TestGetIssuesForOneThread_ErrorCases(t *testing.T) {
 			defer server.Close()
 
 			conn := mockConnectorWithURL(server.URL)
-			_, err := conn.getIssuesForOneThread(0, "TEST")
+			_, err := conn.getIssuesPage(context.Background(), 0, 1, projectJql("TEST", time.Time{}))
 			assert.Error(t, err)
 			if tt.expectErr != nil {
 				assert.True(t, errors.Is(err, tt.expectErr))
@@ -392,7 +837,7 @@ func TestGetTotalIssues_ErrorCases(t *testing.T) {
 			defer server.Close()
 
 			conn := mockConnectorWithURL(server.URL)
-			_, err := conn.getTotalIssues("TEST")
+			_, err := conn.getTotalIssues(context.Background(), projectJql("TEST", time.Time{}))
 			assert.Error(t, err)
 			if tt.expectErr != nil {
 				assert.True(t, errors.Is(err, tt.expectErr))
@@ -419,7 +864,7 @@ func TestRetryRequest_ErrorCases(t *testing.T) {
 			handler: func(w http.ResponseWriter, r *http.Request) {
 				http.Error(w, "Bad Request", http.StatusBadRequest)
 			},
-			expectErr: handlerErr.ErrNoProject,
+			expectErr: myErr.ErrBadRequest,
 		},
 	}
 
@@ -429,7 +874,7 @@ func TestRetryRequest_ErrorCases(t *testing.T) {
 			defer server.Close()
 
 			conn := mockConnectorWithURL(server.URL)
-			_, err := conn.retryRequest("GET", server.URL)
+			_, err := conn.retryRequest(context.Background(), "GET", server.URL)
 			assert.Error(t, err)
 			if tt.expectErr != nil {
 				assert.True(t, errors.Is(err, tt.expectErr))
@@ -438,6 +883,45 @@ func TestRetryRequest_ErrorCases(t *testing.T) {
 	}
 }
 
+func TestRetryRequest_Cancelled(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		http.Error(w, "Error", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	conn.SetRetryPolicy(&BackoffPolicy{MinSleep: time.Hour, MaxSleep: 2 * time.Hour, MaxRetries: 5})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := conn.retryRequest(ctx, "GET", server.URL)
+	// waiting for the next retry is interrupted
+	assert.Less(t, time.Since(start), time.Second)
+	assert.ErrorIs(t, err, myErr.ErrCancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, handlerErr.ErrNoProject)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestGetProjectIssues_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request mustn't be sent")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	conn := mockConnectorWithURL(server.URL)
+	issues, err := conn.GetProjectIssues(ctx, "TEST", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, issues)
+}
+
 func TestContainsSearchProject(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -459,3 +943,258 @@ func TestContainsSearchProject(t *testing.T) {
 		})
 	}
 }
+
+func readFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func mockConnectorV3(url string) *JiraConnector {
+	cfg := config.Config{
+		JiraCfg: config.JiraConfig{
+			Url:           url,
+			ApiVersion:    "3",
+			MinSleep:      10,
+			MaxSleep:      100,
+			IssueInOneReq: 2,
+		},
+	}
+	con, err := NewJiraConnector(&cfg.JiraCfg, slog.Default())
+	if err != nil {
+		panic(err)
+	}
+	return con
+}
+
+func TestNewJiraConnector_ApiVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     string
+		wantPath    string
+		expectedErr error
+	}{
+		{name: "default", version: "", wantPath: "/rest/api/2/project"},
+		{name: "v2", version: "2", wantPath: "/rest/api/2/project"},
+		{name: "v3 with prefix", version: "v3", wantPath: "/rest/api/3/project"},
+		{name: "unsupported", version: "4", expectedErr: myErr.ErrApiVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path atomic.Value
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path.Store(r.URL.Path)
+				io.WriteString(w, `[]`)
+			}))
+			defer server.Close()
+
+			cfg := config.Config{JiraCfg: config.JiraConfig{Url: server.URL, ApiVersion: tt.version}}
+			conn, err := NewJiraConnector(&cfg.JiraCfg, slog.Default())
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			_, err = conn.GetAllProjects(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPath, path.Load())
+		})
+	}
+}
+
+func TestGetProjectIssues_V3TokenPagination(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		tokens []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/search/approximate-count":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "project=CLOUD ORDER BY key ASC", body["jql"])
+			w.Write(readFixture(t, "v3/approximate_count.json"))
+
+		case "/rest/api/3/search/jql":
+			query := r.URL.Query()
+			assert.Equal(t, "project=CLOUD ORDER BY key ASC", query.Get("jql"))
+			assert.Equal(t, "changelog", query.Get("expand"))
+			assert.Equal(t, "2", query.Get("maxResults"))
+			assert.Empty(t, query.Get("startAt"))
+
+			mu.Lock()
+			tokens = append(tokens, query.Get("nextPageToken"))
+			mu.Unlock()
+			if query.Get("nextPageToken") == "" {
+				w.Write(readFixture(t, "v3/search_jql_page1.json"))
+				return
+			}
+			w.Write(readFixture(t, "v3/search_jql_page2.json"))
+
+		case "/rest/api/3/issue/CLOUD-3/changelog":
+			w.Write(readFixture(t, "v3/changelog_cloud3.json"))
+
+		default:
+			http.Error(w, "unexpected path "+r.URL.Path, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var progress [][2]int
+	conn := mockConnectorV3(server.URL)
+	issues, err := conn.GetProjectIssues(context.Background(), "CLOUD", func(fetched, total int) {
+		progress = append(progress, [2]int{fetched, total})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "CAEaAggD"}, tokens)
+	assert.Equal(t, [][2]int{{0, 3}, {2, 3}, {3, 3}}, progress)
+
+	if assert.Len(t, issues, 3) {
+		assert.Equal(t, "CLOUD-1", issues[0].Key)
+		assert.Equal(t, "5b10a2844c20165700ede21g", issues[0].Fields.Author.AccountId)
+		assert.Equal(t, "Mia Krystof", issues[0].Fields.Author.DisplayName)
+		assert.Contains(t, string(issues[0].Fields.Description), `"type": "doc"`)
+		assert.Equal(t, "null", string(issues[1].Fields.Description))
+		// truncated changelog is downloaded separately
+		assert.Len(t, issues[2].Changelog.Histories, 2)
+		assert.Equal(t, "Done", issues[2].Changelog.Histories[1].Items[0].ToString)
+	}
+}
+
+func TestGetProjectIssues_V3Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		countStatus int
+		pageBody    string
+		expectedErr error
+	}{
+		{name: "count failed", countStatus: http.StatusBadRequest, expectedErr: handlerErr.ErrNoProject},
+		{name: "invalid page", countStatus: http.StatusOK, pageBody: `{"issues":`, expectedErr: myErr.ErrUnmarshalAns},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/rest/api/3/search/approximate-count" {
+					w.WriteHeader(tt.countStatus)
+					io.WriteString(w, `{"count": 1}`)
+					return
+				}
+				io.WriteString(w, tt.pageBody)
+			}))
+			defer server.Close()
+
+			conn := mockConnectorV3(server.URL)
+			issues, err := conn.GetProjectIssues(context.Background(), "CLOUD", nil)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.ErrorIs(t, err, myErr.ErrGetIssues)
+			assert.Nil(t, issues)
+		})
+	}
+}
+
+func TestGetFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/field", r.URL.Path)
+		w.Write(readFixture(t, "fields.json"))
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	fields, err := conn.GetFields(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, fields, 5)
+	assert.Equal(t, structures.JiraField{
+		Id:     "customfield_10020",
+		Name:   "Sprint",
+		Custom: true,
+		Schema: structures.JiraFieldSchema{Type: "array", Items: "json", Custom: "com.pyxis.greenhopper.jira:gh-sprint"},
+	}, fields[2])
+}
+
+func TestGetFields_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		expectErr error
+	}{
+		{
+			name: "forbidden",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expectErr: myErr.ErrGetFields,
+		},
+		{
+			name: "invalid json",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, `{"id":`)
+			},
+			expectErr: myErr.ErrUnmarshalAns,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			conn := mockConnectorWithURL(server.URL)
+			_, err := conn.GetFields(context.Background())
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
+
+func TestGetPriorities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/priority", r.URL.Path)
+		io.WriteString(w, `[{"id": "1", "name": "Highest"}, {"id": "10000", "name": "Blocker"}, {"id": "3", "name": "Medium"}]`)
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	priorities, err := conn.GetPriorities(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []structures.JiraPriority{
+		{Id: "1", Name: "Highest"},
+		{Id: "10000", Name: "Blocker"},
+		{Id: "3", Name: "Medium"},
+	}, priorities)
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	_, err = conn.GetPriorities(context.Background())
+	assert.ErrorIs(t, err, myErr.ErrGetPriority)
+}
+
+func TestGetProjectIssues_CustomFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("maxResults") == "0" {
+			io.WriteString(w, `{"total": 1}`)
+			return
+		}
+		io.WriteString(w, `{"total": 1, "issues": [{"key": "TEST-1", "fields": {
+			"summary": "Task",
+			"customfield_10016": 5,
+			"customfield_10020": null
+		}}]}`)
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	issues, err := conn.GetProjectIssues(context.Background(), "TEST", nil)
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "Task", issues[0].Fields.Summary)
+	assert.JSONEq(t, "5", string(issues[0].Fields.Custom["customfield_10016"]))
+	assert.JSONEq(t, "null", string(issues[0].Fields.Custom["customfield_10020"]))
+	assert.NotContains(t, issues[0].Fields.Custom, "summary")
+}
@@ -3,12 +3,35 @@ package errors
 import "errors"
 
 var (
+	ErrAuthConfig = errors.New("incorrect jira auth config")
+	ErrApiVersion = errors.New("unsupported jira api version - need 2 or 3")
+
 	ErrMakeRequest    = errors.New("error make request")
 	ErrMaxTimeRequest = errors.New("unsucsess request - the maximum request execution time has been reached")
+	ErrCancelled      = errors.New("request cancelled")
+
+	ErrTransport        = errors.New("can't reach jira")
+	ErrUnauthorized     = errors.New("jira rejected credentials")
+	ErrForbidden        = errors.New("no permission in jira")
+	ErrNotFound         = errors.New("jira resource not found")
+	ErrBadRequest       = errors.New("jira rejected request")
+	ErrRateLimited      = errors.New("jira rate limit exceeded")
+	ErrServer           = errors.New("jira server error")
+	ErrUnexpectedStatus = errors.New("unexpected jira response status")
 
 	ErrReadResponseBody = errors.New("can't read responce body")
 	ErrUnmarshalAns     = errors.New("can't unmarshal responce body")
+	ErrCompleteRaw      = errors.New("can't put completed parts into raw issue")
+
+	ErrGetIssues    = errors.New("can't get issues")
+	ErrGetChangelog = errors.New("can't get issue changelog")
+	ErrGetWorklog   = errors.New("can't get issue worklog")
+	ErrGetComments  = errors.New("can't get issue comments")
+	ErrGetProjects  = errors.New("can't get project")
+	ErrGetFields    = errors.New("can't get fields")
+	ErrGetPriority  = errors.New("can't get priorities")
 
-	ErrGetIssues   = errors.New("can't get issues")
-	ErrGetProjects = errors.New("can't get project")
+	ErrGetBoards       = errors.New("can't get boards")
+	ErrGetSprints      = errors.New("can't get sprints")
+	ErrGetSprintIssues = errors.New("can't get sprint issues")
 )
@@ -0,0 +1,160 @@
+package connector
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jiraconnector/pkg/config"
+	"golang.org/x/time/rate"
+)
+
+const defaultMaxRetries = 5
+
+// RetryPolicy decides whether a failed attempt is repeated and how long to wait before it.
+// resp is nil if the request failed on the transport level, its body is already closed
+type RetryPolicy interface {
+	Backoff(attempt int, resp *http.Response, err error) (time.Duration, bool)
+}
+
+// BackoffPolicy retries transport errors, 429 and 5xx responses with exponential
+// backoff and jitter. Delay requested by Jira (Retry-After, X-RateLimit-Reset) is
+// used instead of the backoff if it is known
+type BackoffPolicy struct {
+	MinSleep   time.Duration
+	MaxSleep   time.Duration
+	MaxRetries int
+}
+
+// NewBackoffPolicy reads the policy from config, sleeps are set in milliseconds
+func NewBackoffPolicy(cfg *config.JiraConfig) *BackoffPolicy {
+	maxRetries := defaultMaxRetries
+	if cfg.MaxRetries != nil && *cfg.MaxRetries >= 0 {
+		maxRetries = *cfg.MaxRetries
+	}
+
+	return &BackoffPolicy{
+		MinSleep:   time.Duration(max(cfg.MinSleep, 1)) * time.Millisecond,
+		MaxSleep:   time.Duration(max(cfg.MaxSleep, cfg.MinSleep, 1)) * time.Millisecond,
+		MaxRetries: maxRetries,
+	}
+}
+
+func (p *BackoffPolicy) Backoff(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxRetries {
+		return 0, false
+	}
+
+	if err != nil && isAuthError(err) {
+		return 0, false
+	}
+	if err == nil && !retryableStatus(resp.StatusCode) {
+		return 0, false
+	}
+
+	if delay := serverDelay(resp, time.Now()); delay > 0 {
+		// small jitter, so workers don't come back at the same moment
+		return delay + jitter(p.MinSleep), true
+	}
+
+	sleep := p.MaxSleep
+	if attempt < 32 && p.MinSleep<<attempt < p.MaxSleep {
+		sleep = p.MinSleep << attempt
+	}
+	// equal jitter: at least half of the backoff
+	return sleep/2 + jitter(sleep/2), true
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
+
+// serverDelay returns how long Jira asks to wait before the next request:
+// Retry-After of 429/503 responses or X-RateLimit-Reset when no requests remain
+func serverDelay(resp *http.Response, now time.Time) time.Duration {
+	if resp == nil {
+		return 0
+	}
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(max(seconds, 0)) * time.Second
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(now), 0)
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0
+	}
+	value := resp.Header.Get("X-RateLimit-Reset")
+	if reset, err := time.Parse(time.RFC3339, value); err == nil {
+		return max(reset.Sub(now), 0)
+	}
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return max(time.Unix(unix, 0).Sub(now), 0)
+	}
+	return 0
+}
+
+// requestLimiter is shared by all workers of the connector: a token bucket from
+// config plus a pause for everyone when Jira reports that the rate limit is reached
+type requestLimiter struct {
+	bucket *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// newRequestLimiter doesn't limit requests if rate isn't set in config
+func newRequestLimiter(cfg *config.JiraConfig) *requestLimiter {
+	limit := rate.Inf
+	if cfg.RateLimit > 0 {
+		limit = rate.Limit(cfg.RateLimit)
+	}
+
+	return &requestLimiter{
+		bucket: rate.NewLimiter(limit, max(cfg.RateBurst, 1)),
+	}
+}
+
+func (l *requestLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+
+	if pause > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
+	}
+
+	return l.bucket.Wait(ctx)
+}
+
+// Observe pauses all requests if the response says that the rate limit is reached
+func (l *requestLimiter) Observe(resp *http.Response) {
+	delay := serverDelay(resp, time.Now())
+	if delay <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(delay); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
@@ -0,0 +1,299 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	handlerErr "github.com/jiraconnector/internal/apiJiraConnector/jiraHandlers/errors"
+	myErr "github.com/jiraconnector/internal/connector/errors"
+	"github.com/jiraconnector/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackoffPolicy(t *testing.T) {
+	retries := func(n int) *int { return &n }
+
+	p := NewBackoffPolicy(&config.JiraConfig{MinSleep: 50, MaxSleep: 8000, MaxRetries: retries(3)})
+	assert.Equal(t, 50*time.Millisecond, p.MinSleep)
+	assert.Equal(t, 8*time.Second, p.MaxSleep)
+	assert.Equal(t, 3, p.MaxRetries)
+
+	p = NewBackoffPolicy(&config.JiraConfig{})
+	assert.Equal(t, time.Millisecond, p.MinSleep)
+	assert.Equal(t, time.Millisecond, p.MaxSleep)
+	assert.Equal(t, defaultMaxRetries, p.MaxRetries)
+
+	// 0 turns retries off, a negative value is the default
+	p = NewBackoffPolicy(&config.JiraConfig{MaxRetries: retries(0)})
+	assert.Equal(t, 0, p.MaxRetries)
+	_, retry := p.Backoff(0, nil, errors.New("connection reset"))
+	assert.False(t, retry)
+
+	p = NewBackoffPolicy(&config.JiraConfig{MaxRetries: retries(-1)})
+	assert.Equal(t, defaultMaxRetries, p.MaxRetries)
+}
+
+func TestBackoffPolicy(t *testing.T) {
+	p := &BackoffPolicy{MinSleep: 100 * time.Millisecond, MaxSleep: time.Second, MaxRetries: 5}
+	withHeader := func(code int, header map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: code, Header: http.Header{}}
+		for k, v := range header {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name      string
+		attempt   int
+		resp      *http.Response
+		err       error
+		wantRetry bool
+		minDelay  time.Duration
+		maxDelay  time.Duration
+	}{
+		{
+			name:      "transport error",
+			err:       assert.AnError,
+			wantRetry: true,
+			minDelay:  50 * time.Millisecond,
+			maxDelay:  100 * time.Millisecond,
+		},
+		{
+			name:      "backoff grows",
+			attempt:   2,
+			resp:      withHeader(http.StatusBadGateway, nil),
+			wantRetry: true,
+			minDelay:  200 * time.Millisecond,
+			maxDelay:  400 * time.Millisecond,
+		},
+		{
+			name:      "backoff is capped",
+			attempt:   4,
+			resp:      withHeader(http.StatusInternalServerError, nil),
+			wantRetry: true,
+			minDelay:  500 * time.Millisecond,
+			maxDelay:  time.Second,
+		},
+		{
+			name:      "retry after seconds",
+			resp:      withHeader(http.StatusTooManyRequests, map[string]string{"Retry-After": "3"}),
+			wantRetry: true,
+			minDelay:  3 * time.Second,
+			maxDelay:  3*time.Second + 100*time.Millisecond,
+		},
+		{
+			name: "rate limit reset",
+			resp: withHeader(http.StatusTooManyRequests, map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     time.Now().Add(10 * time.Second).UTC().Format(time.RFC3339),
+			}),
+			wantRetry: true,
+			minDelay:  8 * time.Second,
+			maxDelay:  10*time.Second + 100*time.Millisecond,
+		},
+		{
+			name: "unauthorized isn't retried",
+			resp: withHeader(http.StatusUnauthorized, nil),
+		},
+		{
+			name: "forbidden isn't retried",
+			resp: withHeader(http.StatusForbidden, nil),
+		},
+		{
+			name:    "retries exhausted",
+			attempt: 5,
+			err:     assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := p.Backoff(tt.attempt, tt.resp, tt.err)
+			assert.Equal(t, tt.wantRetry, retry)
+			if tt.wantRetry {
+				assert.GreaterOrEqual(t, delay, tt.minDelay)
+				assert.LessOrEqual(t, delay, tt.maxDelay)
+			}
+		})
+	}
+}
+
+func TestServerDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   time.Duration
+	}{
+		{name: "no headers"},
+		{
+			name:   "retry after seconds",
+			header: map[string]string{"Retry-After": "5"},
+			want:   5 * time.Second,
+		},
+		{
+			name:   "retry after date",
+			header: map[string]string{"Retry-After": now.Add(time.Minute).Format(http.TimeFormat)},
+			want:   time.Minute,
+		},
+		{
+			name:   "requests remain",
+			header: map[string]string{"X-RateLimit-Remaining": "10", "X-RateLimit-Reset": now.Add(time.Minute).Format(time.RFC3339)},
+		},
+		{
+			name:   "reset timestamp",
+			header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": now.Add(time.Minute).Format(time.RFC3339)},
+			want:   time.Minute,
+		},
+		{
+			name:   "reset unix time",
+			header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)},
+			want:   30 * time.Second,
+		},
+		{
+			name:   "reset in the past",
+			header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": now.Add(-time.Minute).Format(time.RFC3339)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			for k, v := range tt.header {
+				resp.Header.Set(k, v)
+			}
+			assert.Equal(t, tt.want, serverDelay(resp, now))
+		})
+	}
+}
+
+func TestRetryRequest_TypedErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		expectErr    error
+		wantRequests int32
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, expectErr: myErr.ErrUnauthorized, wantRequests: 1},
+		{name: "forbidden", status: http.StatusForbidden, expectErr: myErr.ErrForbidden, wantRequests: 1},
+		{name: "not found", status: http.StatusNotFound, expectErr: myErr.ErrNotFound, wantRequests: 1},
+		{name: "bad request", status: http.StatusBadRequest, expectErr: myErr.ErrBadRequest, wantRequests: 1},
+		{name: "unexpected status", status: http.StatusConflict, expectErr: myErr.ErrUnexpectedStatus, wantRequests: 1},
+		{name: "rate limited", status: http.StatusTooManyRequests, expectErr: myErr.ErrRateLimited, wantRequests: 3},
+		{name: "server error", status: http.StatusServiceUnavailable, expectErr: myErr.ErrServer, wantRequests: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				http.Error(w, "Error", tt.status)
+			}))
+			defer server.Close()
+
+			conn := mockConnectorWithURL(server.URL)
+			conn.SetRetryPolicy(&BackoffPolicy{MinSleep: time.Millisecond, MaxSleep: time.Millisecond, MaxRetries: 2})
+
+			_, err := conn.retryRequest(context.Background(), "GET", server.URL)
+			assert.ErrorIs(t, err, tt.expectErr)
+			assert.Equal(t, tt.wantRequests, atomic.LoadInt32(&requests))
+			if tt.wantRequests > 1 {
+				assert.ErrorIs(t, err, myErr.ErrMaxTimeRequest)
+			}
+		})
+	}
+}
+
+func TestRetryRequest_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	conn := mockConnectorWithURL(url)
+	conn.SetRetryPolicy(&BackoffPolicy{MinSleep: time.Millisecond, MaxSleep: time.Millisecond, MaxRetries: 1})
+
+	_, err := conn.retryRequest(context.Background(), "GET", url)
+	assert.ErrorIs(t, err, myErr.ErrTransport)
+	assert.NotErrorIs(t, err, handlerErr.ErrNoProject)
+}
+
+func TestRetryRequest_RetryAfter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	start := time.Now()
+	resp, err := conn.retryRequest(context.Background(), "GET", server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	// backoff from config is much shorter, so the delay comes from the header
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRetryRequest_RateLimitPausesAllRequests(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Second).Unix(), 10))
+		}
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	conn := mockConnectorWithURL(server.URL)
+	resp, err := conn.retryRequest(context.Background(), "GET", server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	// the next request waits until the limit is reset
+	start := time.Now()
+	resp, err = conn.retryRequest(context.Background(), "GET", server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Greater(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRequestLimiter(t *testing.T) {
+	l := newRequestLimiter(&config.JiraConfig{RateLimit: 20, RateBurst: 1})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, l.Wait(context.Background()))
+	}
+	// burst of 1 and 20 rps: 4 waits of 50ms
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.Canceled)
+}
+
+func TestRequestLimiter_Unlimited(t *testing.T) {
+	l := newRequestLimiter(&config.JiraConfig{})
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, l.Wait(context.Background()))
+	}
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
@@ -1,19 +1,35 @@
 package datatransformer
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/jiraconnector/internal/adf"
 	"github.com/jiraconnector/internal/structures"
 )
 
 type DataTransformer struct {
-	Project       structures.DBProject
-	Issue         structures.DBIssue
+	Project structures.DBProject
+	Issue   structures.DBIssue
+	// Author is the creator, Reporter and Assignee are nil when they aren't set
 	Author        structures.DBAuthor
-	Assignee      structures.DBAuthor
-	StatusChanges map[string]structures.DBStatusChanges
+	Reporter      *structures.DBAuthor
+	Assignee      *structures.DBAuthor
+	StatusChanges []structures.DBStatusTransition
+	FieldChanges  []structures.DBFieldChange
+	CustomFields  []structures.DBCustomField
+	Links         []structures.DBIssueLink
+	Worklogs      []structures.DBWorklog
+	Comments      []structures.DBComment
+	Labels        []string
+	Components    []structures.DBComponent
+	FixVersions   []structures.DBVersion
 	baseUrl       string
 }
 
@@ -21,27 +37,378 @@ func NewDataTransformer(baseUrl string) *DataTransformer {
 	return &DataTransformer{baseUrl: baseUrl}
 }
 
-func (dt *DataTransformer) TransformStatusDB(jiraChanges *structures.Changelog) map[string]structures.DBStatusChanges {
-	statusChanges := make(map[string]structures.DBStatusChanges)
+// TransformStatusDB returns all status transitions of the issue ordered by time
+func (dt *DataTransformer) TransformStatusDB(jiraChanges *structures.Changelog) []structures.DBStatusTransition {
+	statusChanges := []structures.DBStatusTransition{}
 	for _, history := range jiraChanges.Histories {
 		for _, item := range history.Items {
 			if strings.Compare(item.Field, "status") == 0 {
 				createdTime, _ := time.Parse("2006-01-02T15:04:05.000-0700", history.Created)
-				statusChanges[history.Author.Name] = structures.DBStatusChanges{
+				statusChanges = append(statusChanges, structures.DBStatusTransition{
+					HistoryId:  history.Id,
+					Author:     *dt.TransformAuthorDB(&history.Author),
 					ChangeTime: createdTime,
 					FromStatus: item.FromString,
 					ToStatus:   item.ToString,
-				}
+				})
 			}
 		}
 	}
+
+	// jira returns histories oldest first, but it isn't documented
+	sort.SliceStable(statusChanges, func(i, j int) bool {
+		return statusChanges[i].ChangeTime.Before(statusChanges[j].ChangeTime)
+	})
 	return statusChanges
 }
 
+// TransformFieldChangesDB returns every changelog item of the issue ordered by time
+func (dt *DataTransformer) TransformFieldChangesDB(jiraChanges *structures.Changelog) []structures.DBFieldChange {
+	fieldChanges := []structures.DBFieldChange{}
+	for _, history := range jiraChanges.Histories {
+		createdTime, _ := time.Parse("2006-01-02T15:04:05.000-0700", history.Created)
+		for i, item := range history.Items {
+			fieldChanges = append(fieldChanges, structures.DBFieldChange{
+				HistoryId:  history.Id,
+				ItemIndex:  i,
+				Author:     *dt.TransformAuthorDB(&history.Author),
+				ChangeTime: createdTime,
+				Field:      item.Field,
+				FieldType:  item.Fieldtype,
+				FromValue:  item.From,
+				FromString: item.FromString,
+				ToValue:    item.To,
+				ToString:   item.ToString,
+			})
+		}
+	}
+
+	sort.SliceStable(fieldChanges, func(i, j int) bool {
+		return fieldChanges[i].ChangeTime.Before(fieldChanges[j].ChangeTime)
+	})
+	return fieldChanges
+}
+
+// TransformCustomFieldsDB returns values of the mapped custom fields (id -> logical name).
+// If several ids are mapped to one name, the first one with a value is used
+func (dt *DataTransformer) TransformCustomFieldsDB(jiraIssue *structures.JiraIssue, fields map[string]string) []structures.DBCustomField {
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	customFields := []structures.DBCustomField{}
+	found := make(map[string]bool)
+	for _, id := range ids {
+		name := fields[id]
+		if found[name] {
+			continue
+		}
+
+		values := customFieldValues(jiraIssue.Fields.Custom[id])
+		if len(values) == 0 {
+			continue
+		}
+		found[name] = true
+
+		for i, value := range values {
+			customFields = append(customFields, structures.DBCustomField{
+				Name:        name,
+				FieldId:     id,
+				ValueIndex:  i,
+				Value:       value.text,
+				NumberValue: value.number,
+			})
+		}
+	}
+	return customFields
+}
+
+type customValue struct {
+	text   string
+	number *float64
+}
+
+// customFieldValues converts the raw value to text, arrays (sprints) give a value per item
+func customFieldValues(raw json.RawMessage) []customValue {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		items = []any{value}
+	}
+
+	var values []customValue
+	for _, item := range items {
+		if v, ok := customValueOf(item); ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// customValueOf takes the readable part of objects: option value, sprint or team name, user name
+func customValueOf(item any) (customValue, bool) {
+	switch v := item.(type) {
+	case json.Number:
+		number, err := v.Float64()
+		if err != nil {
+			return customValue{text: v.String()}, true
+		}
+		return customValue{text: v.String(), number: &number}, true
+
+	case string:
+		if name := legacySprintName(v); name != "" {
+			return customValue{text: name}, true
+		}
+		return customValue{text: v}, v != ""
+
+	case bool:
+		return customValue{text: strconv.FormatBool(v)}, true
+
+	case map[string]any:
+		for _, key := range []string{"name", "value", "title", "displayName", "key", "id"} {
+			if field, ok := v[key]; ok {
+				return customValueOf(field)
+			}
+		}
+	}
+	return customValue{}, false
+}
+
+// Jira Server before 8 returns sprints as serialized objects:
+// com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=1,rapidViewId=1,state=CLOSED,name=Sprint 1,startDate=...]
+var legacySprint = regexp.MustCompile(`^com\.atlassian\.greenhopper\.service\.sprint\.Sprint@\w+\[.*?\bname=(.*?),(?:goal|startDate)=`)
+
+func legacySprintName(value string) string {
+	match := legacySprint.FindStringSubmatch(value)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// TransformIssueLinksDB returns the parent, subtasks and typed links of the issue.
+// A link between two issues is returned for both of them, each in its own direction
+func (dt *DataTransformer) TransformIssueLinksDB(jiraIssue *structures.JiraIssue) []structures.DBIssueLink {
+	links := []structures.DBIssueLink{}
+	seen := make(map[structures.DBIssueLink]bool)
+	add := func(link structures.DBIssueLink) {
+		if link.LinkedKey == "" || seen[link] {
+			return
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+
+	if parent := jiraIssue.Fields.Parent; parent != nil {
+		add(structures.DBIssueLink{LinkType: structures.LinkParent, LinkedKey: parent.Key, Description: structures.LinkParent})
+	}
+	for _, subtask := range jiraIssue.Fields.Subtasks {
+		add(structures.DBIssueLink{LinkType: structures.LinkSubtask, LinkedKey: subtask.Key, Description: structures.LinkSubtask})
+	}
+
+	for _, link := range jiraIssue.Fields.Links {
+		if link.OutwardIssue != nil {
+			add(structures.DBIssueLink{LinkType: link.Type.Name, Direction: structures.LinkOutward,
+				LinkedKey: link.OutwardIssue.Key, Description: link.Type.Outward})
+		}
+		if link.InwardIssue != nil {
+			add(structures.DBIssueLink{LinkType: link.Type.Name, Direction: structures.LinkInward,
+				LinkedKey: link.InwardIssue.Key, Description: link.Type.Inward})
+		}
+	}
+	return links
+}
+
+// TransformWorklogsDB returns work logged on the issue ordered by start time
+func (dt *DataTransformer) TransformWorklogsDB(jiraWorklogs *structures.Worklogs) []structures.DBWorklog {
+	worklogs := []structures.DBWorklog{}
+	for _, worklog := range jiraWorklogs.Worklogs {
+		started, _ := time.Parse("2006-01-02T15:04:05.000-0700", worklog.Started)
+		worklogs = append(worklogs, structures.DBWorklog{
+			WorklogId: worklog.Id,
+			Author:    *dt.TransformAuthorDB(&worklog.Author),
+			Started:   started,
+			Seconds:   worklog.TimeSpentSeconds,
+			Comment:   TransformDescription(worklog.Comment),
+		})
+	}
+
+	sort.SliceStable(worklogs, func(i, j int) bool {
+		return worklogs[i].Started.Before(worklogs[j].Started)
+	})
+	return worklogs
+}
+
+// TransformCommentsDB returns comments of the issue ordered by creation time
+func (dt *DataTransformer) TransformCommentsDB(jiraComments *structures.Comments) []structures.DBComment {
+	layout := "2006-01-02T15:04:05.000-0700"
+	comments := []structures.DBComment{}
+	for _, comment := range jiraComments.Comments {
+		createdTime, _ := time.Parse(layout, comment.Created)
+		updatedTime, _ := time.Parse(layout, comment.Updated)
+		comments = append(comments, structures.DBComment{
+			CommentId:   comment.Id,
+			Author:      *dt.TransformAuthorDB(&comment.Author),
+			CreatedTime: createdTime,
+			UpdatedTime: updatedTime,
+			Body:        TransformDescription(comment.Body),
+		})
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedTime.Before(comments[j].CreatedTime)
+	})
+	return comments
+}
+
+// TransformLabelsDB returns labels of the issue without empty and repeated ones
+func (dt *DataTransformer) TransformLabelsDB(jiraIssue *structures.JiraIssue) []string {
+	labels := []string{}
+	seen := make(map[string]bool)
+	for _, label := range jiraIssue.Fields.Labels {
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// TransformComponentsDB returns components of the issue, they are identified by name within the project
+func (dt *DataTransformer) TransformComponentsDB(jiraIssue *structures.JiraIssue) []structures.DBComponent {
+	components := []structures.DBComponent{}
+	seen := make(map[string]bool)
+	for _, component := range jiraIssue.Fields.Components {
+		if component.Name == "" || seen[component.Name] {
+			continue
+		}
+		seen[component.Name] = true
+		components = append(components, structures.DBComponent{Name: component.Name})
+	}
+	return components
+}
+
+// TransformFixVersionsDB returns fix versions of the issue, they are identified by name within the project
+func (dt *DataTransformer) TransformFixVersionsDB(jiraIssue *structures.JiraIssue) []structures.DBVersion {
+	versions := []structures.DBVersion{}
+	seen := make(map[string]bool)
+	for _, version := range jiraIssue.Fields.FixVersions {
+		if version.Name == "" || seen[version.Name] {
+			continue
+		}
+		seen[version.Name] = true
+
+		var releaseDate *time.Time
+		if date, err := time.Parse(time.DateOnly, version.ReleaseDate); err == nil {
+			releaseDate = &date
+		}
+		versions = append(versions, structures.DBVersion{
+			Name:        version.Name,
+			Released:    version.Released,
+			ReleaseDate: releaseDate,
+		})
+	}
+	return versions
+}
+
+// TransformPrioritiesDB ranks priorities by their position in the list of Jira
+func (dt *DataTransformer) TransformPrioritiesDB(jiraPriorities []structures.JiraPriority) []structures.DBPriority {
+	priorities := make([]structures.DBPriority, 0, len(jiraPriorities))
+	for i, priority := range jiraPriorities {
+		priorities = append(priorities, structures.DBPriority{
+			JiraId: priority.Id,
+			Name:   priority.Name,
+			Rank:   i + 1,
+		})
+	}
+	return priorities
+}
+
+// TransformBoardsDB converts scrum boards with their sprints. Sprint dates which
+// aren't set yet (future sprints) or can't be parsed are nil
+func (dt *DataTransformer) TransformBoardsDB(jiraBoards []structures.JiraAgileBoard) []structures.DBBoard {
+	boards := make([]structures.DBBoard, 0, len(jiraBoards))
+	for _, jiraBoard := range jiraBoards {
+		board := structures.DBBoard{
+			JiraId: jiraBoard.Board.Id,
+			Name:   jiraBoard.Board.Name,
+			Type:   jiraBoard.Board.Type,
+		}
+		for _, jiraSprint := range jiraBoard.Sprints {
+			sprint := jiraSprint.Sprint
+			board.Sprints = append(board.Sprints, structures.DBSprint{
+				JiraId:       sprint.Id,
+				Name:         sprint.Name,
+				State:        sprint.State,
+				Goal:         sprint.Goal,
+				StartTime:    sprintTime(sprint.StartDate),
+				EndTime:      sprintTime(sprint.EndDate),
+				CompleteTime: sprintTime(sprint.CompleteDate),
+				IssueKeys:    jiraSprint.IssueKeys,
+			})
+		}
+		boards = append(boards, board)
+	}
+	return boards
+}
+
+// sprintTime parses dates of the agile api, they are RFC 3339 unlike the dates of issues
+func sprintTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 func (dt *DataTransformer) TransformAuthorDB(jiraAuthor *structures.User) *structures.DBAuthor {
 	return &structures.DBAuthor{
-		Name: jiraAuthor.Name,
+		AccountId: userId(jiraAuthor),
+		Name:      userName(jiraAuthor),
+		Email:     jiraAuthor.EmailAddress,
+		Active:    jiraAuthor.Active,
+	}
+}
+
+// TransformOptionalAuthorDB returns nil for a missing user: unassigned issue or hidden reporter
+func (dt *DataTransformer) TransformOptionalAuthorDB(jiraUser *structures.User) *structures.DBAuthor {
+	if jiraUser == nil || userId(jiraUser) == "" {
+		return nil
 	}
+	return dt.TransformAuthorDB(jiraUser)
+}
+
+// userId is the stable id of the user: Jira Cloud has only account ids, Jira Server
+// has user keys which survive renames. Name is the last resort for old Jira versions
+func userId(user *structures.User) string {
+	switch {
+	case user.AccountId != "":
+		return user.AccountId
+	case user.Key != "":
+		return user.Key
+	}
+	return user.Name
+}
+
+// userName is the name shown to people, it falls back to the login
+func userName(user *structures.User) string {
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+	return user.Name
 }
 
 func (dt *DataTransformer) TransformProjectDB(jiraProject *structures.JiraProject) *structures.DBProject {
@@ -61,10 +428,12 @@ func (dt *DataTransformer) TransformIssueDB(jiraIssue *structures.JiraIssue) *st
 	closedTime, _ := time.Parse(layout, jiraIssue.Fields.ClosedTime)
 
 	return &structures.DBIssue{
+		JiraId:      jiraIssue.Id,
 		Key:         jiraIssue.Key,
 		Summary:     jiraIssue.Fields.Summary,
-		Description: jiraIssue.Fields.Description,
-		Type:        jiraIssue.Fields.Type.Description,
+		Description: TransformDescription(jiraIssue.Fields.Description),
+		Type:        jiraIssue.Fields.Type.Name,
+		TypeId:      jiraIssue.Fields.Type.Id,
 		Priority:    jiraIssue.Fields.Priority.Name,
 		Status:      jiraIssue.Fields.Status.Name,
 		CreatedTime: createdTime,
@@ -74,12 +443,40 @@ func (dt *DataTransformer) TransformIssueDB(jiraIssue *structures.JiraIssue) *st
 	}
 }
 
+// TransformDescription returns plain text of REST v2 as is and converts ADF of v3 to Markdown.
+// Broken ADF is saved as raw JSON, so the text isn't lost
+func TransformDescription(description json.RawMessage) string {
+	description = bytes.TrimSpace(description)
+	if len(description) == 0 || bytes.Equal(description, []byte("null")) {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(description, &text); err == nil {
+		return text
+	}
+
+	text, err := adf.ToMarkdown(description)
+	if err != nil {
+		return string(description)
+	}
+	return text
+}
+
 func (dt *DataTransformer) TransformToDbIssueSet(project *structures.JiraProject, jiraIssue *structures.JiraIssue) *DataTransformer {
 	return &DataTransformer{
 		Project:       *dt.TransformProjectDB(project),
 		Issue:         *dt.TransformIssueDB(jiraIssue),
 		Author:        *dt.TransformAuthorDB(&jiraIssue.Fields.Author),
-		Assignee:      *dt.TransformAuthorDB(&jiraIssue.Fields.Assignee),
+		Reporter:      dt.TransformOptionalAuthorDB(jiraIssue.Fields.Reporter),
+		Assignee:      dt.TransformOptionalAuthorDB(jiraIssue.Fields.Assignee),
 		StatusChanges: dt.TransformStatusDB(&jiraIssue.Changelog),
+		FieldChanges:  dt.TransformFieldChangesDB(&jiraIssue.Changelog),
+		Links:         dt.TransformIssueLinksDB(jiraIssue),
+		Worklogs:      dt.TransformWorklogsDB(&jiraIssue.Fields.Worklog),
+		Comments:      dt.TransformCommentsDB(&jiraIssue.Fields.Comment),
+		Labels:        dt.TransformLabelsDB(jiraIssue),
+		Components:    dt.TransformComponentsDB(jiraIssue),
+		FixVersions:   dt.TransformFixVersionsDB(jiraIssue),
 	}
 }
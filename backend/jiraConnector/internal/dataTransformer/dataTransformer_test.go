@@ -1,6 +1,7 @@
 package datatransformer
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -9,17 +10,19 @@ import (
 )
 
 func TestTransformStatusDB(t *testing.T) {
+	zone := time.FixedZone("", -7*3600)
 
 	tests := []struct {
 		name     string
 		input    structures.Changelog
-		expected map[string]structures.DBStatusChanges
+		expected []structures.DBStatusTransition
 	}{
 		{
 			name: "single status change",
 			input: structures.Changelog{
 				Histories: []structures.History{
 					{
+						Id:      "100",
 						Created: "2023-01-01T10:00:00.000-0700",
 						Author:  structures.User{Name: "user1"},
 						Items: []structures.Item{
@@ -32,9 +35,11 @@ func TestTransformStatusDB(t *testing.T) {
 					},
 				},
 			},
-			expected: map[string]structures.DBStatusChanges{
-				"user1": {
-					ChangeTime: time.Date(2023, 1, 1, 10, 0, 0, 0, time.FixedZone("", -7*3600)),
+			expected: []structures.DBStatusTransition{
+				{
+					HistoryId:  "100",
+					Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+					ChangeTime: time.Date(2023, 1, 1, 10, 0, 0, 0, zone),
 					FromStatus: "Open",
 					ToStatus:   "In Progress",
 				},
@@ -45,6 +50,7 @@ func TestTransformStatusDB(t *testing.T) {
 			input: structures.Changelog{
 				Histories: []structures.History{
 					{
+						Id:      "100",
 						Created: "2023-01-01T10:00:00.000-0700",
 						Author:  structures.User{Name: "user1"},
 						Items: []structures.Item{
@@ -61,6 +67,7 @@ func TestTransformStatusDB(t *testing.T) {
 						},
 					},
 					{
+						Id:      "101",
 						Created: "2023-01-02T11:00:00.000-0700",
 						Author:  structures.User{Name: "user2"},
 						Items: []structures.Item{
@@ -73,23 +80,75 @@ func TestTransformStatusDB(t *testing.T) {
 					},
 				},
 			},
-			expected: map[string]structures.DBStatusChanges{
-				"user1": {
-					ChangeTime: time.Date(2023, 1, 1, 10, 0, 0, 0, time.FixedZone("", -7*3600)),
+			expected: []structures.DBStatusTransition{
+				{
+					HistoryId:  "100",
+					Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+					ChangeTime: time.Date(2023, 1, 1, 10, 0, 0, 0, zone),
 					FromStatus: "Open",
 					ToStatus:   "In Progress",
 				},
-				"user2": {
-					ChangeTime: time.Date(2023, 1, 2, 11, 0, 0, 0, time.FixedZone("", -7*3600)),
+				{
+					HistoryId:  "101",
+					Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
+					ChangeTime: time.Date(2023, 1, 2, 11, 0, 0, 0, zone),
 					FromStatus: "In Progress",
 					ToStatus:   "Done",
 				},
 			},
 		},
+		{
+			name: "same author moves issue several times",
+			input: structures.Changelog{
+				Histories: []structures.History{
+					{
+						Id:      "202",
+						Created: "2023-01-03T09:00:00.000-0700",
+						Author:  structures.User{Name: "user1"},
+						Items:   []structures.Item{{Field: "status", FromString: "Closed", ToString: "Reopened"}},
+					},
+					{
+						Id:      "200",
+						Created: "2023-01-01T09:00:00.000-0700",
+						Author:  structures.User{Name: "user1"},
+						Items:   []structures.Item{{Field: "status", FromString: "Open", ToString: "In Progress"}},
+					},
+					{
+						Id:      "201",
+						Created: "2023-01-02T09:00:00.000-0700",
+						Author:  structures.User{Name: "user1"},
+						Items:   []structures.Item{{Field: "status", FromString: "In Progress", ToString: "Closed"}},
+					},
+				},
+			},
+			expected: []structures.DBStatusTransition{
+				{
+					HistoryId:  "200",
+					Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+					ChangeTime: time.Date(2023, 1, 1, 9, 0, 0, 0, zone),
+					FromStatus: "Open",
+					ToStatus:   "In Progress",
+				},
+				{
+					HistoryId:  "201",
+					Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+					ChangeTime: time.Date(2023, 1, 2, 9, 0, 0, 0, zone),
+					FromStatus: "In Progress",
+					ToStatus:   "Closed",
+				},
+				{
+					HistoryId:  "202",
+					Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+					ChangeTime: time.Date(2023, 1, 3, 9, 0, 0, 0, zone),
+					FromStatus: "Closed",
+					ToStatus:   "Reopened",
+				},
+			},
+		},
 		{
 			name:     "empty changelog",
 			input:    structures.Changelog{},
-			expected: map[string]structures.DBStatusChanges{},
+			expected: []structures.DBStatusTransition{},
 		},
 	}
 
@@ -103,6 +162,93 @@ func TestTransformStatusDB(t *testing.T) {
 	}
 }
 
+func TestTransformFieldChangesDB(t *testing.T) {
+	zone := time.FixedZone("", -7*3600)
+
+	tests := []struct {
+		name     string
+		input    structures.Changelog
+		expected []structures.DBFieldChange
+	}{
+		{
+			name: "all items of the history are kept",
+			input: structures.Changelog{
+				Histories: []structures.History{
+					{
+						Id:      "101",
+						Created: "2023-01-02T11:00:00.000-0700",
+						Author:  structures.User{Name: "user2"},
+						Items: []structures.Item{
+							{Field: "assignee", Fieldtype: "jira", From: "user1", FromString: "User 1", To: "user3", ToString: "User 3"},
+						},
+					},
+					{
+						Id:      "100",
+						Created: "2023-01-01T10:00:00.000-0700",
+						Author:  structures.User{Name: "user1"},
+						Items: []structures.Item{
+							{Field: "status", Fieldtype: "jira", From: "1", FromString: "Open", To: "3", ToString: "In Progress"},
+							{Field: "priority", Fieldtype: "jira", From: "4", FromString: "Low", To: "2", ToString: "High"},
+						},
+					},
+				},
+			},
+			expected: []structures.DBFieldChange{
+				{
+					HistoryId:  "100",
+					ItemIndex:  0,
+					Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+					ChangeTime: time.Date(2023, 1, 1, 10, 0, 0, 0, zone),
+					Field:      "status",
+					FieldType:  "jira",
+					FromValue:  "1",
+					FromString: "Open",
+					ToValue:    "3",
+					ToString:   "In Progress",
+				},
+				{
+					HistoryId:  "100",
+					ItemIndex:  1,
+					Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+					ChangeTime: time.Date(2023, 1, 1, 10, 0, 0, 0, zone),
+					Field:      "priority",
+					FieldType:  "jira",
+					FromValue:  "4",
+					FromString: "Low",
+					ToValue:    "2",
+					ToString:   "High",
+				},
+				{
+					HistoryId:  "101",
+					ItemIndex:  0,
+					Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
+					ChangeTime: time.Date(2023, 1, 2, 11, 0, 0, 0, zone),
+					Field:      "assignee",
+					FieldType:  "jira",
+					FromValue:  "user1",
+					FromString: "User 1",
+					ToValue:    "user3",
+					ToString:   "User 3",
+				},
+			},
+		},
+		{
+			name:     "empty changelog",
+			input:    structures.Changelog{},
+			expected: []structures.DBFieldChange{},
+		},
+	}
+
+	dt := NewDataTransformer("base_url")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := dt.TransformFieldChangesDB(&tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestTransformAuthorDB(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -112,7 +258,18 @@ func TestTransformAuthorDB(t *testing.T) {
 		{
 			name:     "regular user",
 			input:    structures.User{Name: "john.doe"},
-			expected: &structures.DBAuthor{Name: "john.doe"},
+			expected: &structures.DBAuthor{AccountId: "john.doe", Name: "john.doe"},
+		},
+		{
+			name:     "cloud user without name",
+			input:    structures.User{AccountId: "5b10a2844c20165700ede21g", DisplayName: "Mia Krystof", Active: true},
+			expected: &structures.DBAuthor{AccountId: "5b10a2844c20165700ede21g", Name: "Mia Krystof", Active: true},
+		},
+		{
+			name: "renamed server user",
+			input: structures.User{Key: "JIRAUSER10100", Name: "j.smith", DisplayName: "John Smith",
+				EmailAddress: "j.smith@example.com"},
+			expected: &structures.DBAuthor{AccountId: "JIRAUSER10100", Name: "John Smith", Email: "j.smith@example.com"},
 		},
 		{
 			name:     "empty user",
@@ -131,6 +288,39 @@ func TestTransformAuthorDB(t *testing.T) {
 	}
 }
 
+func TestTransformOptionalAuthorDB(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *structures.User
+		expected *structures.DBAuthor
+	}{
+		{
+			name:     "assigned user",
+			input:    &structures.User{Name: "john.doe"},
+			expected: &structures.DBAuthor{AccountId: "john.doe", Name: "john.doe"},
+		},
+		{
+			name:     "unassigned issue",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "user without name",
+			input:    &structures.User{},
+			expected: nil,
+		},
+	}
+
+	dt := NewDataTransformer("base_url")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := dt.TransformOptionalAuthorDB(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestTransformProjectDB(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -176,11 +366,12 @@ func TestTransformIssueDB(t *testing.T) {
 		{
 			name: "full issue data",
 			input: structures.JiraIssue{
+				Id:  "10123",
 				Key: "PRJ-123",
 				Fields: structures.Field{
 					Summary:     "Test issue",
-					Description: "Test description",
-					Type:        structures.IssueType{Description: "Task"},
+					Description: json.RawMessage(`"Test description"`),
+					Type:        structures.IssueType{Id: "10002", Name: "Task", Description: "A small piece of work"},
 					Project:     structures.JiraProject{Name: "Project X"},
 					Priority:    structures.IssuePriority{Name: "Major"},
 					Status:      structures.IssueStatus{Name: "Done"},
@@ -189,14 +380,16 @@ func TestTransformIssueDB(t *testing.T) {
 					ClosedTime:  closedTime,
 					TimeSpent:   3600,
 					Author:      structures.User{Name: "author"},
-					Assignee:    structures.User{Name: "assignee"},
+					Assignee:    &structures.User{Name: "assignee"},
 				},
 			},
 			expected: &structures.DBIssue{
+				JiraId:      "10123",
 				Key:         "PRJ-123",
 				Summary:     "Test issue",
 				Description: "Test description",
 				Type:        "Task",
+				TypeId:      "10002",
 				Priority:    "Major",
 				Status:      "Done",
 				CreatedTime: parsedCreated,
@@ -240,11 +433,13 @@ func TestTransformToDbIssueSet(t *testing.T) {
 			Summary:     "Test issue",
 			CreatedTime: createdTime,
 			Author:      structures.User{Name: "author"},
-			Assignee:    structures.User{Name: "assignee"},
+			Reporter:    &structures.User{Name: "reporter"},
+			Assignee:    &structures.User{Name: "assignee"},
 		},
 		Changelog: structures.Changelog{
 			Histories: []structures.History{
 				{
+					Id:      "100",
 					Created: createdTime,
 					Author:  structures.User{Name: "user1"},
 					Items: []structures.Item{
@@ -266,15 +461,28 @@ func TestTransformToDbIssueSet(t *testing.T) {
 			Summary:     "Test issue",
 			CreatedTime: parsedCreated,
 		},
-		Author:   structures.DBAuthor{Name: "author"},
-		Assignee: structures.DBAuthor{Name: "assignee"},
-		StatusChanges: map[string]structures.DBStatusChanges{
-			"user1": {
+		Author:   structures.DBAuthor{AccountId: "author", Name: "author"},
+		Reporter: &structures.DBAuthor{AccountId: "reporter", Name: "reporter"},
+		Assignee: &structures.DBAuthor{AccountId: "assignee", Name: "assignee"},
+		StatusChanges: []structures.DBStatusTransition{
+			{
+				HistoryId:  "100",
+				Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
 				ChangeTime: parsedCreated,
 				FromStatus: "Open",
 				ToStatus:   "In Progress",
 			},
 		},
+		FieldChanges: []structures.DBFieldChange{
+			{
+				HistoryId:  "100",
+				Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+				ChangeTime: parsedCreated,
+				Field:      "status",
+				FromString: "Open",
+				ToString:   "In Progress",
+			},
+		},
 	}
 
 	dt := NewDataTransformer("base_url")
@@ -284,6 +492,276 @@ func TestTransformToDbIssueSet(t *testing.T) {
 	assert.Equal(t, expected.Issue.Key, result.Issue.Key)
 	assert.Equal(t, expected.Issue.Summary, result.Issue.Summary)
 	assert.Equal(t, expected.Author, result.Author)
+	assert.Equal(t, expected.Reporter, result.Reporter)
 	assert.Equal(t, expected.Assignee, result.Assignee)
-	assert.Equal(t, expected.StatusChanges["user1"], result.StatusChanges["user1"])
+	assert.Equal(t, expected.StatusChanges, result.StatusChanges)
+	assert.Equal(t, expected.FieldChanges, result.FieldChanges)
+	assert.Empty(t, result.Links)
+	assert.Empty(t, result.Worklogs)
+	assert.Empty(t, result.Comments)
+	assert.Empty(t, result.Labels)
+}
+
+func TestTransformIssueLinksDB(t *testing.T) {
+	var issue structures.JiraIssue
+	err := json.Unmarshal([]byte(`{"key": "PRJ-2", "fields": {
+		"parent": {"id": "1", "key": "PRJ-1"},
+		"subtasks": [{"id": "3", "key": "PRJ-3"}, {"id": "4", "key": "PRJ-4"}],
+		"issuelinks": [
+			{"id": "10", "type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+			 "outwardIssue": {"key": "PRJ-5"}},
+			{"id": "11", "type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+			 "inwardIssue": {"key": "OTHER-1"}},
+			{"id": "12", "type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+			 "inwardIssue": {"key": "OTHER-1"}},
+			{"id": "13", "type": {"name": "Relates", "inward": "relates to", "outward": "relates to"},
+			 "outwardIssue": {"key": "PRJ-6"}}
+		]
+	}}`), &issue)
+	assert.NoError(t, err)
+
+	dt := NewDataTransformer("base_url")
+	assert.Equal(t, []structures.DBIssueLink{
+		{LinkType: structures.LinkParent, LinkedKey: "PRJ-1", Description: "parent"},
+		{LinkType: structures.LinkSubtask, LinkedKey: "PRJ-3", Description: "subtask"},
+		{LinkType: structures.LinkSubtask, LinkedKey: "PRJ-4", Description: "subtask"},
+		{LinkType: "Blocks", Direction: structures.LinkOutward, LinkedKey: "PRJ-5", Description: "blocks"},
+		{LinkType: "Blocks", Direction: structures.LinkInward, LinkedKey: "OTHER-1", Description: "is blocked by"},
+		{LinkType: "Relates", Direction: structures.LinkOutward, LinkedKey: "PRJ-6", Description: "relates to"},
+	}, dt.TransformIssueLinksDB(&issue))
+
+	assert.Empty(t, dt.TransformIssueLinksDB(&structures.JiraIssue{Key: "PRJ-7"}))
+}
+
+func TestTransformWorklogsDB(t *testing.T) {
+	var issue structures.JiraIssue
+	err := json.Unmarshal([]byte(`{"key": "PRJ-1", "fields": {"worklog": {"total": 2, "worklogs": [
+		{"id": "101", "author": {"accountId": "5b10", "displayName": "Bob"},
+		 "comment": {"type": "doc", "version": 1, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "fix"}]}]},
+		 "started": "2024-03-02T10:00:00.000+0000", "timeSpentSeconds": 1800},
+		{"id": "100", "author": {"name": "alice"}, "comment": "review",
+		 "started": "2024-03-01T09:00:00.000+0000", "timeSpentSeconds": 3600}
+	]}}}`), &issue)
+	assert.NoError(t, err)
+
+	layout := "2006-01-02T15:04:05.000-0700"
+	started1, _ := time.Parse(layout, "2024-03-01T09:00:00.000+0000")
+	started2, _ := time.Parse(layout, "2024-03-02T10:00:00.000+0000")
+
+	dt := NewDataTransformer("base_url")
+	assert.Equal(t, []structures.DBWorklog{
+		{WorklogId: "100", Author: structures.DBAuthor{AccountId: "alice", Name: "alice"}, Started: started1, Seconds: 3600, Comment: "review"},
+		{WorklogId: "101", Author: structures.DBAuthor{AccountId: "5b10", Name: "Bob"}, Started: started2, Seconds: 1800, Comment: "fix"},
+	}, dt.TransformWorklogsDB(&issue.Fields.Worklog))
+
+	assert.Empty(t, dt.TransformWorklogsDB(&structures.Worklogs{}))
+}
+
+func TestTransformCommentsDB(t *testing.T) {
+	var issue structures.JiraIssue
+	err := json.Unmarshal([]byte(`{"key": "PRJ-1", "fields": {"comment": {"total": 2, "comments": [
+		{"id": "11", "author": {"accountId": "5b10", "displayName": "Bob"},
+		 "body": {"type": "doc", "version": 1, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "done"}]}]},
+		 "created": "2024-03-02T10:00:00.000+0000", "updated": "2024-03-02T11:00:00.000+0000"},
+		{"id": "10", "author": {"name": "alice"}, "body": "looks good",
+		 "created": "2024-03-01T09:00:00.000+0000", "updated": "2024-03-01T09:00:00.000+0000"}
+	]}}}`), &issue)
+	assert.NoError(t, err)
+
+	layout := "2006-01-02T15:04:05.000-0700"
+	created1, _ := time.Parse(layout, "2024-03-01T09:00:00.000+0000")
+	created2, _ := time.Parse(layout, "2024-03-02T10:00:00.000+0000")
+	updated2, _ := time.Parse(layout, "2024-03-02T11:00:00.000+0000")
+
+	dt := NewDataTransformer("base_url")
+	assert.Equal(t, []structures.DBComment{
+		{CommentId: "10", Author: structures.DBAuthor{AccountId: "alice", Name: "alice"}, CreatedTime: created1, UpdatedTime: created1, Body: "looks good"},
+		{CommentId: "11", Author: structures.DBAuthor{AccountId: "5b10", Name: "Bob"}, CreatedTime: created2, UpdatedTime: updated2, Body: "done"},
+	}, dt.TransformCommentsDB(&issue.Fields.Comment))
+}
+
+func TestTransformIssueClassifiersDB(t *testing.T) {
+	var issue structures.JiraIssue
+	err := json.Unmarshal([]byte(`{"key": "PRJ-1", "fields": {
+		"labels": ["backend", "", "backend", "urgent"],
+		"components": [{"id": "1", "name": "API"}, {"id": "2", "name": "UI"}, {"id": "1", "name": "API"}],
+		"fixVersions": [
+			{"id": "5", "name": "1.0", "released": true, "releaseDate": "2024-04-01"},
+			{"id": "6", "name": "2.0", "released": false}
+		]
+	}}`), &issue)
+	assert.NoError(t, err)
+
+	dt := NewDataTransformer("base_url")
+	assert.Equal(t, []string{"backend", "urgent"}, dt.TransformLabelsDB(&issue))
+	assert.Equal(t, []structures.DBComponent{{Name: "API"}, {Name: "UI"}}, dt.TransformComponentsDB(&issue))
+
+	releaseDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []structures.DBVersion{
+		{Name: "1.0", Released: true, ReleaseDate: &releaseDate},
+		{Name: "2.0"},
+	}, dt.TransformFixVersionsDB(&issue))
+
+	empty := &structures.JiraIssue{Key: "PRJ-2"}
+	assert.Empty(t, dt.TransformLabelsDB(empty))
+	assert.Empty(t, dt.TransformComponentsDB(empty))
+	assert.Empty(t, dt.TransformFixVersionsDB(empty))
+}
+
+func TestTransformDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		expected    string
+	}{
+		{name: "missing", description: "", expected: ""},
+		{name: "null", description: "null", expected: ""},
+		{name: "rest v2 text", description: `"*bold* text\nnext line"`, expected: "*bold* text\nnext line"},
+		{
+			name:        "rest v3 adf",
+			description: `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Fix "},{"type":"text","text":"sync","marks":[{"type":"code"}]}]},{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"item"}]}]}]}]}`,
+			expected:    "Fix `sync`\n\n- item",
+		},
+		{name: "broken adf", description: `{"type":"doc","content":"oops"}`, expected: `{"type":"doc","content":"oops"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TransformDescription(json.RawMessage(tt.description)))
+		})
+	}
+}
+
+func TestTransformCustomFieldsDB(t *testing.T) {
+	five, eight := 5.0, 8.0
+
+	tests := []struct {
+		name     string
+		custom   map[string]string
+		fields   map[string]string
+		expected []structures.DBCustomField
+	}{
+		{
+			name:   "story points and epic link",
+			custom: map[string]string{"customfield_10016": `5`, "customfield_10014": `"PRJ-1"`},
+			fields: map[string]string{"customfield_10016": "story_points", "customfield_10014": "epic_link"},
+			expected: []structures.DBCustomField{
+				{Name: "epic_link", FieldId: "customfield_10014", Value: "PRJ-1"},
+				{Name: "story_points", FieldId: "customfield_10016", Value: "5", NumberValue: &five},
+			},
+		},
+		{
+			name: "cloud sprints and team",
+			custom: map[string]string{
+				"customfield_10020": `[{"id":1,"name":"Sprint 1","state":"closed"},{"id":2,"name":"Sprint 2","state":"active"}]`,
+				"customfield_10001": `{"id":"36885b3c","name":"Backend"}`,
+			},
+			fields: map[string]string{"customfield_10020": "sprint", "customfield_10001": "team"},
+			expected: []structures.DBCustomField{
+				{Name: "team", FieldId: "customfield_10001", Value: "Backend"},
+				{Name: "sprint", FieldId: "customfield_10020", Value: "Sprint 1"},
+				{Name: "sprint", FieldId: "customfield_10020", ValueIndex: 1, Value: "Sprint 2"},
+			},
+		},
+		{
+			name: "server sprint string",
+			custom: map[string]string{
+				"customfield_10100": `["com.atlassian.greenhopper.service.sprint.Sprint@4f5e[id=3,rapidViewId=1,state=ACTIVE,name=Sprint 3, hotfix,startDate=2024-05-01T10:00:00.000Z,endDate=<null>]"]`,
+			},
+			fields: map[string]string{"customfield_10100": "sprint"},
+			expected: []structures.DBCustomField{
+				{Name: "sprint", FieldId: "customfield_10100", Value: "Sprint 3, hotfix"},
+			},
+		},
+		{
+			name:   "select option",
+			custom: map[string]string{"customfield_10200": `{"self":"...","value":"Payments","id":"10300"}`},
+			fields: map[string]string{"customfield_10200": "department"},
+			expected: []structures.DBCustomField{
+				{Name: "department", FieldId: "customfield_10200", Value: "Payments"},
+			},
+		},
+		{
+			name:   "first mapped id with value wins",
+			custom: map[string]string{"customfield_10002": `null`, "customfield_10016": `8`, "customfield_10026": `3`},
+			fields: map[string]string{"customfield_10002": "story_points", "customfield_10016": "story_points", "customfield_10026": "story_points"},
+			expected: []structures.DBCustomField{
+				{Name: "story_points", FieldId: "customfield_10016", Value: "8", NumberValue: &eight},
+			},
+		},
+		{
+			name:     "missing, null and empty values",
+			custom:   map[string]string{"customfield_10014": `null`, "customfield_10020": `[]`, "customfield_10001": `""`},
+			fields:   map[string]string{"customfield_10014": "epic_link", "customfield_10020": "sprint", "customfield_10001": "team", "customfield_10016": "story_points"},
+			expected: []structures.DBCustomField{},
+		},
+	}
+
+	dt := NewDataTransformer("")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := structures.JiraIssue{Fields: structures.Field{Custom: map[string]json.RawMessage{}}}
+			for id, value := range tt.custom {
+				issue.Fields.Custom[id] = json.RawMessage(value)
+			}
+
+			assert.Equal(t, tt.expected, dt.TransformCustomFieldsDB(&issue, tt.fields))
+		})
+	}
+}
+
+func TestTransformPrioritiesDB(t *testing.T) {
+	dt := NewDataTransformer("http://jira.example.com")
+
+	priorities := dt.TransformPrioritiesDB([]structures.JiraPriority{
+		{Id: "10000", Name: "Blocker"},
+		{Id: "1", Name: "Highest"},
+		{Id: "3", Name: "Medium"},
+	})
+
+	assert.Equal(t, []structures.DBPriority{
+		{JiraId: "10000", Name: "Blocker", Rank: 1},
+		{JiraId: "1", Name: "Highest", Rank: 2},
+		{JiraId: "3", Name: "Medium", Rank: 3},
+	}, priorities)
+}
+
+func TestTransformBoardsDB(t *testing.T) {
+	dt := NewDataTransformer("http://jira.example.com")
+
+	boards := dt.TransformBoardsDB([]structures.JiraAgileBoard{
+		{
+			Board: structures.JiraBoard{Id: 1, Name: "Team A", Type: "scrum"},
+			Sprints: []structures.JiraAgileSprint{
+				{
+					Sprint: structures.JiraSprint{Id: 10, Name: "A 1", State: "closed", Goal: "release",
+						StartDate: "2024-01-01T09:00:00.000Z", EndDate: "2024-01-15T09:00:00.000+03:00",
+						CompleteDate: "2024-01-15T10:00:00.000Z"},
+					IssueKeys: []string{"TEST-1", "TEST-2"},
+				},
+				{Sprint: structures.JiraSprint{Id: 11, Name: "A 2", State: "future"}},
+			},
+		},
+		{Board: structures.JiraBoard{Id: 2, Name: "Empty", Type: "scrum"}},
+	})
+
+	assert.Len(t, boards, 2)
+	assert.Equal(t, 1, boards[0].JiraId)
+	assert.Equal(t, "Team A", boards[0].Name)
+	assert.Len(t, boards[0].Sprints, 2)
+
+	closed := boards[0].Sprints[0]
+	assert.Equal(t, 10, closed.JiraId)
+	assert.Equal(t, "release", closed.Goal)
+	assert.True(t, closed.StartTime.Equal(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)))
+	assert.True(t, closed.EndTime.Equal(time.Date(2024, 1, 15, 6, 0, 0, 0, time.UTC)))
+	assert.True(t, closed.CompleteTime.Equal(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"TEST-1", "TEST-2"}, closed.IssueKeys)
+
+	future := boards[0].Sprints[1]
+	assert.Nil(t, future.StartTime)
+	assert.Nil(t, future.EndTime)
+	assert.Nil(t, future.CompleteTime)
+
+	assert.Empty(t, boards[1].Sprints)
 }
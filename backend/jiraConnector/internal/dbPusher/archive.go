@@ -0,0 +1,229 @@
+package dbpusher
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jiraconnector/internal/archive"
+	archiveErr "github.com/jiraconnector/internal/archive/errors"
+	myerr "github.com/jiraconnector/internal/dbPusher/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/lib/pq"
+)
+
+// archiveChunk limits the number of payloads sent by one statement
+const archiveChunk = 500
+
+// ArchiveIssues keeps raw issues of the project in ArchiveIssue, the latest version
+// of every issue as Jira returned it, and the project with custom fields mapping
+// in ArchiveProject. The project row must be already saved, so it is called after
+// PushIssues
+func (dbp *DbPusher) ArchiveIssues(ctx context.Context, project structures.ProjectRef, prj *structures.JiraProject, fields map[string]string, issues []structures.JiraIssue) error {
+	now := time.Now()
+
+	payload, err := archive.Encode(structures.ArchivedProject{Project: *prj, Fields: fields})
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertArchive, project, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	query := `
+   INSERT INTO archiveproject (projectId, payload, archivedTime)
+   SELECT id, $3, $4 FROM projects WHERE source = $1 AND key = $2
+   ON CONFLICT (projectId)
+   DO UPDATE SET
+       payload = EXCLUDED.payload,
+       archivedTime = EXCLUDED.archivedTime
+   `
+	if _, err := dbp.querier(ctx).ExecContext(ctx, query, project.Source, project.Key, payload, now); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertArchive, project, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	// one statement can't update the same row twice, the last version of the issue wins
+	keys := []string{}
+	payloads := [][]byte{}
+	index := make(map[string]int, len(issues))
+	for i := range issues {
+		payload, err := archive.EncodeIssue(&issues[i])
+		if err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertArchive, issues[i].Key, err)
+			dbp.log.Error(ansErr.Error())
+			return ansErr
+		}
+		if j, ok := index[issues[i].Key]; ok {
+			payloads[j] = payload
+			continue
+		}
+		index[issues[i].Key] = len(keys)
+		keys = append(keys, issues[i].Key)
+		payloads = append(payloads, payload)
+	}
+
+	query = `
+   INSERT INTO archiveissue (projectId, key, payload, archivedTime)
+   SELECT p.id, a.key, a.payload, $5
+   FROM projects p, unnest($3::text[], $4::bytea[]) AS a(key, payload)
+   WHERE p.source = $1 AND p.key = $2
+   ON CONFLICT (projectId, key)
+   DO UPDATE SET
+       payload = EXCLUDED.payload,
+       archivedTime = EXCLUDED.archivedTime
+   `
+	for start := 0; start < len(keys); start += archiveChunk {
+		end := min(start+archiveChunk, len(keys))
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, project.Source, project.Key,
+			pq.Array(keys[start:end]), pq.ByteaArray(payloads[start:end]), now); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertArchive, project, err)
+			dbp.log.Error(ansErr.Error())
+			return ansErr
+		}
+	}
+
+	dbp.log.Info("success archive issues", "project", project.String(), "count", len(keys))
+	return nil
+}
+
+// PruneArchive removes archived issues of the project missing from keys, the full
+// key set of the project in Jira
+func (dbp *DbPusher) PruneArchive(ctx context.Context, project structures.ProjectRef, keys []string) error {
+	query := `
+   DELETE FROM archiveissue
+   WHERE projectId = (SELECT id FROM projects WHERE source = $1 AND key = $2)
+     AND NOT (key = ANY($3))
+   `
+
+	res, err := dbp.querier(ctx).ExecContext(ctx, query, project.Source, project.Key, pq.Array(keys))
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrDeleteArchive, project, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	removed, _ := res.RowsAffected()
+	dbp.log.Info("success prune archive", "project", project.String(), "count", removed)
+	return nil
+}
+
+// LoadArchive returns archived issues of the project ordered by key,
+// archive errors.ErrNotArchived when the project was never archived
+func (dbp *DbPusher) LoadArchive(ctx context.Context, project structures.ProjectRef) (*structures.ArchivedProject, error) {
+	var projectId int
+	var payload []byte
+	query := `
+   SELECT a.projectId, a.payload
+   FROM archiveproject a
+   JOIN projects p ON p.id = a.projectId
+   WHERE p.source = $1 AND p.key = $2
+   `
+
+	err := dbp.querier(ctx).QueryRowContext(ctx, query, project.Source, project.Key).Scan(&projectId, &payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w - %s", archiveErr.ErrNotArchived, project)
+	}
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectArchive, project, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	var archived structures.ArchivedProject
+	if err := archive.Decode(payload, &archived); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectArchive, project, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	rows, err := dbp.querier(ctx).QueryContext(ctx, "SELECT key, payload FROM archiveissue WHERE projectId = $1 ORDER BY key", projectId)
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectArchive, project, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+	defer rows.Close()
+
+	archived.Issues = []structures.JiraIssue{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key, &payload); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectArchive, project, err)
+			dbp.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		issue, err := archive.DecodeIssue(payload)
+		if err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectArchive, key, err)
+			dbp.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		archived.Issues = append(archived.Issues, issue)
+	}
+
+	if err := rows.Err(); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectArchive, project, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	dbp.log.Info("success load archive", "project", project.String(), "count", len(archived.Issues))
+	return &archived, nil
+}
+
+func (dbp *DbPusher) HasArchive(ctx context.Context, project structures.ProjectRef) (bool, error) {
+	var exists bool
+	query := `
+   SELECT EXISTS (
+       SELECT 1 FROM archiveproject a
+       JOIN projects p ON p.id = a.projectId
+       WHERE p.source = $1 AND p.key = $2
+   )
+   `
+
+	if err := dbp.querier(ctx).QueryRowContext(ctx, query, project.Source, project.Key).Scan(&exists); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectArchive, project, err)
+		dbp.log.Error(ansErr.Error())
+		return false, ansErr
+	}
+	return exists, nil
+}
+
+func (dbp *DbPusher) ArchivedProjects(ctx context.Context) ([]structures.ProjectRef, error) {
+	query := `
+   SELECT p.source, p.key
+   FROM archiveproject a
+   JOIN projects p ON p.id = a.projectId
+   ORDER BY p.source, p.key
+   `
+
+	rows, err := dbp.querier(ctx).QueryContext(ctx, query)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectArchive, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+	defer rows.Close()
+
+	projects := []structures.ProjectRef{}
+	for rows.Next() {
+		var project structures.ProjectRef
+		if err := rows.Scan(&project.Source, &project.Key); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectArchive, err)
+			dbp.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectArchive, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	return projects, nil
+}
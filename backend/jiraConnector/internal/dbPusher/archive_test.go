@@ -0,0 +1,260 @@
+package dbpusher
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jiraconnector/internal/archive"
+	archiveErr "github.com/jiraconnector/internal/archive/errors"
+	myerr "github.com/jiraconnector/internal/dbPusher/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// keysArg checks the keys of the archived issues, payloads differ by gzip headers
+type keysArg []string
+
+func (k keysArg) Match(v driver.Value) bool {
+	want, _ := pq.Array([]string(k)).Value()
+	return v == want
+}
+
+func TestArchiveIssues(t *testing.T) {
+	jiraPrj := &structures.JiraProject{Id: "1", Key: "PRJ", Name: "Project"}
+	issues := []structures.JiraIssue{
+		{Key: "PRJ-1", Raw: json.RawMessage(`{"key":"PRJ-1","fields":{"summary":"old"}}`)},
+		{Key: "PRJ-2", Raw: json.RawMessage(`{"key":"PRJ-2"}`)},
+		{Key: "PRJ-1", Raw: json.RawMessage(`{"key":"PRJ-1","fields":{"summary":"new"}}`)},
+	}
+
+	tests := []struct {
+		name      string
+		issues    []structures.JiraIssue
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`INSERT INTO archiveproject`)).
+					WithArgs("default", "PRJ", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(`INSERT INTO archiveissue`)).
+					WithArgs("default", "PRJ", keysArg{"PRJ-1", "PRJ-2"}, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "project error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`INSERT INTO archiveproject`)).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertArchive,
+		},
+		{
+			name: "issues error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`INSERT INTO archiveproject`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(`INSERT INTO archiveissue`)).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertArchive,
+		},
+		{
+			name:   "issue without raw JSON",
+			issues: []structures.JiraIssue{{Key: "PRJ-1", Fields: structures.Field{Summary: "built"}}},
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`INSERT INTO archiveproject`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: archiveErr.ErrNoRaw,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			if tt.issues == nil {
+				tt.issues = issues
+			}
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.ArchiveIssues(context.Background(), prj, jiraPrj, map[string]string{"Team": "customfield_1"}, tt.issues)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPruneArchive(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`DELETE FROM archiveissue`)).
+					WithArgs("default", "PRJ", pq.Array([]string{"PRJ-1"})).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "delete error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`DELETE FROM archiveissue`)).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrDeleteArchive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PruneArchive(context.Background(), prj, []string{"PRJ-1"})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLoadArchive(t *testing.T) {
+	projectPayload, err := archive.Encode(structures.ArchivedProject{
+		Project: structures.JiraProject{Id: "1", Key: "PRJ", Name: "Project"},
+		Fields:  map[string]string{"Team": "customfield_1"},
+	})
+	assert.NoError(t, err)
+	// the payload is the JSON of Jira, fields unknown to the struct are kept in Raw
+	raw := json.RawMessage(`{"id": "10001", "key": "PRJ-1", "fields": {"description": "text"}, "renderedFields": {}}`)
+	issue := structures.JiraIssue{Id: "10001", Key: "PRJ-1", Fields: structures.Field{Description: json.RawMessage(`"text"`)}, Raw: raw}
+	issuePayload, err := archive.EncodeIssue(&issue)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		want      *structures.ArchivedProject
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT a.projectId, a.payload`)).
+					WithArgs("default", "PRJ").
+					WillReturnRows(sqlmock.NewRows([]string{"projectId", "payload"}).AddRow(7, projectPayload))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT key, payload FROM archiveissue`)).
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"key", "payload"}).AddRow("PRJ-1", issuePayload))
+			},
+			want: &structures.ArchivedProject{
+				Project: structures.JiraProject{Id: "1", Key: "PRJ", Name: "Project"},
+				Fields:  map[string]string{"Team": "customfield_1"},
+				Issues:  []structures.JiraIssue{issue},
+			},
+		},
+		{
+			name: "not archived",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT a.projectId, a.payload`)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: archiveErr.ErrNotArchived,
+		},
+		{
+			name: "broken payload",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT a.projectId, a.payload`)).
+					WillReturnRows(sqlmock.NewRows([]string{"projectId", "payload"}).AddRow(7, []byte("{}")))
+			},
+			wantErr: myerr.ErrSelectArchive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			archived, err := dbp.LoadArchive(context.Background(), prj)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, archived)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHasArchive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS`)).
+		WithArgs("default", "PRJ").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	dbp := &DbPusher{db: db, log: slog.Default()}
+	exists, err := dbp.HasArchive(context.Background(), prj)
+
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArchivedProjects(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.source, p.key`)).
+		WillReturnRows(sqlmock.NewRows([]string{"source", "key"}).
+			AddRow("cloud", "ABC").
+			AddRow("default", "PRJ"))
+
+	dbp := &DbPusher{db: db, log: slog.Default()}
+	projects, err := dbp.ArchivedProjects(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []structures.ProjectRef{{Source: "cloud", Key: "ABC"}, {Source: "default", Key: "PRJ"}}, projects)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,436 @@
+package dbpusher
+
+import (
+	"context"
+	"fmt"
+
+	datatransformer "github.com/jiraconnector/internal/dataTransformer"
+	myerr "github.com/jiraconnector/internal/dbPusher/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/lib/pq"
+)
+
+// staging tables live until the end of the transaction, rows are loaded into
+// them by COPY and moved to the real tables by a few set-based statements.
+// Tables left by the previous call in the same transaction are dropped first
+const createStageTables = `
+   DROP TABLE IF EXISTS pg_temp.stage_author, pg_temp.stage_issue, pg_temp.stage_status_change,
+       pg_temp.stage_field_change, pg_temp.stage_custom_field, pg_temp.stage_issue_link, pg_temp.stage_worklog,
+       pg_temp.stage_comment, pg_temp.stage_label, pg_temp.stage_component, pg_temp.stage_fix_version;
+   CREATE TEMP TABLE stage_author (
+       account_id TEXT, name TEXT, email TEXT, active BOOLEAN
+   ) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_issue (
+       id INT, key TEXT, author TEXT, reporter TEXT, assignee TEXT, summary TEXT, description TEXT,
+       type TEXT, type_id TEXT, priority TEXT, status TEXT, created_time TIMESTAMP,
+       closed_time TIMESTAMP, updated_time TIMESTAMP, time_spent INT, jira_id TEXT
+   ) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_status_change (
+       issue_key TEXT, history_id TEXT, author TEXT, change_time TIMESTAMP, from_status TEXT, to_status TEXT
+   ) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_field_change (
+       issue_key TEXT, history_id TEXT, item_index INT, author TEXT, change_time TIMESTAMP, field TEXT,
+       field_type TEXT, from_value TEXT, from_string TEXT, to_value TEXT, to_string TEXT
+   ) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_custom_field (
+       issue_key TEXT, name TEXT, field_id TEXT, value_index INT, value TEXT, number_value DOUBLE PRECISION
+   ) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_issue_link (
+       issue_key TEXT, link_type TEXT, direction TEXT, linked_key TEXT, description TEXT
+   ) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_worklog (
+       issue_key TEXT, worklog_id TEXT, author TEXT, started TIMESTAMP, seconds INT, comment TEXT
+   ) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_comment (
+       issue_key TEXT, comment_id TEXT, author TEXT, created_time TIMESTAMP, updated_time TIMESTAMP, body TEXT
+   ) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_label (issue_key TEXT, name TEXT) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_component (issue_key TEXT, name TEXT) ON COMMIT DROP;
+   CREATE TEMP TABLE stage_fix_version (
+       issue_key TEXT, name TEXT, released BOOLEAN, release_date DATE, ord INT
+   ) ON COMMIT DROP;
+   `
+
+type bulkMerge struct {
+	name  string
+	query string
+	// project is passed as $1
+	project bool
+}
+
+// bulkMerges are run in this order: users before issues, issues moved from other
+// projects before the issue upsert, issues before the rows referencing them.
+// Collections replaced by every sync are deleted first
+var bulkMerges = []bulkMerge{
+	{name: "author", query: `
+   INSERT INTO author (accountId, name, email, active)
+   SELECT account_id, name, email, active FROM stage_author
+   ON CONFLICT (accountId)
+   DO UPDATE SET
+       name = EXCLUDED.name,
+       email = EXCLUDED.email,
+       active = EXCLUDED.active
+   WHERE (author.name, author.email, author.active) IS DISTINCT FROM (EXCLUDED.name, EXCLUDED.email, EXCLUDED.active)
+   `},
+	{name: "moved issue", project: true, query: `
+   WITH moved AS (
+       SELECT DISTINCT ON (t.key) o.id, t.key, t.updated_time
+       FROM stage_issue t
+       JOIN issue o ON o.jiraId = t.jira_id
+       JOIN projects p ON p.id = o.projectId
+       WHERE t.jira_id <> '' AND p.source = (SELECT source FROM projects WHERE id = $1::int)
+       ORDER BY t.key, o.deletedTime IS NOT NULL, o.updatedTime DESC
+   )
+   UPDATE issue SET projectId = $1::int, key = moved.key, deletedTime = NULL
+   FROM moved
+   WHERE issue.id = moved.id
+     AND moved.updated_time >= issue.updatedTime
+     AND NOT EXISTS (SELECT 1 FROM issue o WHERE o.projectId = $1::int AND o.key = moved.key)
+   `},
+	{name: "issue", project: true, query: `
+   WITH merged AS (
+       INSERT INTO issue
+           (projectId, authorId, reporterId, assigneeId, key, summary, description, type, typeId, priority, status, createdTime, closedTime, updatedTime, timeSpent, jiraId)
+       SELECT $1::int, a.id, r.id, s.id, t.key, t.summary, t.description, t.type, t.type_id,
+           t.priority, t.status, t.created_time, t.closed_time, t.updated_time, t.time_spent, NULLIF(t.jira_id, '')
+       FROM stage_issue t
+       JOIN author a ON a.accountId = t.author
+       LEFT JOIN author r ON r.accountId = t.reporter
+       LEFT JOIN author s ON s.accountId = t.assignee
+       ON CONFLICT (projectId, key)
+       DO UPDATE SET
+           authorId = EXCLUDED.authorId,
+           reporterId = EXCLUDED.reporterId,
+           assigneeId = EXCLUDED.assigneeId,
+           summary = EXCLUDED.summary,
+           description = EXCLUDED.description,
+           type = EXCLUDED.type,
+           typeId = EXCLUDED.typeId,
+           priority = EXCLUDED.priority,
+           status = EXCLUDED.status,
+           createdTime = EXCLUDED.createdTime,
+           closedTime = EXCLUDED.closedTime,
+           updatedTime = EXCLUDED.updatedTime,
+           timeSpent = EXCLUDED.timeSpent,
+           jiraId = COALESCE(EXCLUDED.jiraId, issue.jiraId),
+           deletedTime = NULL
+       RETURNING id, key
+   )
+   UPDATE stage_issue t SET id = merged.id FROM merged WHERE merged.key = t.key
+   `},
+	{name: "replaced collections", query: `
+   DELETE FROM issuecustomfield WHERE issueId IN (SELECT id FROM stage_issue);
+   DELETE FROM issuelink WHERE issueId IN (SELECT id FROM stage_issue);
+   DELETE FROM worklog WHERE issueId IN (SELECT id FROM stage_issue);
+   DELETE FROM issuecomment WHERE issueId IN (SELECT id FROM stage_issue);
+   DELETE FROM issuelabel WHERE issueId IN (SELECT id FROM stage_issue);
+   DELETE FROM issuecomponent WHERE issueId IN (SELECT id FROM stage_issue);
+   DELETE FROM issuefixversion WHERE issueId IN (SELECT id FROM stage_issue);
+   `},
+	{name: "status change", query: `
+   INSERT INTO statuschanges (issueId, historyId, authorId, changeTime, fromStatus, toStatus)
+   SELECT i.id, t.history_id, a.id, t.change_time, t.from_status, t.to_status
+   FROM stage_status_change t
+   JOIN stage_issue i ON i.key = t.issue_key
+   JOIN author a ON a.accountId = t.author
+   ON CONFLICT (issueId, historyId)
+   DO UPDATE SET
+       authorId = EXCLUDED.authorId,
+       changeTime = EXCLUDED.changeTime,
+       fromStatus = EXCLUDED.fromStatus,
+       toStatus = EXCLUDED.toStatus
+   `},
+	{name: "field change", query: `
+   INSERT INTO issuefieldchanges
+       (issueId, historyId, itemIndex, authorId, changeTime, field, fieldType, fromValue, fromString, toValue, toString)
+   SELECT i.id, t.history_id, t.item_index, a.id, t.change_time, t.field, t.field_type,
+       t.from_value, t.from_string, t.to_value, t.to_string
+   FROM stage_field_change t
+   JOIN stage_issue i ON i.key = t.issue_key
+   JOIN author a ON a.accountId = t.author
+   ON CONFLICT (issueId, historyId, itemIndex)
+   DO UPDATE SET
+       authorId = EXCLUDED.authorId,
+       changeTime = EXCLUDED.changeTime,
+       field = EXCLUDED.field,
+       fieldType = EXCLUDED.fieldType,
+       fromValue = EXCLUDED.fromValue,
+       fromString = EXCLUDED.fromString,
+       toValue = EXCLUDED.toValue,
+       toString = EXCLUDED.toString
+   `},
+	{name: "custom field", query: `
+   INSERT INTO issuecustomfield (issueId, name, fieldId, valueIndex, value, numberValue)
+   SELECT i.id, t.name, t.field_id, t.value_index, t.value, t.number_value
+   FROM stage_custom_field t
+   JOIN stage_issue i ON i.key = t.issue_key
+   `},
+	{name: "issue link", query: `
+   INSERT INTO issuelink (issueId, linkType, direction, linkedKey, description)
+   SELECT i.id, t.link_type, t.direction, t.linked_key, t.description
+   FROM stage_issue_link t
+   JOIN stage_issue i ON i.key = t.issue_key
+   `},
+	{name: "worklog", query: `
+   INSERT INTO worklog (issueId, worklogId, authorId, started, timeSpentSeconds, comment)
+   SELECT i.id, t.worklog_id, a.id, t.started, t.seconds, t.comment
+   FROM stage_worklog t
+   JOIN stage_issue i ON i.key = t.issue_key
+   JOIN author a ON a.accountId = t.author
+   `},
+	{name: "comment", query: `
+   INSERT INTO issuecomment (issueId, commentId, authorId, createdTime, updatedTime, body)
+   SELECT i.id, t.comment_id, a.id, t.created_time, t.updated_time, t.body
+   FROM stage_comment t
+   JOIN stage_issue i ON i.key = t.issue_key
+   JOIN author a ON a.accountId = t.author
+   `},
+	{name: "label", query: `
+   INSERT INTO label (name) SELECT DISTINCT name FROM stage_label
+   ON CONFLICT (name) DO NOTHING
+   `},
+	{name: "issue label", query: `
+   INSERT INTO issuelabel (issueId, labelId)
+   SELECT DISTINCT i.id, l.id
+   FROM stage_label t
+   JOIN stage_issue i ON i.key = t.issue_key
+   JOIN label l ON l.name = t.name
+   `},
+	{name: "component", project: true, query: `
+   INSERT INTO component (projectId, name) SELECT DISTINCT $1::int, name FROM stage_component
+   ON CONFLICT (projectId, name) DO NOTHING
+   `},
+	{name: "issue component", project: true, query: `
+   INSERT INTO issuecomponent (issueId, componentId)
+   SELECT DISTINCT i.id, c.id
+   FROM stage_component t
+   JOIN stage_issue i ON i.key = t.issue_key
+   JOIN component c ON c.projectId = $1 AND c.name = t.name
+   `},
+	// release state of the version is taken from the last issue, as the row path does
+	{name: "fix version", project: true, query: `
+   INSERT INTO version (projectId, name, released, releaseDate)
+   SELECT DISTINCT ON (name) $1::int, name, released, release_date FROM stage_fix_version
+   ORDER BY name, ord DESC
+   ON CONFLICT (projectId, name)
+   DO UPDATE SET
+       released = EXCLUDED.released,
+       releaseDate = EXCLUDED.releaseDate
+   `},
+	{name: "issue fix version", project: true, query: `
+   INSERT INTO issuefixversion (issueId, versionId)
+   SELECT DISTINCT i.id, v.id
+   FROM stage_fix_version t
+   JOIN stage_issue i ON i.key = t.issue_key
+   JOIN version v ON v.projectId = $1 AND v.name = t.name
+   `},
+}
+
+// stageTable is the content of one staging table, columns are in the COPY order
+type stageTable struct {
+	name    string
+	columns []string
+	rows    [][]any
+}
+
+// issueBatch holds the rows of all staging tables. The same issue, user or
+// changelog item met twice is staged once, the last one wins like in the row path.
+// An empty name or email of a user doesn't replace a known one, Jira hides them in some payloads
+type issueBatch struct {
+	authors       stageTable
+	issues        stageTable
+	statusChanges stageTable
+	fieldChanges  stageTable
+	customFields  stageTable
+	links         stageTable
+	worklogs      stageTable
+	comments      stageTable
+	labels        stageTable
+	components    stageTable
+	fixVersions   stageTable
+}
+
+func newIssueBatch(issues []datatransformer.DataTransformer) *issueBatch {
+	b := &issueBatch{
+		authors: stageTable{name: "stage_author", columns: []string{"account_id", "name", "email", "active"}},
+		issues: stageTable{name: "stage_issue", columns: []string{"key", "author", "reporter", "assignee", "summary",
+			"description", "type", "type_id", "priority", "status", "created_time", "closed_time", "updated_time", "time_spent", "jira_id"}},
+		statusChanges: stageTable{name: "stage_status_change", columns: []string{"issue_key", "history_id", "author",
+			"change_time", "from_status", "to_status"}},
+		fieldChanges: stageTable{name: "stage_field_change", columns: []string{"issue_key", "history_id", "item_index", "author",
+			"change_time", "field", "field_type", "from_value", "from_string", "to_value", "to_string"}},
+		customFields: stageTable{name: "stage_custom_field", columns: []string{"issue_key", "name", "field_id",
+			"value_index", "value", "number_value"}},
+		links:       stageTable{name: "stage_issue_link", columns: []string{"issue_key", "link_type", "direction", "linked_key", "description"}},
+		worklogs:    stageTable{name: "stage_worklog", columns: []string{"issue_key", "worklog_id", "author", "started", "seconds", "comment"}},
+		comments:    stageTable{name: "stage_comment", columns: []string{"issue_key", "comment_id", "author", "created_time", "updated_time", "body"}},
+		labels:      stageTable{name: "stage_label", columns: []string{"issue_key", "name"}},
+		components:  stageTable{name: "stage_component", columns: []string{"issue_key", "name"}},
+		fixVersions: stageTable{name: "stage_fix_version", columns: []string{"issue_key", "name", "released", "release_date", "ord"}},
+	}
+
+	lastByKey := make(map[string]int, len(issues))
+	for i := range issues {
+		lastByKey[issues[i].Issue.Key] = i
+	}
+
+	authors := make(map[string]structures.DBAuthor)
+	addAuthor := func(author *structures.DBAuthor) any {
+		if author == nil {
+			return nil
+		}
+		merged := *author
+		if known, ok := authors[author.AccountId]; ok {
+			if merged.Name == "" {
+				merged.Name = known.Name
+			}
+			if merged.Email == "" {
+				merged.Email = known.Email
+			}
+		}
+		authors[author.AccountId] = merged
+		return author.AccountId
+	}
+
+	statusChanges := make(map[[2]string]int)
+	fieldChanges := make(map[string]int)
+	for i := range issues {
+		if lastByKey[issues[i].Issue.Key] != i {
+			continue
+		}
+		issue := &issues[i]
+		key := issue.Issue.Key
+
+		b.issues.rows = append(b.issues.rows, []any{key, addAuthor(&issue.Author), addAuthor(issue.Reporter),
+			addAuthor(issue.Assignee), issue.Issue.Summary, issue.Issue.Description, issue.Issue.Type, issue.Issue.TypeId,
+			issue.Issue.Priority, issue.Issue.Status, issue.Issue.CreatedTime, issue.Issue.ClosedTime,
+			issue.Issue.UpdatedTime, issue.Issue.TimeSpent, issue.Issue.JiraId})
+
+		for _, change := range issue.StatusChanges {
+			row := []any{key, change.HistoryId, addAuthor(&change.Author), change.ChangeTime, change.FromStatus, change.ToStatus}
+			id := [2]string{key, change.HistoryId}
+			if n, ok := statusChanges[id]; ok {
+				b.statusChanges.rows[n] = row
+				continue
+			}
+			statusChanges[id] = len(b.statusChanges.rows)
+			b.statusChanges.rows = append(b.statusChanges.rows, row)
+		}
+
+		for _, change := range issue.FieldChanges {
+			row := []any{key, change.HistoryId, change.ItemIndex, addAuthor(&change.Author), change.ChangeTime,
+				change.Field, change.FieldType, change.FromValue, change.FromString, change.ToValue, change.ToString}
+			id := fmt.Sprintf("%s/%s/%d", key, change.HistoryId, change.ItemIndex)
+			if n, ok := fieldChanges[id]; ok {
+				b.fieldChanges.rows[n] = row
+				continue
+			}
+			fieldChanges[id] = len(b.fieldChanges.rows)
+			b.fieldChanges.rows = append(b.fieldChanges.rows, row)
+		}
+
+		for _, field := range issue.CustomFields {
+			b.customFields.rows = append(b.customFields.rows, []any{key, field.Name, field.FieldId,
+				field.ValueIndex, field.Value, field.NumberValue})
+		}
+		for _, link := range issue.Links {
+			b.links.rows = append(b.links.rows, []any{key, link.LinkType, link.Direction, link.LinkedKey, link.Description})
+		}
+		for _, worklog := range issue.Worklogs {
+			b.worklogs.rows = append(b.worklogs.rows, []any{key, worklog.WorklogId, addAuthor(&worklog.Author),
+				worklog.Started, worklog.Seconds, worklog.Comment})
+		}
+		for _, comment := range issue.Comments {
+			b.comments.rows = append(b.comments.rows, []any{key, comment.CommentId, addAuthor(&comment.Author),
+				comment.CreatedTime, comment.UpdatedTime, comment.Body})
+		}
+		for _, label := range issue.Labels {
+			b.labels.rows = append(b.labels.rows, []any{key, label})
+		}
+		for _, component := range issue.Components {
+			b.components.rows = append(b.components.rows, []any{key, component.Name})
+		}
+		for _, version := range issue.FixVersions {
+			b.fixVersions.rows = append(b.fixVersions.rows, []any{key, version.Name, version.Released, version.ReleaseDate,
+				len(b.fixVersions.rows)})
+		}
+	}
+
+	for _, author := range authors {
+		b.authors.rows = append(b.authors.rows, []any{author.AccountId, author.Name, author.Email, author.Active})
+	}
+
+	return b
+}
+
+func (b *issueBatch) tables() []*stageTable {
+	return []*stageTable{&b.authors, &b.issues, &b.statusChanges, &b.fieldChanges, &b.customFields,
+		&b.links, &b.worklogs, &b.comments, &b.labels, &b.components, &b.fixVersions}
+}
+
+// pushIssuesBulk saves issues of the project with a constant number of round trips:
+// rows are copied into staging tables and merged by bulkMerges. It is run inside InTx,
+// COPY and the staging tables need the transaction
+func (dbp *DbPusher) pushIssuesBulk(ctx context.Context, project *structures.DBProject, issues []datatransformer.DataTransformer) error {
+	projectId, err := dbp.getProjectId(ctx, project)
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrPushIssue, project.Title, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	batch := newIssueBatch(issues)
+	q := dbp.querier(ctx)
+
+	if _, err := q.ExecContext(ctx, createStageTables); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrStageIssues, err)
+		dbp.log.Error(ansErr.Error(), "project", project)
+		return ansErr
+	}
+
+	for _, table := range batch.tables() {
+		if err := copyRows(ctx, q, table); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrStageIssues, table.name, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+	}
+
+	for _, merge := range bulkMerges {
+		args := []any{}
+		if merge.project {
+			args = append(args, projectId)
+		}
+		if _, err := q.ExecContext(ctx, merge.query, args...); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrMergeIssues, merge.name, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+	}
+
+	dbp.log.Info("success bulk save issues", "project", project,
+		"issues", len(batch.issues.rows), "authors", len(batch.authors.rows))
+	return nil
+}
+
+// copyRows loads the staging table with COPY FROM STDIN, empty tables are skipped
+func copyRows(ctx context.Context, q querier, table *stageTable) error {
+	if len(table.rows) == 0 {
+		return nil
+	}
+
+	stmt, err := q.PrepareContext(ctx, pq.CopyIn(table.name, table.columns...))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range table.rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return err
+		}
+	}
+	// the copy is flushed by the call without arguments
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
@@ -0,0 +1,203 @@
+package dbpusher
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	datatransformer "github.com/jiraconnector/internal/dataTransformer"
+	myerr "github.com/jiraconnector/internal/dbPusher/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+)
+
+func bulkTestIssues(now time.Time) []datatransformer.DataTransformer {
+	user1 := structures.DBAuthor{AccountId: "user1", Name: "User One", Email: "one@example.com", Active: true}
+	user2 := structures.DBAuthor{AccountId: "user2", Name: "User Two", Active: true}
+
+	return []datatransformer.DataTransformer{
+		{
+			Issue:  structures.DBIssue{Key: "PRJ-1", Summary: "old summary", CreatedTime: now},
+			Author: user1,
+		},
+		{
+			Issue:    structures.DBIssue{Key: "PRJ-2", Summary: "second", CreatedTime: now},
+			Author:   user2,
+			Reporter: &user2,
+			StatusChanges: []structures.DBStatusTransition{
+				{HistoryId: "21", Author: user1, ChangeTime: now, FromStatus: "Open", ToStatus: "Closed"},
+			},
+			Labels: []string{"backend"},
+		},
+		// the same issue once more, the last one is saved
+		{
+			Issue:    structures.DBIssue{JiraId: "10001", Key: "PRJ-1", Summary: "new summary", CreatedTime: now},
+			Author:   user1,
+			Assignee: &user2,
+			StatusChanges: []structures.DBStatusTransition{
+				{HistoryId: "11", Author: user2, ChangeTime: now, FromStatus: "Open", ToStatus: "In Progress"},
+				{HistoryId: "11", Author: user2, ChangeTime: now, FromStatus: "Open", ToStatus: "Done"},
+			},
+			Worklogs: []structures.DBWorklog{
+				{WorklogId: "100", Author: user2, Started: now, Seconds: 3600},
+			},
+		},
+	}
+}
+
+func TestNewIssueBatch(t *testing.T) {
+	now := time.Now()
+	batch := newIssueBatch(bulkTestIssues(now))
+
+	assert.Len(t, batch.issues.rows, 2)
+	assert.Equal(t, []any{"PRJ-2", "user2", "user2", nil}, batch.issues.rows[0][:4])
+	assert.Equal(t, []any{"PRJ-1", "user1", nil, "user2", "new summary"}, batch.issues.rows[1][:5])
+	assert.Equal(t, "10001", batch.issues.rows[1][14])
+
+	assert.Len(t, batch.authors.rows, 2)
+	assert.ElementsMatch(t, [][]any{
+		{"user1", "User One", "one@example.com", true},
+		{"user2", "User Two", "", true},
+	}, batch.authors.rows)
+
+	assert.Equal(t, [][]any{
+		{"PRJ-2", "21", "user1", now, "Open", "Closed"},
+		{"PRJ-1", "11", "user2", now, "Open", "Done"},
+	}, batch.statusChanges.rows)
+	assert.Equal(t, [][]any{{"PRJ-1", "100", "user2", now, 3600, ""}}, batch.worklogs.rows)
+	assert.Equal(t, [][]any{{"PRJ-2", "backend"}}, batch.labels.rows)
+	assert.Empty(t, batch.comments.rows)
+
+	for _, table := range batch.tables() {
+		for _, row := range table.rows {
+			assert.Len(t, row, len(table.columns), table.name)
+		}
+	}
+}
+
+func TestNewIssueBatch_Merges(t *testing.T) {
+	now := time.Now()
+	batch := newIssueBatch([]datatransformer.DataTransformer{
+		{
+			Issue:       structures.DBIssue{Key: "PRJ-1", CreatedTime: now},
+			Author:      structures.DBAuthor{AccountId: "user1", Name: "User One", Email: "one@example.com"},
+			FixVersions: []structures.DBVersion{{Name: "1.0"}},
+		},
+		// email hidden in this payload
+		{
+			Issue:       structures.DBIssue{Key: "PRJ-2", CreatedTime: now},
+			Author:      structures.DBAuthor{AccountId: "user1", Name: "Renamed", Active: true},
+			FixVersions: []structures.DBVersion{{Name: "1.0", Released: true}, {Name: "2.0"}},
+		},
+	})
+
+	assert.Equal(t, [][]any{{"user1", "Renamed", "one@example.com", true}}, batch.authors.rows)
+	// the ordinal keeps the release state of the last issue
+	assert.Equal(t, [][]any{
+		{"PRJ-1", "1.0", false, (*time.Time)(nil), 0},
+		{"PRJ-2", "1.0", true, (*time.Time)(nil), 1},
+		{"PRJ-2", "2.0", false, (*time.Time)(nil), 2},
+	}, batch.fixVersions.rows)
+}
+
+func TestPushIssuesBulk(t *testing.T) {
+	now := time.Now()
+	project := structures.DBProject{Source: "default", Title: "Project", Key: "PRJ"}
+
+	expectProject := func(m sqlmock.Sqlmock) {
+		m.ExpectQuery(regexp.QuoteMeta("SELECT id FROM projects WHERE source=$1 AND key=$2")).
+			WithArgs("default", "PRJ").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	}
+	expectCopy := func(m sqlmock.Sqlmock, table string, rows int) {
+		m.ExpectPrepare(regexp.QuoteMeta(`COPY "` + table + `"`))
+		for i := 0; i <= rows; i++ {
+			m.ExpectExec(regexp.QuoteMeta(`COPY "` + table + `"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+	}
+	expectStaging := func(m sqlmock.Sqlmock) {
+		m.ExpectBegin()
+		expectProject(m)
+		m.ExpectExec("DROP TABLE IF EXISTS pg_temp.stage_author").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectCopy(m, "stage_author", 2)
+		expectCopy(m, "stage_issue", 2)
+		expectCopy(m, "stage_status_change", 2)
+		expectCopy(m, "stage_worklog", 1)
+		expectCopy(m, "stage_label", 1)
+	}
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				expectStaging(m)
+				for _, merge := range bulkMerges {
+					exec := m.ExpectExec(regexp.QuoteMeta(merge.query))
+					if merge.project {
+						exec.WithArgs(7)
+					} else {
+						exec.WithoutArgs()
+					}
+					exec.WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "failed to begin transaction",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin().WillReturnError(errors.New("begin error"))
+			},
+			wantErr: myerr.ErrTranBegin,
+		},
+		{
+			name: "failed to copy rows - rollback",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				expectProject(m)
+				m.ExpectExec("DROP TABLE IF EXISTS pg_temp.stage_author").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectPrepare(regexp.QuoteMeta(`COPY "stage_author"`)).WillReturnError(errors.New("copy error"))
+				m.ExpectRollback()
+			},
+			wantErr: myerr.ErrStageIssues,
+		},
+		{
+			name: "failed to merge issues - rollback",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				expectStaging(m)
+				m.ExpectExec(regexp.QuoteMeta(bulkMerges[0].query)).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(regexp.QuoteMeta(bulkMerges[1].query)).WithArgs(7).WillReturnError(errors.New("merge error"))
+				m.ExpectRollback()
+			},
+			wantErr: myerr.ErrMergeIssues,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default(), bulkWrite: true}
+			err = dbp.PushIssues(context.Background(), &project, bulkTestIssues(now))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
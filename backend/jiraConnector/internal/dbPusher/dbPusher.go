@@ -1,7 +1,9 @@
 package dbpusher
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -10,13 +12,17 @@ import (
 	myerr "github.com/jiraconnector/internal/dbPusher/errors"
 	"github.com/jiraconnector/internal/structures"
 	"github.com/jiraconnector/pkg/config"
-	"github.com/jiraconnector/pkg/logger"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
+// DbPusher saves issues by the bulk path (see bulk.go) unless bulkWrite is off,
+// then every row is written by its own statement. chunkSize is the number of
+// issues committed at once, 0 means all issues of the call
 type DbPusher struct {
-	db  *sql.DB
-	log *slog.Logger
+	db        *sql.DB
+	log       *slog.Logger
+	bulkWrite bool
+	chunkSize int
 }
 
 func NewDbPusher(cfg *config.Config, log *slog.Logger) (*DbPusher, error) {
@@ -29,8 +35,10 @@ func NewDbPusher(cfg *config.Config, log *slog.Logger) (*DbPusher, error) {
 	}
 
 	return &DbPusher{
-		db:  db,
-		log: log,
+		db:        db,
+		log:       log,
+		bulkWrite: !cfg.DBCfg.DisableBulkWrite,
+		chunkSize: cfg.DBCfg.CommitChunk,
 	}, nil
 }
 
@@ -43,120 +51,426 @@ func (dbp *DbPusher) Close() {
 	dbp.db.Close()
 }
 
-func (dbp *DbPusher) PushProject(project *structures.DBProject) (int, error) {
+func (dbp *DbPusher) PushProject(ctx context.Context, project *structures.DBProject) (int, error) {
 	var projectId int
-	query := `INSERT INTO projects (title, key, url) VALUES ($1, $2, $3) ON CONFLICT (title) DO NOTHING RETURNING id`
-	if err := dbp.db.QueryRow(query, project.Title, project.Key, project.Url).Scan(&projectId); err != nil {
-		return 0, fmt.Errorf("%w - %s: %w", myerr.ErrInsertProject, project.Title, err)
+	// the same key may be used by several jira instances
+	query := `
+   INSERT INTO projects (source, title, key, url) VALUES ($1, $2, $3, $4)
+   ON CONFLICT (source, key)
+   DO UPDATE SET
+       title = EXCLUDED.title,
+       url = EXCLUDED.url
+   RETURNING id
+   `
+	if err := dbp.querier(ctx).QueryRowContext(ctx, query, project.Source, project.Title, project.Key, project.Url).Scan(&projectId); err != nil {
+		return 0, fmt.Errorf("%w - %s/%s: %w", myerr.ErrInsertProject, project.Source, project.Key, err)
 	}
-	dbp.log.Info("success push project", "project", project.Title)
+	dbp.log.Info("success push project", "source", project.Source, "project", project.Title)
 	return projectId, nil
 }
 
-func (dbp *DbPusher) PushProjects(projects []structures.DBProject) error {
-	tx, err := dbp.db.Begin()
+func (dbp *DbPusher) PushProjects(ctx context.Context, projects []structures.DBProject) error {
+	err := dbp.InTx(ctx, func(ctx context.Context) error {
+		for _, project := range projects {
+			if _, err := dbp.PushProject(ctx, &project); err != nil {
+				ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrPushProject, project.Title, err)
+				dbp.log.Error(ansErr.Error())
+				return ansErr
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		ansErr := fmt.Errorf("%w: %w", myerr.ErrTranBegin, err)
+		return err
+	}
+
+	dbp.log.Info("success save all projects")
+	return nil
+}
+
+// PushAuthor inserts the user or updates the name, email and active flag of the known one
+func (dbp *DbPusher) PushAuthor(ctx context.Context, author *structures.DBAuthor) (int, error) {
+	var authorId int
+	query := `
+   INSERT INTO author (accountId, name, email, active)
+   VALUES ($1, $2, $3, $4)
+   ON CONFLICT (accountId)
+   DO UPDATE SET
+       name = EXCLUDED.name,
+       email = EXCLUDED.email,
+       active = EXCLUDED.active
+   RETURNING id
+   `
+
+	if err := dbp.querier(ctx).QueryRowContext(ctx, query, author.AccountId, author.Name,
+		author.Email, author.Active).Scan(&authorId); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertAuthor, author.AccountId, err)
 		dbp.log.Error(ansErr.Error())
-		return ansErr
+		return 0, ansErr
 	}
 
-	for _, project := range projects {
-		_, err = dbp.PushProject(&project)
-		if err != nil {
-			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrPushProject, project.Title, err)
-			dbp.log.Error(ansErr.Error())
-			tx.Rollback()
+	dbp.log.Info("success push author", "author", author.AccountId)
+	return authorId, nil
+
+}
+
+func (dbp *DbPusher) PushStatusChanges(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	// history id identifies the transition, so repeated sync of the issue only updates it
+	query := `
+   INSERT INTO statuschanges (issueId, historyId, authorId, changeTime, fromStatus, toStatus)
+   VALUES ($1, $2, $3, $4, $5, $6)
+   ON CONFLICT (issueId, historyId)
+   DO UPDATE SET
+       authorId = EXCLUDED.authorId,
+       changeTime = EXCLUDED.changeTime,
+       fromStatus = EXCLUDED.fromStatus,
+       toStatus = EXCLUDED.toStatus
+   `
+
+	authorIds := make(map[string]int)
+	for _, statusChange := range changes.StatusChanges {
+		authorId, ok := authorIds[statusChange.Author.AccountId]
+		if !ok {
+			var err error
+			authorId, err = dbp.getAuthorId(ctx, &statusChange.Author)
+			if err != nil {
+				dbp.log.Error("err get author Id", "author", statusChange.Author.AccountId)
+				return err
+			}
+			authorIds[statusChange.Author.AccountId] = authorId
+		}
+
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, statusChange.HistoryId, authorId,
+			statusChange.ChangeTime, statusChange.FromStatus, statusChange.ToStatus); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertStatusChange, statusChange.HistoryId, err)
+			dbp.log.Error(ansErr.Error(), "author", statusChange.Author.AccountId)
 			return ansErr
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		ansErr := fmt.Errorf("%w: %w", myerr.ErrTranClose, err)
+	dbp.log.Info("success push status changes", "issue", issue, "count", len(changes.StatusChanges))
+	return nil
+}
+
+func (dbp *DbPusher) PushFieldChanges(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	query := `
+   INSERT INTO issuefieldchanges
+       (issueId, historyId, itemIndex, authorId, changeTime, field, fieldType, fromValue, fromString, toValue, toString)
+   VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+   ON CONFLICT (issueId, historyId, itemIndex)
+   DO UPDATE SET
+       authorId = EXCLUDED.authorId,
+       changeTime = EXCLUDED.changeTime,
+       field = EXCLUDED.field,
+       fieldType = EXCLUDED.fieldType,
+       fromValue = EXCLUDED.fromValue,
+       fromString = EXCLUDED.fromString,
+       toValue = EXCLUDED.toValue,
+       toString = EXCLUDED.toString
+   `
+
+	authorIds := make(map[string]int)
+	for _, fieldChange := range changes.FieldChanges {
+		authorId, ok := authorIds[fieldChange.Author.AccountId]
+		if !ok {
+			var err error
+			authorId, err = dbp.getAuthorId(ctx, &fieldChange.Author)
+			if err != nil {
+				dbp.log.Error("err get author Id", "author", fieldChange.Author.AccountId)
+				return err
+			}
+			authorIds[fieldChange.Author.AccountId] = authorId
+		}
+
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, fieldChange.HistoryId, fieldChange.ItemIndex, authorId,
+			fieldChange.ChangeTime, fieldChange.Field, fieldChange.FieldType,
+			fieldChange.FromValue, fieldChange.FromString, fieldChange.ToValue, fieldChange.ToString); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertFieldChange, fieldChange.HistoryId, err)
+			dbp.log.Error(ansErr.Error(), "field", fieldChange.Field)
+			return ansErr
+		}
+	}
+
+	dbp.log.Info("success push field changes", "issue", issue, "count", len(changes.FieldChanges))
+	return nil
+}
+
+// PushCustomFields replaces stored custom field values of the issue: a value
+// removed in Jira has to disappear from the table as well
+func (dbp *DbPusher) PushCustomFields(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	if _, err := dbp.querier(ctx).ExecContext(ctx, "DELETE FROM issuecustomfield WHERE issueId = $1", issue); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrDeleteCustomField, issue, err)
 		dbp.log.Error(ansErr.Error())
 		return ansErr
 	}
 
-	dbp.log.Error("success save all projects")
+	query := `
+   INSERT INTO issuecustomfield
+       (issueId, name, fieldId, valueIndex, value, numberValue)
+   VALUES ($1, $2, $3, $4, $5, $6)
+   `
+
+	for _, field := range changes.CustomFields {
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, field.Name, field.FieldId,
+			field.ValueIndex, field.Value, field.NumberValue); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertCustomField, field.Name, err)
+			dbp.log.Error(ansErr.Error(), "issue", issue)
+			return ansErr
+		}
+	}
+
+	dbp.log.Info("success push custom fields", "issue", issue, "count", len(changes.CustomFields))
 	return nil
+}
+
+// PushIssueLinks replaces stored links of the issue, the linked issues aren't required to exist
+func (dbp *DbPusher) PushIssueLinks(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	if _, err := dbp.querier(ctx).ExecContext(ctx, "DELETE FROM issuelink WHERE issueId = $1", issue); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrDeleteIssueLink, issue, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	query := `
+   INSERT INTO issuelink
+       (issueId, linkType, direction, linkedKey, description)
+   VALUES ($1, $2, $3, $4, $5)
+   `
+
+	for _, link := range changes.Links {
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, link.LinkType, link.Direction,
+			link.LinkedKey, link.Description); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertIssueLink, link.LinkedKey, err)
+			dbp.log.Error(ansErr.Error(), "issue", issue, "type", link.LinkType)
+			return ansErr
+		}
+	}
 
+	dbp.log.Info("success push issue links", "issue", issue, "count", len(changes.Links))
+	return nil
 }
 
-func (dbp *DbPusher) PushAuthor(author *structures.DBAuthor) (int, error) {
-	var authorId int
-	query := "INSERT INTO author (name) VALUES ($1) ON CONFLICT (name) DO NOTHING RETURNING id"
+// PushWorklogs replaces stored worklogs of the issue, a worklog deleted in Jira is deleted here too
+func (dbp *DbPusher) PushWorklogs(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	if _, err := dbp.querier(ctx).ExecContext(ctx, "DELETE FROM worklog WHERE issueId = $1", issue); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrDeleteWorklog, issue, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	query := `
+   INSERT INTO worklog
+       (issueId, worklogId, authorId, started, timeSpentSeconds, comment)
+   VALUES ($1, $2, $3, $4, $5, $6)
+   `
+
+	authorIds := make(map[string]int)
+	for _, worklog := range changes.Worklogs {
+		authorId, ok := authorIds[worklog.Author.AccountId]
+		if !ok {
+			var err error
+			authorId, err = dbp.getAuthorId(ctx, &worklog.Author)
+			if err != nil {
+				dbp.log.Error("err get author Id", "author", worklog.Author.AccountId)
+				return err
+			}
+			authorIds[worklog.Author.AccountId] = authorId
+		}
 
-	if err := dbp.db.QueryRow(query, author.Name).Scan(&authorId); err != nil {
-		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertAuthor, author.Name, err)
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, worklog.WorklogId, authorId,
+			worklog.Started, worklog.Seconds, worklog.Comment); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertWorklog, worklog.WorklogId, err)
+			dbp.log.Error(ansErr.Error(), "issue", issue, "author", worklog.Author.AccountId)
+			return ansErr
+		}
+	}
+
+	dbp.log.Info("success push worklogs", "issue", issue, "count", len(changes.Worklogs))
+	return nil
+}
+
+// PushComments replaces stored comments of the issue, a comment deleted in Jira is deleted here too
+func (dbp *DbPusher) PushComments(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	if _, err := dbp.querier(ctx).ExecContext(ctx, "DELETE FROM issuecomment WHERE issueId = $1", issue); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrDeleteComment, issue, err)
 		dbp.log.Error(ansErr.Error())
-		return 0, ansErr
+		return ansErr
 	}
 
-	dbp.log.Info("success push author", "author", author.Name)
-	return authorId, nil
+	query := `
+   INSERT INTO issuecomment
+       (issueId, commentId, authorId, createdTime, updatedTime, body)
+   VALUES ($1, $2, $3, $4, $5, $6)
+   `
+
+	authorIds := make(map[string]int)
+	for _, comment := range changes.Comments {
+		authorId, ok := authorIds[comment.Author.AccountId]
+		if !ok {
+			var err error
+			authorId, err = dbp.getAuthorId(ctx, &comment.Author)
+			if err != nil {
+				dbp.log.Error("err get author Id", "author", comment.Author.AccountId)
+				return err
+			}
+			authorIds[comment.Author.AccountId] = authorId
+		}
+
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, comment.CommentId, authorId,
+			comment.CreatedTime, comment.UpdatedTime, comment.Body); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertComment, comment.CommentId, err)
+			dbp.log.Error(ansErr.Error(), "issue", issue, "author", comment.Author.AccountId)
+			return ansErr
+		}
+	}
 
+	dbp.log.Info("success push comments", "issue", issue, "count", len(changes.Comments))
+	return nil
 }
 
-func (dbp *DbPusher) PushStatusChanges(issue int, changes *datatransformer.DataTransformer) error {
-	query := "INSERT INTO statuschanges (issueId, authorId, changeTime, fromStatus, toStatus) VALUES ($1, $2, $3, $4, $5)"
-	for author, statusChange := range changes.StatusChanges {
-		if dbp.hasStatusChange(issue, statusChange.ChangeTime) {
-			dbp.log.Warn("already has such status change")
-			return nil
+// PushLabels replaces labels of the issue, labels are shared by all projects
+func (dbp *DbPusher) PushLabels(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	if _, err := dbp.querier(ctx).ExecContext(ctx, "DELETE FROM issuelabel WHERE issueId = $1", issue); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrDeleteLabel, issue, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	query := `
+   WITH l AS (
+       INSERT INTO label (name) VALUES ($2)
+       ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+       RETURNING id
+   )
+   INSERT INTO issuelabel (issueId, labelId) SELECT $1, id FROM l
+   `
+
+	for _, label := range changes.Labels {
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, label); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertLabel, label, err)
+			dbp.log.Error(ansErr.Error(), "issue", issue)
+			return ansErr
 		}
-		authorId, err := dbp.getAuthorId(&structures.DBAuthor{Name: author})
-		if err != nil {
-			dbp.log.Error("err get author Id", "author", author)
-			return err
+	}
+
+	dbp.log.Info("success push labels", "issue", issue, "count", len(changes.Labels))
+	return nil
+}
+
+// PushComponents replaces components of the issue, a component is created in the project of the issue
+func (dbp *DbPusher) PushComponents(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	if _, err := dbp.querier(ctx).ExecContext(ctx, "DELETE FROM issuecomponent WHERE issueId = $1", issue); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrDeleteComponent, issue, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	query := `
+   WITH c AS (
+       INSERT INTO component (projectId, name) SELECT projectId, $2 FROM issue WHERE id = $1
+       ON CONFLICT (projectId, name) DO UPDATE SET name = EXCLUDED.name
+       RETURNING id
+   )
+   INSERT INTO issuecomponent (issueId, componentId) SELECT $1, id FROM c
+   `
+
+	for _, component := range changes.Components {
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, component.Name); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertComponent, component.Name, err)
+			dbp.log.Error(ansErr.Error(), "issue", issue)
+			return ansErr
 		}
-		if _, err := dbp.db.Exec(query, issue, authorId, statusChange.ChangeTime, statusChange.FromStatus, statusChange.ToStatus); err != nil {
-			dbp.log.Error("err insert status change", "author", author)
-			return err
+	}
+
+	dbp.log.Info("success push components", "issue", issue, "count", len(changes.Components))
+	return nil
+}
+
+// PushFixVersions replaces fix versions of the issue, release state of the version is updated by every issue
+func (dbp *DbPusher) PushFixVersions(ctx context.Context, issue int, changes *datatransformer.DataTransformer) error {
+	if _, err := dbp.querier(ctx).ExecContext(ctx, "DELETE FROM issuefixversion WHERE issueId = $1", issue); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrDeleteFixVersion, issue, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	query := `
+   WITH v AS (
+       INSERT INTO version (projectId, name, released, releaseDate)
+       SELECT projectId, $2, $3, $4 FROM issue WHERE id = $1
+       ON CONFLICT (projectId, name) DO UPDATE SET
+           released = EXCLUDED.released,
+           releaseDate = EXCLUDED.releaseDate
+       RETURNING id
+   )
+   INSERT INTO issuefixversion (issueId, versionId) SELECT $1, id FROM v
+   `
+
+	for _, version := range changes.FixVersions {
+		if _, err := dbp.querier(ctx).ExecContext(ctx, query, issue, version.Name,
+			version.Released, version.ReleaseDate); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertFixVersion, version.Name, err)
+			dbp.log.Error(ansErr.Error(), "issue", issue)
+			return ansErr
 		}
 	}
 
-	dbp.log.Info("success push status changes")
+	dbp.log.Info("success push fix versions", "issue", issue, "count", len(changes.FixVersions))
 	return nil
 }
 
-func (dbp *DbPusher) PushIssue(project *structures.DBProject, issue *datatransformer.DataTransformer) (int, error) {
-	projectId, err := dbp.getProjectId(project)
+func (dbp *DbPusher) PushIssue(ctx context.Context, project *structures.DBProject, issue *datatransformer.DataTransformer) (int, error) {
+	projectId, err := dbp.getProjectId(ctx, project)
 	if err != nil {
 		dbp.log.Error("err get project", "project", project)
 		return 0, err
 	}
 
-	authorId, err := dbp.getAuthorId(&issue.Author)
+	authorId, err := dbp.getAuthorId(ctx, &issue.Author)
 	if err != nil {
-		dbp.log.Error("err get author Id", "author", issue.Author.Name)
+		dbp.log.Error("err get author Id", "author", issue.Author.AccountId)
 		return 0, err
 	}
 
-	assegneeId, err := dbp.getAuthorId(&issue.Assignee)
+	reporterId, err := dbp.getOptionalAuthorId(ctx, issue.Reporter)
 	if err != nil {
-		dbp.log.Error("err get assignee Id", "author", issue.Assignee.Name)
+		dbp.log.Error("err get reporter Id", "author", issue.Reporter.AccountId)
 		return 0, err
 	}
 
+	assigneeId, err := dbp.getOptionalAuthorId(ctx, issue.Assignee)
+	if err != nil {
+		dbp.log.Error("err get assignee Id", "author", issue.Assignee.AccountId)
+		return 0, err
+	}
+
+	if err := dbp.moveIssue(ctx, projectId, &issue.Issue); err != nil {
+		return 0, err
+	}
+
+	// an issue found in Jira again is not deleted anymore
 	query := `
    INSERT INTO issue
-       (projectId, authorId, assigneeId, key, summary, description, type, priority, status, createdTime, closedTime, updatedTime, timeSpent)
+       (projectId, authorId, reporterId, assigneeId, key, summary, description, type, typeId, priority, status, createdTime, closedTime, updatedTime, timeSpent, jiraId)
    VALUES
-       ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-   ON CONFLICT (key)
+       ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, ''))
+   ON CONFLICT (projectId, key)
    DO UPDATE SET
-       projectId = EXCLUDED.projectId,
        authorId = EXCLUDED.authorId,
+       reporterId = EXCLUDED.reporterId,
        assigneeId = EXCLUDED.assigneeId,
        summary = EXCLUDED.summary,
        description = EXCLUDED.description,
        type = EXCLUDED.type,
+       typeId = EXCLUDED.typeId,
        priority = EXCLUDED.priority,
        status = EXCLUDED.status,
        createdTime = EXCLUDED.createdTime,
        closedTime = EXCLUDED.closedTime,
        updatedTime = EXCLUDED.updatedTime,
-       timeSpent = EXCLUDED.timeSpent
+       timeSpent = EXCLUDED.timeSpent,
+       jiraId = COALESCE(EXCLUDED.jiraId, issue.jiraId),
+       deletedTime = NULL
    RETURNING id
    `
 
@@ -164,13 +478,14 @@ func (dbp *DbPusher) PushIssue(project *structures.DBProject, issue *datatransfo
 	iss := issue.Issue
 	iss.ProjectId = projectId
 	iss.AuthorId = authorId
-	iss.AssigneeId = assegneeId
+	iss.ReporterId = reporterId
+	iss.AssigneeId = assigneeId
 
-	if err := dbp.db.QueryRow(
-		query, iss.ProjectId, iss.AuthorId, iss.AssigneeId,
-		iss.Key, iss.Summary, iss.Description, iss.Type,
+	if err := dbp.querier(ctx).QueryRowContext(ctx,
+		query, iss.ProjectId, iss.AuthorId, iss.ReporterId, iss.AssigneeId,
+		iss.Key, iss.Summary, iss.Description, iss.Type, iss.TypeId,
 		iss.Priority, iss.Status, iss.CreatedTime,
-		iss.ClosedTime, iss.UpdatedTime, iss.TimeSpent).Scan(&issueId); err != nil {
+		iss.ClosedTime, iss.UpdatedTime, iss.TimeSpent, iss.JiraId).Scan(&issueId); err != nil {
 
 		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertIssue, project.Title, err)
 		dbp.log.Error(ansErr.Error())
@@ -181,68 +496,551 @@ func (dbp *DbPusher) PushIssue(project *structures.DBProject, issue *datatransfo
 	return issueId, nil
 }
 
-func (dbp *DbPusher) PushIssues(project *structures.DBProject, issues []datatransformer.DataTransformer) error {
-	tx, err := dbp.db.Begin()
+// moveIssue re-parents the issue moved to the project from another project of the same
+// Jira: the stored row gets the new project and key and keeps its history. The row is
+// found by the Jira id, a live row is preferred to a tombstoned one. An older payload
+// doesn't move the row back
+func (dbp *DbPusher) moveIssue(ctx context.Context, projectId int, issue *structures.DBIssue) error {
+	if issue.JiraId == "" {
+		return nil
+	}
+
+	query := `
+   UPDATE issue SET projectId = $1, key = $2, deletedTime = NULL
+   WHERE id = (
+       SELECT o.id FROM issue o
+       JOIN projects p ON p.id = o.projectId
+       WHERE o.jiraId = $3 AND p.source = (SELECT source FROM projects WHERE id = $1)
+       ORDER BY o.deletedTime IS NOT NULL, o.updatedTime DESC
+       LIMIT 1
+   )
+   AND updatedTime <= $4
+   AND NOT EXISTS (SELECT 1 FROM issue WHERE projectId = $1 AND key = $2)
+   `
+
+	res, err := dbp.querier(ctx).ExecContext(ctx, query, projectId, issue.Key, issue.JiraId, issue.UpdatedTime)
 	if err != nil {
-		ansErr := fmt.Errorf("%w: %w", myerr.ErrTranBegin, err)
-		dbp.log.Error(ansErr.Error(), "project", project)
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrMoveIssue, issue.Key, err)
+		dbp.log.Error(ansErr.Error())
 		return ansErr
 	}
 
+	if moved, _ := res.RowsAffected(); moved > 0 {
+		dbp.log.Info("issue moved", "issue", issue.Key, "jiraId", issue.JiraId)
+	}
+	return nil
+}
+
+// MarkDeletedIssues tombstones stored issues of the project missing from keys, the full
+// key set of the project in Jira. Rows are kept with deletedTime set, so the analytics
+// can still show them on request. Returns the number of newly tombstoned issues
+func (dbp *DbPusher) MarkDeletedIssues(ctx context.Context, project structures.ProjectRef, keys []string) (int, error) {
+	query := `
+   UPDATE issue SET deletedTime = $4
+   WHERE projectId = (SELECT id FROM projects WHERE source = $1 AND key = $2)
+     AND deletedTime IS NULL
+     AND NOT (key = ANY($3))
+   `
+
+	res, err := dbp.querier(ctx).ExecContext(ctx, query, project.Source, project.Key, pq.Array(keys), time.Now())
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrDeleteIssues, project, err)
+		dbp.log.Error(ansErr.Error())
+		return 0, ansErr
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrDeleteIssues, project, err)
+		dbp.log.Error(ansErr.Error())
+		return 0, ansErr
+	}
+
+	dbp.log.Info("success mark deleted issues", "project", project.String(), "count", deleted)
+	return int(deleted), nil
+}
+
+// PushIssues saves issues of the project in one transaction, or in the transaction
+// of InTx when called inside it. With chunkSize set every chunkSize issues are
+// committed separately, so a failure keeps the chunks saved before it
+func (dbp *DbPusher) PushIssues(ctx context.Context, project *structures.DBProject, issues []datatransformer.DataTransformer) error {
+	chunk := len(issues)
+	if dbp.chunkSize > 0 {
+		chunk = dbp.chunkSize
+	}
+
+	err := dbp.InTx(ctx, func(ctx context.Context) error {
+		for start := 0; start < len(issues); start += chunk {
+			if start > 0 {
+				if err := dbp.commitChunk(ctx); err != nil {
+					return err
+				}
+			}
+
+			end := min(start+chunk, len(issues))
+			push := dbp.pushIssuesByRow
+			if dbp.bulkWrite {
+				push = dbp.pushIssuesBulk
+			}
+			if err := push(ctx, project, issues[start:end]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	dbp.log.Info("success save all issues", "project", project, "count", len(issues))
+	return nil
+}
+
+// pushIssuesByRow writes every row by its own statement, it is run inside InTx
+func (dbp *DbPusher) pushIssuesByRow(ctx context.Context, project *structures.DBProject, issues []datatransformer.DataTransformer) error {
 	for _, issue := range issues {
-		issueId, err := dbp.PushIssue(project, &issue)
+		issueId, err := dbp.PushIssue(ctx, project, &issue)
 		if err != nil {
 			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrPushIssue, project.Title, err)
 			dbp.log.Error(ansErr.Error())
-			tx.Rollback()
 			return ansErr
 		}
 
-		if err := dbp.PushStatusChanges(issueId, &issue); err != nil {
+		if err := dbp.PushStatusChanges(ctx, issueId, &issue); err != nil {
 			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertStatusChange, err)
 			dbp.log.Error(ansErr.Error(), "project", project)
-			tx.Rollback()
 			return ansErr
 		}
+
+		if err := dbp.PushFieldChanges(ctx, issueId, &issue); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertFieldChange, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+
+		if err := dbp.PushCustomFields(ctx, issueId, &issue); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertCustomField, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+
+		if err := dbp.PushIssueLinks(ctx, issueId, &issue); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertIssueLink, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+
+		if err := dbp.PushWorklogs(ctx, issueId, &issue); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertWorklog, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+
+		if err := dbp.PushComments(ctx, issueId, &issue); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertComment, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+
+		if err := dbp.PushLabels(ctx, issueId, &issue); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertLabel, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+
+		if err := dbp.PushComponents(ctx, issueId, &issue); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertComponent, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+
+		if err := dbp.PushFixVersions(ctx, issueId, &issue); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrInsertFixVersion, err)
+			dbp.log.Error(ansErr.Error(), "project", project)
+			return ansErr
+		}
+	}
+
+	return nil
+}
+
+// PushPriorities saves the priority list of the Jira source. Priorities removed
+// from Jira are kept, old changelog entries still refer to them
+func (dbp *DbPusher) PushPriorities(ctx context.Context, source string, priorities []structures.DBPriority) error {
+	ids := make([]string, 0, len(priorities))
+	names := make([]string, 0, len(priorities))
+	ranks := make([]int64, 0, len(priorities))
+	for _, priority := range priorities {
+		ids = append(ids, priority.JiraId)
+		names = append(names, priority.Name)
+		ranks = append(ranks, int64(priority.Rank))
+	}
+
+	query := `
+   INSERT INTO priority (source, jiraId, name, rank)
+   SELECT $1, p.jiraId, p.name, p.rank
+   FROM unnest($2::text[], $3::text[], $4::int[]) AS p(jiraId, name, rank)
+   ON CONFLICT (source, jiraId)
+   DO UPDATE SET
+       name = EXCLUDED.name,
+       rank = EXCLUDED.rank
+   `
+
+	if _, err := dbp.querier(ctx).ExecContext(ctx, query, source, pq.Array(ids), pq.Array(names), pq.Array(ranks)); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertPriority, source, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	dbp.log.Info("success push priorities", "source", source, "count", len(priorities))
+	return nil
+}
+
+// PushBoards saves scrum boards of the project and their sprints. Sprint membership
+// is replaced by the current one, issues of other projects on the board are skipped
+func (dbp *DbPusher) PushBoards(ctx context.Context, project *structures.DBProject, boards []structures.DBBoard) error {
+	projectId, err := dbp.getProjectId(ctx, project)
+	if err != nil {
+		dbp.log.Error("err get project", "project", project)
+		return err
+	}
+
+	for _, board := range boards {
+		board.ProjectId = projectId
+		boardId, err := dbp.pushBoard(ctx, &board)
+		if err != nil {
+			return err
+		}
+
+		for _, sprint := range board.Sprints {
+			sprint.BoardId = boardId
+			sprintId, err := dbp.pushSprint(ctx, &sprint)
+			if err != nil {
+				return err
+			}
+
+			if err := dbp.pushSprintIssues(ctx, projectId, sprintId, sprint.IssueKeys); err != nil {
+				return err
+			}
+		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		ansErr := fmt.Errorf("%w: %w", myerr.ErrTranClose, err)
-		dbp.log.Error(ansErr.Error(), "project", project)
+	dbp.log.Info("success push boards", "project", project, "count", len(boards))
+	return nil
+}
+
+func (dbp *DbPusher) pushBoard(ctx context.Context, board *structures.DBBoard) (int, error) {
+	var boardId int
+	query := `
+   INSERT INTO board (projectId, jiraId, name, type) VALUES ($1, $2, $3, $4)
+   ON CONFLICT (projectId, jiraId)
+   DO UPDATE SET
+       name = EXCLUDED.name,
+       type = EXCLUDED.type
+   RETURNING id
+   `
+
+	if err := dbp.querier(ctx).QueryRowContext(ctx, query, board.ProjectId, board.JiraId, board.Name, board.Type).Scan(&boardId); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertBoard, board.Name, err)
+		dbp.log.Error(ansErr.Error(), "board", board.JiraId)
+		return 0, ansErr
+	}
+	return boardId, nil
+}
+
+func (dbp *DbPusher) pushSprint(ctx context.Context, sprint *structures.DBSprint) (int, error) {
+	var sprintId int
+	query := `
+   INSERT INTO sprint (boardId, jiraId, name, state, goal, startTime, endTime, completeTime)
+   VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+   ON CONFLICT (boardId, jiraId)
+   DO UPDATE SET
+       name = EXCLUDED.name,
+       state = EXCLUDED.state,
+       goal = EXCLUDED.goal,
+       startTime = EXCLUDED.startTime,
+       endTime = EXCLUDED.endTime,
+       completeTime = EXCLUDED.completeTime
+   RETURNING id
+   `
+
+	if err := dbp.querier(ctx).QueryRowContext(ctx, query, sprint.BoardId, sprint.JiraId, sprint.Name, sprint.State, sprint.Goal,
+		nullTime(sprint.StartTime), nullTime(sprint.EndTime), nullTime(sprint.CompleteTime)).Scan(&sprintId); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertSprint, sprint.Name, err)
+		dbp.log.Error(ansErr.Error(), "sprint", sprint.JiraId)
+		return 0, ansErr
+	}
+	return sprintId, nil
+}
+
+func (dbp *DbPusher) pushSprintIssues(ctx context.Context, projectId, sprintId int, keys []string) error {
+	if _, err := dbp.querier(ctx).ExecContext(ctx, "DELETE FROM sprintissue WHERE sprintId = $1", sprintId); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrInsertSprintIssue, sprintId, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	query := `
+   INSERT INTO sprintissue (sprintId, issueId)
+   SELECT $1, id FROM issue WHERE projectId = $2 AND key = ANY($3)
+   `
+
+	if _, err := dbp.querier(ctx).ExecContext(ctx, query, sprintId, projectId, pq.Array(keys)); err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrInsertSprintIssue, sprintId, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+	return nil
+}
+
+func (dbp *DbPusher) GetSyncWatermark(ctx context.Context, project structures.ProjectRef) (time.Time, error) {
+	var watermark sql.NullTime
+	query := `
+   SELECT s.watermark
+   FROM syncstate s
+   JOIN projects p ON p.id = s.projectId
+   WHERE p.source = $1 AND p.key = $2
+   `
+
+	err := dbp.querier(ctx).QueryRowContext(ctx, query, project.Source, project.Key).Scan(&watermark)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectSyncState, project, err)
+		dbp.log.Error(ansErr.Error())
+		return time.Time{}, ansErr
+	}
+
+	dbp.log.Info("success get sync watermark", "project", project.String(), "watermark", watermark.Time)
+	return watermark.Time, nil
+}
+
+func (dbp *DbPusher) PushSyncWatermark(ctx context.Context, project structures.ProjectRef, watermark time.Time) error {
+	// GREATEST ignores NULL, so a sync without issues keeps the previous watermark
+	query := `
+   INSERT INTO syncstate (projectId, watermark, lastSyncTime)
+   SELECT id, $3, $4 FROM projects WHERE source = $1 AND key = $2
+   ON CONFLICT (projectId)
+   DO UPDATE SET
+       watermark = GREATEST(syncstate.watermark, EXCLUDED.watermark),
+       lastSyncTime = EXCLUDED.lastSyncTime
+   `
+
+	mark := sql.NullTime{Time: watermark, Valid: !watermark.IsZero()}
+	if _, err := dbp.querier(ctx).ExecContext(ctx, query, project.Source, project.Key, mark, time.Now()); err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertSyncState, project, err)
+		dbp.log.Error(ansErr.Error())
 		return ansErr
 	}
 
-	dbp.log.Info("success save all issues", "project", project)
+	dbp.log.Info("success push sync watermark", "project", project.String(), "watermark", watermark)
 	return nil
 }
 
-func (dbp *DbPusher) getAuthorId(author *structures.DBAuthor) (int, error) {
+func (dbp *DbPusher) GetProjects(ctx context.Context) ([]structures.ProjectRef, error) {
+	rows, err := dbp.querier(ctx).QueryContext(ctx, "SELECT source, key FROM projects ORDER BY source, key")
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectProject, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+	defer rows.Close()
+
+	projects := []structures.ProjectRef{}
+	for rows.Next() {
+		var project structures.ProjectRef
+		if err := rows.Scan(&project.Source, &project.Key); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectProject, err)
+			dbp.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectProject, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	return projects, nil
+}
+
+func (dbp *DbPusher) PushJob(ctx context.Context, job *structures.Job) (int, error) {
+	var jobId int
+	query := `
+   INSERT INTO jobs (source, project, mode, trigger, state, issuesFetched, issuesTotal, createdTime)
+   VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+   RETURNING id
+   `
+
+	err := dbp.querier(ctx).QueryRowContext(ctx, query, job.Source, job.Project, job.Mode, job.Trigger, job.State,
+		job.IssuesFetched, job.IssuesTotal, job.CreatedTime).Scan(&jobId)
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrInsertJob, job.Ref(), err)
+		dbp.log.Error(ansErr.Error())
+		return 0, ansErr
+	}
+
+	dbp.log.Info("success push job", "job", jobId, "project", job.Ref().String())
+	return jobId, nil
+}
+
+func (dbp *DbPusher) UpdateJob(ctx context.Context, job *structures.Job) error {
+	query := `
+   UPDATE jobs SET
+       state = $2,
+       issuesFetched = $3,
+       issuesTotal = $4,
+       error = $5,
+       startedTime = $6,
+       finishedTime = $7
+   WHERE id = $1
+   `
+
+	_, err := dbp.querier(ctx).ExecContext(ctx, query, job.Id, job.State, job.IssuesFetched, job.IssuesTotal,
+		sql.NullString{String: job.Error, Valid: job.Error != ""},
+		nullTime(job.StartedTime), nullTime(job.FinishedTime))
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrUpdateJob, job.Id, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	return nil
+}
+
+func (dbp *DbPusher) GetJob(ctx context.Context, jobId int) (*structures.Job, error) {
+	query := jobsSelect + "WHERE id = $1"
+
+	job, err := scanJob(dbp.querier(ctx).QueryRowContext(ctx, query, jobId))
+	if err != nil {
+		ansErr := fmt.Errorf("%w - %d: %w", myerr.ErrSelectJob, jobId, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	return job, nil
+}
+
+func (dbp *DbPusher) GetJobs(ctx context.Context, limit int) ([]structures.Job, error) {
+	query := jobsSelect + "ORDER BY id DESC LIMIT $1"
+
+	return dbp.selectJobs(ctx, query, limit)
+}
+
+func (dbp *DbPusher) GetUnfinishedJobs(ctx context.Context) ([]structures.Job, error) {
+	query := jobsSelect + "WHERE state IN ($1, $2) ORDER BY id"
+
+	return dbp.selectJobs(ctx, query, structures.JobQueued, structures.JobRunning)
+}
+
+func (dbp *DbPusher) selectJobs(ctx context.Context, query string, args ...any) ([]structures.Job, error) {
+	rows, err := dbp.querier(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectJob, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+	defer rows.Close()
+
+	jobs := []structures.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectJob, err)
+			dbp.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		jobs = append(jobs, *job)
+	}
+
+	if err := rows.Err(); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectJob, err)
+		dbp.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	return jobs, nil
+}
+
+const jobsSelect = `
+   SELECT id, source, project, mode, trigger, state, issuesFetched, issuesTotal, error, createdTime, startedTime, finishedTime
+   FROM jobs
+   `
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row rowScanner) (*structures.Job, error) {
+	var job structures.Job
+	var jobErr sql.NullString
+	var started, finished sql.NullTime
+
+	err := row.Scan(&job.Id, &job.Source, &job.Project, &job.Mode, &job.Trigger, &job.State, &job.IssuesFetched,
+		&job.IssuesTotal, &jobErr, &job.CreatedTime, &started, &finished)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Error = jobErr.String
+	if started.Valid {
+		job.StartedTime = &started.Time
+	}
+	if finished.Valid {
+		job.FinishedTime = &finished.Time
+	}
+
+	return &job, nil
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func (dbp *DbPusher) getAuthorId(ctx context.Context, author *structures.DBAuthor) (int, error) {
 	var authorId int
 	var err error
-	query := "SELECT id FROM author WHERE name=$1"
+	// the user is written only when it is new or its name, email or active flag changed
+	query := "SELECT id FROM author WHERE accountId=$1 AND name=$2 AND email=$3 AND active=$4"
 
-	_ = dbp.db.QueryRow(query, author.Name).Scan(&authorId)
+	_ = dbp.querier(ctx).QueryRowContext(ctx, query, author.AccountId, author.Name, author.Email, author.Active).Scan(&authorId)
 	if authorId == 0 {
-		authorId, err = dbp.PushAuthor(author)
+		authorId, err = dbp.PushAuthor(ctx, author)
 		if err != nil {
-			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectAuthor, author.Name, err)
+			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectAuthor, author.AccountId, err)
 			dbp.log.Error(ansErr.Error())
 			return 0, ansErr
 		}
 	}
 
-	dbp.log.Info("success get author\assignee id", "author\assignee", author.Name)
+	dbp.log.Info("success get author\assignee id", "author\assignee", author.AccountId)
 	return authorId, nil
 }
 
-func (dbp *DbPusher) getProjectId(project *structures.DBProject) (int, error) {
+// getOptionalAuthorId returns nil for a missing user, e.g. assignee of an unassigned issue
+func (dbp *DbPusher) getOptionalAuthorId(ctx context.Context, author *structures.DBAuthor) (*int, error) {
+	if author == nil {
+		return nil, nil
+	}
+
+	authorId, err := dbp.getAuthorId(ctx, author)
+	if err != nil {
+		return nil, err
+	}
+	return &authorId, nil
+}
+
+func (dbp *DbPusher) getProjectId(ctx context.Context, project *structures.DBProject) (int, error) {
 	var projectId int
 	var err error
-	query := "SELECT id FROM projects WHERE title=$1"
+	query := "SELECT id FROM projects WHERE source=$1 AND key=$2"
 
-	_ = dbp.db.QueryRow(query, project.Title).Scan(&projectId)
+	_ = dbp.querier(ctx).QueryRowContext(ctx, query, project.Source, project.Key).Scan(&projectId)
 	if projectId == 0 {
-		projectId, err = dbp.PushProject(project)
+		projectId, err = dbp.PushProject(ctx, project)
 		if err != nil {
 			ansErr := fmt.Errorf("%w - %s: %w", myerr.ErrSelectProject, project.Title, err)
 			dbp.log.Error(ansErr.Error())
@@ -254,18 +1052,6 @@ func (dbp *DbPusher) getProjectId(project *structures.DBProject) (int, error) {
 	return projectId, nil
 }
 
-func (dbp *DbPusher) hasStatusChange(issue int, time time.Time) bool {
-	var count int
-	query := "SELECT COUNT(*) FROM statuschanges WHERE issueId=$1 AND changeTime=$2"
-	if err := dbp.db.QueryRow(query, issue, time).Scan(&count); err != nil {
-		dbp.log.Error("err select status change", logger.Err(err))
-		return false
-	}
-
-	dbp.log.Info("success check has status change")
-	return count != 0
-}
-
 func buildConnectionstring(cfg *config.DBConfig) string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
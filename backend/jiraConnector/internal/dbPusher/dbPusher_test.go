@@ -1,6 +1,9 @@
 package dbpusher
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -12,6 +15,7 @@ import (
 	datatransformer "github.com/jiraconnector/internal/dataTransformer"
 	myerr "github.com/jiraconnector/internal/dbPusher/errors"
 	"github.com/jiraconnector/internal/structures"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,7 +38,7 @@ func TestPushProject(t *testing.T) {
 			title: "Test Project",
 			mockQuery: func() {
 				mock.ExpectQuery("INSERT INTO projects").
-					WithArgs("Test Project", "", "").
+					WithArgs("", "Test Project", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			},
 			wantErr:    false,
@@ -45,7 +49,7 @@ func TestPushProject(t *testing.T) {
 			title: "Bad Project",
 			mockQuery: func() {
 				mock.ExpectQuery("INSERT INTO projects").
-					WithArgs("Bad Project", "", "").
+					WithArgs("", "Bad Project", "", "").
 					WillReturnError(fmt.Errorf("insert error"))
 			},
 			wantErr:    true,
@@ -56,7 +60,7 @@ func TestPushProject(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockQuery()
-			id, err := dbp.PushProject(&structures.DBProject{Title: tt.title})
+			id, err := dbp.PushProject(context.Background(), &structures.DBProject{Title: tt.title})
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -86,10 +90,10 @@ func TestPushProjects(t *testing.T) {
 			mockSetup: func(m *sqlmock.Sqlmock) {
 				(*m).ExpectBegin()
 				(*m).ExpectQuery("INSERT INTO projects").
-					WithArgs("Project A", "", "").
+					WithArgs("", "Project A", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				(*m).ExpectQuery("INSERT INTO projects").
-					WithArgs("Project B", "", "").
+					WithArgs("", "Project B", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				(*m).ExpectCommit()
 			},
@@ -115,10 +119,10 @@ func TestPushProjects(t *testing.T) {
 			mockSetup: func(m *sqlmock.Sqlmock) {
 				(*m).ExpectBegin()
 				(*m).ExpectQuery("INSERT INTO projects").
-					WithArgs("Project A", "", "").
+					WithArgs("", "Project A", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				(*m).ExpectQuery("INSERT INTO projects").
-					WithArgs("Project B", "", "").
+					WithArgs("", "Project B", "", "").
 					WillReturnError(errors.New("insert error"))
 				(*m).ExpectRollback()
 			},
@@ -133,7 +137,7 @@ func TestPushProjects(t *testing.T) {
 			mockSetup: func(m *sqlmock.Sqlmock) {
 				(*m).ExpectBegin()
 				(*m).ExpectQuery("INSERT INTO projects").
-					WithArgs("Project A", "", "").
+					WithArgs("", "Project A", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				(*m).ExpectCommit().WillReturnError(errors.New("commit error"))
 			},
@@ -165,7 +169,7 @@ func TestPushProjects(t *testing.T) {
 
 			dbp := &DbPusher{db: db, log: slog.Default()}
 
-			err = dbp.PushProjects(tt.projects)
+			err = dbp.PushProjects(context.Background(), tt.projects)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("PushProjects() error = %v, wantErr %v", err, tt.wantErr)
@@ -201,10 +205,21 @@ func TestPushAuthor(t *testing.T) {
 	}{
 		{
 			name:   "success insert",
-			author: structures.DBAuthor{Name: "Author1"},
+			author: structures.DBAuthor{AccountId: "author1", Name: "Author1"},
 			mockQuery: func() {
 				mock.ExpectQuery("INSERT INTO author").
-					WithArgs("Author1").
+					WithArgs("author1", "Author1", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantErr:    false,
+			wantResult: 1,
+		},
+		{
+			name:   "renamed user keeps its id",
+			author: structures.DBAuthor{AccountId: "author1", Name: "New Name", Email: "new@example.com", Active: true},
+			mockQuery: func() {
+				mock.ExpectQuery(`INSERT INTO author .+ON CONFLICT \(accountId\)\s+DO UPDATE`).
+					WithArgs("author1", "New Name", "new@example.com", true).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			},
 			wantErr:    false,
@@ -212,10 +227,10 @@ func TestPushAuthor(t *testing.T) {
 		},
 		{
 			name:   "insert error",
-			author: structures.DBAuthor{Name: "Author2"},
+			author: structures.DBAuthor{AccountId: "author2", Name: "Author2"},
 			mockQuery: func() {
 				mock.ExpectQuery("INSERT INTO author").
-					WithArgs("Author2").
+					WithArgs("author2", "Author2", "", false).
 					WillReturnError(errors.New("db error"))
 			},
 			wantErr:    true,
@@ -226,7 +241,7 @@ func TestPushAuthor(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockQuery()
-			id, err := dbp.PushAuthor(&tt.author)
+			id, err := dbp.PushAuthor(context.Background(), &tt.author)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -238,106 +253,144 @@ func TestPushAuthor(t *testing.T) {
 	}
 }
 
-func TestHasStatusChange(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	dbp := &DbPusher{db: db, log: slog.Default()}
+func TestPushStatusChanges(t *testing.T) {
+	issueID := 123
+	changeTime := time.Now()
+	upsert := regexp.QuoteMeta(`INSERT INTO statuschanges (issueId, historyId, authorId, changeTime, fromStatus, toStatus)`)
 
-	timeVal := time.Now()
+	changes := datatransformer.DataTransformer{
+		StatusChanges: []structures.DBStatusTransition{
+			{HistoryId: "10", Author: structures.DBAuthor{AccountId: "jdoe", Name: "John Doe"}, ChangeTime: changeTime, FromStatus: "Open", ToStatus: "In Progress"},
+			{HistoryId: "11", Author: structures.DBAuthor{AccountId: "jdoe", Name: "John Doe"}, ChangeTime: changeTime.Add(time.Hour), FromStatus: "In Progress", ToStatus: "Closed"},
+			{HistoryId: "12", Author: structures.DBAuthor{AccountId: "jane", Name: "Jane Doe"}, ChangeTime: changeTime.Add(2 * time.Hour), FromStatus: "Closed", ToStatus: "Reopened"},
+		},
+	}
 
 	tests := []struct {
 		name      string
-		issueID   int
-		time      time.Time
-		mockQuery func()
-		want      bool
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
 	}{
 		{
-			name:    "found",
-			issueID: 1,
-			time:    timeVal,
-			mockQuery: func() {
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM statuschanges`).
-					WithArgs(1, timeVal).
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
-			},
-			want: true,
-		},
-		{
-			name:    "not found",
-			issueID: 2,
-			time:    timeVal,
-			mockQuery: func() {
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM statuschanges`).
-					WithArgs(2, timeVal).
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			name: "all transitions of the same author are saved",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("jdoe", "John Doe", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				m.ExpectExec(upsert).
+					WithArgs(issueID, "10", 4, changeTime, "Open", "In Progress").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				// author id is taken from cache
+				m.ExpectExec(upsert).
+					WithArgs(issueID, "11", 4, changeTime.Add(time.Hour), "In Progress", "Closed").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("jane", "Jane Doe", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				m.ExpectExec(upsert).
+					WithArgs(issueID, "12", 5, changeTime.Add(2*time.Hour), "Closed", "Reopened").
+					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
-			want: false,
 		},
 		{
-			name:    "query error",
-			issueID: 3,
-			time:    timeVal,
-			mockQuery: func() {
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM statuschanges`).
-					WithArgs(3, timeVal).
+			name: "insert error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("jdoe", "John Doe", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				m.ExpectExec(upsert).
 					WillReturnError(errors.New("db error"))
 			},
-			want: false,
+			wantErr: myerr.ErrInsertStatusChange,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockQuery()
-			result := dbp.hasStatusChange(tt.issueID, tt.time)
-			assert.Equal(t, tt.want, result)
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushStatusChanges(context.Background(), issueID, &changes)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestPushStatusChanges(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	dbp := &DbPusher{db: db, log: slog.Default()}
-
+func TestPushFieldChanges(t *testing.T) {
 	issueID := 123
-	authorName := "John Doe"
 	changeTime := time.Now()
-	fromStatus := "Open"
-	toStatus := "In Progress"
+	upsert := regexp.QuoteMeta(`INSERT INTO issuefieldchanges`)
 
 	changes := datatransformer.DataTransformer{
-		StatusChanges: map[string]structures.DBStatusChanges{
-			authorName: {
-				ChangeTime: changeTime,
-				FromStatus: fromStatus,
-				ToStatus:   toStatus,
-			},
+		FieldChanges: []structures.DBFieldChange{
+			{HistoryId: "10", ItemIndex: 0, Author: structures.DBAuthor{AccountId: "jdoe", Name: "John Doe"}, ChangeTime: changeTime,
+				Field: "priority", FieldType: "jira", FromValue: "4", FromString: "Low", ToValue: "2", ToString: "High"},
+			{HistoryId: "10", ItemIndex: 1, Author: structures.DBAuthor{AccountId: "jdoe", Name: "John Doe"}, ChangeTime: changeTime,
+				Field: "assignee", FieldType: "jira", FromValue: "john", FromString: "John Doe", ToValue: "jane", ToString: "Jane Doe"},
 		},
 	}
 
-	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM statuschanges WHERE issueId=\$1 AND changeTime=\$2`).
-		WithArgs(issueID, changeTime).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "all items are saved",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("jdoe", "John Doe", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				m.ExpectExec(upsert).
+					WithArgs(issueID, "10", 0, 4, changeTime, "priority", "jira", "4", "Low", "2", "High").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(upsert).
+					WithArgs(issueID, "10", 1, 4, changeTime, "assignee", "jira", "john", "John Doe", "jane", "Jane Doe").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "insert error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("jdoe", "John Doe", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				m.ExpectExec(upsert).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertFieldChange,
+		},
+	}
 
-	mock.ExpectQuery(`SELECT id FROM author WHERE name=\$1`).
-		WithArgs(authorName).
-		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
 
-	mock.ExpectExec(`INSERT INTO statuschanges \(issueId, authorId, changeTime, fromStatus, toStatus\) VALUES \(\$1, \$2, \$3, \$4, \$5\)`).
-		WithArgs(issueID, 4, changeTime, fromStatus, toStatus).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+			tt.mockQuery(mock)
 
-	err = dbp.PushStatusChanges(issueID, &changes)
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushFieldChanges(context.Background(), issueID, &changes)
 
-	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
 }
 
 func TestPushIssue(t *testing.T) {
@@ -351,8 +404,8 @@ func TestPushIssue(t *testing.T) {
 			Status:      "Open",
 			CreatedTime: time.Now(),
 		},
-		Author:   structures.DBAuthor{Name: "user1"},
-		Assignee: structures.DBAuthor{Name: "user2"},
+		Author:   structures.DBAuthor{AccountId: "user1", Name: "user1"},
+		Assignee: &structures.DBAuthor{AccountId: "user2", Name: "user2"},
 	}
 	tests := []struct {
 		name          string
@@ -371,23 +424,23 @@ func TestPushIssue(t *testing.T) {
 				//(*m).ExpectQuery(`SELECT id FROM projects WHERE title=\$1`).WithArgs("Project1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 				(*m).ExpectQuery(regexp.QuoteMeta(`
 					INSERT INTO projects`)).
-					WithArgs("Project1", "", "").
+					WithArgs("", "Project1", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 				// Mock getAuthorId (author) - сначала SELECT возвращает 0, потом INSERT
-				(*m).ExpectQuery(`SELECT id FROM author WHERE name=\$1`).
-					WithArgs("user1").
+				(*m).ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("user1", "user1", "", false).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).
-					WithArgs("user1").
+					WithArgs("user1", "user1", "", false).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
 
 				// Mock getAuthorId (assignee) - сначала SELECT возвращает 0, потом INSERT
-				(*m).ExpectQuery(`SELECT id FROM author WHERE name=\$1`).
-					WithArgs("user2").
+				(*m).ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("user2", "user2", "", false).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).
-					WithArgs("user2").
+					WithArgs("user2", "user2", "", false).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
 
 				// Mock insert issue
@@ -405,23 +458,81 @@ func TestPushIssue(t *testing.T) {
 				// Project уже существует
 				(*m).ExpectQuery(regexp.QuoteMeta(`
 					INSERT INTO projects`)).
-					WithArgs("Project1", "", "").
+					WithArgs("", "Project1", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 				// Авторы не существуют
 				// Mock getAuthorId (author) - сначала SELECT возвращает 0, потом INSERT
-				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE name=$1`)).WithArgs("user1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
-				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).WithArgs("user1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).WithArgs("user1", "user1", "", false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).WithArgs("user1", "user1", "", false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
 
 				// Mock getAuthorId (assignee) - сначала SELECT возвращает 0, потом INSERT
-				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE name=$1`)).WithArgs("user2").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
-				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).WithArgs("user2").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).WithArgs("user2", "user2", "", false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).WithArgs("user2", "user2", "", false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
 
 				// Insert issue
 				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO issue`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(100))
 			},
 			expectedId: 100,
 		},
+		{
+			name:    "unassigned issue with reporter",
+			project: "Project1",
+			issue: datatransformer.DataTransformer{
+				Issue:    structures.DBIssue{Key: "PRJ-2", Type: "Bug", TypeId: "10004"},
+				Author:   structures.DBAuthor{AccountId: "user1", Name: "user1"},
+				Reporter: &structures.DBAuthor{AccountId: "user3", Name: "user3"},
+			},
+			mockSetup: func(m *sqlmock.Sqlmock) {
+				(*m).ExpectQuery(regexp.QuoteMeta(`
+					INSERT INTO projects`)).
+					WithArgs("", "Project1", "", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).WithArgs("user1", "user1", "", false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).WithArgs("user3", "user3", "", false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+				// assignee is stored as NULL
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO issue`)).
+					WithArgs(1, 2, 4, nil, "PRJ-2", "", "", "Bug", "10004", "", "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 0, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(101))
+			},
+			expectedId: 101,
+		},
+		{
+			name:    "issue moved from another project",
+			project: "Project1",
+			issue: datatransformer.DataTransformer{
+				Issue:  structures.DBIssue{JiraId: "10001", Key: "PRJ-3"},
+				Author: structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			},
+			mockSetup: func(m *sqlmock.Sqlmock) {
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO projects`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+				// the row found by the Jira id gets the new project and key
+				(*m).ExpectExec(regexp.QuoteMeta(`UPDATE issue SET projectId = $1, key = $2, deletedTime = NULL`)).
+					WithArgs(1, "PRJ-3", "10001", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO issue`)).
+					WithArgs(1, 2, nil, nil, "PRJ-3", "", "", "", "", "", "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 0, "10001").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(55))
+			},
+			expectedId: 55,
+		},
+		{
+			name:    "failed to move issue",
+			project: "Project1",
+			issue: datatransformer.DataTransformer{
+				Issue:  structures.DBIssue{JiraId: "10001", Key: "PRJ-3"},
+				Author: structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			},
+			mockSetup: func(m *sqlmock.Sqlmock) {
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO projects`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				(*m).ExpectExec(regexp.QuoteMeta(`UPDATE issue SET projectId`)).WillReturnError(errors.New("db error"))
+			},
+			expectedError: myerr.ErrMoveIssue,
+		},
 		{
 			name:    "failed to insert project",
 			project: "Project1",
@@ -433,7 +544,7 @@ func TestPushIssue(t *testing.T) {
 				//(*m).ExpectQuery(`SELECT id FROM projects WHERE title=\$1`).WithArgs("Project1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 				(*m).ExpectQuery(regexp.QuoteMeta(`
 					INSERT INTO projects`)).
-					WithArgs("Project1", "", "").
+					WithArgs("", "Project1", "", "").
 					WillReturnError(myerr.ErrInsertProject)
 			},
 			expectedError: myerr.ErrSelectProject,
@@ -443,21 +554,21 @@ func TestPushIssue(t *testing.T) {
 			project: "Project1",
 			issue: datatransformer.DataTransformer{
 				Issue:  structures.DBIssue{Key: "PRJ-1"},
-				Author: structures.DBAuthor{Name: "user1"},
+				Author: structures.DBAuthor{AccountId: "user1", Name: "user1"},
 			},
 			mockSetup: func(m *sqlmock.Sqlmock) {
 				// Project успешно находится
 				(*m).ExpectQuery(regexp.QuoteMeta(`
 					INSERT INTO projects`)).
-					WithArgs("Project1", "", "").
+					WithArgs("", "Project1", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 				// Author не найден и ошибка при вставке
-				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE name=$1`)).
-					WithArgs("user1").
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).
+					WithArgs("user1", "user1", "", false).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).
-					WithArgs("user1").
+					WithArgs("user1", "user1", "", false).
 					WillReturnError(myerr.ErrInsertAuthor)
 			},
 			expectedError: myerr.ErrSelectAuthor,
@@ -470,24 +581,24 @@ func TestPushIssue(t *testing.T) {
 					Key:     "PRJ-1",
 					Summary: "Test",
 				},
-				Author:   structures.DBAuthor{Name: "user1"},
-				Assignee: structures.DBAuthor{Name: "user2"},
+				Author:   structures.DBAuthor{AccountId: "user1", Name: "user1"},
+				Assignee: &structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			},
 			mockSetup: func(m *sqlmock.Sqlmock) {
 				// Project успешно находится
 				(*m).ExpectQuery(regexp.QuoteMeta(`
 					INSERT INTO projects`)).
-					WithArgs("Project1", "", "").
+					WithArgs("", "Project1", "", "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 				// Author успешно находится/вставляется
-				(*m).ExpectQuery("SELECT id FROM author WHERE name=\\$1").
-					WithArgs("user1").
+				(*m).ExpectQuery("SELECT id FROM author WHERE accountId=\\$1").
+					WithArgs("user1", "user1", "", false).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
 
 				// Assignee успешно находится/вставляется
-				(*m).ExpectQuery("SELECT id FROM author WHERE name=\\$1").
-					WithArgs("user2").
+				(*m).ExpectQuery("SELECT id FROM author WHERE accountId=\\$1").
+					WithArgs("user2", "user2", "", false).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
 
 				// Ошибка при вставке issue
@@ -511,7 +622,7 @@ func TestPushIssue(t *testing.T) {
 			}
 
 			dbp := &DbPusher{db: db, log: slog.Default()}
-			id, err := dbp.PushIssue(&structures.DBProject{Title: tt.project}, &tt.issue)
+			id, err := dbp.PushIssue(context.Background(), &structures.DBProject{Title: tt.project}, &tt.issue)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -527,187 +638,1173 @@ func TestPushIssue(t *testing.T) {
 		})
 	}
 }
-func TestPushIssues(t *testing.T) {
-	now := time.Now()
+func TestPushCustomFields(t *testing.T) {
+	issueID := 123
+	points := 5.0
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM issuecustomfield WHERE issueId = $1`)
+	insert := regexp.QuoteMeta(`INSERT INTO issuecustomfield`)
 
-	testStatusChange1 := map[string]structures.DBStatusChanges{
-		"user1": {
-			IssueId:    100,
-			AuthorId:   1,
-			ChangeTime: now.Add(60 * time.Minute),
-			FromStatus: "process",
-			ToStatus:   "approved",
-		},
-		"user2": {
-			IssueId:    100,
-			AuthorId:   2,
-			ChangeTime: now.Add(120 * time.Minute),
-			FromStatus: "process",
-			ToStatus:   "process",
-		},
-	}
-	testStatusChange2 := map[string]structures.DBStatusChanges{
-		"user1": {
-			IssueId:    101,
-			AuthorId:   1,
-			ChangeTime: now.Add(60 * time.Minute),
-			FromStatus: "process",
-			ToStatus:   "approved",
-		},
-		"user2": {
-			IssueId:    101,
-			AuthorId:   2,
-			ChangeTime: now.Add(120 * time.Minute),
-			FromStatus: "process",
-			ToStatus:   "process",
-		},
-	}
-	testIssues := []datatransformer.DataTransformer{
-		{
-			Issue: structures.DBIssue{
-				Key:         "PRJ-1",
-				Summary:     "Test issue",
-				Description: "Test description",
-				Type:        "Task",
-				Priority:    "High",
-				Status:      "Open",
-				CreatedTime: now,
-			},
-			Author:        structures.DBAuthor{Name: "user1"},
-			Assignee:      structures.DBAuthor{Name: "user2"},
-			StatusChanges: testStatusChange1,
-		},
-		{
-			Issue: structures.DBIssue{
-				Key:         "PRJ-2",
-				Summary:     "Test issue",
-				Description: "Test description",
-				Type:        "Task",
-				Priority:    "High",
-				Status:      "Open",
-				CreatedTime: now,
-			},
-			Author:        structures.DBAuthor{Name: "user1"},
-			Assignee:      structures.DBAuthor{Name: "user2"},
-			StatusChanges: testStatusChange2,
+	changes := datatransformer.DataTransformer{
+		CustomFields: []structures.DBCustomField{
+			{Name: "story_points", FieldId: "customfield_10016", Value: "5", NumberValue: &points},
+			{Name: "sprint", FieldId: "customfield_10020", ValueIndex: 0, Value: "Sprint 1"},
+			{Name: "sprint", FieldId: "customfield_10020", ValueIndex: 1, Value: "Sprint 2"},
 		},
 	}
 
 	tests := []struct {
-		name          string
-		project       string
-		issues        []datatransformer.DataTransformer
-		mockSetup     func(*sqlmock.Sqlmock)
-		expectedError error
+		name      string
+		changes   datatransformer.DataTransformer
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
 	}{
 		{
-			name:    "success with no issues",
-			project: "Project1",
-			issues:  []datatransformer.DataTransformer{},
-			mockSetup: func(m *sqlmock.Sqlmock) {
-				(*m).ExpectBegin()
-				(*m).ExpectCommit()
+			name:    "old values are replaced",
+			changes: changes,
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(insert).
+					WithArgs(issueID, "story_points", "customfield_10016", 0, "5", &points).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(insert).
+					WithArgs(issueID, "sprint", "customfield_10020", 0, "Sprint 1", nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(insert).
+					WithArgs(issueID, "sprint", "customfield_10020", 1, "Sprint 2", nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
-			expectedError: nil,
 		},
 		{
-			name:    "failed to begin transaction",
-			project: "Project1",
-			issues:  testIssues,
-			mockSetup: func(m *sqlmock.Sqlmock) {
-				(*m).ExpectBegin().WillReturnError(errors.New("begin error"))
+			name: "issue without custom fields",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 0))
 			},
-			expectedError: myerr.ErrTranBegin,
 		},
 		{
-			name:    "failed to push issue - rollback",
-			project: "Project1",
-			issues:  testIssues,
-			mockSetup: func(m *sqlmock.Sqlmock) {
-				(*m).ExpectBegin()
-				(*m).ExpectQuery(regexp.QuoteMeta(`
-					INSERT INTO projects`)).
-					WithArgs("Project1", "", "").
-					WillReturnError(errors.New("project error"))
-				(*m).ExpectRollback()
+			name:    "delete error",
+			changes: changes,
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WillReturnError(errors.New("db error"))
 			},
-			expectedError: myerr.ErrPushIssue,
+			wantErr: myerr.ErrDeleteCustomField,
 		},
 		{
-			name:    "failed to push status changes - rollback",
-			project: "Project1",
-			issues:  testIssues,
-			mockSetup: func(m *sqlmock.Sqlmock) {
-				(*m).ExpectBegin()
-				// First issue
-				(*m).ExpectQuery(`SELECT id FROM projects WHERE title=\$1`).
-					WithArgs("Project1").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
-				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO projects`)).
-					WithArgs("Project1", "", "").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			name:    "insert error",
+			changes: changes,
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(insert).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertCustomField,
+		},
+	}
 
-				// Author queries for first issue
-				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE name=$1`)).
-					WithArgs("user1").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
-				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).
-					WithArgs("user1").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
 
-				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE name=$1`)).
-					WithArgs("user2").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
-				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).
-					WithArgs("user2").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+			tt.mockQuery(mock)
 
-				// Insert first issue
-				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO issue`)).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(100))
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushCustomFields(context.Background(), issueID, &tt.changes)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
 
-				// Status change fails (не завязываемся на конкретное время,
-				// так как порядок обхода map не детерминирован)
-				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM statuschanges WHERE issueId=$1 AND changeTime=$2`)).
-					WithArgs(testStatusChange1["user1"].IssueId, sqlmock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+func TestPushIssueLinks(t *testing.T) {
+	issueID := 123
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM issuelink WHERE issueId = $1`)
+	insert := regexp.QuoteMeta(`INSERT INTO issuelink`)
 
-				// Author already exists for status changes
-				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE name=$1`)).
-					WithArgs("user1").
-					WillReturnError(myerr.ErrInsertAuthor)
+	changes := datatransformer.DataTransformer{
+		Links: []structures.DBIssueLink{
+			{LinkType: structures.LinkParent, LinkedKey: "PRJ-1", Description: "parent"},
+			{LinkType: "Blocks", Direction: structures.LinkInward, LinkedKey: "OTHER-1", Description: "is blocked by"},
+		},
+	}
 
-				(*m).ExpectRollback()
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "old links are replaced",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(insert).
+					WithArgs(issueID, "parent", "", "PRJ-1", "parent").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(insert).
+					WithArgs(issueID, "Blocks", "inward", "OTHER-1", "is blocked by").
+					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
-			expectedError: myerr.ErrInsertStatusChange,
+		},
+		{
+			name: "delete error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrDeleteIssueLink,
+		},
+		{
+			name: "insert error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(insert).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertIssueLink,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
-			if err != nil {
-				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
-			}
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
 			defer db.Close()
 
-			if tt.mockSetup != nil {
-				tt.mockSetup(&mock)
-			}
+			tt.mockQuery(mock)
 
 			dbp := &DbPusher{db: db, log: slog.Default()}
-			err = dbp.PushIssues(&structures.DBProject{Title: tt.project}, tt.issues)
+			err = dbp.PushIssueLinks(context.Background(), issueID, &changes)
 
-			if tt.expectedError != nil {
-				assert.Error(t, err)
-				assert.True(t, errors.Is(err, tt.expectedError), "expected error %v, got %v", tt.expectedError, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
 			} else {
 				assert.NoError(t, err)
 			}
-
-			if err := mock.ExpectationsWereMet(); err != nil {
-				t.Errorf("there were unfulfilled expectations: %s", err)
-			}
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
+
+func TestPushWorklogs(t *testing.T) {
+	issueID := 123
+	started := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM worklog WHERE issueId = $1`)
+	insert := regexp.QuoteMeta(`INSERT INTO worklog`)
+
+	changes := datatransformer.DataTransformer{
+		Worklogs: []structures.DBWorklog{
+			{WorklogId: "100", Author: structures.DBAuthor{AccountId: "alice", Name: "alice"}, Started: started, Seconds: 3600, Comment: "review"},
+			{WorklogId: "101", Author: structures.DBAuthor{AccountId: "alice", Name: "alice"}, Started: started.Add(time.Hour), Seconds: 1800},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "old worklogs are replaced",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("alice", "alice", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				m.ExpectExec(insert).
+					WithArgs(issueID, "100", 7, started, 3600, "review").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(insert).
+					WithArgs(issueID, "101", 7, started.Add(time.Hour), 1800, "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "delete error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrDeleteWorklog,
+		},
+		{
+			name: "insert error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("alice", "alice", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				m.ExpectExec(insert).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertWorklog,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushWorklogs(context.Background(), issueID, &changes)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPushComments(t *testing.T) {
+	issueID := 123
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM issuecomment WHERE issueId = $1`)
+	insert := regexp.QuoteMeta(`INSERT INTO issuecomment`)
+
+	changes := datatransformer.DataTransformer{
+		Comments: []structures.DBComment{
+			{CommentId: "10", Author: structures.DBAuthor{AccountId: "alice", Name: "alice"}, CreatedTime: created, UpdatedTime: created, Body: "first"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "old comments are replaced",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("alice", "alice", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				m.ExpectExec(insert).
+					WithArgs(issueID, "10", 7, created, created, "first").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "delete error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrDeleteComment,
+		},
+		{
+			name: "insert error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(`SELECT id FROM author WHERE accountId=\$1`).
+					WithArgs("alice", "alice", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				m.ExpectExec(insert).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertComment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushComments(context.Background(), issueID, &changes)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPushIssueClassifiers(t *testing.T) {
+	issueID := 123
+	releaseDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	changes := datatransformer.DataTransformer{
+		Labels:      []string{"backend", "urgent"},
+		Components:  []structures.DBComponent{{Name: "API"}},
+		FixVersions: []structures.DBVersion{{Name: "1.0", Released: true, ReleaseDate: &releaseDate}},
+	}
+
+	tests := []struct {
+		name      string
+		push      func(dbp *DbPusher) error
+		table     string
+		inserts   [][]driver.Value
+		deleteErr error
+		insertErr error
+	}{
+		{
+			name:      "labels",
+			push:      func(dbp *DbPusher) error { return dbp.PushLabels(context.Background(), issueID, &changes) },
+			table:     "issuelabel",
+			inserts:   [][]driver.Value{{issueID, "backend"}, {issueID, "urgent"}},
+			deleteErr: myerr.ErrDeleteLabel,
+			insertErr: myerr.ErrInsertLabel,
+		},
+		{
+			name:      "components",
+			push:      func(dbp *DbPusher) error { return dbp.PushComponents(context.Background(), issueID, &changes) },
+			table:     "issuecomponent",
+			inserts:   [][]driver.Value{{issueID, "API"}},
+			deleteErr: myerr.ErrDeleteComponent,
+			insertErr: myerr.ErrInsertComponent,
+		},
+		{
+			name:      "fix versions",
+			push:      func(dbp *DbPusher) error { return dbp.PushFixVersions(context.Background(), issueID, &changes) },
+			table:     "issuefixversion",
+			inserts:   [][]driver.Value{{issueID, "1.0", true, releaseDate}},
+			deleteErr: myerr.ErrDeleteFixVersion,
+			insertErr: myerr.ErrInsertFixVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		deleteQuery := regexp.QuoteMeta(`DELETE FROM ` + tt.table + ` WHERE issueId = $1`)
+		insert := regexp.QuoteMeta(`INSERT INTO ` + tt.table)
+
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(deleteQuery).WithArgs(issueID).WillReturnResult(sqlmock.NewResult(0, 1))
+			for _, args := range tt.inserts {
+				mock.ExpectExec(insert).WithArgs(args...).WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			assert.NoError(t, tt.push(&DbPusher{db: db, log: slog.Default()}))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+
+		t.Run(tt.name+" delete error", func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(deleteQuery).WillReturnError(errors.New("db error"))
+			assert.ErrorIs(t, tt.push(&DbPusher{db: db, log: slog.Default()}), tt.deleteErr)
+		})
+
+		t.Run(tt.name+" insert error", func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(deleteQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(insert).WillReturnError(errors.New("db error"))
+			assert.ErrorIs(t, tt.push(&DbPusher{db: db, log: slog.Default()}), tt.insertErr)
+		})
+	}
+}
+
+func TestPushIssues(t *testing.T) {
+	now := time.Now()
+
+	testStatusChange1 := []structures.DBStatusTransition{
+		{
+			HistoryId:  "1001",
+			IssueId:    100,
+			AuthorId:   1,
+			Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			ChangeTime: now.Add(60 * time.Minute),
+			FromStatus: "process",
+			ToStatus:   "approved",
+		},
+		{
+			HistoryId:  "1002",
+			IssueId:    100,
+			AuthorId:   2,
+			Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
+			ChangeTime: now.Add(120 * time.Minute),
+			FromStatus: "process",
+			ToStatus:   "process",
+		},
+	}
+	testStatusChange2 := []structures.DBStatusTransition{
+		{
+			HistoryId:  "1011",
+			IssueId:    101,
+			AuthorId:   1,
+			Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			ChangeTime: now.Add(60 * time.Minute),
+			FromStatus: "process",
+			ToStatus:   "approved",
+		},
+		{
+			HistoryId:  "1012",
+			IssueId:    101,
+			AuthorId:   2,
+			Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
+			ChangeTime: now.Add(120 * time.Minute),
+			FromStatus: "process",
+			ToStatus:   "process",
+		},
+	}
+	testIssues := []datatransformer.DataTransformer{
+		{
+			Issue: structures.DBIssue{
+				Key:         "PRJ-1",
+				Summary:     "Test issue",
+				Description: "Test description",
+				Type:        "Task",
+				Priority:    "High",
+				Status:      "Open",
+				CreatedTime: now,
+			},
+			Author:        structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			Assignee:      &structures.DBAuthor{AccountId: "user2", Name: "user2"},
+			StatusChanges: testStatusChange1,
+		},
+		{
+			Issue: structures.DBIssue{
+				Key:         "PRJ-2",
+				Summary:     "Test issue",
+				Description: "Test description",
+				Type:        "Task",
+				Priority:    "High",
+				Status:      "Open",
+				CreatedTime: now,
+			},
+			Author:        structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			Assignee:      &structures.DBAuthor{AccountId: "user2", Name: "user2"},
+			StatusChanges: testStatusChange2,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		project       string
+		issues        []datatransformer.DataTransformer
+		mockSetup     func(*sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name:    "success with no issues",
+			project: "Project1",
+			issues:  []datatransformer.DataTransformer{},
+			mockSetup: func(m *sqlmock.Sqlmock) {
+				(*m).ExpectBegin()
+				(*m).ExpectCommit()
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "failed to begin transaction",
+			project: "Project1",
+			issues:  testIssues,
+			mockSetup: func(m *sqlmock.Sqlmock) {
+				(*m).ExpectBegin().WillReturnError(errors.New("begin error"))
+			},
+			expectedError: myerr.ErrTranBegin,
+		},
+		{
+			name:    "failed to push issue - rollback",
+			project: "Project1",
+			issues:  testIssues,
+			mockSetup: func(m *sqlmock.Sqlmock) {
+				(*m).ExpectBegin()
+				(*m).ExpectQuery(regexp.QuoteMeta(`
+					INSERT INTO projects`)).
+					WithArgs("", "Project1", "", "").
+					WillReturnError(errors.New("project error"))
+				(*m).ExpectRollback()
+			},
+			expectedError: myerr.ErrPushIssue,
+		},
+		{
+			name:    "failed to push status changes - rollback",
+			project: "Project1",
+			issues:  testIssues,
+			mockSetup: func(m *sqlmock.Sqlmock) {
+				(*m).ExpectBegin()
+				// First issue
+				(*m).ExpectQuery(`SELECT id FROM projects WHERE source=\$1 AND key=\$2`).
+					WithArgs("", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO projects`)).
+					WithArgs("", "Project1", "", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+				// Author queries for first issue
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).
+					WithArgs("user1", "user1", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).
+					WithArgs("user1", "user1", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).
+					WithArgs("user2", "user2", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO author`)).
+					WithArgs("user2", "user2", "", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+				// Insert first issue
+				(*m).ExpectQuery(regexp.QuoteMeta(`INSERT INTO issue`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(100))
+
+				// Status change fails on the first transition
+				(*m).ExpectQuery(regexp.QuoteMeta(`SELECT id FROM author WHERE accountId=$1`)).
+					WithArgs("user1", "user1", "", false).
+					WillReturnError(myerr.ErrInsertAuthor)
+
+				(*m).ExpectRollback()
+			},
+			expectedError: myerr.ErrInsertStatusChange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(&mock)
+			}
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushIssues(context.Background(), &structures.DBProject{Title: tt.project}, tt.issues)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedError), "expected error %v, got %v", tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestPushPriorities(t *testing.T) {
+	priorities := []structures.DBPriority{
+		{JiraId: "1", Name: "Highest", Rank: 1},
+		{JiraId: "3", Name: "Medium", Rank: 2},
+	}
+	query := regexp.QuoteMeta(`INSERT INTO priority`)
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs("default", pq.Array([]string{"1", "3"}), pq.Array([]string{"Highest", "Medium"}), pq.Array([]int64{1, 2})).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "insert error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertPriority,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushPriorities(context.Background(), "default", priorities)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPushBoards(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(14 * 24 * time.Hour)
+	project := &structures.DBProject{Source: "default", Key: "TEST"}
+	boards := []structures.DBBoard{
+		{
+			JiraId: 1,
+			Name:   "Team A",
+			Type:   "scrum",
+			Sprints: []structures.DBSprint{
+				{JiraId: 10, Name: "A 1", State: "closed", StartTime: &start, EndTime: &end, CompleteTime: &end,
+					IssueKeys: []string{"TEST-1", "TEST-2"}},
+				{JiraId: 11, Name: "A 2", State: "future"},
+			},
+		},
+	}
+
+	projectId := func(m sqlmock.Sqlmock) {
+		m.ExpectQuery(`SELECT id FROM projects WHERE source=\$1 AND key=\$2`).
+			WithArgs("default", "TEST").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	}
+	board := regexp.QuoteMeta(`INSERT INTO board`)
+	sprint := regexp.QuoteMeta(`INSERT INTO sprint (`)
+	deleteIssues := regexp.QuoteMeta(`DELETE FROM sprintissue WHERE sprintId = $1`)
+	sprintIssues := regexp.QuoteMeta(`INSERT INTO sprintissue`)
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "boards, sprints and members are saved",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				projectId(m)
+				m.ExpectQuery(board).
+					WithArgs(7, 1, "Team A", "scrum").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+				m.ExpectQuery(sprint).
+					WithArgs(3, 10, "A 1", "closed", "", start, end, end).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+				m.ExpectExec(deleteIssues).WithArgs(30).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(sprintIssues).
+					WithArgs(30, 7, pq.Array([]string{"TEST-1", "TEST-2"})).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+
+				m.ExpectQuery(sprint).
+					WithArgs(3, 11, "A 2", "future", "", nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(31))
+				m.ExpectExec(deleteIssues).WithArgs(31).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(sprintIssues).
+					WithArgs(31, 7, pq.Array([]string(nil))).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "board error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				projectId(m)
+				m.ExpectQuery(board).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertBoard,
+		},
+		{
+			name: "sprint error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				projectId(m)
+				m.ExpectQuery(board).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				m.ExpectQuery(sprint).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertSprint,
+		},
+		{
+			name: "sprint issues error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				projectId(m)
+				m.ExpectQuery(board).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				m.ExpectQuery(sprint).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+				m.ExpectExec(deleteIssues).WithArgs(30).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(sprintIssues).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertSprintIssue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushBoards(context.Background(), project, boards)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetSyncWatermark(t *testing.T) {
+	watermark := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		want      time.Time
+		wantErr   error
+	}{
+		{
+			name: "found",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT s.watermark`).
+					WithArgs("default", "PRJ").
+					WillReturnRows(sqlmock.NewRows([]string{"watermark"}).AddRow(watermark))
+			},
+			want: watermark,
+		},
+		{
+			name: "never synced",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT s.watermark`).
+					WithArgs("default", "PRJ").
+					WillReturnRows(sqlmock.NewRows([]string{"watermark"}))
+			},
+			want: time.Time{},
+		},
+		{
+			name: "query error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT s.watermark`).
+					WithArgs("default", "PRJ").
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrSelectSyncState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			got, err := dbp.GetSyncWatermark(context.Background(), prj)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPushSyncWatermark(t *testing.T) {
+	watermark := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		watermark time.Time
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			watermark: watermark,
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`INSERT INTO syncstate`).
+					WithArgs("default", "PRJ", sql.NullTime{Time: watermark, Valid: true}, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:      "no issues keeps watermark null",
+			watermark: time.Time{},
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`INSERT INTO syncstate`).
+					WithArgs("default", "PRJ", sql.NullTime{}, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:      "insert error",
+			watermark: watermark,
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`INSERT INTO syncstate`).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertSyncState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.PushSyncWatermark(context.Background(), prj, tt.watermark)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+var prj = structures.ProjectRef{Source: "default", Key: "PRJ"}
+
+func TestMarkDeletedIssues(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		want      int
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE issue SET deletedTime = $4`)).
+					WithArgs("default", "PRJ", pq.Array([]string{"PRJ-1", "PRJ-2"}), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			want: 3,
+		},
+		{
+			name: "update error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE issue SET deletedTime`)).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrDeleteIssues,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			deleted, err := dbp.MarkDeletedIssues(context.Background(), prj, []string{"PRJ-1", "PRJ-2"})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, deleted)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+var jobColumns = []string{"id", "source", "project", "mode", "trigger", "state", "issuesFetched", "issuesTotal", "error", "createdTime", "startedTime", "finishedTime"}
+
+func TestPushJob(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	job := &structures.Job{Source: "default", Project: "PRJ", Mode: structures.SyncFull, Trigger: structures.TriggerManual, State: structures.JobQueued, CreatedTime: created}
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		want      int
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`INSERT INTO jobs`).
+					WithArgs("default", "PRJ", structures.SyncFull, structures.TriggerManual, structures.JobQueued, 0, 0, created).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			},
+			want: 3,
+		},
+		{
+			name: "insert error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`INSERT INTO jobs`).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrInsertJob,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			got, err := dbp.PushJob(context.Background(), job)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateJob(t *testing.T) {
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		job       structures.Job
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "running job",
+			job:  structures.Job{Id: 1, State: structures.JobRunning, IssuesFetched: 5, IssuesTotal: 10, StartedTime: &started},
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE jobs SET`).
+					WithArgs(1, structures.JobRunning, 5, 10, sql.NullString{}, sql.NullTime{Time: started, Valid: true}, sql.NullTime{}).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "failed job",
+			job:  structures.Job{Id: 1, State: structures.JobFailed, Error: "boom", StartedTime: &started, FinishedTime: &started},
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE jobs SET`).
+					WithArgs(1, structures.JobFailed, 0, 0, sql.NullString{String: "boom", Valid: true},
+						sql.NullTime{Time: started, Valid: true}, sql.NullTime{Time: started, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "update error",
+			job:  structures.Job{Id: 1},
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE jobs SET`).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrUpdateJob,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.UpdateJob(context.Background(), &tt.job)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetJob(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	finished := created.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		want      *structures.Job
+		wantErr   error
+	}{
+		{
+			name: "finished job",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`FROM jobs WHERE id`).
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(jobColumns).
+						AddRow(7, "default", "PRJ", "full", "scheduled", "failed", 1, 2, "boom", created, created, finished))
+			},
+			want: &structures.Job{
+				Id: 7, Source: "default", Project: "PRJ", Mode: structures.SyncFull, Trigger: structures.TriggerScheduled, State: structures.JobFailed,
+				IssuesFetched: 1, IssuesTotal: 2, Error: "boom",
+				CreatedTime: created, StartedTime: &created, FinishedTime: &finished,
+			},
+		},
+		{
+			name: "queued job",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`FROM jobs WHERE id`).
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(jobColumns).
+						AddRow(7, "default", "PRJ", "incremental", "manual", "queued", 0, 0, nil, created, nil, nil))
+			},
+			want: &structures.Job{
+				Id: 7, Source: "default", Project: "PRJ", Mode: structures.SyncIncremental, Trigger: structures.TriggerManual, State: structures.JobQueued,
+				CreatedTime: created,
+			},
+		},
+		{
+			name: "not found",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`FROM jobs WHERE id`).
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(jobColumns))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			got, err := dbp.GetJob(context.Background(), 7)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, myerr.ErrSelectJob)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetJobs(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	dbp := &DbPusher{db: db, log: slog.Default()}
+
+	mock.ExpectQuery(`FROM jobs ORDER BY id DESC LIMIT`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(jobColumns).
+			AddRow(2, "default", "PRJ", "full", "manual", "running", 0, 0, nil, created, created, nil).
+			AddRow(1, "default", "PRJ", "full", "manual", "succeeded", 3, 3, nil, created, created, created))
+
+	jobs, err := dbp.GetJobs(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, 2, jobs[0].Id)
+	assert.Nil(t, jobs[0].FinishedTime)
+	assert.Equal(t, structures.JobSucceeded, jobs[1].State)
+
+	mock.ExpectQuery(`FROM jobs ORDER BY id DESC`).
+		WillReturnError(errors.New("db error"))
+
+	_, err = dbp.GetJobs(context.Background(), 2)
+	assert.ErrorIs(t, err, myerr.ErrSelectJob)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUnfinishedJobs(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	dbp := &DbPusher{db: db, log: slog.Default()}
+
+	mock.ExpectQuery(`FROM jobs WHERE state IN`).
+		WithArgs(structures.JobQueued, structures.JobRunning).
+		WillReturnRows(sqlmock.NewRows(jobColumns).
+			AddRow(1, "default", "PRJ", "full", "manual", "running", 5, 10, nil, created, created, nil))
+
+	jobs, err := dbp.GetUnfinishedJobs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []structures.Job{{
+		Id: 1, Source: "default", Project: "PRJ", Mode: structures.SyncFull, Trigger: structures.TriggerManual, State: structures.JobRunning,
+		IssuesFetched: 5, IssuesTotal: 10, CreatedTime: created, StartedTime: &created,
+	}}, jobs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProjects(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	dbp := &DbPusher{db: db, log: slog.Default()}
+
+	mock.ExpectQuery(`SELECT source, key FROM projects`).
+		WillReturnRows(sqlmock.NewRows([]string{"source", "key"}).
+			AddRow("cloud", "PRJ").
+			AddRow("default", "AAR").
+			AddRow("default", "PRJ"))
+
+	projects, err := dbp.GetProjects(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []structures.ProjectRef{
+		{Source: "cloud", Key: "PRJ"},
+		{Source: "default", Key: "AAR"},
+		prj,
+	}, projects)
+
+	mock.ExpectQuery(`SELECT source, key FROM projects`).
+		WillReturnError(errors.New("db error"))
+
+	_, err = dbp.GetProjects(context.Background())
+	assert.ErrorIs(t, err, myerr.ErrSelectProject)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPushIssues_Cancelled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dbp := &DbPusher{db: db, log: slog.Default()}
+	err = dbp.PushIssues(ctx, &structures.DBProject{Title: "Project1"}, []datatransformer.DataTransformer{{}})
+	assert.ErrorIs(t, err, myerr.ErrTranBegin)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -12,10 +12,46 @@ var (
 	ErrInsertAuthor = errors.New("can't insert author")
 	ErrSelectAuthor = errors.New("can't select author")
 
-	ErrInsertIssue = errors.New("can't insert Issue")
-	ErrPushIssue   = errors.New("can't push Issue")
+	ErrInsertIssue  = errors.New("can't insert Issue")
+	ErrPushIssue    = errors.New("can't push Issue")
+	ErrMoveIssue    = errors.New("can't move Issue")
+	ErrDeleteIssues = errors.New("can't mark deleted issues")
 
 	ErrInsertStatusChange = errors.New("can't insert status change")
+	ErrInsertFieldChange  = errors.New("can't insert field change")
+	ErrInsertCustomField  = errors.New("can't insert custom field")
+	ErrDeleteCustomField  = errors.New("can't delete custom fields")
+	ErrInsertIssueLink    = errors.New("can't insert issue link")
+	ErrDeleteIssueLink    = errors.New("can't delete issue links")
+	ErrInsertWorklog      = errors.New("can't insert worklog")
+	ErrDeleteWorklog      = errors.New("can't delete worklogs")
+	ErrInsertComment      = errors.New("can't insert comment")
+	ErrDeleteComment      = errors.New("can't delete comments")
+	ErrInsertLabel        = errors.New("can't insert label")
+	ErrDeleteLabel        = errors.New("can't delete labels")
+	ErrInsertComponent    = errors.New("can't insert component")
+	ErrDeleteComponent    = errors.New("can't delete components")
+	ErrInsertFixVersion   = errors.New("can't insert fix version")
+	ErrDeleteFixVersion   = errors.New("can't delete fix versions")
+	ErrStageIssues        = errors.New("can't copy issues into staging tables")
+	ErrMergeIssues        = errors.New("can't merge staged issues")
+
+	ErrInsertPriority = errors.New("can't insert priorities")
+
+	ErrInsertBoard       = errors.New("can't insert board")
+	ErrInsertSprint      = errors.New("can't insert sprint")
+	ErrInsertSprintIssue = errors.New("can't insert sprint issues")
+
+	ErrSelectSyncState = errors.New("can't select sync state")
+	ErrInsertSyncState = errors.New("can't insert sync state")
+
+	ErrInsertArchive = errors.New("can't insert archived issues")
+	ErrSelectArchive = errors.New("can't select archived issues")
+	ErrDeleteArchive = errors.New("can't delete archived issues")
+
+	ErrInsertJob = errors.New("can't insert job")
+	ErrUpdateJob = errors.New("can't update job")
+	ErrSelectJob = errors.New("can't select job")
 
 	ErrTranBegin = errors.New("error transaction begin")
 	ErrTranClose = errors.New("error transaction close")
@@ -0,0 +1,90 @@
+package dbpusher
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	myerr "github.com/jiraconnector/internal/dbPusher/errors"
+)
+
+// querier is *sql.DB or *sql.Tx, every statement of DbPusher goes through the
+// querier of the context so the methods called inside InTx share its transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+type txKey struct{}
+
+// txState is kept in the context by InTx, tx is replaced when a chunk is committed
+type txState struct {
+	tx *sql.Tx
+}
+
+func (dbp *DbPusher) querier(ctx context.Context) querier {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+	return dbp.db
+}
+
+// InTx runs fn in one transaction: it is committed when fn succeeds and rolled
+// back otherwise. DbPusher methods must be called with the context passed to fn.
+// InTx called inside another one joins the outer transaction
+func (dbp *DbPusher) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+
+	tx, err := dbp.db.BeginTx(ctx, nil)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrTranBegin, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	state := &txState{tx: tx}
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		if rbErr := state.tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			dbp.log.Error("can't rollback transaction", "error", rbErr)
+		}
+		return err
+	}
+
+	if err := state.tx.Commit(); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrTranClose, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+	return nil
+}
+
+// commitChunk commits the work done so far in the transaction of InTx and
+// continues in a new one, a later failure rolls back only the current chunk
+func (dbp *DbPusher) commitChunk(ctx context.Context) error {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil
+	}
+
+	if err := state.tx.Commit(); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrTranClose, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	tx, err := dbp.db.BeginTx(ctx, nil)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrTranBegin, err)
+		dbp.log.Error(ansErr.Error())
+		return ansErr
+	}
+	state.tx = tx
+
+	dbp.log.Info("chunk committed")
+	return nil
+}
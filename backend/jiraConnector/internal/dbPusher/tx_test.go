@@ -0,0 +1,107 @@
+package dbpusher
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	myerr "github.com/jiraconnector/internal/dbPusher/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInTx(t *testing.T) {
+	insert := func(ctx context.Context, dbp *DbPusher) error {
+		_, err := dbp.querier(ctx).ExecContext(ctx, "INSERT INTO label")
+		return err
+	}
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		fn        func(ctx context.Context, dbp *DbPusher) error
+		wantErr   error
+	}{
+		{
+			name: "nested calls share one transaction",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO label").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec("INSERT INTO label").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+			fn: func(ctx context.Context, dbp *DbPusher) error {
+				if err := insert(ctx, dbp); err != nil {
+					return err
+				}
+				return dbp.InTx(ctx, func(ctx context.Context) error {
+					return insert(ctx, dbp)
+				})
+			},
+		},
+		{
+			name: "error rolls back",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO label").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			fn:      insert,
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "failure after a chunk rolls back only the current chunk",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO label").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO label").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			fn: func(ctx context.Context, dbp *DbPusher) error {
+				if err := insert(ctx, dbp); err != nil {
+					return err
+				}
+				if err := dbp.commitChunk(ctx); err != nil {
+					return err
+				}
+				return insert(ctx, dbp)
+			},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "commit error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO label").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit().WillReturnError(errors.New("connection lost"))
+			},
+			fn:      insert,
+			wantErr: myerr.ErrTranClose,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			dbp := &DbPusher{db: db, log: slog.Default()}
+			err = dbp.InTx(context.Background(), func(ctx context.Context) error {
+				return tt.fn(ctx, dbp)
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
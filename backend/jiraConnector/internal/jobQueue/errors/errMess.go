@@ -0,0 +1,14 @@
+package errors
+
+import "errors"
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrQueueFull   = errors.New("job queue is full")
+	ErrQueueClosed = errors.New("job queue is closed")
+	ErrProjectBusy = errors.New("project already has unfinished job")
+	ErrJobFinished = errors.New("job is already finished")
+
+	ErrRestoreJobs     = errors.New("can't restore unfinished jobs")
+	ErrShutdownTimeout = errors.New("running jobs were interrupted by shutdown")
+)
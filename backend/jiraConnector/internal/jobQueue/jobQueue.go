@@ -0,0 +1,336 @@
+package jobqueue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	myErr "github.com/jiraconnector/internal/jobQueue/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/config"
+	"github.com/jiraconnector/pkg/logger"
+)
+
+//go:generate mockery
+
+type JobStoreInterface interface {
+	PushJob(ctx context.Context, job *structures.Job) (int, error)
+	UpdateJob(ctx context.Context, job *structures.Job) error
+	GetJob(ctx context.Context, jobId int) (*structures.Job, error)
+	GetJobs(ctx context.Context, limit int) ([]structures.Job, error)
+	GetUnfinishedJobs(ctx context.Context) ([]structures.Job, error)
+}
+
+type ProjectSyncerInterface interface {
+	SyncProject(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, progress structures.ProgressFunc) error
+}
+
+// JobQueue runs project synchronizations in the background.
+// Every job is stored in the database, so jobs interrupted by a restart
+// are picked up again by Start.
+type JobQueue struct {
+	store   JobStoreInterface
+	syncer  ProjectSyncerInterface
+	workers int
+	size    int
+	log     *slog.Logger
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending []structures.Job
+	// unfinished jobs by project ref
+	active  map[string]int
+	running map[int]context.CancelFunc
+	closed  bool
+	// base context of all jobs, cancelled when shutdown timeout expires
+	base context.Context
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+func NewJobQueue(cfg *config.Config, store JobStoreInterface, syncer ProjectSyncerInterface, log *slog.Logger) *JobQueue {
+	q := &JobQueue{
+		store:   store,
+		syncer:  syncer,
+		workers: max(cfg.JobsCfg.Workers, 1),
+		size:    max(cfg.JobsCfg.QueueSize, 1),
+		log:     log,
+		active:  map[string]int{},
+		running: map[int]context.CancelFunc{},
+	}
+	q.cond = sync.NewCond(&q.mu)
+	q.base, q.stop = context.WithCancel(context.Background())
+
+	return q
+}
+
+// Start restores unfinished jobs and runs workers
+func (q *JobQueue) Start(ctx context.Context) error {
+	jobs, err := q.store.GetUnfinishedJobs(ctx)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myErr.ErrRestoreJobs, err)
+		q.log.Error(ansErr.Error())
+		return ansErr
+	}
+
+	q.mu.Lock()
+	for _, job := range jobs {
+		// running job was interrupted, it is simply started from the beginning
+		job.State = structures.JobQueued
+		job.IssuesFetched = 0
+		job.StartedTime = nil
+		q.updateJob(&job)
+		q.pending = append(q.pending, job)
+		q.active[job.Ref().String()]++
+	}
+	q.mu.Unlock()
+
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+
+	q.log.Info("start job queue", "workers", q.workers, "restored", len(jobs))
+	return nil
+}
+
+func (q *JobQueue) Enqueue(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, trigger structures.JobTrigger) (*structures.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.enqueue(ctx, project, mode, trigger)
+}
+
+// EnqueueIfIdle doesn't add a job for a project which is already queued or running
+func (q *JobQueue) EnqueueIfIdle(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, trigger structures.JobTrigger) (*structures.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.active[project.String()] > 0 {
+		return nil, myErr.ErrProjectBusy
+	}
+
+	return q.enqueue(ctx, project, mode, trigger)
+}
+
+func (q *JobQueue) enqueue(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, trigger structures.JobTrigger) (*structures.Job, error) {
+	if q.closed {
+		return nil, myErr.ErrQueueClosed
+	}
+
+	if len(q.pending) >= q.size {
+		q.log.Error(myErr.ErrQueueFull.Error(), "project", project.String())
+		return nil, myErr.ErrQueueFull
+	}
+
+	job := structures.Job{
+		Source:      project.Source,
+		Project:     project.Key,
+		Mode:        mode,
+		Trigger:     trigger,
+		State:       structures.JobQueued,
+		CreatedTime: time.Now(),
+	}
+
+	jobId, err := q.store.PushJob(ctx, &job)
+	if err != nil {
+		q.log.Error("error push job", logger.Err(err), "project", project.String())
+		return nil, fmt.Errorf("%w", err)
+	}
+	job.Id = jobId
+
+	q.pending = append(q.pending, job)
+	q.active[project.String()]++
+	q.cond.Signal()
+
+	q.log.Info("enqueue job", "job", job.Id, "project", project.String(), "mode", mode, "trigger", trigger)
+	return &job, nil
+}
+
+func (q *JobQueue) GetJob(ctx context.Context, jobId int) (*structures.Job, error) {
+	job, err := q.store.GetJob(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w - %d: %w", myErr.ErrJobNotFound, jobId, err)
+		}
+		q.log.Error("error get job", logger.Err(err), "job", jobId)
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return job, nil
+}
+
+func (q *JobQueue) GetJobs(ctx context.Context, limit int) ([]structures.Job, error) {
+	jobs, err := q.store.GetJobs(ctx, limit)
+	if err != nil {
+		q.log.Error("error get jobs", logger.Err(err))
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return jobs, nil
+}
+
+// Cancel removes the queued job from the queue or stops the running one.
+// Running job is stopped asynchronously, its state becomes cancelled when sync returns
+func (q *JobQueue) Cancel(ctx context.Context, jobId int) (*structures.Job, error) {
+	q.mu.Lock()
+	for i, job := range q.pending {
+		if job.Id != jobId {
+			continue
+		}
+		q.pending = slices.Delete(q.pending, i, i+1)
+		q.release(job.Ref())
+		q.mu.Unlock()
+
+		finished := time.Now()
+		job.State = structures.JobCancelled
+		job.FinishedTime = &finished
+		q.updateJob(&job)
+
+		q.log.Info("cancel queued job", "job", job.Id, "project", job.Ref().String())
+		return &job, nil
+	}
+
+	cancel, running := q.running[jobId]
+	q.mu.Unlock()
+
+	if running {
+		cancel()
+		q.log.Info("cancel running job", "job", jobId)
+	}
+
+	job, err := q.GetJob(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !running {
+		return nil, fmt.Errorf("%w - %d: %s", myErr.ErrJobFinished, jobId, job.State)
+	}
+	return job, nil
+}
+
+// Close waits for running jobs, jobs left in queue stay queued in the database
+func (q *JobQueue) Close() {
+	q.Shutdown(context.Background())
+}
+
+// Shutdown stops taking jobs from the queue and waits for running jobs until ctx is done.
+// Then running jobs are cancelled and returned to the queue, so they are restarted by Start
+func (q *JobQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.stop()
+		q.log.Info("close job queue")
+		return nil
+	case <-ctx.Done():
+	}
+
+	q.stop()
+	<-done
+
+	ansErr := fmt.Errorf("%w: %w", myErr.ErrShutdownTimeout, ctx.Err())
+	q.log.Error(ansErr.Error())
+	return ansErr
+}
+
+func (q *JobQueue) worker() {
+	defer q.wg.Done()
+
+	for {
+		q.mu.Lock()
+		for len(q.pending) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return
+		}
+		job := q.pending[0]
+		q.pending = q.pending[1:]
+		// job is registered as running right away, so Cancel always finds it
+		ctx, cancel := context.WithCancel(q.base)
+		q.running[job.Id] = cancel
+		q.mu.Unlock()
+
+		q.run(ctx, &job)
+		cancel()
+	}
+}
+
+func (q *JobQueue) run(ctx context.Context, job *structures.Job) {
+	started := time.Now()
+	job.State = structures.JobRunning
+	job.StartedTime = &started
+	q.updateJob(job)
+
+	err := q.syncer.SyncProject(ctx, job.Ref(), job.Mode, func(fetched, total int) {
+		job.IssuesFetched = fetched
+		job.IssuesTotal = total
+		q.updateJob(job)
+	})
+
+	q.mu.Lock()
+	delete(q.running, job.Id)
+	q.mu.Unlock()
+
+	finished := time.Now()
+	job.FinishedTime = &finished
+	if err != nil && q.base.Err() != nil {
+		// interrupted by shutdown, nothing is saved until the sync is finished
+		job.State = structures.JobQueued
+		job.IssuesFetched = 0
+		job.StartedTime = nil
+		job.FinishedTime = nil
+		q.log.Info("job interrupted by shutdown, it will be restarted", "job", job.Id, "project", job.Ref().String())
+	} else if err != nil && ctx.Err() != nil {
+		job.State = structures.JobCancelled
+		job.Error = err.Error()
+		q.log.Info("job cancelled", "job", job.Id, "project", job.Ref().String())
+	} else if err != nil {
+		job.State = structures.JobFailed
+		job.Error = err.Error()
+		q.log.Error("job failed", logger.Err(err), "job", job.Id, "project", job.Ref().String())
+	} else {
+		job.State = structures.JobSucceeded
+		q.log.Info("job succeeded", "job", job.Id, "project", job.Ref().String(), "issues", job.IssuesFetched)
+	}
+	q.updateJob(job)
+
+	q.mu.Lock()
+	q.release(job.Ref())
+	q.mu.Unlock()
+}
+
+// release must be called under q.mu
+func (q *JobQueue) release(project structures.ProjectRef) {
+	ref := project.String()
+	q.active[ref]--
+	if q.active[ref] == 0 {
+		delete(q.active, ref)
+	}
+}
+
+// updateJob only logs the error - failed status update mustn't stop the synchronization.
+// State of the job is saved even if the job itself is cancelled, so context isn't inherited
+func (q *JobQueue) updateJob(job *structures.Job) {
+	if err := q.store.UpdateJob(context.Background(), job); err != nil {
+		q.log.Error("error update job", logger.Err(err), "job", job.Id)
+	}
+}
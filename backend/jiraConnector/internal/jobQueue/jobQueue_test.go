@@ -0,0 +1,532 @@
+package jobqueue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	myErr "github.com/jiraconnector/internal/jobQueue/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var prj = structures.ProjectRef{Source: "default", Key: "PRJ"}
+
+func newTestQueue(store JobStoreInterface, syncer ProjectSyncerInterface, workers, size int) *JobQueue {
+	cfg := &config.Config{JobsCfg: config.JobsConfig{Workers: workers, QueueSize: size}}
+	return NewJobQueue(cfg, store, syncer, slog.Default())
+}
+
+// recordUpdates saves copies of updated jobs and signals when a job is finished
+func recordUpdates(store *MockJobStoreInterface) (func() []structures.Job, chan struct{}) {
+	var mu sync.Mutex
+	var updates []structures.Job
+	done := make(chan struct{}, 10)
+
+	store.On("UpdateJob", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		job := *args.Get(1).(*structures.Job)
+		mu.Lock()
+		updates = append(updates, job)
+		mu.Unlock()
+		if job.Finished() {
+			done <- struct{}{}
+		}
+	}).Return(nil)
+
+	return func() []structures.Job {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]structures.Job(nil), updates...)
+	}, done
+}
+
+func waitDone(t *testing.T, done chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job wasn't finished")
+	}
+}
+
+func TestNewJobQueue(t *testing.T) {
+	q := newTestQueue(nil, nil, 0, 0)
+	assert.Equal(t, 1, q.workers)
+	assert.Equal(t, 1, q.size)
+
+	q = newTestQueue(nil, nil, 3, 50)
+	assert.Equal(t, 3, q.workers)
+	assert.Equal(t, 50, q.size)
+}
+
+func TestEnqueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		pending  int
+		closed   bool
+		pushErr  error
+		wantErr  error
+		wantPush bool
+	}{
+		{
+			name:     "success",
+			size:     2,
+			wantPush: true,
+		},
+		{
+			name:     "store error",
+			size:     2,
+			pushErr:  errors.New("db error"),
+			wantPush: true,
+		},
+		{
+			name:    "queue full",
+			size:    1,
+			pending: 1,
+			wantErr: myErr.ErrQueueFull,
+		},
+		{
+			name:    "queue closed",
+			size:    1,
+			closed:  true,
+			wantErr: myErr.ErrQueueClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(MockJobStoreInterface)
+			q := newTestQueue(store, new(MockProjectSyncerInterface), 1, tt.size)
+			q.pending = make([]structures.Job, tt.pending)
+			q.closed = tt.closed
+
+			if tt.wantPush {
+				store.On("PushJob", mock.Anything, mock.MatchedBy(func(job *structures.Job) bool {
+					return job.Ref() == prj && job.Mode == structures.SyncFull &&
+						job.Trigger == structures.TriggerManual && job.State == structures.JobQueued
+				})).Return(4, tt.pushErr)
+			}
+
+			job, err := q.Enqueue(context.Background(), prj, structures.SyncFull, structures.TriggerManual)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.pushErr != nil:
+				assert.ErrorIs(t, err, tt.pushErr)
+				assert.Len(t, q.pending, tt.pending)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, 4, job.Id)
+				assert.Equal(t, structures.JobQueued, job.State)
+				assert.Len(t, q.pending, tt.pending+1)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEnqueueIfIdle(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	syncer := new(MockProjectSyncerInterface)
+	q := newTestQueue(store, syncer, 1, 10)
+
+	store.On("GetUnfinishedJobs", mock.Anything).Return([]structures.Job{}, nil)
+	store.On("PushJob", mock.Anything, mock.Anything).Return(1, nil)
+	_, done := recordUpdates(store)
+
+	release := make(chan struct{})
+	syncer.On("SyncProject", mock.Anything, prj, structures.SyncIncremental, mock.Anything).
+		Run(func(args mock.Arguments) { <-release }).
+		Return(nil)
+
+	assert.NoError(t, q.Start(context.Background()))
+
+	job, err := q.EnqueueIfIdle(context.Background(), prj, structures.SyncIncremental, structures.TriggerScheduled)
+	assert.NoError(t, err)
+	assert.Equal(t, structures.TriggerScheduled, job.Trigger)
+
+	// the first job is queued or running - no second one
+	_, err = q.EnqueueIfIdle(context.Background(), prj, structures.SyncIncremental, structures.TriggerScheduled)
+	assert.ErrorIs(t, err, myErr.ErrProjectBusy)
+
+	close(release)
+	waitDone(t, done)
+
+	assert.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return q.active[prj.String()] == 0
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = q.EnqueueIfIdle(context.Background(), prj, structures.SyncIncremental, structures.TriggerScheduled)
+	assert.NoError(t, err)
+	waitDone(t, done)
+
+	q.Close()
+	syncer.AssertNumberOfCalls(t, "SyncProject", 2)
+}
+
+func TestEnqueueIfIdle_SameKeyOtherSource(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	q := newTestQueue(store, new(MockProjectSyncerInterface), 1, 10)
+
+	store.On("PushJob", mock.Anything, mock.Anything).Return(1, nil)
+
+	// workers aren't started, so both jobs stay queued
+	_, err := q.EnqueueIfIdle(context.Background(), prj, structures.SyncFull, structures.TriggerScheduled)
+	assert.NoError(t, err)
+
+	job, err := q.EnqueueIfIdle(context.Background(), structures.ProjectRef{Source: "cloud", Key: "PRJ"}, structures.SyncFull, structures.TriggerScheduled)
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud", job.Source)
+	assert.Equal(t, "PRJ", job.Project)
+
+	_, err = q.EnqueueIfIdle(context.Background(), prj, structures.SyncFull, structures.TriggerScheduled)
+	assert.ErrorIs(t, err, myErr.ErrProjectBusy)
+}
+
+func TestGetJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		storeErr error
+		wantErr  error
+	}{
+		{
+			name: "found",
+		},
+		{
+			name:     "not found",
+			storeErr: fmt.Errorf("select job: %w", sql.ErrNoRows),
+			wantErr:  myErr.ErrJobNotFound,
+		},
+		{
+			name:     "store error",
+			storeErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(MockJobStoreInterface)
+			q := newTestQueue(store, nil, 1, 1)
+
+			var stored *structures.Job
+			if tt.storeErr == nil {
+				stored = &structures.Job{Id: 1}
+			}
+			store.On("GetJob", mock.Anything, 1).Return(stored, tt.storeErr)
+
+			job, err := q.GetJob(context.Background(), 1)
+
+			if tt.storeErr != nil {
+				assert.Error(t, err)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				} else {
+					assert.NotErrorIs(t, err, myErr.ErrJobNotFound)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, stored, job)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	tests := []struct {
+		name      string
+		syncErr   error
+		wantState structures.JobState
+	}{
+		{
+			name:      "succeeded",
+			wantState: structures.JobSucceeded,
+		},
+		{
+			name:      "failed",
+			syncErr:   errors.New("jira error"),
+			wantState: structures.JobFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(MockJobStoreInterface)
+			syncer := new(MockProjectSyncerInterface)
+			q := newTestQueue(store, syncer, 1, 10)
+
+			store.On("GetUnfinishedJobs", mock.Anything).Return([]structures.Job{}, nil)
+			store.On("PushJob", mock.Anything, mock.Anything).Return(1, nil)
+			updates, done := recordUpdates(store)
+
+			syncer.On("SyncProject", mock.Anything, prj, structures.SyncIncremental, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(3).(structures.ProgressFunc)(3, 4)
+				}).
+				Return(tt.syncErr)
+
+			assert.NoError(t, q.Start(context.Background()))
+			_, err := q.Enqueue(context.Background(), prj, structures.SyncIncremental, structures.TriggerManual)
+			assert.NoError(t, err)
+
+			waitDone(t, done)
+			q.Close()
+
+			got := updates()
+			assert.Len(t, got, 3)
+			assert.Equal(t, structures.JobRunning, got[0].State)
+			assert.NotNil(t, got[0].StartedTime)
+			assert.Equal(t, 3, got[1].IssuesFetched)
+			assert.Equal(t, 4, got[1].IssuesTotal)
+
+			last := got[2]
+			assert.Equal(t, tt.wantState, last.State)
+			assert.NotNil(t, last.FinishedTime)
+			if tt.syncErr != nil {
+				assert.Equal(t, tt.syncErr.Error(), last.Error)
+			} else {
+				assert.Empty(t, last.Error)
+			}
+
+			store.AssertExpectations(t)
+			syncer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStartRestoresJobs(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	syncer := new(MockProjectSyncerInterface)
+	q := newTestQueue(store, syncer, 1, 10)
+
+	started := time.Now()
+	store.On("GetUnfinishedJobs", mock.Anything).Return([]structures.Job{
+		{Id: 1, Source: "default", Project: "PRJ", Mode: structures.SyncFull, State: structures.JobRunning, IssuesFetched: 5, StartedTime: &started},
+	}, nil)
+	updates, done := recordUpdates(store)
+	syncer.On("SyncProject", mock.Anything, prj, structures.SyncFull, mock.Anything).Return(nil)
+
+	assert.NoError(t, q.Start(context.Background()))
+	waitDone(t, done)
+	q.Close()
+
+	got := updates()
+	// interrupted job is reset to queued before running again
+	assert.Equal(t, structures.JobQueued, got[0].State)
+	assert.Zero(t, got[0].IssuesFetched)
+	assert.Nil(t, got[0].StartedTime)
+	assert.Equal(t, structures.JobSucceeded, got[len(got)-1].State)
+
+	store.AssertExpectations(t)
+	syncer.AssertExpectations(t)
+}
+
+func TestStartError(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	q := newTestQueue(store, nil, 1, 10)
+
+	store.On("GetUnfinishedJobs", mock.Anything).Return(nil, errors.New("db error"))
+
+	err := q.Start(context.Background())
+	assert.ErrorIs(t, err, myErr.ErrRestoreJobs)
+	store.AssertExpectations(t)
+}
+
+func TestClose(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	q := newTestQueue(store, new(MockProjectSyncerInterface), 2, 10)
+
+	store.On("GetUnfinishedJobs", mock.Anything).Return([]structures.Job{}, nil)
+	assert.NoError(t, q.Start(context.Background()))
+
+	// returns only after all workers are stopped
+	q.Close()
+
+	_, err := q.Enqueue(context.Background(), prj, structures.SyncFull, structures.TriggerManual)
+	assert.ErrorIs(t, err, myErr.ErrQueueClosed)
+	store.AssertExpectations(t)
+}
+
+func TestShutdownWaitsForRunningJob(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	syncer := new(MockProjectSyncerInterface)
+	q := newTestQueue(store, syncer, 1, 10)
+
+	store.On("GetUnfinishedJobs", mock.Anything).Return([]structures.Job{}, nil)
+	store.On("PushJob", mock.Anything, mock.Anything).Return(1, nil)
+	updates, _ := recordUpdates(store)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	syncer.On("SyncProject", mock.Anything, prj, structures.SyncFull, mock.Anything).
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(nil)
+
+	assert.NoError(t, q.Start(context.Background()))
+	_, err := q.Enqueue(context.Background(), prj, structures.SyncFull, structures.TriggerManual)
+	assert.NoError(t, err)
+	<-started
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, q.Shutdown(ctx))
+
+	got := updates()
+	assert.Equal(t, structures.JobSucceeded, got[len(got)-1].State)
+	store.AssertExpectations(t)
+	syncer.AssertExpectations(t)
+}
+
+func TestShutdownTimeoutRequeuesJob(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	syncer := new(MockProjectSyncerInterface)
+	q := newTestQueue(store, syncer, 1, 10)
+
+	store.On("GetUnfinishedJobs", mock.Anything).Return([]structures.Job{}, nil)
+	store.On("PushJob", mock.Anything, mock.Anything).Return(1, nil)
+	updates, _ := recordUpdates(store)
+
+	started := make(chan struct{})
+	syncer.On("SyncProject", mock.Anything, prj, structures.SyncFull, mock.Anything).
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(context.Canceled)
+
+	assert.NoError(t, q.Start(context.Background()))
+	_, err := q.Enqueue(context.Background(), prj, structures.SyncFull, structures.TriggerManual)
+	assert.NoError(t, err)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = q.Shutdown(ctx)
+	assert.ErrorIs(t, err, myErr.ErrShutdownTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// interrupted job stays queued, so it is restarted with the app
+	got := updates()
+	last := got[len(got)-1]
+	assert.Equal(t, structures.JobQueued, last.State)
+	assert.Nil(t, last.StartedTime)
+	assert.Nil(t, last.FinishedTime)
+	assert.Empty(t, last.Error)
+	assert.Empty(t, q.running)
+	assert.Empty(t, q.active)
+	store.AssertExpectations(t)
+	syncer.AssertExpectations(t)
+}
+
+func TestCancelQueuedJob(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	q := newTestQueue(store, nil, 1, 10)
+
+	store.On("PushJob", mock.Anything, mock.Anything).Return(1, nil)
+	updates, done := recordUpdates(store)
+
+	// workers aren't started, so the job stays in the queue
+	_, err := q.Enqueue(context.Background(), prj, structures.SyncFull, structures.TriggerManual)
+	assert.NoError(t, err)
+
+	job, err := q.Cancel(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, structures.JobCancelled, job.State)
+	assert.NotNil(t, job.FinishedTime)
+	waitDone(t, done)
+
+	assert.Empty(t, q.pending)
+	assert.Empty(t, q.active)
+	assert.Equal(t, structures.JobCancelled, updates()[0].State)
+	store.AssertExpectations(t)
+}
+
+func TestCancelRunningJob(t *testing.T) {
+	store := new(MockJobStoreInterface)
+	syncer := new(MockProjectSyncerInterface)
+	q := newTestQueue(store, syncer, 1, 10)
+
+	store.On("GetUnfinishedJobs", mock.Anything).Return([]structures.Job{}, nil)
+	store.On("PushJob", mock.Anything, mock.Anything).Return(1, nil)
+	store.On("GetJob", mock.Anything, 1).Return(&structures.Job{Id: 1, State: structures.JobRunning}, nil)
+	updates, done := recordUpdates(store)
+
+	started := make(chan struct{})
+	syncer.On("SyncProject", mock.Anything, prj, structures.SyncFull, mock.Anything).
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(context.Canceled)
+
+	assert.NoError(t, q.Start(context.Background()))
+	_, err := q.Enqueue(context.Background(), prj, structures.SyncFull, structures.TriggerManual)
+	assert.NoError(t, err)
+	<-started
+
+	job, err := q.Cancel(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, job.Id)
+
+	waitDone(t, done)
+	q.Close()
+
+	got := updates()
+	assert.Equal(t, structures.JobCancelled, got[len(got)-1].State)
+	assert.Empty(t, q.running)
+	assert.Empty(t, q.active)
+	store.AssertExpectations(t)
+	syncer.AssertExpectations(t)
+}
+
+func TestCancelInactiveJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		stored   *structures.Job
+		storeErr error
+		wantErr  error
+	}{
+		{
+			name:    "finished job",
+			stored:  &structures.Job{Id: 1, State: structures.JobSucceeded},
+			wantErr: myErr.ErrJobFinished,
+		},
+		{
+			name:     "unknown job",
+			storeErr: fmt.Errorf("select: %w", sql.ErrNoRows),
+			wantErr:  myErr.ErrJobNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(MockJobStoreInterface)
+			q := newTestQueue(store, nil, 1, 10)
+
+			store.On("GetJob", mock.Anything, 1).Return(tt.stored, tt.storeErr)
+
+			job, err := q.Cancel(context.Background(), 1)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, job)
+			store.AssertExpectations(t)
+		})
+	}
+}
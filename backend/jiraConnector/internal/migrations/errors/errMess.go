@@ -0,0 +1,16 @@
+package errors
+
+import "errors"
+
+var (
+	ErrMigrationFile = errors.New("incorrect migration file")
+	ErrLock          = errors.New("can't lock database for migrations")
+	ErrVersionTable  = errors.New("can't prepare schema version table")
+	ErrBaseline      = errors.New("database wasn't created by migrations and its schema doesn't match any version - migrate it manually")
+	ErrSelectVersion = errors.New("can't select applied migrations")
+	ErrChecksum      = errors.New("applied migration was changed")
+	ErrUnknown       = errors.New("database has migration unknown to this build")
+	ErrApply         = errors.New("can't apply migration")
+	ErrRevert        = errors.New("can't revert migration")
+	ErrCommand       = errors.New("incorrect migrate command - need up, down [steps] or status")
+)
@@ -0,0 +1,84 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	myerr "github.com/jiraconnector/internal/migrations/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		// versions go one by one without gaps
+		assert.Equal(t, i+1, migration.Version)
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+		assert.Equal(t, checksum(migration.Up), migration.Checksum)
+	}
+	assert.Equal(t, "init", migrations[0].Name)
+}
+
+func TestLoad_Files(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int
+		wantErr bool
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"sql/0010_later.up.sql":   {Data: []byte("SELECT 10")},
+				"sql/0010_later.down.sql": {Data: []byte("SELECT -10")},
+				"sql/0002_first.up.sql":   {Data: []byte("SELECT 2")},
+				"sql/0002_first.down.sql": {Data: []byte("SELECT -2")},
+			},
+			want: []int{2, 10},
+		},
+		{
+			name: "missing down file",
+			files: fstest.MapFS{
+				"sql/0001_init.up.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "incorrect file name",
+			files: fstest.MapFS{
+				"sql/init.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "version used twice",
+			files: fstest.MapFS{
+				"sql/0001_init.up.sql":    {Data: []byte("SELECT 1")},
+				"sql/0001_init.down.sql":  {Data: []byte("SELECT -1")},
+				"sql/0001_other.up.sql":   {Data: []byte("SELECT 1")},
+				"sql/0001_other.down.sql": {Data: []byte("SELECT -1")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := load(tt.files, "sql")
+			if tt.wantErr {
+				assert.ErrorIs(t, err, myerr.ErrMigrationFile)
+				return
+			}
+			assert.NoError(t, err)
+			versions := []int{}
+			for _, migration := range migrations {
+				versions = append(versions, migration.Version)
+			}
+			assert.Equal(t, tt.want, versions)
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"time"
+
+	myerr "github.com/jiraconnector/internal/migrations/errors"
+)
+
+// lockKey is the key of the postgres advisory lock, it keeps several
+// instances of the connector from migrating the database at the same time
+const lockKey = 7355608
+
+const createVersionTable = `
+   CREATE TABLE schema_migrations (
+       version INT PRIMARY KEY,
+       name TEXT NOT NULL,
+       checksum TEXT NOT NULL,
+       appliedTime TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+   )
+   `
+
+const insertVersion = "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)"
+
+// baselineSchema is a scratch schema for finding the version of a database created
+// before the migrations by the init script of the postgres container
+const baselineSchema = "migration_baseline"
+
+const selectColumns = `
+   SELECT table_name, column_name, data_type, is_nullable
+   FROM information_schema.columns
+   WHERE table_schema = $1 AND table_name <> 'schema_migrations'
+   ORDER BY table_name, column_name
+   `
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	log        *slog.Logger
+}
+
+// Status is the state of the migration in the database. Changed is set when
+// the applied migration differs from the file, Unknown when there is no file
+type Status struct {
+	Version     int
+	Name        string
+	Applied     bool
+	AppliedTime *time.Time
+	Changed     bool
+	Unknown     bool
+}
+
+type appliedMigration struct {
+	name     string
+	checksum string
+	time     time.Time
+}
+
+func NewMigrator(db *sql.DB, log *slog.Logger) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		log:        log,
+	}, nil
+}
+
+// Up applies all pending migrations, every migration runs in its own transaction
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.run(ctx, conn, migration.Up, insertVersion,
+				migration.Version, migration.Name, migration.Checksum); err != nil {
+				ansErr := fmt.Errorf("%w - %d_%s: %w", myerr.ErrApply, migration.Version, migration.Name, err)
+				m.log.Error(ansErr.Error())
+				return ansErr
+			}
+			m.log.Info("applied migration", "version", migration.Version, "name", migration.Name)
+			count++
+		}
+
+		m.log.Info("database schema is up to date", "version", m.latest(), "applied", count)
+		return nil
+	})
+}
+
+// Down reverts the last steps applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("%w - steps %d", myerr.ErrCommand, steps)
+	}
+
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.run(ctx, conn, migration.Down, "DELETE FROM schema_migrations WHERE version = $1",
+				migration.Version); err != nil {
+				ansErr := fmt.Errorf("%w - %d_%s: %w", myerr.ErrRevert, migration.Version, migration.Name, err)
+				m.log.Error(ansErr.Error())
+				return ansErr
+			}
+			m.log.Info("reverted migration", "version", migration.Version, "name", migration.Name)
+			steps--
+		}
+		return nil
+	})
+}
+
+// Status returns the state of all known and applied migrations ordered by version,
+// the database isn't changed
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var exists bool
+	if err := m.db.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectVersion, err)
+		m.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	applied := make(map[int]appliedMigration)
+	if exists {
+		var err error
+		if applied, err = m.applied(ctx, m.db); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if a, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedTime = &a.time
+			status.Changed = a.checksum != migration.Checksum
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	// applied by a newer build of the connector
+	for version, a := range applied {
+		statuses = append(statuses, Status{Version: version, Name: a.name, Applied: true, AppliedTime: &a.time, Unknown: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// locked runs fn holding the advisory lock, the lock belongs to the connection
+// so all statements of fn have to use conn
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrLock, err)
+		m.log.Error(ansErr.Error())
+		return ansErr
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrLock, err)
+		m.log.Error(ansErr.Error())
+		return ansErr
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+			m.log.Error("can't unlock database after migrations", "error", err)
+		}
+	}()
+
+	return fn(conn)
+}
+
+// prepare creates the version table on the first run and checks
+// that applied migrations match the embedded ones
+func (m *Migrator) prepare(ctx context.Context, conn *sql.Conn) (map[int]appliedMigration, error) {
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrVersionTable, err)
+		m.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+	if !exists {
+		if err := m.createVersionTable(ctx, conn); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrVersionTable, err)
+			m.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+	}
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+	for version, a := range applied {
+		migration, ok := known[version]
+		if !ok {
+			ansErr := fmt.Errorf("%w - %d_%s", myerr.ErrUnknown, version, a.name)
+			m.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		if migration.Checksum != a.checksum {
+			ansErr := fmt.Errorf("%w - %d_%s", myerr.ErrChecksum, version, migration.Name)
+			m.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+	}
+	return applied, nil
+}
+
+func (m *Migrator) createVersionTable(ctx context.Context, conn *sql.Conn) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, createVersionTable); err != nil {
+		return err
+	}
+
+	var existing bool
+	if err := tx.QueryRowContext(ctx, "SELECT to_regclass('projects') IS NOT NULL").Scan(&existing); err != nil {
+		return err
+	}
+	if existing {
+		version, err := m.baselineVersion(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, err := tx.ExecContext(ctx, insertVersion, migration.Version, migration.Name, migration.Checksum); err != nil {
+				return err
+			}
+		}
+		m.log.Info("adopted existing database", "version", version)
+	}
+
+	return tx.Commit()
+}
+
+// baselineVersion finds the version of a database created by the init script: migrations
+// are applied one by one to the scratch schema, the last one after which the scratch
+// schema has the same columns as the database is the version. The scratch schema is
+// rolled back. A database which doesn't match any version isn't adopted
+func (m *Migrator) baselineVersion(ctx context.Context, tx *sql.Tx) (int, error) {
+	var schema string
+	if err := tx.QueryRowContext(ctx, "SELECT current_schema()").Scan(&schema); err != nil {
+		return 0, err
+	}
+	existing, err := schemaColumns(ctx, tx, schema)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT baseline"); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, "CREATE SCHEMA "+baselineSchema); err != nil {
+		return 0, err
+	}
+	// search_path is set back by the rollback to the savepoint
+	if _, err := tx.ExecContext(ctx, "SET LOCAL search_path TO "+baselineSchema); err != nil {
+		return 0, err
+	}
+
+	version := 0
+	for _, migration := range m.migrations {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return 0, fmt.Errorf("%d_%s: %w", migration.Version, migration.Name, err)
+		}
+		columns, err := schemaColumns(ctx, tx, baselineSchema)
+		if err != nil {
+			return 0, err
+		}
+		if slices.Equal(columns, existing) {
+			version = migration.Version
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT baseline"); err != nil {
+		return 0, err
+	}
+	if version == 0 {
+		return 0, fmt.Errorf("%w - schema %s", myerr.ErrBaseline, schema)
+	}
+	return version, nil
+}
+
+// schemaColumns lists columns of all tables of the schema except the version table
+func schemaColumns(ctx context.Context, q querier, schema string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, selectColumns, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var table, column, dataType, nullable string
+		if err := rows.Scan(&table, &column, &dataType, &nullable); err != nil {
+			return nil, err
+		}
+		columns = append(columns, fmt.Sprintf("%s.%s %s %s", table, column, dataType, nullable))
+	}
+	return columns, rows.Err()
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func (m *Migrator) applied(ctx context.Context, q querier) (map[int]appliedMigration, error) {
+	rows, err := q.QueryContext(ctx, "SELECT version, name, checksum, appliedTime FROM schema_migrations")
+	if err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectVersion, err)
+		m.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var version int
+		var a appliedMigration
+		if err := rows.Scan(&version, &a.name, &a.checksum, &a.time); err != nil {
+			ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectVersion, err)
+			m.log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		applied[version] = a
+	}
+	if err := rows.Err(); err != nil {
+		ansErr := fmt.Errorf("%w: %w", myerr.ErrSelectVersion, err)
+		m.log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+	return applied, nil
+}
+
+// run executes the migration and records it in the version table in one transaction
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, query, record string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
@@ -0,0 +1,295 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	myerr "github.com/jiraconnector/internal/migrations/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "init", Up: "CREATE TABLE first", Down: "DROP TABLE first", Checksum: checksum("CREATE TABLE first")},
+	{Version: 2, Name: "second", Up: "CREATE TABLE second", Down: "DROP TABLE second", Checksum: checksum("CREATE TABLE second")},
+}
+
+var (
+	lockQuery     = regexp.QuoteMeta("SELECT pg_advisory_lock($1)")
+	unlockQuery   = regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")
+	versionTable  = regexp.QuoteMeta("SELECT to_regclass('schema_migrations') IS NOT NULL")
+	projectTable  = regexp.QuoteMeta("SELECT to_regclass('projects') IS NOT NULL")
+	selectApplied = regexp.QuoteMeta("SELECT version, name, checksum, appliedTime FROM schema_migrations")
+	insertApplied = regexp.QuoteMeta("INSERT INTO schema_migrations")
+	columnsQuery  = regexp.QuoteMeta("SELECT table_name, column_name, data_type, is_nullable")
+)
+
+func columnRows(tables ...string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"table_name", "column_name", "data_type", "is_nullable"})
+	for _, table := range tables {
+		rows.AddRow(table, "id", "integer", "NO")
+	}
+	return rows
+}
+
+// expectBaseline expects the migrations to be applied to the scratch schema,
+// existing is the list of tables of the database
+func expectBaseline(m sqlmock.Sqlmock, existing ...string) {
+	m.ExpectQuery(regexp.QuoteMeta("SELECT current_schema()")).WillReturnRows(sqlmock.NewRows([]string{"schema"}).AddRow("public"))
+	m.ExpectQuery(columnsQuery).WithArgs("public").WillReturnRows(columnRows(existing...))
+	m.ExpectExec("SAVEPOINT baseline").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectExec("CREATE SCHEMA migration_baseline").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectExec("SET LOCAL search_path TO migration_baseline").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectExec("CREATE TABLE first").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectQuery(columnsQuery).WithArgs("migration_baseline").WillReturnRows(columnRows("first"))
+	m.ExpectExec("CREATE TABLE second").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectQuery(columnsQuery).WithArgs("migration_baseline").WillReturnRows(columnRows("first", "second"))
+	m.ExpectExec("ROLLBACK TO SAVEPOINT baseline").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func appliedRows(versions ...int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "name", "checksum", "appliedTime"})
+	for _, version := range versions {
+		migration := testMigrations[version-1]
+		rows.AddRow(migration.Version, migration.Name, migration.Checksum, time.Now())
+	}
+	return rows
+}
+
+func TestUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "empty database gets all migrations",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.ExpectBegin()
+				m.ExpectExec("CREATE TABLE schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(projectTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.ExpectCommit()
+				m.ExpectQuery(selectApplied).WillReturnRows(appliedRows())
+				for _, migration := range testMigrations {
+					m.ExpectBegin()
+					m.ExpectExec(migration.Up).WillReturnResult(sqlmock.NewResult(0, 0))
+					m.ExpectExec(insertApplied).
+						WithArgs(migration.Version, migration.Name, migration.Checksum).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					m.ExpectCommit()
+				}
+				m.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "only pending migrations are applied",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.ExpectQuery(selectApplied).WillReturnRows(appliedRows(1))
+				m.ExpectBegin()
+				m.ExpectExec("CREATE TABLE second").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(insertApplied).WithArgs(2, "second", testMigrations[1].Checksum).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+				m.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "database of the init script is adopted",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.ExpectBegin()
+				m.ExpectExec("CREATE TABLE schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(projectTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				expectBaseline(m, "first")
+				// schema has the first migration, it is only recorded
+				m.ExpectExec(insertApplied).WithArgs(1, "init", testMigrations[0].Checksum).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+				m.ExpectQuery(selectApplied).WillReturnRows(appliedRows(1))
+				m.ExpectBegin()
+				m.ExpectExec("CREATE TABLE second").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(insertApplied).WithArgs(2, "second", testMigrations[1].Checksum).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+				m.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "database of the init script with all migrations",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.ExpectBegin()
+				m.ExpectExec("CREATE TABLE schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(projectTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				expectBaseline(m, "first", "second")
+				m.ExpectExec(insertApplied).WithArgs(1, "init", testMigrations[0].Checksum).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(insertApplied).WithArgs(2, "second", testMigrations[1].Checksum).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+				m.ExpectQuery(selectApplied).WillReturnRows(appliedRows(1, 2))
+				m.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "unknown schema isn't adopted",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.ExpectBegin()
+				m.ExpectExec("CREATE TABLE schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(projectTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				expectBaseline(m, "first", "projects")
+				// the version table isn't created either
+				m.ExpectRollback()
+				m.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: myerr.ErrBaseline,
+		},
+		{
+			name: "changed migration isn't applied again",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.ExpectQuery(selectApplied).WillReturnRows(sqlmock.NewRows([]string{"version", "name", "checksum", "appliedTime"}).
+					AddRow(1, "init", "other checksum", time.Now()))
+				m.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: myerr.ErrChecksum,
+		},
+		{
+			name: "database of a newer build",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.ExpectQuery(selectApplied).WillReturnRows(appliedRows(1, 2).AddRow(3, "third", "checksum", time.Now()))
+				m.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: myerr.ErrUnknown,
+		},
+		{
+			name: "failed migration is rolled back",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.ExpectQuery(selectApplied).WillReturnRows(appliedRows(1))
+				m.ExpectBegin()
+				m.ExpectExec("CREATE TABLE second").WillReturnError(errors.New("syntax error"))
+				m.ExpectRollback()
+				m.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: myerr.ErrApply,
+		},
+		{
+			name: "lock error",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnError(errors.New("db error"))
+			},
+			wantErr: myerr.ErrLock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			m := &Migrator{db: db, migrations: testMigrations, log: slog.Default()}
+			err = m.Up(context.Background())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDown(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(lockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(selectApplied).WillReturnRows(appliedRows(1, 2))
+	// the last migration is reverted first
+	for _, migration := range []Migration{testMigrations[1], testMigrations[0]} {
+		mock.ExpectBegin()
+		mock.ExpectExec(migration.Down).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).
+			WithArgs(migration.Version).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	mock.ExpectExec(unlockQuery).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	m := &Migrator{db: db, migrations: testMigrations, log: slog.Default()}
+	// more steps than applied migrations
+	assert.NoError(t, m.Down(context.Background(), 5))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.ErrorIs(t, m.Down(context.Background(), 0), myerr.ErrCommand)
+}
+
+func TestStatus(t *testing.T) {
+	applied := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		mockQuery func(m sqlmock.Sqlmock)
+		want      []Status
+	}{
+		{
+			name: "no version table",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			want: []Status{
+				{Version: 1, Name: "init"},
+				{Version: 2, Name: "second"},
+			},
+		},
+		{
+			name: "changed and unknown migrations",
+			mockQuery: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(versionTable).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.ExpectQuery(selectApplied).WillReturnRows(sqlmock.NewRows([]string{"version", "name", "checksum", "appliedTime"}).
+					AddRow(3, "third", "checksum", applied).
+					AddRow(1, "init", "other checksum", applied))
+			},
+			want: []Status{
+				{Version: 1, Name: "init", Applied: true, AppliedTime: &applied, Changed: true},
+				{Version: 2, Name: "second"},
+				{Version: 3, Name: "third", Applied: true, AppliedTime: &applied, Unknown: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockQuery(mock)
+
+			m := &Migrator{db: db, migrations: testMigrations, log: slog.Default()}
+			statuses, err := m.Status(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, statuses)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS StatusChanges;
+DROP TABLE IF EXISTS Issue;
+DROP TABLE IF EXISTS Author;
+DROP TABLE IF EXISTS Projects;
@@ -0,0 +1,2 @@
+DROP TABLE Jobs;
+DROP TABLE SyncState;
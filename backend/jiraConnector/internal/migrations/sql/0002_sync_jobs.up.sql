@@ -0,0 +1,21 @@
+-- watermarks of the incremental sync and background update jobs of projects
+CREATE TABLE SyncState (
+    projectId INT PRIMARY KEY,
+    watermark TIMESTAMP WITH TIME ZONE,
+    lastSyncTime TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE Jobs (
+    id serial PRIMARY KEY,
+    project TEXT NOT NULL,
+    mode TEXT NOT NULL,
+    trigger TEXT NOT NULL DEFAULT 'manual',
+    state TEXT NOT NULL,
+    issuesFetched INT NOT NULL DEFAULT 0,
+    issuesTotal INT NOT NULL DEFAULT 0,
+    error TEXT,
+    createdTime TIMESTAMP WITH TIME ZONE NOT NULL,
+    startedTime TIMESTAMP WITH TIME ZONE,
+    finishedTime TIMESTAMP WITH TIME ZONE
+);
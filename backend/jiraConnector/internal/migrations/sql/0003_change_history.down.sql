@@ -0,0 +1,4 @@
+DROP TABLE IssueFieldChanges;
+
+ALTER TABLE StatusChanges DROP CONSTRAINT statuschanges_pkey;
+ALTER TABLE StatusChanges DROP COLUMN historyId;
@@ -0,0 +1,28 @@
+-- status changes are keyed by the id of the changelog history, all changelog items
+-- are kept in IssueFieldChanges. Status changes saved without history ids are removed,
+-- sync watermarks too, so the next sync of every project is full and loads them again.
+DELETE FROM StatusChanges;
+
+ALTER TABLE StatusChanges ADD COLUMN historyId TEXT NOT NULL;
+ALTER TABLE StatusChanges ADD PRIMARY KEY (issueId, historyId);
+
+CREATE TABLE IssueFieldChanges (
+    issueId INT NOT NULL,
+    historyId TEXT NOT NULL,
+    itemIndex INT NOT NULL,
+    authorId INT NOT NULL,
+    changeTime TIMESTAMP WITHOUT TIME ZONE,
+    field TEXT NOT NULL,
+    fieldType TEXT,
+    fromValue TEXT,
+    fromString TEXT,
+    toValue TEXT,
+    toString TEXT,
+    PRIMARY KEY (issueId, historyId, itemIndex),
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (authorId) REFERENCES Author (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_issuefieldchanges_field ON IssueFieldChanges (field);
+
+DELETE FROM SyncState;
@@ -0,0 +1,14 @@
+-- projects of other sources are removed, keys are unique in the only Jira again
+DELETE FROM Projects WHERE source <> 'default';
+DELETE FROM Jobs WHERE source <> 'default';
+
+ALTER TABLE Jobs DROP COLUMN source;
+
+ALTER TABLE Issue DROP CONSTRAINT issue_projectid_key_key;
+ALTER TABLE Issue ALTER COLUMN key DROP NOT NULL;
+ALTER TABLE Issue ADD UNIQUE (key);
+
+ALTER TABLE Projects DROP CONSTRAINT projects_source_key_key;
+ALTER TABLE Projects ALTER COLUMN key DROP NOT NULL;
+ALTER TABLE Projects ADD UNIQUE (title);
+ALTER TABLE Projects DROP COLUMN source;
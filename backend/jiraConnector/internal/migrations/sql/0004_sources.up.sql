@@ -0,0 +1,12 @@
+-- projects of several Jira instances: a project is unique by the source and the key,
+-- an issue key by the project. Existing projects and jobs belong to the default source.
+ALTER TABLE Projects ADD COLUMN source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE Projects DROP CONSTRAINT projects_title_key;
+ALTER TABLE Projects ALTER COLUMN key SET NOT NULL;
+ALTER TABLE Projects ADD UNIQUE (source, key);
+
+ALTER TABLE Issue DROP CONSTRAINT issue_key_key;
+ALTER TABLE Issue ALTER COLUMN key SET NOT NULL;
+ALTER TABLE Issue ADD UNIQUE (projectId, key);
+
+ALTER TABLE Jobs ADD COLUMN source TEXT NOT NULL DEFAULT 'default';
@@ -0,0 +1,10 @@
+DROP TABLE IssueFixVersion;
+DROP TABLE Version;
+DROP TABLE IssueComponent;
+DROP TABLE Component;
+DROP TABLE IssueLabel;
+DROP TABLE Label;
+DROP TABLE IssueComment;
+DROP TABLE Worklog;
+DROP TABLE IssueLink;
+DROP TABLE IssueCustomField;
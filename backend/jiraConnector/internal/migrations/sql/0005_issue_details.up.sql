@@ -0,0 +1,98 @@
+-- custom fields, links, worklogs, comments, labels, components and fix versions of issues
+CREATE TABLE IssueCustomField (
+    issueId INT NOT NULL,
+    name TEXT NOT NULL,
+    fieldId TEXT NOT NULL,
+    valueIndex INT NOT NULL DEFAULT 0,
+    value TEXT NOT NULL,
+    numberValue DOUBLE PRECISION,
+    PRIMARY KEY (issueId, name, valueIndex),
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_issuecustomfield_value ON IssueCustomField (name, value);
+
+CREATE TABLE IssueLink (
+    issueId INT NOT NULL,
+    linkType TEXT NOT NULL,
+    direction TEXT NOT NULL DEFAULT '',
+    linkedKey TEXT NOT NULL,
+    description TEXT,
+    PRIMARY KEY (issueId, linkType, direction, linkedKey),
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_issuelink_linkedkey ON IssueLink (linkedKey, linkType);
+
+CREATE TABLE Worklog (
+    issueId INT NOT NULL,
+    worklogId TEXT NOT NULL,
+    authorId INT NOT NULL,
+    started TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    timeSpentSeconds INT NOT NULL,
+    comment TEXT,
+    PRIMARY KEY (issueId, worklogId),
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (authorId) REFERENCES Author (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_worklog_started ON Worklog (started);
+
+CREATE TABLE IssueComment (
+    issueId INT NOT NULL,
+    commentId TEXT NOT NULL,
+    authorId INT NOT NULL,
+    createdTime TIMESTAMP WITHOUT TIME ZONE,
+    updatedTime TIMESTAMP WITHOUT TIME ZONE,
+    body TEXT,
+    PRIMARY KEY (issueId, commentId),
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (authorId) REFERENCES Author (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE Label (
+    id serial PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IssueLabel (
+    issueId INT NOT NULL,
+    labelId INT NOT NULL,
+    PRIMARY KEY (issueId, labelId),
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (labelId) REFERENCES Label (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE Component (
+    id serial PRIMARY KEY,
+    projectId INT NOT NULL,
+    name TEXT NOT NULL,
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    UNIQUE (projectId, name)
+);
+
+CREATE TABLE IssueComponent (
+    issueId INT NOT NULL,
+    componentId INT NOT NULL,
+    PRIMARY KEY (issueId, componentId),
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (componentId) REFERENCES Component (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE Version (
+    id serial PRIMARY KEY,
+    projectId INT NOT NULL,
+    name TEXT NOT NULL,
+    released BOOLEAN NOT NULL DEFAULT false,
+    releaseDate DATE,
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    UNIQUE (projectId, name)
+);
+
+CREATE TABLE IssueFixVersion (
+    issueId INT NOT NULL,
+    versionId INT NOT NULL,
+    PRIMARY KEY (issueId, versionId),
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (versionId) REFERENCES Version (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
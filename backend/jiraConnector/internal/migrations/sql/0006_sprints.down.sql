@@ -0,0 +1,3 @@
+DROP TABLE SprintIssue;
+DROP TABLE Sprint;
+DROP TABLE Board;
@@ -0,0 +1,32 @@
+-- scrum boards of projects and their sprints
+CREATE TABLE Board (
+    id serial PRIMARY KEY,
+    projectId INT NOT NULL,
+    jiraId INT NOT NULL,
+    name TEXT,
+    type TEXT,
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    UNIQUE (projectId, jiraId)
+);
+
+CREATE TABLE Sprint (
+    id serial PRIMARY KEY,
+    boardId INT NOT NULL,
+    jiraId INT NOT NULL,
+    name TEXT NOT NULL,
+    state TEXT NOT NULL,
+    goal TEXT,
+    startTime TIMESTAMP WITHOUT TIME ZONE,
+    endTime TIMESTAMP WITHOUT TIME ZONE,
+    completeTime TIMESTAMP WITHOUT TIME ZONE,
+    FOREIGN KEY (boardId) REFERENCES Board (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    UNIQUE (boardId, jiraId)
+);
+
+CREATE TABLE SprintIssue (
+    sprintId INT NOT NULL,
+    issueId INT NOT NULL,
+    PRIMARY KEY (sprintId, issueId),
+    FOREIGN KEY (sprintId) REFERENCES Sprint (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
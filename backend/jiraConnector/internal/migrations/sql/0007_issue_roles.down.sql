@@ -0,0 +1,10 @@
+-- assigneeId stores the reporter again, as it was before the roles were split
+ALTER TABLE Issue DROP CONSTRAINT issue_assigneeid_fkey;
+
+UPDATE Issue SET assigneeId = COALESCE(reporterId, authorId);
+
+ALTER TABLE Issue ALTER COLUMN assigneeId SET NOT NULL;
+ALTER TABLE Issue DROP COLUMN typeId;
+ALTER TABLE Issue DROP COLUMN reporterId;
+
+DELETE FROM SyncState;
@@ -0,0 +1,14 @@
+-- reporter, creator and assignee as separate roles, issue type by name and id.
+-- assigneeId used to store the reporter, so it becomes reporterId and the real
+-- assignee is loaded by the next sync. Sync watermarks are removed, so the next
+-- sync of every project is full and rewrites all issues, including their type.
+ALTER TABLE Issue ADD COLUMN reporterId INT;
+ALTER TABLE Issue ADD COLUMN typeId TEXT;
+ALTER TABLE Issue ALTER COLUMN assigneeId DROP NOT NULL;
+
+UPDATE Issue SET reporterId = assigneeId, assigneeId = NULL;
+
+ALTER TABLE Issue ADD FOREIGN KEY (reporterId) REFERENCES Author (id) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE Issue ADD FOREIGN KEY (assigneeId) REFERENCES Author (id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+DELETE FROM SyncState;
@@ -0,0 +1,9 @@
+-- display names aren't unique, users are identified by their ids again
+UPDATE Author SET name = accountId;
+
+ALTER TABLE Author ADD UNIQUE (name);
+ALTER TABLE Author DROP COLUMN active;
+ALTER TABLE Author DROP COLUMN email;
+ALTER TABLE Author DROP COLUMN accountId;
+
+DELETE FROM SyncState;
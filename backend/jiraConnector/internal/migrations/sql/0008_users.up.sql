@@ -0,0 +1,14 @@
+-- users are keyed by the account id (Jira Cloud) or the user key (Jira Server),
+-- name becomes the display name. Existing users keep their login as the id until
+-- the next sync, sync watermarks are removed so every project is synced in full.
+ALTER TABLE Author ADD COLUMN accountId TEXT;
+ALTER TABLE Author ADD COLUMN email TEXT;
+ALTER TABLE Author ADD COLUMN active BOOLEAN;
+
+UPDATE Author SET accountId = COALESCE(name, '');
+
+ALTER TABLE Author ALTER COLUMN accountId SET NOT NULL;
+ALTER TABLE Author ADD UNIQUE (accountId);
+ALTER TABLE Author DROP CONSTRAINT author_name_key;
+
+DELETE FROM SyncState;
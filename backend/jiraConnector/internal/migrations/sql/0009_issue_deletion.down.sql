@@ -0,0 +1,6 @@
+-- tombstoned issues are removed, they were hidden from the analytics
+DELETE FROM Issue WHERE deletedTime IS NOT NULL;
+
+DROP INDEX issue_jiraid_idx;
+ALTER TABLE Issue DROP COLUMN deletedTime;
+ALTER TABLE Issue DROP COLUMN jiraId;
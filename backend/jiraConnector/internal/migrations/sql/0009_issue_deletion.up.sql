@@ -0,0 +1,9 @@
+-- jiraId follows the issue when it is moved to another project and gets a new key,
+-- deletedTime marks issues that are no longer in Jira. Existing issues get their
+-- ids on the next sync, so sync watermarks are removed and every project is synced in full.
+ALTER TABLE Issue ADD COLUMN jiraId TEXT;
+ALTER TABLE Issue ADD COLUMN deletedTime TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX issue_jiraid_idx ON Issue (jiraId);
+
+DELETE FROM SyncState;
@@ -0,0 +1,2 @@
+DROP TABLE ArchiveIssue;
+DROP TABLE ArchiveProject;
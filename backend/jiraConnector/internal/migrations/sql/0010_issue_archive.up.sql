@@ -0,0 +1,17 @@
+-- raw issues of Jira as gzip compressed JSON, the latest version of every issue,
+-- so the normalized tables can be rebuilt without downloading the project again
+CREATE TABLE ArchiveProject (
+    projectId INT PRIMARY KEY,
+    payload BYTEA NOT NULL,
+    archivedTime TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE ArchiveIssue (
+    projectId INT NOT NULL,
+    key TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    archivedTime TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (projectId, key),
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,10 @@
+-- priorities of every Jira source in the order of Jira, rank 1 is the highest.
+-- The changelog keeps priority ids, the rank tells whether a change raised it
+CREATE TABLE Priority (
+    id SERIAL PRIMARY KEY,
+    source TEXT NOT NULL,
+    jiraId TEXT NOT NULL,
+    name TEXT NOT NULL,
+    rank INT NOT NULL,
+    UNIQUE (source, jiraId)
+);
@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrSchedule = errors.New("incorrect schedule expression")
+	ErrMode     = errors.New("incorrect scheduler mode - need full or incremental")
+	ErrSource   = errors.New("scheduled project needs source/KEY - there are several jira sources without default")
+)
@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	jobErr "github.com/jiraconnector/internal/jobQueue/errors"
+	myErr "github.com/jiraconnector/internal/scheduler/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/config"
+	"github.com/jiraconnector/pkg/logger"
+	"github.com/robfig/cron/v3"
+)
+
+//go:generate mockery
+
+type ProjectStoreInterface interface {
+	GetProjects(ctx context.Context) ([]structures.ProjectRef, error)
+}
+
+type JobQueueInterface interface {
+	EnqueueIfIdle(ctx context.Context, project structures.ProjectRef, mode structures.SyncMode, trigger structures.JobTrigger) (*structures.Job, error)
+}
+
+// Scheduler periodically puts sync jobs for the saved projects into the job queue.
+// Outcome of every run is stored by the queue as a job with the scheduled trigger.
+type Scheduler struct {
+	cron     *cron.Cron
+	projects ProjectStoreInterface
+	jobs     JobQueueInterface
+	mode     structures.SyncMode
+	// projects with their own schedule are skipped by the global one
+	own map[structures.ProjectRef]bool
+	log *slog.Logger
+}
+
+func NewScheduler(cfg *config.Config, projects ProjectStoreInterface, jobs JobQueueInterface, log *slog.Logger) (*Scheduler, error) {
+	mode := structures.SyncMode(cfg.SchedCfg.Mode)
+	switch mode {
+	case "":
+		mode = structures.SyncIncremental
+	case structures.SyncIncremental, structures.SyncFull:
+	default:
+		ansErr := fmt.Errorf("%w: %s", myErr.ErrMode, mode)
+		log.Error(ansErr.Error())
+		return nil, ansErr
+	}
+
+	s := &Scheduler{
+		cron:     cron.New(),
+		projects: projects,
+		jobs:     jobs,
+		mode:     mode,
+		own:      map[structures.ProjectRef]bool{},
+		log:      log,
+	}
+
+	if cfg.SchedCfg.Schedule != "" {
+		if _, err := s.cron.AddFunc(cfg.SchedCfg.Schedule, s.syncAll); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %w", myErr.ErrSchedule, cfg.SchedCfg.Schedule, err)
+			log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+	}
+
+	defaultSource := cfg.DefaultSourceName()
+	for name, schedule := range cfg.SchedCfg.Projects {
+		project := structures.ParseProjectRef(name, defaultSource)
+		if project.Source == "" {
+			ansErr := fmt.Errorf("%w - %s", myErr.ErrSource, name)
+			log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+
+		if _, err := s.cron.AddFunc(schedule, func() { s.syncProject(project) }); err != nil {
+			ansErr := fmt.Errorf("%w - %s: %s: %w", myErr.ErrSchedule, project, schedule, err)
+			log.Error(ansErr.Error())
+			return nil, ansErr
+		}
+		s.own[project] = true
+	}
+
+	return s, nil
+}
+
+func (s *Scheduler) Start() {
+	s.cron.Start()
+	s.log.Info("start scheduler", "entries", len(s.cron.Entries()), "mode", s.mode)
+}
+
+// Stop waits for the running enqueue, jobs themselves are stopped by the queue
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+	s.log.Info("stop scheduler")
+}
+
+func (s *Scheduler) syncAll() {
+	projects, err := s.projects.GetProjects(context.Background())
+	if err != nil {
+		s.log.Error("scheduled sync: error get projects", logger.Err(err))
+		return
+	}
+
+	for _, project := range projects {
+		if !s.own[project] {
+			s.enqueue(project)
+		}
+	}
+}
+
+func (s *Scheduler) syncProject(project structures.ProjectRef) {
+	projects, err := s.projects.GetProjects(context.Background())
+	if err != nil {
+		s.log.Error("scheduled sync: error get projects", logger.Err(err), "project", project.String())
+		return
+	}
+
+	// only projects which were loaded by someone are kept fresh
+	if !slices.Contains(projects, project) {
+		s.log.Info("scheduled sync: project isn't saved, skip", "project", project.String())
+		return
+	}
+
+	s.enqueue(project)
+}
+
+func (s *Scheduler) enqueue(project structures.ProjectRef) {
+	job, err := s.jobs.EnqueueIfIdle(context.Background(), project, s.mode, structures.TriggerScheduled)
+	if err != nil {
+		if errors.Is(err, jobErr.ErrProjectBusy) {
+			s.log.Info("scheduled sync: previous sync isn't finished, skip", "project", project.String())
+		} else {
+			s.log.Error("scheduled sync: error enqueue job", logger.Err(err), "project", project.String())
+		}
+		return
+	}
+
+	s.log.Info("scheduled sync", "project", project.String(), "job", job.Id)
+}
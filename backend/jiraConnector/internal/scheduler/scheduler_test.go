@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	jobErr "github.com/jiraconnector/internal/jobQueue/errors"
+	myErr "github.com/jiraconnector/internal/scheduler/errors"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestScheduler(t *testing.T, cfg config.SchedulerConfig, projects ProjectStoreInterface, jobs JobQueueInterface) *Scheduler {
+	s, err := NewScheduler(&config.Config{SchedCfg: cfg}, projects, jobs, slog.Default())
+	assert.NoError(t, err)
+	return s
+}
+
+func ref(key string) structures.ProjectRef {
+	return structures.ProjectRef{Source: config.DefaultSource, Key: key}
+}
+
+func TestNewScheduler(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.SchedulerConfig
+		sources     map[string]config.JiraConfig
+		wantEntries int
+		wantMode    structures.SyncMode
+		wantErr     error
+	}{
+		{
+			name:        "cron expression",
+			cfg:         config.SchedulerConfig{Schedule: "0 3 * * *"},
+			wantEntries: 1,
+			wantMode:    structures.SyncIncremental,
+		},
+		{
+			name:        "interval with project overrides",
+			cfg:         config.SchedulerConfig{Schedule: "@every 6h", Mode: "full", Projects: map[string]string{"AAR": "@every 30m", "PRJ": "*/15 * * * *"}},
+			wantEntries: 3,
+			wantMode:    structures.SyncFull,
+		},
+		{
+			name:        "only projects",
+			cfg:         config.SchedulerConfig{Projects: map[string]string{"AAR": "@hourly"}},
+			wantEntries: 1,
+			wantMode:    structures.SyncIncremental,
+		},
+		{
+			name:    "invalid global schedule",
+			cfg:     config.SchedulerConfig{Schedule: "every day"},
+			wantErr: myErr.ErrSchedule,
+		},
+		{
+			name:    "invalid project schedule",
+			cfg:     config.SchedulerConfig{Projects: map[string]string{"AAR": "@every never"}},
+			wantErr: myErr.ErrSchedule,
+		},
+		{
+			name:    "invalid mode",
+			cfg:     config.SchedulerConfig{Schedule: "@daily", Mode: "partial"},
+			wantErr: myErr.ErrMode,
+		},
+		{
+			name:        "projects of several sources",
+			cfg:         config.SchedulerConfig{Projects: map[string]string{"cloud/AAR": "@hourly", "onprem/AAR": "@daily"}},
+			sources:     map[string]config.JiraConfig{"cloud": {}, "onprem": {}},
+			wantEntries: 2,
+			wantMode:    structures.SyncIncremental,
+		},
+		{
+			name:    "project without source",
+			cfg:     config.SchedulerConfig{Projects: map[string]string{"AAR": "@hourly"}},
+			sources: map[string]config.JiraConfig{"cloud": {}, "onprem": {}},
+			wantErr: myErr.ErrSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewScheduler(&config.Config{SchedCfg: tt.cfg, JiraSources: tt.sources}, nil, nil, slog.Default())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, s.cron.Entries(), tt.wantEntries)
+			assert.Equal(t, tt.wantMode, s.mode)
+		})
+	}
+}
+
+func TestSyncAll(t *testing.T) {
+	projects := new(MockProjectStoreInterface)
+	jobs := new(MockJobQueueInterface)
+	s := newTestScheduler(t, config.SchedulerConfig{Schedule: "@daily", Projects: map[string]string{"OWN": "@hourly"}}, projects, jobs)
+
+	// the same key of another source has no own schedule
+	other := structures.ProjectRef{Source: "other", Key: "OWN"}
+	projects.On("GetProjects", mock.Anything).Return([]structures.ProjectRef{ref("AAR"), ref("BUSY"), ref("FAIL"), ref("OWN"), other}, nil)
+	jobs.On("EnqueueIfIdle", mock.Anything, ref("AAR"), structures.SyncIncremental, structures.TriggerScheduled).Return(&structures.Job{Id: 1}, nil)
+	jobs.On("EnqueueIfIdle", mock.Anything, ref("BUSY"), structures.SyncIncremental, structures.TriggerScheduled).Return(nil, jobErr.ErrProjectBusy)
+	jobs.On("EnqueueIfIdle", mock.Anything, ref("FAIL"), structures.SyncIncremental, structures.TriggerScheduled).Return(nil, errors.New("db error"))
+	jobs.On("EnqueueIfIdle", mock.Anything, other, structures.SyncIncremental, structures.TriggerScheduled).Return(&structures.Job{Id: 2}, nil)
+
+	s.syncAll()
+
+	// project with own schedule isn't synced by the global one
+	jobs.AssertNotCalled(t, "EnqueueIfIdle", mock.Anything, ref("OWN"), mock.Anything, mock.Anything)
+	projects.AssertExpectations(t)
+	jobs.AssertExpectations(t)
+}
+
+func TestSyncAll_ProjectsError(t *testing.T) {
+	projects := new(MockProjectStoreInterface)
+	jobs := new(MockJobQueueInterface)
+	s := newTestScheduler(t, config.SchedulerConfig{Schedule: "@daily"}, projects, jobs)
+
+	projects.On("GetProjects", mock.Anything).Return(nil, errors.New("db error"))
+
+	s.syncAll()
+
+	jobs.AssertNotCalled(t, "EnqueueIfIdle", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	projects.AssertExpectations(t)
+}
+
+func TestSyncProject(t *testing.T) {
+	projects := new(MockProjectStoreInterface)
+	jobs := new(MockJobQueueInterface)
+	s := newTestScheduler(t, config.SchedulerConfig{Mode: "full", Projects: map[string]string{"AAR": "@hourly", "NEW": "@hourly"}}, projects, jobs)
+
+	projects.On("GetProjects", mock.Anything).Return([]structures.ProjectRef{ref("AAR")}, nil)
+	jobs.On("EnqueueIfIdle", mock.Anything, ref("AAR"), structures.SyncFull, structures.TriggerScheduled).Return(&structures.Job{Id: 1}, nil)
+
+	s.syncProject(ref("AAR"))
+	// project which was never loaded isn't synced
+	s.syncProject(ref("NEW"))
+
+	jobs.AssertNotCalled(t, "EnqueueIfIdle", mock.Anything, ref("NEW"), mock.Anything, mock.Anything)
+	projects.AssertExpectations(t)
+	jobs.AssertExpectations(t)
+}
+
+func TestStartStop(t *testing.T) {
+	s := newTestScheduler(t, config.SchedulerConfig{Schedule: "@daily"}, new(MockProjectStoreInterface), new(MockJobQueueInterface))
+
+	s.Start()
+	assert.False(t, s.cron.Entries()[0].Next.IsZero())
+	s.Stop()
+}
@@ -2,35 +2,174 @@ package structures
 
 import "time"
 
-type DBStatusChanges struct {
+// DBStatusTransition is one status change from the issue changelog,
+// HistoryId is the id of Jira changelog entry and doesn't change between syncs
+type DBStatusTransition struct {
+	HistoryId  string
 	IssueId    int
 	AuthorId   int
+	Author     DBAuthor
 	ChangeTime time.Time
 	FromStatus string
 	ToStatus   string
 }
 
+// DBFieldChange is one item of the issue changelog, a single changelog entry
+// may change several fields so the item is identified by HistoryId and ItemIndex
+type DBFieldChange struct {
+	HistoryId  string
+	ItemIndex  int
+	IssueId    int
+	AuthorId   int
+	Author     DBAuthor
+	ChangeTime time.Time
+	Field      string
+	FieldType  string
+	FromValue  string
+	FromString string
+	ToValue    string
+	ToString   string
+}
+
+// DBCustomField is one value of the mapped custom field, multi-value fields
+// (sprints) have a row per value. NumberValue is set for numeric values
+type DBCustomField struct {
+	IssueId     int
+	Name        string
+	FieldId     string
+	ValueIndex  int
+	Value       string
+	NumberValue *float64
+}
+
+// types of the issue hierarchy in IssueLink, other links keep the Jira link type name
+const (
+	LinkParent  = "parent"
+	LinkSubtask = "subtask"
+)
+
+// directions of Jira links: the issue "blocks" (outward) or "is blocked by" (inward) the linked one
+const (
+	LinkOutward = "outward"
+	LinkInward  = "inward"
+)
+
+// DBIssueLink is a relation of the issue to LinkedKey. The linked issue may be in
+// another project or not loaded, so it is kept by key. Hierarchy links have no direction
+type DBIssueLink struct {
+	IssueId     int
+	LinkType    string
+	Direction   string
+	LinkedKey   string
+	Description string
+}
+
+// DBWorklog is work logged on the issue by Author, WorklogId doesn't change between syncs
+type DBWorklog struct {
+	WorklogId string
+	IssueId   int
+	AuthorId  int
+	Author    DBAuthor
+	Started   time.Time
+	Seconds   int
+	Comment   string
+}
+
+// DBComment is a comment of the issue, CommentId doesn't change between syncs
+type DBComment struct {
+	CommentId   string
+	IssueId     int
+	AuthorId    int
+	Author      DBAuthor
+	CreatedTime time.Time
+	UpdatedTime time.Time
+	Body        string
+}
+
+// DBComponent is a component of the project, it is unique by name within the project
+type DBComponent struct {
+	Id        int
+	ProjectId int
+	Name      string
+}
+
+// DBVersion is a version of the project, ReleaseDate is nil until it is planned
+type DBVersion struct {
+	Id          int
+	ProjectId   int
+	Name        string
+	Released    bool
+	ReleaseDate *time.Time
+}
+
+// DBPriority is a priority of the Jira instance, Rank is its position in the
+// priority list of Jira, 1 is the highest
+type DBPriority struct {
+	JiraId string
+	Name   string
+	Rank   int
+}
+
+// DBBoard is a scrum board of the project, JiraId is unique only within the Jira instance
+type DBBoard struct {
+	Id        int
+	ProjectId int
+	JiraId    int
+	Name      string
+	Type      string
+	Sprints   []DBSprint
+}
+
+// DBSprint times are nil until the sprint is started or completed. IssueKeys are
+// all issues which were in the sprint, including the ones moved to the next sprint
+type DBSprint struct {
+	Id           int
+	BoardId      int
+	JiraId       int
+	Name         string
+	State        string
+	Goal         string
+	StartTime    *time.Time
+	EndTime      *time.Time
+	CompleteTime *time.Time
+	IssueKeys    []string
+}
+
+// DBAuthor is a Jira user, it is unique by AccountId: the account id on Jira Cloud
+// and the user key on Jira Server. Name is the display name and may change
 type DBAuthor struct {
-	Id   int
-	Name string
+	Id        int
+	AccountId string
+	Name      string
+	Email     string
+	Active    bool
 }
 
+// DBProject is unique by Source and Key, the same key may exist in several Jira instances
 type DBProject struct {
-	Id    int
-	Title string
-	Key   string
-	Url   string
+	Id     int
+	Source string
+	Title  string
+	Key    string
+	Url    string
 }
 
+// DBIssue AuthorId is the creator of the issue. ReporterId and AssigneeId are nil
+// when the issue has no reporter or is unassigned. Type is the name of the issue type.
+// JiraId is the id of the issue in Jira, it is kept when the issue is moved to
+// another project and gets a new key
 type DBIssue struct {
 	Id          int
+	JiraId      string
 	ProjectId   int
 	AuthorId    int
-	AssigneeId  int
+	ReporterId  *int
+	AssigneeId  *int
 	Key         string
 	Summary     string
 	Description string
 	Type        string
+	TypeId      string
 	Priority    string
 	Status      string
 	CreatedTime time.Time
@@ -1,5 +1,10 @@
 package structures
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type JiraProject struct {
 	// response: ".../project"
 	Id   string `json:"id"`
@@ -16,32 +21,184 @@ type JiraIssues struct {
 	Issues     []JiraIssue `json:"issues"`
 }
 
+type JiraIssuesRaw struct {
+	// response: ".../search?jql=project=idproject", issues are decoded one by one
+	// to keep their JSON for the archive
+	Issues []json.RawMessage `json:"issues"`
+}
+
+type JiraIssuesPage struct {
+	// response: "/rest/api/3/search/jql?jql=project=idproject&nextPageToken=token"
+	Issues        []json.RawMessage `json:"issues"`
+	NextPageToken string            `json:"nextPageToken"`
+	IsLast        bool              `json:"isLast"`
+}
+
+type JiraIssuesCount struct {
+	// response: "/rest/api/3/search/approximate-count"
+	Count int `json:"count"`
+}
+
 type JiraIssue struct {
 	Id        string    `json:"id"`
 	Key       string    `json:"key"`
 	Fields    Field     `json:"fields"`
 	Changelog Changelog `json:"changelog"`
+	// JSON of the issue as Jira returned it, it is archived instead of the struct
+	Raw json.RawMessage `json:"-"`
 }
 
 type Field struct {
-	Project     JiraProject   `json:"project"`
-	Author      User          `json:"creator"`
-	Assignee    User          `json:"reporter"`
-	Summary     string        `json:"summary"`
-	Description string        `json:"description"`
-	Type        IssueType     `json:"issuetype"`
-	Priority    IssuePriority `json:"priority"`
-	Status      IssueStatus   `json:"status"`
-	CreatedTime string        `json:"created"`
-	ClosedTime  string        `json:"resolutiondate"`
-	UpdatedTime string        `json:"updated"`
-	TimeSpent   int           `json:"timespent"`
+	Project JiraProject `json:"project"`
+	// creator of the issue, the reporter may be set to someone else
+	Author   User  `json:"creator"`
+	Reporter *User `json:"reporter"`
+	// nil for unassigned issues
+	Assignee *User  `json:"assignee"`
+	Summary  string `json:"summary"`
+	// plain string in REST v2, Atlassian Document Format in v3
+	Description json.RawMessage `json:"description"`
+	Type        IssueType       `json:"issuetype"`
+	Priority    IssuePriority   `json:"priority"`
+	Status      IssueStatus     `json:"status"`
+	CreatedTime string          `json:"created"`
+	ClosedTime  string          `json:"resolutiondate"`
+	UpdatedTime string          `json:"updated"`
+	TimeSpent   int             `json:"timespent"`
+	Links       []IssueLink     `json:"issuelinks"`
+	// parent of a subtask, on Jira Cloud also the epic of a story
+	Parent   *LinkedIssue  `json:"parent"`
+	Subtasks []LinkedIssue `json:"subtasks"`
+	// search embeds only the first worklogs, the rest are requested separately
+	Worklog     Worklogs        `json:"worklog"`
+	Comment     Comments        `json:"comment"`
+	Labels      []string        `json:"labels"`
+	Components  []JiraComponent `json:"components"`
+	FixVersions []JiraVersion   `json:"fixVersions"`
+	// customfield_XXXXX values as they are, their meaning differs between Jira instances
+	Custom map[string]json.RawMessage `json:"-"`
+}
+
+func (f *Field) UnmarshalJSON(data []byte) error {
+	// alias has no UnmarshalJSON, so the standard fields are decoded as usual
+	type standardField Field
+	if err := json.Unmarshal(data, (*standardField)(f)); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+
+	f.Custom = nil
+	for id, value := range all {
+		if !strings.HasPrefix(id, "customfield_") {
+			continue
+		}
+		if f.Custom == nil {
+			f.Custom = make(map[string]json.RawMessage)
+		}
+		f.Custom[id] = value
+	}
+	return nil
+}
+
+// MarshalJSON puts custom fields back next to the standard ones, so archived
+// issues are decoded the same way as responses of Jira
+func (f Field) MarshalJSON() ([]byte, error) {
+	type standardField Field
+	data, err := json.Marshal(standardField(f))
+	if err != nil || len(f.Custom) == 0 {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for id, value := range f.Custom {
+		all[id] = value
+	}
+	return json.Marshal(all)
+}
+
+type JiraField struct {
+	// response: ".../field"
+	Id     string          `json:"id"`
+	Name   string          `json:"name"`
+	Custom bool            `json:"custom"`
+	Schema JiraFieldSchema `json:"schema"`
+}
+
+type JiraPriority struct {
+	// response: ".../priority", ordered from the highest priority
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type JiraFieldSchema struct {
+	Type  string `json:"type"`
+	Items string `json:"items"`
+	// plugin type of the custom field, e.g. com.pyxis.greenhopper.jira:gh-sprint
+	Custom string `json:"custom"`
+}
+
+type JiraBoardsPage struct {
+	// response: "/rest/agile/1.0/board?projectKeyOrId=KEY"
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	IsLast     bool        `json:"isLast"`
+	Values     []JiraBoard `json:"values"`
+}
+
+type JiraBoard struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+	// scrum, kanban or simple, only scrum boards have sprints
+	Type string `json:"type"`
+}
+
+type JiraSprintsPage struct {
+	// response: "/rest/agile/1.0/board/{id}/sprint"
+	StartAt    int          `json:"startAt"`
+	MaxResults int          `json:"maxResults"`
+	IsLast     bool         `json:"isLast"`
+	Values     []JiraSprint `json:"values"`
+}
+
+type JiraSprint struct {
+	Id int `json:"id"`
+	// future, active or closed
+	State        string `json:"state"`
+	Name         string `json:"name"`
+	Goal         string `json:"goal"`
+	StartDate    string `json:"startDate"`
+	EndDate      string `json:"endDate"`
+	CompleteDate string `json:"completeDate"`
+	// board where the sprint was created, the board list contains sprints of other boards too
+	OriginBoardId int `json:"originBoardId"`
+}
+
+// JiraAgileBoard is a scrum board with its sprints and keys of the sprint issues
+type JiraAgileBoard struct {
+	Board   JiraBoard
+	Sprints []JiraAgileSprint
+}
+
+type JiraAgileSprint struct {
+	Sprint    JiraSprint
+	IssueKeys []string
 }
 
 type User struct {
-	Key         string `json:"key"`
-	Name        string `json:"name"`
-	DisplayName string `json:"displayName"`
+	// Jira Cloud returns only account id and display name
+	AccountId    string `json:"accountId"`
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+	Active       bool   `json:"active"`
 }
 
 type IssueType struct {
@@ -61,6 +218,74 @@ type IssueStatus struct {
 	Description string `json:"description"`
 }
 
+// IssueLink has either InwardIssue or OutwardIssue, the other side is the issue itself
+type IssueLink struct {
+	Id           string        `json:"id"`
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  *LinkedIssue  `json:"inwardIssue"`
+	OutwardIssue *LinkedIssue  `json:"outwardIssue"`
+}
+
+type IssueLinkType struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	// descriptions of both directions, e.g. "is blocked by" and "blocks"
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+type LinkedIssue struct {
+	Id  string `json:"id"`
+	Key string `json:"key"`
+}
+
+type Worklogs struct {
+	// embedded in the issue and response: ".../issue/{key}/worklog"
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Worklogs   []Worklog `json:"worklogs"`
+}
+
+type Worklog struct {
+	Id     string `json:"id"`
+	Author User   `json:"author"`
+	// plain string in REST v2, Atlassian Document Format in v3
+	Comment          json.RawMessage `json:"comment"`
+	Started          string          `json:"started"`
+	TimeSpentSeconds int             `json:"timeSpentSeconds"`
+}
+
+type Comments struct {
+	// embedded in the issue and response: ".../issue/{key}/comment"
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Comments   []Comment `json:"comments"`
+}
+
+type Comment struct {
+	Id     string `json:"id"`
+	Author User   `json:"author"`
+	// plain string in REST v2, Atlassian Document Format in v3
+	Body    json.RawMessage `json:"body"`
+	Created string          `json:"created"`
+	Updated string          `json:"updated"`
+}
+
+type JiraComponent struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type JiraVersion struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Released bool   `json:"released"`
+	// date only, e.g. 2024-03-01
+	ReleaseDate string `json:"releaseDate"`
+}
+
 type Changelog struct {
 	StartAt    int       `json:"startAt"`
 	MaxResults int       `json:"maxResults"`
@@ -68,6 +293,15 @@ type Changelog struct {
 	Histories  []History `json:"histories"`
 }
 
+type ChangelogPage struct {
+	// response: ".../issue/{key}/changelog"
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	IsLast     bool      `json:"isLast"`
+	Values     []History `json:"values"`
+}
+
 type History struct {
 	Id      string `json:"id"`
 	Author  User   `json:"author"`
@@ -0,0 +1,44 @@
+package structures
+
+import "time"
+
+type JobState string
+
+const (
+	JobQueued    JobState = "queued"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+	JobCancelled JobState = "cancelled"
+)
+
+type JobTrigger string
+
+const (
+	TriggerManual    JobTrigger = "manual"
+	TriggerScheduled JobTrigger = "scheduled"
+)
+
+// Job describes one background synchronization of a project
+type Job struct {
+	Id            int        `json:"id"`
+	Source        string     `json:"source"`
+	Project       string     `json:"project"`
+	Mode          SyncMode   `json:"mode"`
+	Trigger       JobTrigger `json:"trigger"`
+	State         JobState   `json:"state"`
+	IssuesFetched int        `json:"issuesFetched"`
+	IssuesTotal   int        `json:"issuesTotal"`
+	Error         string     `json:"error,omitempty"`
+	CreatedTime   time.Time  `json:"createdTime"`
+	StartedTime   *time.Time `json:"startedTime,omitempty"`
+	FinishedTime  *time.Time `json:"finishedTime,omitempty"`
+}
+
+func (j *Job) Ref() ProjectRef {
+	return ProjectRef{Source: j.Source, Key: j.Project}
+}
+
+func (j *Job) Finished() bool {
+	return j.State == JobSucceeded || j.State == JobFailed || j.State == JobCancelled
+}
@@ -1,6 +1,7 @@
 package structures
 
 type ResponseProject struct {
+	Source   string        `json:"source"`
 	Projects []JiraProject `json:"projects"`
 	PageInfo PageInfo      `json:"pageInfo"`
 }
@@ -10,8 +11,3 @@ type PageInfo struct {
 	CurrentPage   int `json:"currentPage"`
 	ProjectsCount int `json:"projectsCount"`
 }
-
-type ResponseUpdate struct {
-	Project string `json:"project"`
-	Status  string `json:"status"`
-}
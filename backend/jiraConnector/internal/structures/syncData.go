@@ -0,0 +1,43 @@
+package structures
+
+import "strings"
+
+type SyncMode string
+
+const (
+	// SyncFull re-downloads every issue of the project
+	SyncFull SyncMode = "full"
+	// SyncIncremental downloads only issues updated since the last stored watermark
+	SyncIncremental SyncMode = "incremental"
+	// SyncRetransform rebuilds the project from archived issues without contacting Jira
+	SyncRetransform SyncMode = "retransform"
+)
+
+// ArchivedProject is the raw data of the project kept by the archive: the project,
+// custom fields mapping used by the last sync and the latest version of every issue
+type ArchivedProject struct {
+	Project JiraProject       `json:"project"`
+	Fields  map[string]string `json:"fields"`
+	Issues  []JiraIssue       `json:"-"`
+}
+
+// ProgressFunc reports how many of the total issues are already downloaded
+type ProgressFunc func(fetched, total int)
+
+// ProjectRef identifies the project among several Jira instances
+type ProjectRef struct {
+	Source string `json:"source"`
+	Key    string `json:"key"`
+}
+
+func (r ProjectRef) String() string {
+	return r.Source + "/" + r.Key
+}
+
+// ParseProjectRef reads "source/KEY", project without source belongs to defaultSource
+func ParseProjectRef(ref, defaultSource string) ProjectRef {
+	if source, key, ok := strings.Cut(ref, "/"); ok {
+		return ProjectRef{Source: source, Key: key}
+	}
+	return ProjectRef{Source: defaultSource, Key: ref}
+}
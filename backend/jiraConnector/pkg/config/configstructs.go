@@ -1,29 +1,112 @@
 package config
 
+import "time"
+
+// DBConfig DisableBulkWrite saves issues row by row instead of COPY into staging tables.
+// CommitChunk is the number of issues committed at once, 0 makes the project sync atomic
 type DBConfig struct {
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	Name     string `yaml:"name"`
+	Host             string `yaml:"host"`
+	Port             string `yaml:"port"`
+	User             string `yaml:"user"`
+	Password         string `yaml:"password"`
+	Name             string `yaml:"name"`
+	DisableBulkWrite bool   `yaml:"disable_bulk_write"`
+	CommitChunk      int    `yaml:"commit_chunk"`
 }
 
+// JiraConfig sleeps between retries are in milliseconds. MaxRetries which isn't set
+// (or is negative) means the default number of retries, 0 turns retries off.
+// RateLimit is a limit of requests per second shared by all threads, 0 means no limit.
+// ApiVersion 3 is Jira Cloud REST api with ADF descriptions and token based search.
+// CustomFields maps ids of custom fields (customfield_10016) to logical names
+// (story_points, sprint, epic_link, team or any other). Well-known fields which
+// aren't mapped are found by /field unless DisableFieldDiscovery is set.
+// Scrum boards and sprints are loaded from the agile api unless DisableAgile is set
 type JiraConfig struct {
-	Url           string `yaml:"url"`
-	ThreadCount   int    `yaml:"thread_count"`
-	IssueInOneReq int    `yaml:"issue_in_one_request"`
-	MinSleep      int    `yaml:"min_sleep"`
-	MaxSleep      int    `yaml:"max_sleep"`
+	Url           string  `yaml:"url"`
+	ApiVersion    string  `yaml:"api_version" env:"JIRA_API_VERSION" env-default:"2"`
+	ThreadCount   int     `yaml:"thread_count"`
+	IssueInOneReq int     `yaml:"issue_in_one_request"`
+	MinSleep      int     `yaml:"min_sleep"`
+	MaxSleep      int     `yaml:"max_sleep"`
+	MaxRetries    *int    `yaml:"max_retries"`
+	RateLimit     float64 `yaml:"rate_limit"`
+	RateBurst     int     `yaml:"rate_burst" env-default:"1"`
+
+	CustomFields          map[string]string `yaml:"custom_fields"`
+	DisableFieldDiscovery bool              `yaml:"disable_field_discovery"`
+	DisableAgile          bool              `yaml:"disable_agile"`
+
+	Auth JiraAuthConfig `yaml:"auth"`
+}
+
+// JiraAuthConfig Type is one of none (default), basic, bearer, oauth1, oauth2.
+// Every secret can be set in the config, in the env or read from the *_file
+type JiraAuthConfig struct {
+	Type string `yaml:"type" env:"JIRA_AUTH_TYPE"`
+
+	// basic - user email with api token (Jira Cloud) or login with password,
+	// bearer - personal access token (Jira Data Center)
+	User      string `yaml:"user" env:"JIRA_USER"`
+	Token     string `yaml:"token" env:"JIRA_TOKEN"`
+	TokenFile string `yaml:"token_file" env:"JIRA_TOKEN_FILE"`
+
+	// oauth1 - application link with RSA-SHA1 signature
+	ConsumerKey     string `yaml:"consumer_key" env:"JIRA_CONSUMER_KEY"`
+	PrivateKey      string `yaml:"private_key" env:"JIRA_PRIVATE_KEY"`
+	PrivateKeyFile  string `yaml:"private_key_file" env:"JIRA_PRIVATE_KEY_FILE"`
+	AccessToken     string `yaml:"access_token" env:"JIRA_ACCESS_TOKEN"`
+	AccessTokenFile string `yaml:"access_token_file" env:"JIRA_ACCESS_TOKEN_FILE"`
+
+	// oauth2 - refresh token grant if refresh token is set, client credentials otherwise
+	ClientId         string   `yaml:"client_id" env:"JIRA_CLIENT_ID"`
+	ClientSecret     string   `yaml:"client_secret" env:"JIRA_CLIENT_SECRET"`
+	ClientSecretFile string   `yaml:"client_secret_file" env:"JIRA_CLIENT_SECRET_FILE"`
+	RefreshToken     string   `yaml:"refresh_token" env:"JIRA_REFRESH_TOKEN"`
+	RefreshTokenFile string   `yaml:"refresh_token_file" env:"JIRA_REFRESH_TOKEN_FILE"`
+	TokenUrl         string   `yaml:"token_url" env-default:"https://auth.atlassian.com/oauth/token"`
+	Scopes           []string `yaml:"scopes"`
+}
+
+type JobsConfig struct {
+	Workers   int `yaml:"workers" env-default:"1"`
+	QueueSize int `yaml:"queue_size" env-default:"100"`
+}
+
+// SchedulerConfig expressions are cron specs ("0 3 * * *") or intervals ("@every 6h").
+// Projects have their own schedule instead of the global one, they are set
+// as "KEY" for the default source or "source/KEY".
+type SchedulerConfig struct {
+	Schedule string            `yaml:"schedule"`
+	Mode     string            `yaml:"mode" env-default:"incremental"`
+	Projects map[string]string `yaml:"projects"`
 }
 
 type ServerConfig struct {
 	Port string `yaml:"port"`
+	// requests and sync jobs which aren't finished in time are cancelled on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"30s"`
+}
+
+// ArchiveConfig Storage keeps raw issues of every sync in postgres or in the local
+// directory Dir, so the normalized tables can be rebuilt by retransform.
+// Empty Storage turns the archive off
+type ArchiveConfig struct {
+	Storage string `yaml:"storage" env:"ARCHIVE_STORAGE"`
+	Dir     string `yaml:"dir" env:"ARCHIVE_DIR"`
 }
 
+// Config JiraSources are named Jira instances analyzed side by side,
+// see Sources for how they are combined with the jira-connector section
 type Config struct {
-	Env       string       `yaml:"env"`
-	LogFile   string       `yaml:"log_file"`
-	DBCfg     DBConfig     `yaml:"database"`
-	JiraCfg   JiraConfig   `yaml:"jira-connector"`
-	ServerCfg ServerConfig `yaml:"server"`
+	Env           string                `yaml:"env"`
+	LogFile       string                `yaml:"log_file"`
+	DBCfg         DBConfig              `yaml:"database"`
+	JiraCfg       JiraConfig            `yaml:"jira-connector"`
+	JiraSources   map[string]JiraConfig `yaml:"jira-sources"`
+	DefaultSource string                `yaml:"default_source"`
+	JobsCfg       JobsConfig            `yaml:"jobs"`
+	SchedCfg      SchedulerConfig       `yaml:"scheduler"`
+	ServerCfg     ServerConfig          `yaml:"server"`
+	ArchiveCfg    ArchiveConfig         `yaml:"archive"`
 }
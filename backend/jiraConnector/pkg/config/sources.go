@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultSource is the name of the Jira instance from the jira-connector section
+const DefaultSource = "default"
+
+// Sources returns Jira instances by name. Without jira-sources the only source is
+// the jira-connector section. Request settings which aren't set in a source
+// (threads, page size, sleeps, retries, rate limit) are taken from jira-connector.
+// Settings of the Jira instance itself (api version, custom fields, field discovery,
+// agile and auth) aren't inherited, api version of a source is 2 unless it is set
+func (c *Config) Sources() (map[string]JiraConfig, error) {
+	if len(c.JiraSources) == 0 {
+		return map[string]JiraConfig{DefaultSource: c.JiraCfg}, nil
+	}
+
+	sources := make(map[string]JiraConfig, len(c.JiraSources))
+	for name, source := range c.JiraSources {
+		if name == "" || strings.ContainsAny(name, "/ ") {
+			return nil, fmt.Errorf("invalid jira source name %q: it mustn't be empty or contain '/' and spaces", name)
+		}
+		if source.Url == "" {
+			return nil, fmt.Errorf("jira source %s: url isn't set", name)
+		}
+		sources[name] = source.inherit(&c.JiraCfg)
+	}
+
+	if c.DefaultSource != "" {
+		if _, ok := sources[c.DefaultSource]; !ok {
+			return nil, fmt.Errorf("default source %s isn't in jira-sources", c.DefaultSource)
+		}
+	}
+
+	return sources, nil
+}
+
+// DefaultSourceName is used by requests without source. It is empty if there
+// are several sources and default_source isn't set, then source is required
+func (c *Config) DefaultSourceName() string {
+	if c.DefaultSource != "" {
+		return c.DefaultSource
+	}
+	if len(c.JiraSources) == 0 {
+		return DefaultSource
+	}
+	if len(c.JiraSources) == 1 {
+		for name := range c.JiraSources {
+			return name
+		}
+	}
+	return ""
+}
+
+func (source JiraConfig) inherit(base *JiraConfig) JiraConfig {
+	if source.ThreadCount == 0 {
+		source.ThreadCount = base.ThreadCount
+	}
+	if source.IssueInOneReq == 0 {
+		source.IssueInOneReq = base.IssueInOneReq
+	}
+	if source.MinSleep == 0 {
+		source.MinSleep = base.MinSleep
+	}
+	if source.MaxSleep == 0 {
+		source.MaxSleep = base.MaxSleep
+	}
+	if source.MaxRetries == nil {
+		source.MaxRetries = base.MaxRetries
+	}
+	if source.RateLimit == 0 {
+		source.RateLimit = base.RateLimit
+	}
+	if source.RateBurst == 0 {
+		source.RateBurst = base.RateBurst
+	}
+	// defaults of the nested sections aren't filled for map values
+	if source.Auth.TokenUrl == "" {
+		source.Auth.TokenUrl = base.Auth.TokenUrl
+	}
+	return source
+}
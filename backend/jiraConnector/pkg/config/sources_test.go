@@ -0,0 +1,111 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSources_WithoutJiraSources(t *testing.T) {
+	cfg := Config{JiraCfg: JiraConfig{Url: "https://jira.example.com", ThreadCount: 4}}
+
+	sources, err := cfg.Sources()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]JiraConfig{DefaultSource: cfg.JiraCfg}, sources)
+	assert.Equal(t, DefaultSource, cfg.DefaultSourceName())
+}
+
+func TestSources_Inherit(t *testing.T) {
+	retries := func(n int) *int { return &n }
+	cfg := Config{
+		JiraCfg: JiraConfig{
+			ThreadCount:   4,
+			IssueInOneReq: 50,
+			MinSleep:      100,
+			MaxSleep:      1000,
+			MaxRetries:    retries(3),
+			RateLimit:     10,
+			RateBurst:     5,
+			ApiVersion:    "3",
+			CustomFields:  map[string]string{"customfield_10016": "story_points"},
+			DisableAgile:  true,
+			Auth:          JiraAuthConfig{Type: "basic", User: "user@example.com", TokenUrl: "https://auth.atlassian.com/oauth/token"},
+		},
+		JiraSources: map[string]JiraConfig{
+			"server": {Url: "https://jira.example.com", ThreadCount: 2, MaxRetries: retries(0), RateLimit: 2, RateBurst: 1},
+			"cloud":  {Url: "https://example.atlassian.net", ApiVersion: "3"},
+		},
+		DefaultSource: "server",
+	}
+
+	sources, err := cfg.Sources()
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+
+	assert.Equal(t, 2, sources["server"].ThreadCount)
+	assert.Equal(t, 4, sources["cloud"].ThreadCount)
+	assert.Equal(t, 50, sources["cloud"].IssueInOneReq)
+	assert.Equal(t, 3, *sources["cloud"].MaxRetries)
+	// retries turned off in the source aren't inherited
+	assert.Equal(t, 0, *sources["server"].MaxRetries)
+	assert.Equal(t, 10.0, sources["cloud"].RateLimit)
+	assert.Equal(t, 5, sources["cloud"].RateBurst)
+	assert.Equal(t, 2.0, sources["server"].RateLimit)
+	assert.Equal(t, 1, sources["server"].RateBurst)
+	assert.Equal(t, "3", sources["cloud"].ApiVersion)
+	assert.Equal(t, cfg.JiraCfg.Auth.TokenUrl, sources["cloud"].Auth.TokenUrl)
+
+	// settings of the Jira instance are per source
+	assert.Empty(t, sources["server"].ApiVersion)
+	assert.Empty(t, sources["server"].CustomFields)
+	assert.False(t, sources["server"].DisableAgile)
+	assert.Empty(t, sources["server"].Auth.Type)
+	assert.Empty(t, sources["server"].Auth.User)
+	assert.Equal(t, "server", cfg.DefaultSourceName())
+}
+
+func TestSources_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{
+			name: "name with slash",
+			cfg:  Config{JiraSources: map[string]JiraConfig{"a/b": {Url: "https://jira.example.com"}}},
+		},
+		{
+			name: "empty name",
+			cfg:  Config{JiraSources: map[string]JiraConfig{"": {Url: "https://jira.example.com"}}},
+		},
+		{
+			name: "without url",
+			cfg:  Config{JiraSources: map[string]JiraConfig{"server": {}}},
+		},
+		{
+			name: "unknown default source",
+			cfg: Config{
+				JiraSources:   map[string]JiraConfig{"server": {Url: "https://jira.example.com"}},
+				DefaultSource: "cloud",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.cfg.Sources()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDefaultSourceName(t *testing.T) {
+	single := Config{JiraSources: map[string]JiraConfig{"server": {Url: "https://jira.example.com"}}}
+	assert.Equal(t, "server", single.DefaultSourceName())
+
+	several := Config{JiraSources: map[string]JiraConfig{
+		"server": {Url: "https://jira.example.com"},
+		"cloud":  {Url: "https://example.atlassian.net"},
+	}}
+	assert.Equal(t, "", several.DefaultSourceName())
+}
@@ -0,0 +1,88 @@
+//go:build integration
+// +build integration
+
+package dbintegrations
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	archiveErr "github.com/jiraconnector/internal/archive/errors"
+	datatransformer "github.com/jiraconnector/internal/dataTransformer"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	resetTestDB(t)
+
+	project := structures.DBProject{Source: "default", Title: "Archived", Key: "PRJ"}
+	ref := structures.ProjectRef{Source: "default", Key: "PRJ"}
+	jiraPrj := &structures.JiraProject{Id: "1", Key: "PRJ", Name: "Archived"}
+	fields := map[string]string{"customfield_10016": "story_points"}
+
+	_, err := DB.LoadArchive(ctx, ref)
+	assert.ErrorIs(t, err, archiveErr.ErrNotArchived)
+
+	// archived issues are saved after the project
+	require.NoError(t, DB.PushIssues(ctx, &project, generateIssues(1)))
+
+	// issues are archived as Jira returned them
+	issues := []structures.JiraIssue{
+		rawIssue(t, `{"id": "10001", "key": "PRJ-1", "fields": {"summary": "first", "customfield_10016": 5}}`),
+		rawIssue(t, `{"id": "10002", "key": "PRJ-2", "fields": {"summary": "second",
+			"creator": {"accountId": "account-1", "displayName": "User 1"},
+			"created": "2024-01-01T10:00:00.000+0000", "updated": "2024-01-02T10:00:00.000+0000"}}`),
+	}
+	require.NoError(t, DB.ArchiveIssues(ctx, ref, jiraPrj, fields, issues))
+
+	// the next sync replaces the archived version
+	updated := rawIssue(t, `{"id": "10001", "key": "PRJ-1", "fields": {"summary": "updated", "customfield_10016": 5},
+		"renderedFields": {"summary": "updated"}}`)
+	require.NoError(t, DB.ArchiveIssues(ctx, ref, jiraPrj, fields, []structures.JiraIssue{updated}))
+
+	exists, err := DB.HasArchive(ctx, ref)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	projects, err := DB.ArchivedProjects(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []structures.ProjectRef{ref}, projects)
+
+	archived, err := DB.LoadArchive(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, *jiraPrj, archived.Project)
+	assert.Equal(t, fields, archived.Fields)
+	require.Len(t, archived.Issues, 2)
+	assert.Equal(t, "updated", archived.Issues[0].Fields.Summary)
+	assert.Equal(t, updated.Raw, archived.Issues[0].Raw)
+	assert.JSONEq(t, `5`, string(archived.Issues[0].Fields.Custom["customfield_10016"]))
+
+	require.NoError(t, DB.PruneArchive(ctx, ref, []string{"PRJ-2"}))
+	archived, err = DB.LoadArchive(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, archived.Issues, 1)
+	assert.Equal(t, "PRJ-2", archived.Issues[0].Key)
+
+	// the archive is rebuilt into the same issues
+	dt := datatransformer.NewDataTransformer("")
+	var data []datatransformer.DataTransformer
+	for i := range archived.Issues {
+		data = append(data, *dt.TransformToDbIssueSet(&archived.Project, &archived.Issues[i]))
+	}
+	require.NoError(t, DB.PushIssues(ctx, &project, data))
+
+	var summary string
+	require.NoError(t, DB.Db().QueryRow("SELECT summary FROM issue WHERE key = 'PRJ-2'").Scan(&summary))
+	assert.Equal(t, "second", summary)
+}
+
+func rawIssue(t *testing.T, raw string) structures.JiraIssue {
+	var issue structures.JiraIssue
+	require.NoError(t, json.Unmarshal([]byte(raw), &issue))
+	issue.Raw = json.RawMessage(raw)
+	return issue
+}
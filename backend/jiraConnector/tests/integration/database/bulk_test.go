@@ -0,0 +1,204 @@
+//go:build integration
+// +build integration
+
+package dbintegrations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	datatransformer "github.com/jiraconnector/internal/dataTransformer"
+	dbpusher "github.com/jiraconnector/internal/dbPusher"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotQueries read saved data without generated ids, so results of both write paths can be compared
+var snapshotQueries = map[string]string{
+	"issue": `
+   SELECT i.key, a.accountId, r.accountId, s.accountId, i.summary, i.type, i.typeId, i.status, i.createdTime, i.timeSpent
+   FROM issue i JOIN author a ON a.id = i.authorId
+   LEFT JOIN author r ON r.id = i.reporterId LEFT JOIN author s ON s.id = i.assigneeId`,
+	"author": "SELECT accountId, name, email, active FROM author",
+	"status": `
+   SELECT i.key, c.historyId, a.accountId, c.changeTime, c.fromStatus, c.toStatus
+   FROM statuschanges c JOIN issue i ON i.id = c.issueId JOIN author a ON a.id = c.authorId`,
+	"field": `
+   SELECT i.key, c.historyId, c.itemIndex, a.accountId, c.field, c.toString
+   FROM issuefieldchanges c JOIN issue i ON i.id = c.issueId JOIN author a ON a.id = c.authorId`,
+	"custom":    "SELECT i.key, c.name, c.valueIndex, c.value, c.numberValue FROM issuecustomfield c JOIN issue i ON i.id = c.issueId",
+	"link":      "SELECT i.key, l.linkType, l.direction, l.linkedKey FROM issuelink l JOIN issue i ON i.id = l.issueId",
+	"worklog":   "SELECT i.key, w.worklogId, a.accountId, w.timeSpentSeconds FROM worklog w JOIN issue i ON i.id = w.issueId JOIN author a ON a.id = w.authorId",
+	"comment":   "SELECT i.key, c.commentId, a.accountId, c.body FROM issuecomment c JOIN issue i ON i.id = c.issueId JOIN author a ON a.id = c.authorId",
+	"label":     "SELECT i.key, l.name FROM issuelabel il JOIN issue i ON i.id = il.issueId JOIN label l ON l.id = il.labelId",
+	"component": "SELECT i.key, c.name FROM issuecomponent ic JOIN issue i ON i.id = ic.issueId JOIN component c ON c.id = ic.componentId",
+	"version":   "SELECT i.key, v.name, v.released, v.releaseDate FROM issuefixversion iv JOIN issue i ON i.id = iv.issueId JOIN version v ON v.id = iv.versionId",
+}
+
+func snapshot(t *testing.T) map[string]string {
+	result := make(map[string]string, len(snapshotQueries))
+	for name, query := range snapshotQueries {
+		var rows string
+		err := DB.Db().QueryRow(
+			fmt.Sprintf("SELECT COALESCE(string_agg(t::text, E'\\n' ORDER BY t::text), '') FROM (%s) t", query)).Scan(&rows)
+		require.NoError(t, err, name)
+		result[name] = rows
+	}
+	return result
+}
+
+// generateIssues builds count issues with every kind of nested data, users are shared by issues
+func generateIssues(count int) []datatransformer.DataTransformer {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	users := make([]structures.DBAuthor, 20)
+	for i := range users {
+		users[i] = structures.DBAuthor{
+			AccountId: fmt.Sprintf("account-%d", i),
+			Name:      fmt.Sprintf("User %d", i),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Active:    i%5 != 0,
+		}
+	}
+	user := func(i int) structures.DBAuthor { return users[i%len(users)] }
+	releaseDate := base.AddDate(0, 2, 0)
+
+	issues := make([]datatransformer.DataTransformer, 0, count)
+	for i := 0; i < count; i++ {
+		key := fmt.Sprintf("PRJ-%d", i+1)
+		created := base.Add(time.Duration(i) * time.Hour)
+		reporter := user(i + 1)
+		issue := datatransformer.DataTransformer{
+			Issue: structures.DBIssue{
+				JiraId:      fmt.Sprint(10001 + i),
+				Key:         key,
+				Summary:     "Issue " + key,
+				Description: "Description of " + key,
+				Type:        "Task",
+				TypeId:      "10002",
+				Priority:    "Major",
+				Status:      "Closed",
+				CreatedTime: created,
+				ClosedTime:  created.Add(48 * time.Hour),
+				UpdatedTime: created.Add(48 * time.Hour),
+				TimeSpent:   7200,
+			},
+			Author:   user(i),
+			Reporter: &reporter,
+			Labels:   []string{"backend", fmt.Sprintf("team-%d", i%3)},
+			Components: []structures.DBComponent{
+				{Name: fmt.Sprintf("component-%d", i%4)},
+			},
+			FixVersions: []structures.DBVersion{
+				{Name: fmt.Sprintf("1.%d", i%5), Released: true, ReleaseDate: &releaseDate},
+			},
+		}
+		if i%2 == 0 {
+			assignee := user(i + 2)
+			issue.Assignee = &assignee
+		}
+
+		for j, status := range []string{"Open", "In Progress", "Review", "Closed"}[1:] {
+			issue.StatusChanges = append(issue.StatusChanges, structures.DBStatusTransition{
+				HistoryId:  fmt.Sprintf("%d-%d", i, j),
+				Author:     user(i + j),
+				ChangeTime: created.Add(time.Duration(j+1) * time.Hour),
+				FromStatus: []string{"Open", "In Progress", "Review"}[j],
+				ToStatus:   status,
+			})
+			issue.FieldChanges = append(issue.FieldChanges,
+				structures.DBFieldChange{HistoryId: fmt.Sprintf("%d-%d", i, j), ItemIndex: 0, Author: user(i + j),
+					ChangeTime: created.Add(time.Duration(j+1) * time.Hour), Field: "status", ToString: status},
+				structures.DBFieldChange{HistoryId: fmt.Sprintf("%d-%d", i, j), ItemIndex: 1, Author: user(i + j),
+					ChangeTime: created.Add(time.Duration(j+1) * time.Hour), Field: "assignee", ToString: user(i + j).Name})
+		}
+
+		points := float64(i%8 + 1)
+		issue.CustomFields = []structures.DBCustomField{
+			{Name: "story_points", FieldId: "customfield_10016", Value: fmt.Sprint(points), NumberValue: &points},
+			{Name: "team", FieldId: "customfield_10020", Value: fmt.Sprintf("team-%d", i%3)},
+		}
+		if i > 0 {
+			issue.Links = []structures.DBIssueLink{
+				{LinkType: "Blocks", Direction: structures.LinkOutward, LinkedKey: fmt.Sprintf("PRJ-%d", i), Description: "blocks"},
+			}
+		}
+		for j := 0; j < 2; j++ {
+			issue.Worklogs = append(issue.Worklogs, structures.DBWorklog{
+				WorklogId: fmt.Sprintf("%d-%d", i, j), Author: user(i + j), Started: created.Add(time.Hour), Seconds: 3600,
+			})
+			issue.Comments = append(issue.Comments, structures.DBComment{
+				CommentId: fmt.Sprintf("%d-%d", i, j), Author: user(i + j + 3),
+				CreatedTime: created.Add(time.Hour), UpdatedTime: created.Add(time.Hour), Body: "comment",
+			})
+		}
+
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func TestPushIssues_BulkMatchesRows(t *testing.T) {
+	ctx := context.Background()
+	project := structures.DBProject{Source: "default", Title: "Bulk", Key: "PRJ"}
+
+	first := generateIssues(50)
+	// the second sync changes issues, removes nested data and adds new issues
+	second := generateIssues(60)
+	for i := range second[:10] {
+		second[i].Issue.Status = "Reopened"
+		second[i].Assignee = nil
+		second[i].Labels = nil
+		second[i].Worklogs = second[i].Worklogs[:1]
+	}
+
+	push := func(db *dbpusher.DbPusher) map[string]string {
+		resetTestDB(t)
+		require.NoError(t, db.PushIssues(ctx, &project, first))
+		require.NoError(t, db.PushIssues(ctx, &project, second))
+		return snapshot(t)
+	}
+
+	rows := push(RowDB)
+	bulk := push(DB)
+
+	for name := range snapshotQueries {
+		assert.NotEmpty(t, rows[name], name)
+		assert.Equal(t, rows[name], bulk[name], name)
+	}
+}
+
+// BenchmarkPushIssues compares the row by row path with the bulk one, every
+// iteration after the first updates already saved issues like a repeated sync:
+//
+//	go test -tags integration -run ^$ -bench PushIssues ./tests/integration/database
+func BenchmarkPushIssues(b *testing.B) {
+	ctx := context.Background()
+	project := structures.DBProject{Source: "default", Title: "Bench", Key: "PRJ"}
+
+	for _, count := range []int{100, 1000} {
+		issues := generateIssues(count)
+		for _, path := range []struct {
+			name string
+			db   *dbpusher.DbPusher
+		}{
+			{name: "row", db: RowDB},
+			{name: "bulk", db: DB},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", path.name, count), func(b *testing.B) {
+				_, err := DB.Db().Exec("TRUNCATE projects, author, label RESTART IDENTITY CASCADE")
+				require.NoError(b, err)
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if err := path.db.PushIssues(ctx, &project, issues); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(count*b.N)/b.Elapsed().Seconds(), "issues/s")
+			})
+		}
+	}
+}
@@ -35,11 +35,11 @@ func TestLoadNewProject(t *testing.T) {
 	assert.Equal(t, 0, count, "Project should not exist before test")
 
 	// 2. Загружаем проект
-	_, err = testDB.PushProject(&testProject)
+	_, err = testDB.PushProject(context.Background(), &testProject)
 	assert.NoError(t, err)
 
 	// 3. Загружаем задачи
-	err = testDB.PushIssues(&testProject, testIssues)
+	err = testDB.PushIssues(context.Background(), &testProject, testIssues)
 	assert.NoError(t, err)
 
 	// 4. Проверяем, что проект и задачи сохранились
@@ -67,10 +67,10 @@ func TestUpdateExistingProject(t *testing.T) {
 
 	testIssue, testProject := setupTestData()
 
-	_, err = testDB.PushProject(&testProject)
+	_, err = testDB.PushProject(context.Background(), &testProject)
 	assert.NoError(t, err)
 
-	err = testDB.PushIssues(&testProject, testIssue)
+	err = testDB.PushIssues(context.Background(), &testProject, testIssue)
 	assert.NoError(t, err)
 
 	// 2. Подготавливаем обновленные данные
@@ -78,7 +78,7 @@ func TestUpdateExistingProject(t *testing.T) {
 
 	// 3. Обновляем проект (симулируем вызов /updateProject)
 	// обновляем задачи
-	err = testDB.PushIssues(&testProject, updatedIssues)
+	err = testDB.PushIssues(context.Background(), &testProject, updatedIssues)
 	assert.NoError(t, err)
 
 	// 4. Проверяем результаты
@@ -101,63 +101,90 @@ func TestProjectEdgeCases(t *testing.T) {
 	resetTestDB(t)
 
 	t.Run("DuplicateProject", func(t *testing.T) {
-		project := structures.DBProject{Title: "Test"}
+		project := structures.DBProject{Source: "default", Title: "Test", Key: "TST"}
 
 		// Первое сохранение должно пройти успешно
-		_, err := DB.PushProject(&project)
+		id, err := DB.PushProject(context.Background(), &project)
 		assert.NoError(t, err)
 
-		_, err = DB.PushProject(&project)
-		assert.Error(t, err, "Should reject duplicate project title")
+		// Повторное сохранение обновляет тот же проект
+		project.Title = "Renamed"
+		againId, err := DB.PushProject(context.Background(), &project)
+		assert.NoError(t, err)
+		assert.Equal(t, id, againId)
+	})
+
+	t.Run("SameKeyOtherSource", func(t *testing.T) {
+		first := structures.DBProject{Source: "default", Title: "Test", Key: "SRC"}
+		second := structures.DBProject{Source: "cloud", Title: "Test", Key: "SRC"}
+
+		firstId, err := DB.PushProject(context.Background(), &first)
+		assert.NoError(t, err)
+
+		secondId, err := DB.PushProject(context.Background(), &second)
+		assert.NoError(t, err)
+		assert.NotEqual(t, firstId, secondId)
 	})
 }
 
 func setUpdTestData() []datatransformer.DataTransformer {
 	now := time.Now()
-	testStatusChange1 := map[string]structures.DBStatusChanges{
-		"user1": {
+	testStatusChange1 := []structures.DBStatusTransition{
+		{
+			HistoryId:  "11",
 			IssueId:    1,
 			AuthorId:   1,
+			Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
 			ChangeTime: now.Add(60 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "approved",
 		},
-		"user2": {
+		{
+			HistoryId:  "12",
 			IssueId:    1,
 			AuthorId:   2,
+			Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			ChangeTime: now.Add(120 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "process",
 		},
 	}
-	testStatusChange2 := map[string]structures.DBStatusChanges{
-		"user1": {
+	testStatusChange2 := []structures.DBStatusTransition{
+		{
+			HistoryId:  "21",
 			IssueId:    2,
 			AuthorId:   1,
+			Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
 			ChangeTime: now.Add(60 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "approved",
 		},
-		"user2": {
+		{
+			HistoryId:  "22",
 			IssueId:    2,
 			AuthorId:   2,
+			Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			ChangeTime: now.Add(120 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "process",
 		},
 	}
 
-	testStatusChange3 := map[string]structures.DBStatusChanges{
-		"user1": {
+	testStatusChange3 := []structures.DBStatusTransition{
+		{
+			HistoryId:  "31",
 			IssueId:    3,
 			AuthorId:   1,
+			Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
 			ChangeTime: now.Add(160 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "approved",
 		},
-		"user2": {
+		{
+			HistoryId:  "32",
 			IssueId:    3,
 			AuthorId:   2,
+			Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			ChangeTime: now.Add(170 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "process",
@@ -174,8 +201,8 @@ func setUpdTestData() []datatransformer.DataTransformer {
 				Status:      "Close",
 				CreatedTime: now,
 			},
-			Author:        structures.DBAuthor{Name: "user1"},
-			Assignee:      structures.DBAuthor{Name: "user2"},
+			Author:        structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			Assignee:      &structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			StatusChanges: testStatusChange1,
 		},
 		{
@@ -188,8 +215,8 @@ func setUpdTestData() []datatransformer.DataTransformer {
 				Status:      "Open",
 				CreatedTime: now,
 			},
-			Author:        structures.DBAuthor{Name: "user1"},
-			Assignee:      structures.DBAuthor{Name: "user2"},
+			Author:        structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			Assignee:      &structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			StatusChanges: testStatusChange2,
 		},
 		{
@@ -202,8 +229,8 @@ func setUpdTestData() []datatransformer.DataTransformer {
 				Status:      "Open",
 				CreatedTime: now,
 			},
-			Author:        structures.DBAuthor{Name: "user1"},
-			Assignee:      structures.DBAuthor{Name: "user2"},
+			Author:        structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			Assignee:      &structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			StatusChanges: testStatusChange3,
 		},
 	}
@@ -213,33 +240,41 @@ func setUpdTestData() []datatransformer.DataTransformer {
 func setupTestData() ([]datatransformer.DataTransformer, structures.DBProject) {
 	// Подготовка тестовых данных
 	now := time.Now()
-	testStatusChange1 := map[string]structures.DBStatusChanges{
-		"user1": {
+	testStatusChange1 := []structures.DBStatusTransition{
+		{
+			HistoryId:  "11",
 			IssueId:    1,
 			AuthorId:   1,
+			Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
 			ChangeTime: now.Add(60 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "approved",
 		},
-		"user2": {
+		{
+			HistoryId:  "12",
 			IssueId:    1,
 			AuthorId:   2,
+			Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			ChangeTime: now.Add(120 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "process",
 		},
 	}
-	testStatusChange2 := map[string]structures.DBStatusChanges{
-		"user1": {
+	testStatusChange2 := []structures.DBStatusTransition{
+		{
+			HistoryId:  "21",
 			IssueId:    2,
 			AuthorId:   1,
+			Author:     structures.DBAuthor{AccountId: "user1", Name: "user1"},
 			ChangeTime: now.Add(60 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "approved",
 		},
-		"user2": {
+		{
+			HistoryId:  "22",
 			IssueId:    2,
 			AuthorId:   2,
+			Author:     structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			ChangeTime: now.Add(120 * time.Minute),
 			FromStatus: "process",
 			ToStatus:   "process",
@@ -256,8 +291,8 @@ func setupTestData() ([]datatransformer.DataTransformer, structures.DBProject) {
 				Status:      "Open",
 				CreatedTime: now,
 			},
-			Author:        structures.DBAuthor{Name: "user1"},
-			Assignee:      structures.DBAuthor{Name: "user2"},
+			Author:        structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			Assignee:      &structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			StatusChanges: testStatusChange1,
 		},
 		{
@@ -270,8 +305,8 @@ func setupTestData() ([]datatransformer.DataTransformer, structures.DBProject) {
 				Status:      "Open",
 				CreatedTime: now,
 			},
-			Author:        structures.DBAuthor{Name: "user1"},
-			Assignee:      structures.DBAuthor{Name: "user2"},
+			Author:        structures.DBAuthor{AccountId: "user1", Name: "user1"},
+			Assignee:      &structures.DBAuthor{AccountId: "user2", Name: "user2"},
 			StatusChanges: testStatusChange2,
 		},
 	}
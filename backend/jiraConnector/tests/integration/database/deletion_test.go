@@ -0,0 +1,128 @@
+//go:build integration
+// +build integration
+
+package dbintegrations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	datatransformer "github.com/jiraconnector/internal/dataTransformer"
+	dbpusher "github.com/jiraconnector/internal/dbPusher"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deletedKeys returns keys of the tombstoned issues of the project
+func deletedKeys(t *testing.T, project string) []string {
+	rows, err := DB.Db().Query(`
+   SELECT i.key FROM issue i JOIN projects p ON p.id = i.projectId
+   WHERE p.key = $1 AND i.deletedTime IS NOT NULL
+   ORDER BY i.id`, project)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		require.NoError(t, rows.Scan(&key))
+		keys = append(keys, key)
+	}
+	require.NoError(t, rows.Err())
+	return keys
+}
+
+func TestMarkDeletedIssues(t *testing.T) {
+	ctx := context.Background()
+	project := structures.DBProject{Source: "default", Title: "Deleted", Key: "PRJ"}
+	ref := structures.ProjectRef{Source: "default", Key: "PRJ"}
+
+	for name, db := range map[string]*dbpusher.DbPusher{"bulk": DB, "row": RowDB} {
+		t.Run(name, func(t *testing.T) {
+			resetTestDB(t)
+
+			issues := generateIssues(5)
+			require.NoError(t, db.PushIssues(ctx, &project, issues))
+
+			// PRJ-2 and PRJ-4 are gone from Jira
+			deleted, err := db.MarkDeletedIssues(ctx, ref, []string{"PRJ-1", "PRJ-3", "PRJ-5"})
+			require.NoError(t, err)
+			assert.Equal(t, 2, deleted)
+			assert.Equal(t, []string{"PRJ-2", "PRJ-4"}, deletedKeys(t, "PRJ"))
+
+			// already tombstoned issues keep their deletion time
+			deleted, err = db.MarkDeletedIssues(ctx, ref, []string{"PRJ-1", "PRJ-3", "PRJ-5"})
+			require.NoError(t, err)
+			assert.Zero(t, deleted)
+
+			// the history of a tombstoned issue is kept
+			var comments int
+			require.NoError(t, DB.Db().QueryRow(`
+   SELECT COUNT(*) FROM issuecomment c JOIN issue i ON i.id = c.issueId WHERE i.key = 'PRJ-2'`).Scan(&comments))
+			assert.Equal(t, len(issues[1].Comments), comments)
+
+			// an issue found in Jira again is restored
+			require.NoError(t, db.PushIssues(ctx, &project, issues[1:2]))
+			assert.Equal(t, []string{"PRJ-4"}, deletedKeys(t, "PRJ"))
+		})
+	}
+}
+
+func TestPushIssues_MovedIssueIsReparented(t *testing.T) {
+	ctx := context.Background()
+	from := structures.DBProject{Source: "default", Title: "From", Key: "PRJ"}
+	to := structures.DBProject{Source: "default", Title: "To", Key: "OTH"}
+
+	for name, db := range map[string]*dbpusher.DbPusher{"bulk": DB, "row": RowDB} {
+		t.Run(name, func(t *testing.T) {
+			resetTestDB(t)
+
+			issues := generateIssues(3)
+			require.NoError(t, db.PushIssues(ctx, &from, issues))
+
+			var issueId int
+			require.NoError(t, DB.Db().QueryRow("SELECT id FROM issue WHERE key = 'PRJ-2'").Scan(&issueId))
+
+			// PRJ-2 is moved to OTH and becomes OTH-7, Jira keeps its id
+			moved := issues[1]
+			moved.Issue.Key = "OTH-7"
+			moved.Issue.UpdatedTime = issues[1].Issue.UpdatedTime.Add(time.Hour)
+			require.NoError(t, db.PushIssues(ctx, &to, []datatransformer.DataTransformer{moved}))
+
+			var key, project string
+			require.NoError(t, DB.Db().QueryRow(`
+   SELECT i.key, p.key FROM issue i JOIN projects p ON p.id = i.projectId WHERE i.id = $1`, issueId).Scan(&key, &project))
+			assert.Equal(t, "OTH-7", key)
+			assert.Equal(t, "OTH", project)
+
+			var count int
+			require.NoError(t, DB.Db().QueryRow("SELECT COUNT(*) FROM issue").Scan(&count))
+			assert.Equal(t, 3, count)
+			require.NoError(t, DB.Db().QueryRow("SELECT COUNT(*) FROM issuecomment WHERE issueId = $1", issueId).Scan(&count))
+			assert.Equal(t, len(moved.Comments), count)
+
+			// the moved issue isn't tombstoned by the next full sync of the old project
+			deleted, err := db.MarkDeletedIssues(ctx, structures.ProjectRef{Source: "default", Key: "PRJ"}, []string{"PRJ-1", "PRJ-3"})
+			require.NoError(t, err)
+			assert.Zero(t, deleted)
+
+			// ids of different Jira instances are unrelated, the issue isn't taken from them
+			other := structures.DBProject{Source: "other", Title: "Other", Key: "OTH"}
+			require.NoError(t, db.PushIssues(ctx, &other, []datatransformer.DataTransformer{moved}))
+			require.NoError(t, DB.Db().QueryRow("SELECT COUNT(*) FROM issue").Scan(&count))
+			assert.Equal(t, 4, count)
+
+			require.NoError(t, DB.Db().QueryRow("SELECT key FROM issue WHERE id = $1", issueId).Scan(&key))
+			assert.Equal(t, "OTH-7", key)
+
+			// an older payload of the issue doesn't move it back
+			require.NoError(t, db.PushIssues(ctx, &from, issues[1:2]))
+			require.NoError(t, DB.Db().QueryRow(`
+   SELECT i.key, p.key FROM issue i JOIN projects p ON p.id = i.projectId WHERE i.id = $1`, issueId).Scan(&key, &project))
+			assert.Equal(t, "OTH-7", key)
+			assert.Equal(t, "OTH", project)
+		})
+	}
+}
@@ -6,13 +6,13 @@ package dbintegrations
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 	"time"
 
 	dbpusher "github.com/jiraconnector/internal/dbPusher"
+	"github.com/jiraconnector/internal/migrations"
 	"github.com/jiraconnector/pkg/config"
 	"github.com/jiraconnector/pkg/logger"
 	"github.com/stretchr/testify/require"
@@ -22,7 +22,11 @@ import (
 
 var DB *dbpusher.DbPusher
 
-const sqlCreatePath = "initDB/create.sql"
+// RowDB writes issues row by row, it is compared with the bulk path of DB
+var RowDB *dbpusher.DbPusher
+
+// testCfg is the config of DB, tests change it to create pushers with other settings
+var testCfg config.Config
 
 func TestMain(m *testing.M) {
 	ctx := context.Background()
@@ -63,6 +67,7 @@ func TestMain(m *testing.M) {
 	}
 
 	log := logger.SetupLogger(cfg.Env, cfg.LogFile)
+	testCfg = cfg
 
 	DB, err = dbpusher.NewDbPusher(&cfg, log)
 	if err != nil {
@@ -70,17 +75,16 @@ func TestMain(m *testing.M) {
 	}
 	defer DB.Close()
 
-	_, filename, _, _ := runtime.Caller(0)
-	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filename))))
-	sqlPath := filepath.Join(projectRoot, "build", sqlCreatePath)
-	sqlBytes, err := os.ReadFile(sqlPath)
+	rowCfg := cfg
+	rowCfg.DBCfg.DisableBulkWrite = true
+	RowDB, err = dbpusher.NewDbPusher(&rowCfg, log)
 	if err != nil {
-		panic(fmt.Errorf("failed to read create.sql: %w", err))
+		panic(fmt.Errorf("failed to connect to test db: %w", err))
 	}
+	defer RowDB.Close()
 
-	_, err = DB.Db().Exec(string(sqlBytes))
-	if err != nil {
-		panic(fmt.Errorf("failed to execute schema.sql: %w", err))
+	if err := migrate(); err != nil {
+		panic(fmt.Errorf("failed to migrate test db: %w", err))
 	}
 
 	os.Exit(m.Run())
@@ -94,12 +98,13 @@ func resetTestDB(t *testing.T) {
 	require.NoError(t, err)
 
 	// Повторно применяем миграции
-	_, filename, _, _ := runtime.Caller(0)
-	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filename))))
-	sqlPath := filepath.Join(projectRoot, "build", sqlCreatePath)
-	sqlBytes, err := os.ReadFile(sqlPath)
-	require.NoError(t, err)
+	require.NoError(t, migrate())
+}
 
-	_, err = DB.Db().Exec(string(sqlBytes))
-	require.NoError(t, err)
+func migrate() error {
+	migrator, err := migrations.NewMigrator(DB.Db(), slog.Default())
+	if err != nil {
+		return err
+	}
+	return migrator.Up(context.Background())
 }
@@ -0,0 +1,171 @@
+//go:build integration
+// +build integration
+
+package dbintegrations
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	dbpusher "github.com/jiraconnector/internal/dbPusher"
+	"github.com/jiraconnector/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// injectFailure makes the database reject a comment with the body "fail",
+// so the failure happens in the middle of the batch on both write paths
+func injectFailure(t *testing.T) {
+	_, err := DB.Db().Exec(`
+   CREATE FUNCTION injected_failure() RETURNS trigger AS $$
+   BEGIN
+       RAISE EXCEPTION 'injected failure';
+   END
+   $$ LANGUAGE plpgsql;
+   CREATE TRIGGER injected_failure BEFORE INSERT ON issuecomment
+   FOR EACH ROW WHEN (NEW.body = 'fail') EXECUTE FUNCTION injected_failure();
+   `)
+	require.NoError(t, err)
+}
+
+func countRows(t *testing.T) map[string]int {
+	counts := make(map[string]int)
+	for _, table := range []string{"projects", "author", "issue", "statuschanges", "issuefieldchanges",
+		"issuecustomfield", "issuelink", "worklog", "issuecomment", "label", "component", "version", "syncstate"} {
+		var count int
+		require.NoError(t, DB.Db().QueryRow("SELECT COUNT(*) FROM "+table).Scan(&count))
+		counts[table] = count
+	}
+	return counts
+}
+
+func assertNothingWritten(t *testing.T) {
+	for table, count := range countRows(t) {
+		assert.Zero(t, count, table)
+	}
+}
+
+func TestPushIssues_FailureWritesNothing(t *testing.T) {
+	ctx := context.Background()
+	project := structures.DBProject{Source: "default", Title: "Tx", Key: "PRJ"}
+
+	for name, db := range map[string]*dbpusher.DbPusher{"bulk": DB, "row": RowDB} {
+		t.Run(name, func(t *testing.T) {
+			resetTestDB(t)
+			injectFailure(t)
+
+			issues := generateIssues(30)
+			issues[17].Comments[1].Body = "fail"
+
+			err := db.PushIssues(ctx, &project, issues)
+			assert.ErrorContains(t, err, "injected failure")
+			assertNothingWritten(t)
+		})
+	}
+}
+
+func TestPushProjects_FailureWritesNothing(t *testing.T) {
+	resetTestDB(t)
+
+	projects := []structures.DBProject{
+		{Source: "default", Title: "First", Key: "FIRST"},
+		{Source: "default", Title: "Second", Key: "SECOND"},
+		// key is required
+		{Source: "default", Title: "Broken"},
+	}
+	_, err := DB.Db().Exec("ALTER TABLE projects ADD CHECK (key <> '')")
+	require.NoError(t, err)
+
+	assert.Error(t, DB.PushProjects(context.Background(), projects))
+	assertNothingWritten(t)
+}
+
+func TestInTx_ProjectSyncIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	project := structures.DBProject{Source: "default", Title: "Tx", Key: "PRJ"}
+	boards := []structures.DBBoard{
+		{JiraId: 1, Name: "Board", Type: "scrum", Sprints: []structures.DBSprint{
+			{JiraId: 10, Name: "Sprint 1", State: "active", IssueKeys: []string{"PRJ-1", "PRJ-2"}},
+		}},
+	}
+
+	for name, db := range map[string]*dbpusher.DbPusher{"bulk": DB, "row": RowDB} {
+		t.Run(name, func(t *testing.T) {
+			resetTestDB(t)
+
+			// the watermark is the last step of the sync, its failure discards issues and boards
+			err := db.InTx(ctx, func(ctx context.Context) error {
+				require.NoError(t, db.PushIssues(ctx, &project, generateIssues(20)))
+				require.NoError(t, db.PushBoards(ctx, &project, boards))
+				return errors.New("watermark error")
+			})
+			assert.EqualError(t, err, "watermark error")
+			assertNothingWritten(t)
+
+			var boardCount int
+			require.NoError(t, DB.Db().QueryRow("SELECT COUNT(*) FROM board").Scan(&boardCount))
+			assert.Zero(t, boardCount)
+
+			err = db.InTx(ctx, func(ctx context.Context) error {
+				if err := db.PushIssues(ctx, &project, generateIssues(20)); err != nil {
+					return err
+				}
+				if err := db.PushBoards(ctx, &project, boards); err != nil {
+					return err
+				}
+				return db.PushSyncWatermark(ctx, structures.ProjectRef{Source: "default", Key: "PRJ"}, time.Now())
+			})
+			require.NoError(t, err)
+
+			counts := countRows(t)
+			assert.Equal(t, 20, counts["issue"])
+			assert.Equal(t, 1, counts["syncstate"])
+		})
+	}
+}
+
+func TestPushIssues_ChunkedCommit(t *testing.T) {
+	ctx := context.Background()
+	project := structures.DBProject{Source: "default", Title: "Tx", Key: "PRJ"}
+
+	for name, disableBulk := range map[string]bool{"bulk": false, "row": true} {
+		t.Run(name, func(t *testing.T) {
+			resetTestDB(t)
+			injectFailure(t)
+
+			cfg := testCfg
+			cfg.DBCfg.DisableBulkWrite = disableBulk
+			cfg.DBCfg.CommitChunk = 5
+			chunked, err := dbpusher.NewDbPusher(&cfg, slog.Default())
+			require.NoError(t, err)
+			defer chunked.Close()
+
+			issues := generateIssues(30)
+			issues[12].Comments[0].Body = "fail"
+
+			err = chunked.PushIssues(ctx, &project, issues)
+			assert.ErrorContains(t, err, "injected failure")
+
+			// two chunks before the failing one are kept, the failing chunk is rolled back
+			var keys []string
+			rows, err := DB.Db().Query("SELECT key FROM issue ORDER BY id")
+			require.NoError(t, err)
+			defer rows.Close()
+			for rows.Next() {
+				var key string
+				require.NoError(t, rows.Scan(&key))
+				keys = append(keys, key)
+			}
+			require.NoError(t, rows.Err())
+			assert.Equal(t, []string{"PRJ-1", "PRJ-2", "PRJ-3", "PRJ-4", "PRJ-5",
+				"PRJ-6", "PRJ-7", "PRJ-8", "PRJ-9", "PRJ-10"}, keys)
+
+			var comments int
+			require.NoError(t, DB.Db().QueryRow("SELECT COUNT(*) FROM issuecomment").Scan(&comments))
+			assert.Equal(t, 20, comments)
+		})
+	}
+}
@@ -4,12 +4,12 @@
 package jiraapiintegrations
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
-	"github.com/jiraconnector/internal/connector"
 	"github.com/jiraconnector/pkg/config"
 	"github.com/jiraconnector/pkg/logger"
 	"github.com/stretchr/testify/assert"
@@ -31,9 +31,9 @@ func TestGetAllProjects(t *testing.T) {
 	}
 	log := logger.SetupLogger("test", "")
 
-	conn := connector.NewJiraConnector(&cfg, log)
+	conn := newTestConnector(t, &cfg, log)
 
-	projects, err := conn.GetAllProjects()
+	projects, err := conn.GetAllProjects(context.Background())
 	require.NoError(t, err)
 	assert.Len(t, projects, 3)
 	assert.Equal(t, "TEST1", projects[0].Key)
@@ -46,23 +46,23 @@ func TestGetProjectsPage(t *testing.T) {
 	cfg := config.Config{JiraCfg: config.JiraConfig{Url: ts.URL}}
 	log := logger.SetupLogger("test", "")
 
-	conn := connector.NewJiraConnector(&cfg, log)
+	conn := newTestConnector(t, &cfg, log)
 
 	t.Run("First page", func(t *testing.T) {
-		resp, err := conn.GetProjectsPage("", 2, 1)
+		resp, err := conn.GetProjectsPage(context.Background(), "", 2, 1)
 		require.NoError(t, err)
 		assert.Len(t, resp.Projects, 2)
 		assert.Equal(t, 2, resp.PageInfo.PageCount)
 	})
 
 	t.Run("Search filtered", func(t *testing.T) {
-		resp, err := conn.GetProjectsPage("test", 10, 1)
+		resp, err := conn.GetProjectsPage(context.Background(), "test", 10, 1)
 		require.NoError(t, err)
 		assert.Len(t, resp.Projects, 2) // Only TEST1 and TEST2 match
 	})
 
 	t.Run("Empty page", func(t *testing.T) {
-		resp, err := conn.GetProjectsPage("", 2, 3)
+		resp, err := conn.GetProjectsPage(context.Background(), "", 2, 3)
 		require.NoError(t, err)
 		assert.Empty(t, resp.Projects)
 	})
@@ -72,9 +72,9 @@ func TestErrorHandling(t *testing.T) {
 	log := logger.SetupLogger("debug", "jiraApiintegrations.log")
 	t.Run("Jira unavailable", func(t *testing.T) {
 		cfg := config.Config{JiraCfg: config.JiraConfig{Url: "http://invalid"}}
-		conn := connector.NewJiraConnector(&cfg, log)
+		conn := newTestConnector(t, &cfg, log)
 
-		_, err := conn.GetAllProjects()
+		_, err := conn.GetAllProjects(context.Background())
 		assert.Error(t, err)
 	})
 
@@ -91,9 +91,9 @@ func TestErrorHandling(t *testing.T) {
 				MaxSleep: 50,
 			},
 		}
-		conn := connector.NewJiraConnector(&cfg, log)
+		conn := newTestConnector(t, &cfg, log)
 
-		_, err := conn.GetAllProjects()
+		_, err := conn.GetAllProjects(context.Background())
 		assert.Error(t, err)
 	})
 
@@ -104,9 +104,9 @@ func TestErrorHandling(t *testing.T) {
 		defer ts.Close()
 
 		cfg := config.Config{JiraCfg: config.JiraConfig{Url: ts.URL}}
-		conn := connector.NewJiraConnector(&cfg, log)
+		conn := newTestConnector(t, &cfg, log)
 
-		_, err := conn.GetAllProjects()
+		_, err := conn.GetAllProjects(context.Background())
 		assert.Error(t, err)
 	})
 }
@@ -131,9 +131,9 @@ func TestRetryRequest(t *testing.T) {
 		},
 	}
 	log := logger.SetupLogger("test", "")
-	conn := connector.NewJiraConnector(&cfg, log)
+	conn := newTestConnector(t, &cfg, log)
 
-	projects, err := conn.GetAllProjects()
+	projects, err := conn.GetAllProjects(context.Background())
 	require.NoError(t, err)
 	assert.Len(t, projects, 1)
 	assert.Equal(t, 3, attempt)
@@ -153,9 +153,9 @@ func TestRetryRequestTimeout(t *testing.T) {
 		},
 	}
 	log := logger.SetupLogger("test", "")
-	conn := connector.NewJiraConnector(&cfg, log)
+	conn := newTestConnector(t, &cfg, log)
 
-	_, err := conn.GetAllProjects()
+	_, err := conn.GetAllProjects(context.Background())
 	assert.Error(t, err)
 }
 
@@ -170,10 +170,10 @@ func TestPerformance(t *testing.T) {
 			IssueInOneReq: 100,
 		},
 	}
-	conn := connector.NewJiraConnector(&cfg, logger.SetupLogger("debug", "jiraApiintegrations.log"))
+	conn := newTestConnector(t, &cfg, logger.SetupLogger("debug", "jiraApiintegrations.log"))
 
 	start := time.Now()
-	_, err := conn.GetProjectIssues("PERF")
+	_, err := conn.GetProjectIssues(context.Background(), "PERF", nil)
 	assert.NoError(t, err)
 	assert.Less(t, time.Since(start).Seconds(), 1.0, "Should complete in under 1 second")
 }
@@ -6,15 +6,25 @@ package jiraapiintegrations
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/jiraconnector/internal/connector"
 	"github.com/jiraconnector/internal/structures"
+	"github.com/jiraconnector/pkg/config"
+	"github.com/stretchr/testify/require"
 )
 
+func newTestConnector(t *testing.T, cfg *config.Config, log *slog.Logger) *connector.JiraConnector {
+	conn, err := connector.NewJiraConnector(&cfg.JiraCfg, log)
+	require.NoError(t, err)
+	return conn
+}
+
 func setupTestServer(t *testing.T) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
@@ -40,7 +50,7 @@ func handleProjectsRequest(w http.ResponseWriter, r *http.Request) {
 
 func handleSearchRequest(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
-	project := query.Get("jql")[8:] // extract project from "project=XXX"
+	project := strings.Fields(query.Get("jql")[8:])[0] // extract project from "project=XXX ORDER BY ..."
 	maxResults, _ := strconv.Atoi(query.Get("maxResults"))
 	startAt, _ := strconv.Atoi(query.Get("startAt"))
 
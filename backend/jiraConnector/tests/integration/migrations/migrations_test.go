@@ -0,0 +1,204 @@
+//go:build integration
+// +build integration
+
+package migrationintegrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jiraconnector/internal/migrations"
+	myerr "github.com/jiraconnector/internal/migrations/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	_ "github.com/lib/pq"
+)
+
+var DB *sql.DB
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	containerReq := testcontainers.ContainerRequest{
+		Image:        "postgres:15",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "testuser",
+			"POSTGRES_PASSWORD": "testpass",
+			"POSTGRES_DB":       "testdb",
+		},
+		WaitingFor: wait.ForListeningPort("5432/tcp").WithStartupTimeout(30 * time.Second),
+	}
+
+	postgresC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: containerReq,
+		Started:          true,
+	})
+	if err != nil {
+		panic(fmt.Errorf("failed to start postgres container: %w", err))
+	}
+	defer postgresC.Terminate(ctx)
+
+	host, _ := postgresC.Host(ctx)
+	port, _ := postgresC.MappedPort(ctx, "5432")
+
+	DB, err = sql.Open("postgres", fmt.Sprintf("host=%s port=%s user=testuser password=testpass dbname=testdb sslmode=disable",
+		host, port.Port()))
+	if err != nil {
+		panic(fmt.Errorf("failed to connect to test db: %w", err))
+	}
+	defer DB.Close()
+
+	os.Exit(m.Run())
+}
+
+func resetTestDB(t *testing.T) *migrations.Migrator {
+	_, err := DB.Exec("DROP SCHEMA public CASCADE")
+	require.NoError(t, err)
+	_, err = DB.Exec("CREATE SCHEMA public")
+	require.NoError(t, err)
+
+	migrator, err := migrations.NewMigrator(DB, slog.Default())
+	require.NoError(t, err)
+	return migrator
+}
+
+func requireApplied(t *testing.T, migrator *migrations.Migrator, applied bool) {
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.Equal(t, applied, status.Applied, "migration %d_%s", status.Version, status.Name)
+		assert.False(t, status.Changed)
+	}
+}
+
+func TestUpDown(t *testing.T) {
+	ctx := context.Background()
+	migrator := resetTestDB(t)
+	all, err := migrations.Load()
+	require.NoError(t, err)
+
+	requireApplied(t, migrator, false)
+
+	require.NoError(t, migrator.Up(ctx))
+	requireApplied(t, migrator, true)
+	// nothing to do the second time
+	require.NoError(t, migrator.Up(ctx))
+
+	// every down migration reverts its up migration
+	require.NoError(t, migrator.Down(ctx, len(all)))
+	requireApplied(t, migrator, false)
+
+	var tables int
+	require.NoError(t, DB.QueryRow(`
+		SELECT COUNT(*) FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_name <> 'schema_migrations'
+	`).Scan(&tables))
+	assert.Zero(t, tables)
+
+	require.NoError(t, migrator.Up(ctx))
+	requireApplied(t, migrator, true)
+}
+
+func TestUp_AdoptsInitScriptDatabase(t *testing.T) {
+	ctx := context.Background()
+	migrator := resetTestDB(t)
+	all, err := migrations.Load()
+	require.NoError(t, err)
+
+	// database created by the init script of the postgres container before the migrations
+	_, err = DB.Exec(all[0].Up)
+	require.NoError(t, err)
+	_, err = DB.Exec(`
+		INSERT INTO Projects (title, key) VALUES ('Project', 'PRJ');
+		INSERT INTO Author (name) VALUES ('creator'), ('reporter');
+		INSERT INTO Issue (projectId, authorId, assigneeId, key) VALUES (1, 1, 2, 'PRJ-1');
+		INSERT INTO StatusChanges (issueId, authorId, fromStatus, toStatus) VALUES (1, 1, 'Open', 'Done');
+	`)
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Up(ctx))
+	requireApplied(t, migrator, true)
+
+	var source string
+	require.NoError(t, DB.QueryRow("SELECT source FROM Projects WHERE key = 'PRJ'").Scan(&source))
+	assert.Equal(t, "default", source)
+
+	// the old assignee was the reporter
+	var reporterId sql.NullInt64
+	var assigneeId sql.NullInt64
+	require.NoError(t, DB.QueryRow("SELECT reporterId, assigneeId FROM Issue WHERE key = 'PRJ-1'").Scan(&reporterId, &assigneeId))
+	assert.Equal(t, int64(2), reporterId.Int64)
+	assert.False(t, assigneeId.Valid)
+
+	var accountId string
+	require.NoError(t, DB.QueryRow("SELECT accountId FROM Author WHERE id = 2").Scan(&accountId))
+	assert.Equal(t, "reporter", accountId)
+
+	// status changes without history ids are loaded again by the next sync
+	var statusChanges int
+	require.NoError(t, DB.QueryRow("SELECT COUNT(*) FROM StatusChanges").Scan(&statusChanges))
+	assert.Zero(t, statusChanges)
+
+	// the same issue key is allowed in another source
+	_, err = DB.Exec(`
+		INSERT INTO Projects (source, title, key) VALUES ('cloud', 'Project', 'PRJ');
+		INSERT INTO Issue (projectId, authorId, key) VALUES (2, 1, 'PRJ-1');
+	`)
+	require.NoError(t, err)
+}
+
+func TestUp_AdoptsLaterInitScriptDatabase(t *testing.T) {
+	ctx := context.Background()
+	migrator := resetTestDB(t)
+	all, err := migrations.Load()
+	require.NoError(t, err)
+	require.Greater(t, len(all), 2)
+
+	// later init scripts had the schema of some of the migrations
+	for _, migration := range all[:len(all)-2] {
+		_, err = DB.Exec(migration.Up)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, migrator.Up(ctx))
+	requireApplied(t, migrator, true)
+}
+
+func TestUp_UnknownSchema(t *testing.T) {
+	ctx := context.Background()
+	migrator := resetTestDB(t)
+
+	_, err := DB.Exec("CREATE TABLE Projects (id serial PRIMARY KEY, name TEXT)")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, migrator.Up(ctx), myerr.ErrBaseline)
+
+	// nothing is changed
+	var exists bool
+	require.NoError(t, DB.QueryRow("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists))
+	assert.False(t, exists)
+}
+
+func TestUp_ChangedMigration(t *testing.T) {
+	ctx := context.Background()
+	migrator := resetTestDB(t)
+
+	require.NoError(t, migrator.Up(ctx))
+	_, err := DB.Exec("UPDATE schema_migrations SET checksum = 'edited' WHERE version = 1")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, migrator.Up(ctx), myerr.ErrChecksum)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Changed)
+}
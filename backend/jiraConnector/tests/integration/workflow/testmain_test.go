@@ -12,8 +12,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
-	"path/filepath"
-	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -36,8 +34,6 @@ var (
 	testLogger *slog.Logger
 )
 
-const sqlCreatePath = "initDB/create.sql"
-
 // TestMain настраивает все зависимости перед запуском тестов
 func TestMain(m *testing.M) {
 	// get config
@@ -70,7 +66,7 @@ func TestMain(m *testing.M) {
 
 	// start my app
 	go func() {
-		if err := testApp.Run(); err != nil {
+		if err := testApp.Run(context.Background()); err != nil {
 			testLogger.Error("Server error", "error", err)
 		}
 	}()
@@ -112,19 +108,7 @@ func setupTestDB(cfg *config.Config, log *slog.Logger) (testcontainers.Container
 		panic(fmt.Errorf("failed to connect to test db: %w", err))
 	}
 
-	_, filename, _, _ := runtime.Caller(0)
-	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filename))))
-	sqlPath := filepath.Join(projectRoot, "build", sqlCreatePath)
-	sqlBytes, err := os.ReadFile(sqlPath)
-	if err != nil {
-		panic(fmt.Errorf("failed to read create.sql: %w", err))
-	}
-
-	_, err = DB.Db().Exec(string(sqlBytes))
-	if err != nil {
-		panic(fmt.Errorf("failed to execute schema.sql: %w", err))
-	}
-
+	// the schema is created by migrations of the app
 	testDB = DB.Db()
 
 	return postgresC, nil
@@ -159,7 +143,7 @@ func setupMockJira() *httptest.Server {
 			json.NewEncoder(w).Encode(projects[0])
 
 		case strings.Contains(r.URL.Path, "/rest/api/2/search"):
-			projectKey := strings.TrimPrefix(r.URL.Query().Get("jql"), "project=")
+			projectKey := strings.Fields(strings.TrimPrefix(r.URL.Query().Get("jql"), "project="))[0]
 			if issues, ok := issues[projectKey]; ok {
 				w.Header().Set("Content-Type", "application/json")
 				json.NewEncoder(w).Encode(structures.JiraIssues{
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jiraconnector/internal/structures"
 	"github.com/stretchr/testify/assert"
@@ -44,7 +45,26 @@ func TestFullIntegration(t *testing.T) {
 		)
 		require.NoError(t, err)
 		defer resp.Body.Close()
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		var job structures.Job
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+
+		// Ждём завершения задачи
+		require.Eventually(t, func() bool {
+			jobResp, err := http.Get(fmt.Sprintf("http://localhost%s/api/v1/connector/jobs/%d", testConfig.ServerCfg.Port, job.Id))
+			if err != nil {
+				return false
+			}
+			defer jobResp.Body.Close()
+
+			if err := json.NewDecoder(jobResp.Body).Decode(&job); err != nil {
+				return false
+			}
+			return job.Finished()
+		}, 10*time.Second, 100*time.Millisecond)
+		assert.Equal(t, structures.JobSucceeded, job.State)
+		assert.Equal(t, 2, job.IssuesTotal)
 
 		// Проверяем БД
 		var projectCount int